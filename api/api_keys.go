@@ -0,0 +1,139 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pro-posal/webserver/internal/utils"
+	"github.com/pro-posal/webserver/models"
+	"github.com/pro-posal/webserver/services"
+)
+
+type PostAPIKeyRequestBody struct {
+	Name      string               `json:"name"`
+	Scopes    []models.APIKeyScope `json:"scopes"`
+	ExpiresAt *time.Time           `json:"expires_at"`
+	// ServiceAccountID creates the key for a service account instead of the caller
+	ServiceAccountID string `json:"service_account_id"`
+}
+
+type GetAPIKeysResponseBody struct {
+	TotalAPIKeys int              `json:"total_api_keys"`
+	APIKeys      []*models.APIKey `json:"api_keys"`
+}
+
+type PostServiceAccountRequestBody struct {
+	Name string      `json:"name"`
+	Role models.Role `json:"role"`
+}
+
+type GetServiceAccountsResponseBody struct {
+	TotalServiceAccounts int                      `json:"total_service_accounts"`
+	ServiceAccounts      []*models.ServiceAccount `json:"service_accounts"`
+}
+
+// PostAPIKey creates an API key; the response is the only time the key itself is shown.
+func (a *API) PostAPIKey(w http.ResponseWriter, r *http.Request) {
+	var request PostAPIKeyRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	apiKey, err := a.authService.CreateAPIKey(r.Context(), services.CreateAPIKeyRequest{
+		CompanyID:        mux.Vars(r)["companyId"],
+		Name:             request.Name,
+		Scopes:           request.Scopes,
+		ExpiresAt:        request.ExpiresAt,
+		ServiceAccountID: request.ServiceAccountID,
+		RequestedBy:      utils.GetUserIDFromSession(r).String(),
+	})
+	if err != nil {
+		log.Printf("Error Creating API Key: %v", err)
+		writeServiceError(w, err, "Error Creating API Key")
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, apiKey)
+}
+
+func (a *API) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	apiKeys, err := a.authService.GetAPIKeys(r.Context(), mux.Vars(r)["companyId"], utils.GetUserIDFromSession(r).String())
+	if err != nil {
+		log.Printf("Error Getting API Keys: %v", err)
+		writeServiceError(w, err, "Error Getting API Keys")
+		return
+	}
+
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, GetAPIKeysResponseBody{
+		TotalAPIKeys: len(apiKeys),
+		APIKeys:      apiKeys,
+	})
+}
+
+func (a *API) DeleteAPIKey(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	err := a.authService.RevokeAPIKey(r.Context(), vars["companyId"], vars["keyId"], utils.GetUserIDFromSession(r).String())
+	if err != nil {
+		log.Printf("Error Revoking API Key: %v", err)
+		writeServiceError(w, err, "Error Revoking API Key")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *API) PostServiceAccount(w http.ResponseWriter, r *http.Request) {
+	var request PostServiceAccountRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	account, err := a.authService.CreateServiceAccount(r.Context(), services.CreateServiceAccountRequest{
+		CompanyID:   mux.Vars(r)["companyId"],
+		Name:        request.Name,
+		Role:        request.Role,
+		RequestedBy: utils.GetUserIDFromSession(r).String(),
+	})
+	if err != nil {
+		log.Printf("Error Creating Service Account: %v", err)
+		writeServiceError(w, err, "Error Creating Service Account")
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, account)
+}
+
+func (a *API) GetServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	accounts, err := a.authService.GetServiceAccounts(r.Context(), mux.Vars(r)["companyId"], utils.GetUserIDFromSession(r).String())
+	if err != nil {
+		log.Printf("Error Getting Service Accounts: %v", err)
+		writeServiceError(w, err, "Error Getting Service Accounts")
+		return
+	}
+
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, GetServiceAccountsResponseBody{
+		TotalServiceAccounts: len(accounts),
+		ServiceAccounts:      accounts,
+	})
+}
+
+// DeleteServiceAccount moves the service account to the trash and revokes all of its API keys.
+func (a *API) DeleteServiceAccount(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	err := a.authService.DeleteServiceAccount(r.Context(), vars["companyId"], vars["userId"], utils.GetUserIDFromSession(r).String())
+	if err != nil {
+		log.Printf("Error Deleting Service Account: %v", err)
+		writeServiceError(w, err, "Error Deleting Service Account")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pro-posal/webserver/internal/utils"
+	"github.com/pro-posal/webserver/models"
+	"github.com/pro-posal/webserver/services"
+)
+
+type GetAuditLogsResponseBody struct {
+	TotalAuditLogs int64              `json:"total_audit_logs"`
+	AuditLogs      []*models.AuditLog `json:"audit_logs"`
+	Page           int                `json:"page"`
+	PageSize       int                `json:"page_size"`
+}
+
+func (a *API) GetAuditLogs(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseAuditLogFilter(r)
+	if err != nil {
+		log.Printf("Error parsing audit log filter: %v", err)
+		http.Error(w, "Error parsing audit log filter", http.StatusBadRequest)
+		return
+	}
+	filter = filter.Paged()
+
+	auditLogs, total, err := a.auditLogs.GetAuditLogs(r.Context(), filter)
+	if err != nil {
+		log.Printf("Error Getting Audit Logs: %v", err)
+		writeServiceError(w, err, "Error Getting Audit Logs")
+		return
+	}
+
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, GetAuditLogsResponseBody{
+		TotalAuditLogs: total,
+		AuditLogs:      auditLogs,
+		Page:           filter.Page,
+		PageSize:       filter.PageSize,
+	})
+}
+
+// auditLogCSVHeader lists the columns of the audit log export, before/after/changes hold JSON.
+var auditLogCSVHeader = []string{
+	"id", "created_at", "actor_id", "session_id", "action", "entity_type", "entity_id",
+	"ip_address", "request_id", "before", "after", "changes",
+}
+
+func (a *API) GetAuditLogsExport(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseAuditLogFilter(r)
+	if err != nil {
+		log.Printf("Error parsing audit log filter: %v", err)
+		http.Error(w, "Error parsing audit log filter", http.StatusBadRequest)
+		return
+	}
+
+	auditLogs, err := a.auditLogs.ExportAuditLogs(r.Context(), filter)
+	if err != nil {
+		log.Printf("Error Exporting Audit Logs: %v", err)
+		writeServiceError(w, err, "Error Exporting Audit Logs")
+		return
+	}
+
+	var sheet bytes.Buffer
+	writer := csv.NewWriter(&sheet)
+	writer.Write(auditLogCSVHeader)
+	for _, auditLog := range auditLogs {
+		changes := ""
+		if len(auditLog.Changes) > 0 {
+			encoded, err := json.Marshal(auditLog.Changes)
+			if err != nil {
+				log.Printf("Error marshaling changes of audit log %v: %v", auditLog.ID, err)
+			}
+			changes = string(encoded)
+		}
+		writer.Write([]string{
+			auditLog.ID,
+			auditLog.CreatedAt.Format(time.RFC3339),
+			auditLog.ActorID,
+			auditLog.SessionID,
+			auditLog.Action,
+			auditLog.EntityType,
+			auditLog.EntityID,
+			auditLog.IPAddress,
+			auditLog.RequestID,
+			string(auditLog.Before),
+			string(auditLog.After),
+			changes,
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		log.Printf("Error writing audit log export: %v", err)
+		http.Error(w, "Error writing audit log export", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="audit.csv"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(sheet.Bytes())
+}
+
+// parseAuditLogFilter reads the filter from the query string; from and to are RFC 3339 times.
+func parseAuditLogFilter(r *http.Request) (services.AuditLogFilter, error) {
+	query := r.URL.Query()
+	filter := services.AuditLogFilter{
+		CompanyID:  mux.Vars(r)["companyId"],
+		ActorID:    query.Get("actor_id"),
+		EntityType: query.Get("entity_type"),
+		EntityID:   query.Get("entity_id"),
+		Action:     query.Get("action"),
+		RequestID:  query.Get("request_id"),
+	}
+
+	for _, param := range []struct {
+		name  string
+		value **time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		if value := query.Get(param.name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return filter, fmt.Errorf("invalid %s %q", param.name, value)
+			}
+			*param.value = &parsed
+		}
+	}
+
+	for _, param := range []struct {
+		name  string
+		value *int
+	}{{"page", &filter.Page}, {"page_size", &filter.PageSize}} {
+		if value := query.Get(param.name); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 1 {
+				return filter, fmt.Errorf("invalid %s %q", param.name, value)
+			}
+			*param.value = parsed
+		}
+	}
+	return filter, nil
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pro-posal/webserver/models"
+	"github.com/pro-posal/webserver/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAuditLogService struct {
+	filter    services.AuditLogFilter
+	auditLogs []*models.AuditLog
+}
+
+func (f *fakeAuditLogService) GetAuditLogs(_ context.Context, filter services.AuditLogFilter) ([]*models.AuditLog, int64, error) {
+	f.filter = filter
+	return f.auditLogs, int64(len(f.auditLogs)), nil
+}
+
+func (f *fakeAuditLogService) ExportAuditLogs(_ context.Context, filter services.AuditLogFilter) ([]*models.AuditLog, error) {
+	f.filter = filter
+	return f.auditLogs, nil
+}
+
+func TestGetAuditLogs_ParsesFilter(t *testing.T) {
+	auditLogs := &fakeAuditLogService{}
+	api := NewAPI(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, auditLogs)
+
+	req, err := http.NewRequest("GET", "/companies/"+TEST_COMPANY_ID+"/audit?entity_type=category&action=delete&from=2024-06-01T00:00:00Z&page=2", nil)
+	require.NoError(t, err)
+	req = mux.SetURLVars(req, map[string]string{"companyId": TEST_COMPANY_ID})
+
+	rr := httptest.NewRecorder()
+	api.GetAuditLogs(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	assert.Equal(t, TEST_COMPANY_ID, auditLogs.filter.CompanyID)
+	assert.Equal(t, "category", auditLogs.filter.EntityType)
+	assert.Equal(t, "delete", auditLogs.filter.Action)
+	require.NotNil(t, auditLogs.filter.From)
+	assert.True(t, auditLogs.filter.From.Equal(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Nil(t, auditLogs.filter.To)
+
+	var body GetAuditLogsResponseBody
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	assert.Equal(t, 2, body.Page)
+	assert.Equal(t, 50, body.PageSize)
+}
+
+func TestGetAuditLogs_InvalidFilter(t *testing.T) {
+	api := NewAPI(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &fakeAuditLogService{})
+
+	for _, query := range []string{"from=yesterday", "page=0", "page_size=many"} {
+		req, err := http.NewRequest("GET", "/companies/"+TEST_COMPANY_ID+"/audit?"+query, nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		api.GetAuditLogs(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+	}
+}
+
+func TestGetAuditLogsExport_WritesCSV(t *testing.T) {
+	auditLogs := &fakeAuditLogService{auditLogs: []*models.AuditLog{{
+		ID:         "entry",
+		Action:     "update",
+		EntityType: "category",
+		EntityID:   "category",
+		Before:     json.RawMessage(`{"description":"old"}`),
+		After:      json.RawMessage(`{"description":"new"}`),
+		Changes: map[string]models.AuditChange{
+			"description": {Before: json.RawMessage(`"old"`), After: json.RawMessage(`"new"`)},
+		},
+		RequestID: "request",
+		CreatedAt: time.Date(2024, 6, 19, 8, 15, 6, 0, time.UTC),
+	}}}
+	api := NewAPI(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, auditLogs)
+
+	req, err := http.NewRequest("GET", "/companies/"+TEST_COMPANY_ID+"/audit/export", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	api.GetAuditLogsExport(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+
+	records, err := csv.NewReader(rr.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, auditLogCSVHeader, records[0])
+	assert.Equal(t, "2024-06-19T08:15:06Z", records[1][1])
+	assert.Equal(t, `{"description":"new"}`, records[1][10])
+	assert.JSONEq(t, `{"description":{"before":"old","after":"new"}}`, records[1][11])
+}
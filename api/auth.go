@@ -2,11 +2,17 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 
+	"github.com/gorilla/mux"
 	"github.com/pro-posal/webserver/internal/utils"
+	"github.com/pro-posal/webserver/models"
+	"github.com/pro-posal/webserver/services"
 )
 
 type PostUsersLoginRequestBody struct {
@@ -15,8 +21,19 @@ type PostUsersLoginRequestBody struct {
 }
 
 type PostUsersLoginResponseBody struct {
-	AccessToken string `json:"access_token"`
-	ExpiresAt   int64  `json:"expires_at"`
+	AccessToken      string `json:"access_token,omitempty"`
+	ExpiresAt        int64  `json:"expires_at,omitempty"`
+	RefreshToken     string `json:"refresh_token,omitempty"`
+	RefreshExpiresAt int64  `json:"refresh_expires_at,omitempty"`
+	// When two-factor authentication is enabled the login only returns a challenge, see PostAuthTwoFactor
+	TwoFactorRequired      bool   `json:"two_factor_required,omitempty"`
+	ChallengeToken         string `json:"challenge_token,omitempty"`
+	ChallengeExpiresAt     int64  `json:"challenge_expires_at,omitempty"`
+	TwoFactorSetupRequired bool   `json:"two_factor_setup_required,omitempty"`
+}
+
+type PostAuthRefreshRequestBody struct {
+	RefreshToken string `json:"refresh_token"`
 }
 
 func (a *API) PostUsersLogin(w http.ResponseWriter, r *http.Request) {
@@ -28,22 +45,170 @@ func (a *API) PostUsersLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := a.authService.CreateAuthToken(r.Context(), strings.ToLower(request.Email), request.Password)
+	token, err := a.authService.CreateAuthToken(r.Context(), services.CreateAuthTokenRequest{
+		Email:    strings.ToLower(request.Email),
+		Password: request.Password,
+		Client:   sessionClient(r),
+	})
 	if err != nil {
 		if strings.Contains(err.Error(), "invalid email or password") {
 			http.Error(w, "Invalid email or password", http.StatusForbidden)
 			return
 		}
+		if errors.Is(err, services.ErrEmailNotVerified) {
+			http.Error(w, "Email address is not verified", http.StatusForbidden)
+			return
+		}
+		var throttled *services.LoginThrottledError
+		if errors.As(err, &throttled) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			http.Error(w, "Too many failed login attempts", http.StatusTooManyRequests)
+			return
+		}
 
 		log.Printf("Failed creating auth token: %v", err)
 		http.Error(w, "Failed creating auth token", http.StatusInternalServerError)
 		return
 	}
 
-	resp, err := json.Marshal(PostUsersLoginResponseBody{
-		AccessToken: token.BearerToken,
-		ExpiresAt:   token.ExpiresAt.UnixMilli(),
+	writeAuthToken(w, token)
+}
+
+// GetJWKS publishes the public signing keys so other services can verify our tokens without sharing a secret.
+func (a *API) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	// Keys are rotated well ahead of being retired, so caches only need to refresh now and then
+	w.Header().Set("Cache-Control", "public, max-age=900")
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, a.authService.PublicKeys())
+}
+
+// PostAuthRefresh exchanges a refresh token for a new access token and refresh token.
+func (a *API) PostAuthRefresh(w http.ResponseWriter, r *http.Request) {
+	var request PostAuthRefreshRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	token, err := a.authService.RefreshAuthToken(r.Context(), services.RefreshAuthTokenRequest{
+		RefreshToken: request.RefreshToken,
+		Client:       sessionClient(r),
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRefreshToken) {
+			http.Error(w, "Invalid refresh token", http.StatusForbidden)
+			return
+		}
+
+		log.Printf("Failed refreshing auth token: %v", err)
+		http.Error(w, "Failed refreshing auth token", http.StatusInternalServerError)
+		return
+	}
+
+	writeAuthToken(w, token)
+}
+
+type GetSessionsResponseBody struct {
+	TotalSessions int                     `json:"total_sessions"`
+	Sessions      []*models.ActiveSession `json:"sessions"`
+}
+
+type PostRevokeOtherSessionsResponseBody struct {
+	RevokedSessions int64 `json:"revoked_sessions"`
+}
+
+// PostAuthLogout ends the session the request was made with.
+func (a *API) PostAuthLogout(w http.ResponseWriter, r *http.Request) {
+	session := utils.GetSessionFromContext(r.Context())
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := a.authService.Logout(r.Context(), session); err != nil {
+		log.Printf("Error Logging Out: %v", err)
+		http.Error(w, "Error Logging Out", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *API) GetSessions(w http.ResponseWriter, r *http.Request) {
+	session := utils.GetSessionFromContext(r.Context())
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	sessions, err := a.authService.GetActiveSessions(r.Context(), session)
+	if err != nil {
+		log.Printf("Error Getting Sessions: %v", err)
+		http.Error(w, "Error Getting Sessions", http.StatusBadRequest)
+		return
+	}
+	utils.MarshalAndWriteResponse(w, GetSessionsResponseBody{
+		TotalSessions: len(sessions),
+		Sessions:      sessions,
 	})
+}
+
+func (a *API) DeleteSession(w http.ResponseWriter, r *http.Request) {
+	session := utils.GetSessionFromContext(r.Context())
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	err := a.authService.RevokeSession(r.Context(), session, mux.Vars(r)["sessionId"])
+	if err != nil {
+		log.Printf("Error Revoking Session: %v", err)
+		http.Error(w, "Error Revoking Session", http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *API) PostRevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	session := utils.GetSessionFromContext(r.Context())
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	revoked, err := a.authService.RevokeOtherSessions(r.Context(), session)
+	if err != nil {
+		log.Printf("Error Revoking Sessions: %v", err)
+		http.Error(w, "Error Revoking Sessions", http.StatusBadRequest)
+		return
+	}
+	utils.MarshalAndWriteResponse(w, PostRevokeOtherSessionsResponseBody{RevokedSessions: revoked})
+}
+
+func sessionClient(r *http.Request) services.SessionClient {
+	return services.SessionClient{
+		UserAgent: r.UserAgent(),
+		IPAddress: utils.GetClientIP(r),
+	}
+}
+
+func writeAuthToken(w http.ResponseWriter, token *models.AuthToken) {
+	body := PostUsersLoginResponseBody{
+		AccessToken:            token.BearerToken,
+		ExpiresAt:              token.ExpiresAt.UnixMilli(),
+		RefreshToken:           token.RefreshToken,
+		RefreshExpiresAt:       token.RefreshExpiresAt.UnixMilli(),
+		TwoFactorSetupRequired: token.TwoFactorSetupRequired,
+	}
+	if token.Challenge != nil {
+		body = PostUsersLoginResponseBody{
+			TwoFactorRequired:  true,
+			ChallengeToken:     token.Challenge.Token,
+			ChallengeExpiresAt: token.Challenge.ExpiresAt.UnixMilli(),
+		}
+	}
+
+	resp, err := json.Marshal(body)
 	if err != nil {
 		log.Printf("Failed marshaling response: %v", err)
 		http.Error(w, "Failed creating response object", http.StatusInternalServerError)
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/pro-posal/webserver/internal/authz"
+	"github.com/pro-posal/webserver/internal/utils"
+)
+
+// PostAuthzExplainRequestBody describes the request to explain. Body is only needed for routes
+// that find the company in the request body.
+type PostAuthzExplainRequestBody struct {
+	UserID string          `json:"user_id"`
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Body   json.RawMessage `json:"body"`
+}
+
+type PostAuthzExplainResponseBody struct {
+	Route string `json:"route"`
+	*authz.Decision
+}
+
+// PostAuthzExplain evaluates the policy of the route a request would match for a user, without
+// making the request.
+func (a *API) PostAuthzExplain(w http.ResponseWriter, r *http.Request) {
+	var request PostAuthzExplainRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+	if request.UserID == "" || request.Method == "" || !strings.HasPrefix(request.Path, "/") {
+		http.Error(w, "user_id, method and an absolute path are required", http.StatusBadRequest)
+		return
+	}
+
+	target, err := http.NewRequestWithContext(r.Context(), strings.ToUpper(request.Method), request.Path, bytes.NewReader(request.Body))
+	if err != nil {
+		log.Printf("Error building request to explain: %v", err)
+		http.Error(w, "Invalid method or path", http.StatusBadRequest)
+		return
+	}
+
+	var match mux.RouteMatch
+	if !a.router.Match(target, &match) || match.MatchErr != nil {
+		http.Error(w, "No route matches the method and path", http.StatusNotFound)
+		return
+	}
+	policy, ok := authz.RoutePolicy(match.Route)
+	if !ok {
+		log.Printf("No authorization policy found for %v %v", target.Method, target.URL.Path)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	target = mux.SetURLVars(target, match.Vars)
+
+	caller, err := authz.LoadCaller(r.Context(), a.db.Conn, request.UserID)
+	if err != nil {
+		log.Printf("Error loading permissions of user %v: %v", request.UserID, err)
+		http.Error(w, "Error loading the user's permissions", http.StatusInternalServerError)
+		return
+	}
+	decision, err := policy.Explain(target, a.db.Conn, caller)
+	if err != nil {
+		log.Printf("Error explaining authorization of user %v: %v", request.UserID, err)
+		http.Error(w, "Error evaluating the route's policy", http.StatusInternalServerError)
+		return
+	}
+
+	template, _ := match.Route.GetPathTemplate()
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, PostAuthzExplainResponseBody{
+		Route:    target.Method + " " + template,
+		Decision: decision,
+	})
+}
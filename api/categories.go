@@ -1,10 +1,15 @@
 package api
 
 import (
+	"bytes"
+	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
+	"github.com/pro-posal/webserver/internal/catalog"
 	"github.com/pro-posal/webserver/internal/utils"
 	"github.com/pro-posal/webserver/models"
 	"github.com/pro-posal/webserver/services"
@@ -12,12 +17,10 @@ import (
 
 type PostCategoriesRequestBody struct {
 	CompanyID   string `json:"company_id"`
-	CategoryID  string `json:"category_id"`
 	Description string `json:"description"`
 	Type        string `json:"type"`
 }
 type PutCategoriesRequestBody struct {
-	CategoryID  string `json:"category_id"`
 	Description string `json:"description"`
 }
 
@@ -54,9 +57,9 @@ func (a *API) PostSub(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Error parsing request body", http.StatusBadRequest)
 		return
 	}
-	category, err := a.categoryManagment.CreateCategory(r.Context(), services.CreateCategoryRequest{
-		CompanyID:   request.CompanyID,
-		CategoryID:  request.CategoryID,
+	// The sub category goes into the company of its parent, a company_id in the body is ignored
+	category, err := a.categoryManagment.CreateSub(r.Context(), services.CreateCategoryRequest{
+		CategoryID:  mux.Vars(r)["categoryId"],
 		Description: request.Description,
 		Type:        request.Type})
 	if err != nil {
@@ -75,8 +78,9 @@ func (a *API) PutCategories(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Error parsing request body", http.StatusBadRequest)
 		return
 	}
-	category, err := a.categoryManagment.UpdateCategory(r.Context(), request.CategoryID, services.UpdateCategoryRequest{
-		CategoryID:  request.CategoryID,
+	categoryID := mux.Vars(r)["id"]
+	category, err := a.categoryManagment.UpdateCategory(r.Context(), categoryID, services.UpdateCategoryRequest{
+		CategoryID:  categoryID,
 		Description: request.Description})
 	if err != nil {
 		log.Printf("Error Updating a Category: %v", err)
@@ -88,7 +92,7 @@ func (a *API) PutCategories(w http.ResponseWriter, r *http.Request) {
 
 func (a *API) GetCategories(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
-	companyId := vars["id"]
+	companyId := vars["companyId"]
 	categories, err := a.categoryManagment.GetCategory(r.Context(), companyId)
 	if err != nil {
 		log.Printf("Error Getting Categories: %v", err)
@@ -102,7 +106,7 @@ func (a *API) GetCategories(w http.ResponseWriter, r *http.Request) {
 }
 
 func (a *API) GetSubCategories(w http.ResponseWriter, r *http.Request) {
-	categories, err := a.categoryManagment.GetSub(r.Context(), r.URL.Query().Get("id"))
+	categories, err := a.categoryManagment.GetSub(r.Context(), mux.Vars(r)["categoryId"])
 	if err != nil {
 		log.Printf("Error Getting Sub Categories: %v", err)
 		http.Error(w, "Error Getting Sub Categories", http.StatusBadRequest)
@@ -114,8 +118,241 @@ func (a *API) GetSubCategories(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+type GetCategoryTreeResponseBody struct {
+	Categories []*models.CategoryNode `json:"categories"`
+}
+
+func (a *API) GetCategoryTree(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	query := r.URL.Query()
+
+	depth := 0
+	if value := query.Get("depth"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			log.Printf("Error parsing depth: %v", err)
+			http.Error(w, "Error parsing depth", http.StatusBadRequest)
+			return
+		}
+		depth = parsed
+	}
+
+	includeDeleted := false
+	if value := query.Get("include_deleted"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Printf("Error parsing include_deleted: %v", err)
+			http.Error(w, "Error parsing include_deleted", http.StatusBadRequest)
+			return
+		}
+		includeDeleted = parsed
+	}
+
+	categories, err := a.categoryManagment.GetCategoryTree(r.Context(), services.GetCategoryTreeRequest{
+		CompanyID:      vars["companyId"],
+		Depth:          depth,
+		IncludeDeleted: includeDeleted,
+		RequestedBy:    utils.GetUserIDFromSession(r).String(),
+	})
+	if err != nil {
+		log.Printf("Error Getting Category Tree: %v", err)
+		writeServiceError(w, err, "Error Getting Category Tree")
+		return
+	}
+	utils.MarshalAndWriteResponse(w, GetCategoryTreeResponseBody{Categories: categories})
+}
+
+type PutCategoriesOrderRequestBody struct {
+	ParentID    string   `json:"parent_id"`
+	CategoryIDs []string `json:"category_ids"`
+}
+
+type PostMoveCategoryRequestBody struct {
+	ParentID string `json:"parent_id"`
+	Position *int   `json:"position"`
+}
+
+func (a *API) PutCategoriesOrder(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var request PutCategoriesOrderRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	categories, err := a.categoryManagment.ReorderCategories(r.Context(), services.ReorderCategoriesRequest{
+		CompanyID:   vars["companyId"],
+		ParentID:    request.ParentID,
+		CategoryIDs: request.CategoryIDs,
+	})
+	if err != nil {
+		log.Printf("Error Reordering Categories: %v", err)
+		http.Error(w, "Error Reordering Categories", http.StatusBadRequest)
+		return
+	}
+	utils.MarshalAndWriteResponse(w, GetCategoriesResponseBody{
+		TotalCompanies: len(categories),
+		Description:    categories,
+	})
+}
+
+func (a *API) PostMoveCategory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var request PostMoveCategoryRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	category, err := a.categoryManagment.MoveCategory(r.Context(), services.MoveCategoryRequest{
+		CompanyID:  vars["companyId"],
+		CategoryID: vars["categoryId"],
+		ParentID:   request.ParentID,
+		Position:   request.Position,
+	})
+	if err != nil {
+		log.Printf("Error Moving a Category: %v", err)
+		http.Error(w, "Error Moving a Category", http.StatusBadRequest)
+		return
+	}
+	utils.MarshalAndWriteResponse(w, category)
+}
+
+// maxCatalogUploadSize bounds the catalog sheets accepted by the import endpoint.
+const maxCatalogUploadSize = 10 << 20
+
+// PostCatalogImport accepts a catalog sheet either as a multipart "file" field or as the raw
+// request body, with the format taken from ?format=, the file name or the content type.
+func (a *API) PostCatalogImport(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	query := r.URL.Query()
+
+	dryRun := false
+	if value := query.Get("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Printf("Error parsing dry_run: %v", err)
+			http.Error(w, "Error parsing dry_run", http.StatusBadRequest)
+			return
+		}
+		dryRun = parsed
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxCatalogUploadSize)
+	formatHints := []string{query.Get("format")}
+	var sheet io.Reader = r.Body
+	if file, header, err := r.FormFile("file"); err == nil {
+		defer file.Close()
+		sheet = file
+		formatHints = append(formatHints, header.Filename, header.Header.Get("Content-Type"))
+	} else {
+		formatHints = append(formatHints, r.Header.Get("Content-Type"))
+	}
+
+	format, err := catalog.ParseFormat(formatHints...)
+	if err != nil {
+		log.Printf("Error parsing catalog format: %v", err)
+		http.Error(w, "Error parsing catalog format", http.StatusBadRequest)
+		return
+	}
+
+	rows, err := catalog.ReadRows(sheet, format)
+	if err != nil {
+		log.Printf("Error reading catalog sheet: %v", err)
+		http.Error(w, "Error reading catalog sheet", http.StatusBadRequest)
+		return
+	}
+
+	report, err := a.categoryManagment.ImportCatalog(r.Context(), services.ImportCatalogRequest{
+		CompanyID: vars["companyId"],
+		Rows:      rows,
+		DryRun:    dryRun,
+	})
+	if err != nil {
+		log.Printf("Error Importing Catalog: %v", err)
+		http.Error(w, "Error Importing Catalog", http.StatusBadRequest)
+		return
+	}
+
+	if len(report.Errors) > 0 {
+		utils.MarshalAndWriteResponseWithStatus(w, http.StatusUnprocessableEntity, report)
+		return
+	}
+	utils.MarshalAndWriteResponse(w, report)
+}
+
+func (a *API) GetCatalogExport(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	format, err := catalog.ParseFormat(r.URL.Query().Get("format"), string(catalog.FormatCSV))
+	if err != nil {
+		log.Printf("Error parsing catalog format: %v", err)
+		http.Error(w, "Error parsing catalog format", http.StatusBadRequest)
+		return
+	}
+
+	rows, err := a.categoryManagment.ExportCatalog(r.Context(), vars["companyId"])
+	if err != nil {
+		log.Printf("Error Exporting Catalog: %v", err)
+		http.Error(w, "Error Exporting Catalog", http.StatusBadRequest)
+		return
+	}
+
+	var sheet bytes.Buffer
+	if err := catalog.WriteRows(&sheet, format, rows); err != nil {
+		log.Printf("Error writing catalog sheet: %v", err)
+		http.Error(w, "Error writing catalog sheet", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="catalog.%s"`, format))
+	w.WriteHeader(http.StatusOK)
+	w.Write(sheet.Bytes())
+}
+
+type PostCatalogCopyRequestBody struct {
+	TargetCompanyID string                       `json:"target_company_id"`
+	CategoryID      string                       `json:"category_id"`
+	TargetParentID  string                       `json:"target_parent_id"`
+	ConflictPolicy  models.CatalogConflictPolicy `json:"conflict_policy"`
+}
+
+func (a *API) PostCatalogCopy(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var request PostCatalogCopyRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	report, err := a.categoryManagment.CopyCatalog(r.Context(), services.CopyCatalogRequest{
+		SourceCompanyID: vars["companyId"],
+		TargetCompanyID: request.TargetCompanyID,
+		CategoryID:      request.CategoryID,
+		TargetParentID:  request.TargetParentID,
+		ConflictPolicy:  request.ConflictPolicy,
+		RequestedBy:     utils.GetUserIDFromSession(r).String(),
+	})
+	if err != nil {
+		log.Printf("Error Copying Catalog: %v", err)
+		writeServiceError(w, err, "Error Copying Catalog")
+		return
+	}
+	utils.MarshalAndWriteResponse(w, report)
+}
+
 func (a *API) DeleteCategories(w http.ResponseWriter, r *http.Request) {
-	category, err := a.categoryManagment.DeleteCategory(r.Context(), r.URL.Query().Get("id"))
+	category, err := a.categoryManagment.DeleteCategory(r.Context(), mux.Vars(r)["id"], utils.GetUserIDFromSession(r).String())
 	if err != nil {
 		log.Printf("Error Deleting a Category: %v", err)
 		http.Error(w, "Error Deleting a Category", http.StatusBadRequest)
@@ -16,9 +16,10 @@ type PostCompanyRequestBody struct {
 	LogoBase64 string `json:"logo_base64"`
 }
 type PUTCompanyRequestBody struct {
-	Name       string `json:"name"`
-	Address    string `json:"address"`
-	LogoBase64 string `json:"logo_base64"`
+	Name             string `json:"name"`
+	Address          string `json:"address"`
+	LogoBase64       string `json:"logo_base64"`
+	RequireTwoFactor *bool  `json:"require_two_factor"`
 }
 
 type GetCompaniesResponseBody struct {
@@ -50,24 +51,21 @@ func (a *API) PostCompanies(w http.ResponseWriter, r *http.Request) {
 
 func (a *API) GetCompanies(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
-	contactId := vars["id"]
+	companyId := vars["companyId"]
 
-	companies, err := a.companyManagement.GetCompanies(r.Context(), contactId)
+	company, err := a.companyManagement.GetCompany(r.Context(), companyId)
 	if err != nil {
-		log.Printf("Error Getting Companies for this ID: %v", err)
-		http.Error(w, "Error Getting Companies", http.StatusBadRequest)
+		log.Printf("Error Getting Company for this ID: %v", err)
+		http.Error(w, "Error Getting Company", http.StatusBadRequest)
 		return
 	}
 
-	responseBody := GetCompaniesResponseBody{TotalCompanies: len(companies), Companies: companies}
-
-	utils.MarshalAndWriteResponse(w, responseBody)
-
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, company)
 }
 
 func (a *API) UpdateCompanies(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
-	companyId := vars["id"]
+	companyId := vars["companyId"]
 	var request PUTCompanyRequestBody
 
 	err := utils.UnmarshalRequest(r, &request)
@@ -78,9 +76,10 @@ func (a *API) UpdateCompanies(w http.ResponseWriter, r *http.Request) {
 	}
 
 	company, err := a.companyManagement.UpdateCompany(r.Context(), companyId, services.UpdateCompanyRequest{
-		Name:       request.Name,
-		Address:    request.Address,
-		LogoBase64: request.LogoBase64,
+		Name:             request.Name,
+		Address:          request.Address,
+		LogoBase64:       request.LogoBase64,
+		RequireTwoFactor: request.RequireTwoFactor,
 	})
 
 	if err != nil {
@@ -94,9 +93,9 @@ func (a *API) UpdateCompanies(w http.ResponseWriter, r *http.Request) {
 
 func (a *API) DeleteCompany(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
-	companyId := vars["id"]
+	companyId := vars["companyId"]
 
-	company, err := a.companyManagement.DeleteCompany(r.Context(), companyId)
+	company, err := a.companyManagement.DeleteCompany(r.Context(), companyId, utils.GetUserIDFromSession(r).String())
 	if err != nil {
 		log.Printf("Error deleting company: %v", err)
 		http.Error(w, "Error deleting company", http.StatusBadRequest)
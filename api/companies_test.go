@@ -25,7 +25,7 @@ func TestPostCompanies_HappyPath(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	companyServiceMock := services.NewMockCompanyManagementService(ctrl)
 
-	api := NewAPI(nil, nil, nil, companyServiceMock, nil, nil, nil, nil)
+	api := NewAPI(nil, nil, nil, companyServiceMock, nil, nil, nil, nil, nil, nil, nil)
 
 	companyServiceMock.EXPECT().
 		CreateCompany(gomock.Any(), gomock.Any()).
@@ -62,7 +62,7 @@ func TestPostCompanies_ErrorIsReturned(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	companyServiceMock := services.NewMockCompanyManagementService(ctrl)
 
-	api := NewAPI(nil, nil, nil, companyServiceMock, nil, nil, nil, nil)
+	api := NewAPI(nil, nil, nil, companyServiceMock, nil, nil, nil, nil, nil, nil, nil)
 
 	companyServiceMock.EXPECT().
 		CreateCompany(gomock.Any(), gomock.Any()).
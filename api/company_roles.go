@@ -0,0 +1,137 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pro-posal/webserver/internal/utils"
+	"github.com/pro-posal/webserver/models"
+	"github.com/pro-posal/webserver/services"
+)
+
+type CompanyRoleRequestBody struct {
+	Name         string              `json:"name"`
+	Description  string              `json:"description"`
+	Capabilities []models.Capability `json:"capabilities"`
+}
+
+type GetCompanyRolesResponseBody struct {
+	TotalRoles int                   `json:"total_roles"`
+	Roles      []*models.CompanyRole `json:"roles"`
+}
+
+type GetCapabilitiesResponseBody struct {
+	Capabilities []models.Capability                 `json:"capabilities"`
+	BuiltInRoles map[models.Role][]models.Capability `json:"built_in_roles"`
+}
+
+func (a *API) PostCompanyRole(w http.ResponseWriter, r *http.Request) {
+	var request CompanyRoleRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	role, err := a.permissionsManagement.CreateCompanyRole(r.Context(), services.CompanyRoleRequest{
+		CompanyID:    mux.Vars(r)["companyId"],
+		Name:         request.Name,
+		Description:  request.Description,
+		Capabilities: request.Capabilities,
+		RequestedBy:  utils.GetUserIDFromSession(r).String(),
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrCompanyRoleExists) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+
+		log.Printf("Error Creating Company Role: %v", err)
+		writeServiceError(w, err, "Error Creating Company Role")
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, role)
+}
+
+func (a *API) GetCompanyRoles(w http.ResponseWriter, r *http.Request) {
+	roles, err := a.permissionsManagement.GetCompanyRoles(r.Context(), mux.Vars(r)["companyId"])
+	if err != nil {
+		log.Printf("Error Getting Company Roles: %v", err)
+		http.Error(w, "Error Getting Company Roles", http.StatusInternalServerError)
+		return
+	}
+
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, GetCompanyRolesResponseBody{
+		TotalRoles: len(roles),
+		Roles:      roles,
+	})
+}
+
+func (a *API) GetCapabilities(w http.ResponseWriter, r *http.Request) {
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, GetCapabilitiesResponseBody{
+		Capabilities: models.Capabilities,
+		BuiltInRoles: models.RoleCapabilities,
+	})
+}
+
+func (a *API) PutCompanyRole(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var request CompanyRoleRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	role, err := a.permissionsManagement.UpdateCompanyRole(r.Context(), services.CompanyRoleRequest{
+		CompanyID:    vars["companyId"],
+		RoleID:       vars["roleId"],
+		Name:         request.Name,
+		Description:  request.Description,
+		Capabilities: request.Capabilities,
+		RequestedBy:  utils.GetUserIDFromSession(r).String(),
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrCompanyRoleNotFound) {
+			http.Error(w, "Company role not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, services.ErrCompanyRoleExists) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+
+		log.Printf("Error Updating Company Role: %v", err)
+		writeServiceError(w, err, "Error Updating Company Role")
+		return
+	}
+
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, role)
+}
+
+func (a *API) DeleteCompanyRole(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	err := a.permissionsManagement.DeleteCompanyRole(r.Context(), vars["companyId"], vars["roleId"], utils.GetUserIDFromSession(r).String())
+	if err != nil {
+		if errors.Is(err, services.ErrCompanyRoleNotFound) {
+			http.Error(w, "Company role not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, services.ErrCompanyRoleInUse) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+
+		log.Printf("Error Deleting Company Role: %v", err)
+		writeServiceError(w, err, "Error Deleting Company Role")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -1,24 +1,54 @@
 package api
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/pro-posal/webserver/internal/rendering"
 	"github.com/pro-posal/webserver/internal/utils"
 	"github.com/pro-posal/webserver/models"
 	"github.com/pro-posal/webserver/services"
 )
 
 type PostContractRequestBody struct {
-	Name      string `json:"name"`
-	Template  string `json:"template"`
-	CompanyID string `json:"company_id"`
+	Name          string                `json:"name"`
+	Template      string                `json:"template"`
+	Language      string                `json:"language"`
+	Direction     string                `json:"direction"`
+	TranslationOf string                `json:"translation_of"`
+	Schema        models.TemplateSchema `json:"schema"`
 }
 
 type PUTContractRequestBody struct {
-	Name     string `json:"name"`
-	Template string `json:"template"`
+	Name      string                 `json:"name"`
+	Template  string                 `json:"template"`
+	Language  string                 `json:"language"`
+	Direction string                 `json:"direction"`
+	Schema    *models.TemplateSchema `json:"schema"`
+}
+
+type PostRenderContractRequestBody struct {
+	Arguments map[string]any `json:"arguments"`
+	Format    string         `json:"format"`
+}
+
+type PostLegalClauseRequestBody struct {
+	Name      string `json:"name"`
+	Content   string `json:"content"`
+	Mandatory bool   `json:"mandatory"`
+}
+
+type GetLegalClausesResponseBody struct {
+	TotalLegalClauses int                   `json:"total_legal_clauses"`
+	LegalClauses      []*models.LegalClause `json:"legal_clauses"`
+}
+
+type TemplateLintErrorResponseBody struct {
+	Error    string               `json:"error"`
+	Findings []models.LintFinding `json:"findings"`
 }
 
 type GetContractResponseBody struct {
@@ -35,12 +65,16 @@ func (a *API) PostContractsTemplates(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	contract, err := a.contractManagment.PostContractsTemplate(r.Context(), services.CreateContractTemplateRequest{
-		Name:      request.Name,
-		Template:  request.Template,
-		CompanyID: request.CompanyID})
+		Name:          request.Name,
+		Template:      request.Template,
+		CompanyID:     mux.Vars(r)["companyId"],
+		Language:      request.Language,
+		Direction:     request.Direction,
+		TranslationOf: request.TranslationOf,
+		Schema:        request.Schema})
 	if err != nil {
 		log.Printf("Error Creating a Contract Template: %v", err)
-		http.Error(w, "Error Creating a Contract Template", http.StatusBadRequest)
+		writeContractTemplateError(w, err, "Error Creating a Contract Template")
 		return
 	}
 
@@ -49,7 +83,7 @@ func (a *API) PostContractsTemplates(w http.ResponseWriter, r *http.Request) {
 
 func (a *API) GetContractsTemplate(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
-	id := vars["id"]
+	id := vars["contractId"]
 
 	contracts, err := a.contractManagment.GetContractsTemplate(r.Context(), id)
 	if err != nil {
@@ -86,11 +120,14 @@ func (a *API) UpdateContractsTemplates(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	contract, err := a.contractManagment.UpdateContractsTemplate(r.Context(), id, services.UpdateContractsTemplatesRequest{
-		Name:     request.Name,
-		Template: request.Template})
+		Name:      request.Name,
+		Template:  request.Template,
+		Language:  request.Language,
+		Direction: request.Direction,
+		Schema:    request.Schema})
 	if err != nil {
 		log.Printf("Error Updating Contract Template: %v", err)
-		http.Error(w, "Error Updating Contract Template", http.StatusBadRequest)
+		writeContractTemplateError(w, err, "Error Updating Contract Template")
 		return
 	}
 	utils.MarshalAndWriteResponse(w, contract)
@@ -100,7 +137,7 @@ func (a *API) DeleteContractsTemplates(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	contract, err := a.contractManagment.DeleteContractsTemplate(r.Context(), id)
+	contract, err := a.contractManagment.DeleteContractsTemplate(r.Context(), id, utils.GetUserIDFromSession(r).String())
 	if err != nil {
 		log.Printf("Error Deleting Contract Template: %v", err)
 		http.Error(w, "Error Deleting Contract Template", http.StatusBadRequest)
@@ -108,3 +145,141 @@ func (a *API) DeleteContractsTemplates(w http.ResponseWriter, r *http.Request) {
 	}
 	utils.MarshalAndWriteResponse(w, contract)
 }
+
+func (a *API) GetContractsTemplateTranslations(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["contractId"]
+
+	contracts, err := a.contractManagment.GetContractsTemplateTranslations(r.Context(), id)
+	if err != nil {
+		log.Printf("Error Getting Contract Template Translations: %v", err)
+		http.Error(w, "Error Getting Contract Template Translations", http.StatusBadRequest)
+		return
+	}
+	responseBody := GetContractResponseBody{TotalCompanies: len(contracts), ContractTemplate: contracts}
+	utils.MarshalAndWriteResponse(w, responseBody)
+}
+
+func (a *API) RenderContractsTemplate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["contractId"]
+	var request PostRenderContractRequestBody
+
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	rendered, err := a.contractManagment.RenderContractsTemplate(r.Context(), id, services.RenderContractTemplateRequest{
+		Arguments: request.Arguments,
+		Format:    request.Format})
+	if err != nil {
+		log.Printf("Error Rendering Contract Template: %v", err)
+		http.Error(w, "Error Rendering Contract Template", http.StatusBadRequest)
+		return
+	}
+	if rendered.ContentType == rendering.FormatPDF.ContentType() {
+		w.Header().Set("Content-Type", rendered.ContentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.pdf"`, rendered.ContractTemplateID))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(rendered.Content))
+		return
+	}
+	utils.MarshalAndWriteResponse(w, rendered)
+}
+
+func (a *API) PostLegalClauses(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var request PostLegalClauseRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	clause, err := a.contractManagment.CreateLegalClause(r.Context(), services.CreateLegalClauseRequest{
+		CompanyID: vars["companyId"],
+		Name:      request.Name,
+		Content:   request.Content,
+		Mandatory: request.Mandatory,
+	})
+	if err != nil {
+		log.Printf("Error Creating a Legal Clause: %v", err)
+		http.Error(w, "Error Creating a Legal Clause", http.StatusBadRequest)
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, clause)
+}
+
+func (a *API) GetLegalClauses(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	clauses, err := a.contractManagment.GetLegalClauses(r.Context(), vars["companyId"])
+	if err != nil {
+		log.Printf("Error Getting Legal Clauses: %v", err)
+		http.Error(w, "Error Getting Legal Clauses", http.StatusBadRequest)
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, GetLegalClausesResponseBody{
+		TotalLegalClauses: len(clauses),
+		LegalClauses:      clauses,
+	})
+}
+
+func (a *API) UpdateLegalClause(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var request PostLegalClauseRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	clause, err := a.contractManagment.UpdateLegalClause(r.Context(), vars["companyId"], vars["clauseId"], services.UpdateLegalClauseRequest{
+		Name:      request.Name,
+		Content:   request.Content,
+		Mandatory: request.Mandatory,
+	})
+	if err != nil {
+		log.Printf("Error Updating Legal Clause: %v", err)
+		http.Error(w, "Error Updating Legal Clause", http.StatusBadRequest)
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, clause)
+}
+
+func (a *API) DeleteLegalClause(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	clause, err := a.contractManagment.DeleteLegalClause(r.Context(), vars["companyId"], vars["clauseId"])
+	if err != nil {
+		log.Printf("Error Deleting Legal Clause: %v", err)
+		http.Error(w, "Error Deleting Legal Clause", http.StatusBadRequest)
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, clause)
+}
+
+// writeContractTemplateError reports lint failures as 422 with the findings, so the editor
+// can point at the offending lines.
+func writeContractTemplateError(w http.ResponseWriter, err error, message string) {
+	var lintError *services.TemplateLintError
+	if errors.As(err, &lintError) {
+		utils.MarshalAndWriteResponseWithStatus(w, http.StatusUnprocessableEntity, TemplateLintErrorResponseBody{
+			Error:    message,
+			Findings: lintError.Findings,
+		})
+		return
+	}
+	http.Error(w, message, http.StatusBadRequest)
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pro-posal/webserver/internal/utils"
+	"github.com/pro-posal/webserver/models"
+	"github.com/pro-posal/webserver/services"
+)
+
+type PostGalleryTemplateRequestBody struct {
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	Template    string                   `json:"template"`
+	Language    string                   `json:"language"`
+	Direction   string                   `json:"direction"`
+	Schema      models.TemplateSchema    `json:"schema"`
+	Categories  []models.GalleryCategory `json:"categories"`
+}
+
+type PostAdoptGalleryTemplateRequestBody struct {
+	Name string `json:"name"`
+}
+
+type GetGalleryTemplatesResponseBody struct {
+	TotalGalleryTemplates int                       `json:"total_gallery_templates"`
+	GalleryTemplates      []*models.GalleryTemplate `json:"gallery_templates"`
+}
+
+func (a *API) PostGalleryTemplates(w http.ResponseWriter, r *http.Request) {
+	var request PostGalleryTemplateRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	galleryTemplate, err := a.galleryManagment.PublishGalleryTemplate(r.Context(), galleryRequestFromBody(r, request))
+	if err != nil {
+		log.Printf("Error Publishing a Gallery Template: %v", err)
+		writeServiceError(w, err, "Error Publishing a Gallery Template")
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, galleryTemplate)
+}
+
+func (a *API) GetGalleryTemplates(w http.ResponseWriter, r *http.Request) {
+	galleryTemplates, err := a.galleryManagment.GetGalleryTemplates(r.Context())
+	if err != nil {
+		log.Printf("Error Getting Gallery Templates: %v", err)
+		http.Error(w, "Error Getting Gallery Templates", http.StatusBadRequest)
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, GetGalleryTemplatesResponseBody{
+		TotalGalleryTemplates: len(galleryTemplates),
+		GalleryTemplates:      galleryTemplates,
+	})
+}
+
+func (a *API) GetGalleryTemplate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["galleryTemplateId"]
+
+	galleryTemplate, err := a.galleryManagment.GetGalleryTemplate(r.Context(), id)
+	if err != nil {
+		log.Printf("Error Getting Gallery Template: %v", err)
+		http.Error(w, "Error Getting Gallery Template", http.StatusBadRequest)
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, galleryTemplate)
+}
+
+func (a *API) UpdateGalleryTemplate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["galleryTemplateId"]
+
+	var request PostGalleryTemplateRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	galleryTemplate, err := a.galleryManagment.UpdateGalleryTemplate(r.Context(), id, galleryRequestFromBody(r, request))
+	if err != nil {
+		log.Printf("Error Updating Gallery Template: %v", err)
+		writeServiceError(w, err, "Error Updating Gallery Template")
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, galleryTemplate)
+}
+
+func (a *API) DeleteGalleryTemplate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["galleryTemplateId"]
+
+	galleryTemplate, err := a.galleryManagment.DeleteGalleryTemplate(r.Context(), id, utils.GetUserIDFromSession(r).String())
+	if err != nil {
+		log.Printf("Error Deleting Gallery Template: %v", err)
+		writeServiceError(w, err, "Error Deleting Gallery Template")
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, galleryTemplate)
+}
+
+func (a *API) AdoptGalleryTemplate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var request PostAdoptGalleryTemplateRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	contract, err := a.galleryManagment.AdoptGalleryTemplate(r.Context(), services.AdoptGalleryTemplateRequest{
+		GalleryTemplateID: vars["galleryTemplateId"],
+		CompanyID:         vars["companyId"],
+		Name:              request.Name,
+		AdoptedBy:         utils.GetUserIDFromSession(r).String(),
+	})
+	if err != nil {
+		log.Printf("Error Adopting Gallery Template: %v", err)
+		writeServiceError(w, err, "Error Adopting Gallery Template")
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, contract)
+}
+
+func (a *API) GetOutdatedContractsTemplates(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["companyId"]
+
+	contracts, err := a.galleryManagment.GetOutdatedContractsTemplates(r.Context(), id)
+	if err != nil {
+		log.Printf("Error Getting Outdated Contract Templates: %v", err)
+		http.Error(w, "Error Getting Outdated Contract Templates", http.StatusBadRequest)
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, GetContractResponseBody{
+		TotalCompanies:   len(contracts),
+		ContractTemplate: contracts,
+	})
+}
+
+func galleryRequestFromBody(r *http.Request, request PostGalleryTemplateRequestBody) services.PublishGalleryTemplateRequest {
+	return services.PublishGalleryTemplateRequest{
+		Name:        request.Name,
+		Description: request.Description,
+		Template:    request.Template,
+		Language:    request.Language,
+		Direction:   request.Direction,
+		Schema:      request.Schema,
+		Categories:  request.Categories,
+		PublishedBy: utils.GetUserIDFromSession(r).String(),
+	}
+}
+
+func writeServiceError(w http.ResponseWriter, err error, message string) {
+	var unauthorizedError *services.UnauthorizedError
+	if errors.As(err, &unauthorizedError) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	http.Error(w, message, http.StatusBadRequest)
+}
@@ -0,0 +1,214 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pro-posal/webserver/internal/utils"
+	"github.com/pro-posal/webserver/models"
+	"github.com/pro-posal/webserver/services"
+)
+
+type IdentityProviderRequestBody struct {
+	Name   string `json:"name"`
+	Issuer string `json:"issuer"`
+	// The client is registered at the identity provider with our /sso/callback address as redirect URL
+	ClientID     string   `json:"client_id"`
+	ClientSecret string   `json:"client_secret"`
+	Scopes       []string `json:"scopes"`
+	// GroupsClaim names the ID token claim listing the user's groups, "groups" by default
+	GroupsClaim string                 `json:"groups_claim"`
+	GroupRoles  map[string]models.Role `json:"group_roles"`
+	DefaultRole models.Role            `json:"default_role"`
+	Enabled     bool                   `json:"enabled"`
+}
+
+type GetIdentityProvidersResponseBody struct {
+	TotalIdentityProviders int                        `json:"total_identity_providers"`
+	IdentityProviders      []*models.IdentityProvider `json:"identity_providers"`
+}
+
+type PostOIDCStartRequestBody struct {
+	ProviderID string `json:"provider_id"`
+}
+
+type PostOIDCCallbackRequestBody struct {
+	Code  string `json:"code"`
+	State string `json:"state"`
+}
+
+func (a *API) PostIdentityProvider(w http.ResponseWriter, r *http.Request) {
+	var request IdentityProviderRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	provider, err := a.authService.CreateIdentityProvider(r.Context(), identityProviderRequest(r, request))
+	if err != nil {
+		log.Printf("Error Creating Identity Provider: %v", err)
+		writeServiceError(w, err, "Error Creating Identity Provider")
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, provider)
+}
+
+func (a *API) GetIdentityProviders(w http.ResponseWriter, r *http.Request) {
+	providers, err := a.authService.GetIdentityProviders(r.Context(), mux.Vars(r)["companyId"], utils.GetUserIDFromSession(r).String())
+	if err != nil {
+		log.Printf("Error Getting Identity Providers: %v", err)
+		writeServiceError(w, err, "Error Getting Identity Providers")
+		return
+	}
+
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, GetIdentityProvidersResponseBody{
+		TotalIdentityProviders: len(providers),
+		IdentityProviders:      providers,
+	})
+}
+
+// PutIdentityProvider replaces a provider's settings, leaving client_secret empty keeps the current secret.
+func (a *API) PutIdentityProvider(w http.ResponseWriter, r *http.Request) {
+	var request IdentityProviderRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	provider, err := a.authService.UpdateIdentityProvider(r.Context(), mux.Vars(r)["providerId"], identityProviderRequest(r, request))
+	if err != nil {
+		log.Printf("Error Updating Identity Provider: %v", err)
+		writeServiceError(w, err, "Error Updating Identity Provider")
+		return
+	}
+
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, provider)
+}
+
+func (a *API) DeleteIdentityProvider(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	err := a.authService.DeleteIdentityProvider(r.Context(), vars["companyId"], vars["providerId"], utils.GetUserIDFromSession(r).String())
+	if err != nil {
+		log.Printf("Error Deleting Identity Provider: %v", err)
+		writeServiceError(w, err, "Error Deleting Identity Provider")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PostOIDCStart begins a single sign-on, the web app sends the user to the returned authorization URL.
+func (a *API) PostOIDCStart(w http.ResponseWriter, r *http.Request) {
+	var request PostOIDCStartRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	login, err := a.authService.StartOIDCLogin(r.Context(), services.StartOIDCLoginRequest{
+		ProviderID: request.ProviderID,
+		Client:     sessionClient(r),
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidIdentityProvider) {
+			http.Error(w, "Identity provider not found", http.StatusNotFound)
+			return
+		}
+
+		log.Printf("Failed starting single sign-on: %v", err)
+		http.Error(w, "Failed starting single sign-on", http.StatusBadGateway)
+		return
+	}
+
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, login)
+}
+
+// PostOIDCLink begins a single sign-on that links the identity to the caller's account, which
+// completes through PostOIDCCallback like any other sign in.
+func (a *API) PostOIDCLink(w http.ResponseWriter, r *http.Request) {
+	var request PostOIDCStartRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	login, err := a.authService.StartOIDCLogin(r.Context(), services.StartOIDCLoginRequest{
+		ProviderID: request.ProviderID,
+		LinkUserID: utils.GetUserIDFromSession(r).String(),
+		Client:     sessionClient(r),
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidIdentityProvider) {
+			http.Error(w, "Identity provider not found", http.StatusNotFound)
+			return
+		}
+
+		log.Printf("Failed starting single sign-on: %v", err)
+		http.Error(w, "Failed starting single sign-on", http.StatusBadGateway)
+		return
+	}
+
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, login)
+}
+
+// PostOIDCCallback completes a single sign-on with the code and state the identity provider
+// redirected back to the web app with, and answers like PostUsersLogin.
+func (a *API) PostOIDCCallback(w http.ResponseWriter, r *http.Request) {
+	var request PostOIDCCallbackRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	token, err := a.authService.CompleteOIDCLogin(r.Context(), services.CompleteOIDCLoginRequest{
+		Code:   request.Code,
+		State:  request.State,
+		Client: sessionClient(r),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidOIDCState), errors.Is(err, services.ErrOIDCLoginFailed),
+			errors.Is(err, services.ErrInvalidIdentityProvider):
+			http.Error(w, "Single sign-on failed, please try again", http.StatusUnauthorized)
+		case errors.Is(err, services.ErrOIDCNoRole), errors.Is(err, services.ErrOIDCEmailNotVerified),
+			errors.Is(err, services.ErrOIDCAccountExists), errors.Is(err, services.ErrOIDCIdentityLinked),
+			errors.Is(err, services.ErrEmailNotVerified):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		default:
+			log.Printf("Failed completing single sign-on: %v", err)
+			http.Error(w, "Failed completing single sign-on", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	writeAuthToken(w, token)
+}
+
+func identityProviderRequest(r *http.Request, body IdentityProviderRequestBody) services.IdentityProviderRequest {
+	return services.IdentityProviderRequest{
+		CompanyID:    mux.Vars(r)["companyId"],
+		Name:         body.Name,
+		Issuer:       body.Issuer,
+		ClientID:     body.ClientID,
+		ClientSecret: body.ClientSecret,
+		Scopes:       body.Scopes,
+		GroupsClaim:  body.GroupsClaim,
+		GroupRoles:   body.GroupRoles,
+		DefaultRole:  body.DefaultRole,
+		Enabled:      body.Enabled,
+		RequestedBy:  utils.GetUserIDFromSession(r).String(),
+	}
+}
@@ -0,0 +1,57 @@
+package integrationtests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/pro-posal/webserver/api"
+	"github.com/pro-posal/webserver/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func explain(t *testing.T, request api.PostAuthzExplainRequestBody) api.PostAuthzExplainResponseBody {
+	t.Helper()
+	var explanation api.PostAuthzExplainResponseBody
+	client.Post(t, "/authz/explain", request, http.StatusOK, &explanation)
+	require.NotNil(t, explanation.Decision)
+	return explanation
+}
+
+func TestAuthzExplain_ReportsWhyARequestIsDenied(t *testing.T) {
+	company := postCompany(t, client)
+	otherCompany := postCompany(t, client)
+	contributor, user := signUp(t)
+	grantRole(t, company.ID, user.ID, models.CompanyContributorRole)
+	grantRole(t, otherCompany.ID, user.ID, models.CompanyAdminRole)
+
+	allowed := explain(t, api.PostAuthzExplainRequestBody{UserID: user.ID, Method: "get", Path: "/companies/" + company.ID})
+	assert.True(t, allowed.Allowed)
+	assert.Equal(t, "GET /companies/{companyId}", allowed.Route)
+	assert.Equal(t, company.ID, allowed.CompanyID)
+
+	// Only the permissions in the requested company matter
+	denied := explain(t, api.PostAuthzExplainRequestBody{UserID: user.ID, Method: "PUT", Path: "/companies/" + company.ID})
+	assert.False(t, denied.Allowed)
+	assert.Contains(t, denied.Reason, string(models.CapabilityCompanyManage))
+	require.Len(t, denied.Permissions, 1)
+	assert.Equal(t, models.CompanyContributorRole, denied.Permissions[0].Role)
+
+	// Routes that find the company in the body are explained with the body
+	body, err := json.Marshal(map[string]string{"company_id": otherCompany.ID})
+	require.NoError(t, err)
+	fromBody := explain(t, api.PostAuthzExplainRequestBody{UserID: user.ID, Method: "POST", Path: "/permissions/" + otherCompany.ID, Body: body})
+	assert.True(t, fromBody.Allowed)
+	assert.Equal(t, otherCompany.ID, fromBody.CompanyID)
+
+	self := explain(t, api.PostAuthzExplainRequestBody{UserID: user.ID, Method: "GET", Path: "/users/" + company.ID})
+	assert.False(t, self.Allowed)
+	assert.Equal(t, "the user themselves", self.Rule)
+
+	client.Post(t, "/authz/explain", api.PostAuthzExplainRequestBody{UserID: user.ID, Method: "GET", Path: "/nowhere"}, http.StatusNotFound, nil)
+	client.Post(t, "/authz/explain", api.PostAuthzExplainRequestBody{Method: "GET", Path: "/status"}, http.StatusBadRequest, nil)
+
+	// Only platform admins may ask
+	contributor.Post(t, "/authz/explain", api.PostAuthzExplainRequestBody{UserID: user.ID, Method: "GET", Path: "/status"}, http.StatusUnauthorized, nil)
+}
@@ -0,0 +1,236 @@
+package integrationtests
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pro-posal/webserver/api"
+	"github.com/pro-posal/webserver/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// postCategory adds a category to the company through the client, under parentID when it is set.
+func postCategory(t *testing.T, c *ApiClient, companyID string, parentID string, description string, categoryType models.CategoryType) *models.Category {
+	t.Helper()
+	url := "/categories"
+	if parentID != "" {
+		url += "/" + parentID
+	}
+
+	var category models.Category
+	c.Post(t, url, map[string]string{
+		"company_id":  companyID,
+		"category_id": parentID,
+		"description": description,
+		"type":        string(categoryType),
+	}, http.StatusCreated, &category)
+	return &category
+}
+
+// importCatalog imports the CSV sheet into the company's catalog and returns its category tree.
+func importCatalog(t *testing.T, companyID string, sheet string) []*models.CategoryNode {
+	t.Helper()
+	var report models.CatalogImportReport
+	client.Post(t, fmt.Sprintf("/companies/%s/categories/import?format=csv", companyID), strings.NewReader(sheet), http.StatusCreated, &report)
+	require.Empty(t, report.Errors)
+	return getCategoryTree(t, client, companyID, "")
+}
+
+func getCategoryTree(t *testing.T, c *ApiClient, companyID string, query string) []*models.CategoryNode {
+	t.Helper()
+	var tree api.GetCategoryTreeResponseBody
+	c.Get(t, fmt.Sprintf("/companies/%s/categories/tree%s", companyID, query), http.StatusCreated, &tree)
+	return tree.Categories
+}
+
+func TestGetCategoryTree_NestsLevelsAndHidesDeleted(t *testing.T) {
+	company := postCompany(t, client)
+	contributor, contributorUser := signUp(t)
+	grantRole(t, company.ID, contributorUser.ID, models.CompanyContributorRole)
+
+	kitchen := postCategory(t, client, company.ID, "", "Kitchen", models.CategoryTypeCategory)
+	cabinets := postCategory(t, client, company.ID, kitchen.ID, "Cabinets", models.CategoryTypeSubCategory)
+	worktops := postCategory(t, client, company.ID, kitchen.ID, "Worktops", models.CategoryTypeSubCategory)
+	postCategory(t, client, company.ID, cabinets.ID, "Oak door", models.CategoryTypeDescription)
+	postCategory(t, client, company.ID, cabinets.ID, "Pine door", models.CategoryTypeDescription)
+
+	tree := getCategoryTree(t, client, company.ID, "")
+	require.Len(t, tree, 1)
+	assert.Equal(t, kitchen.ID, tree[0].ID)
+	assert.Equal(t, 1, tree[0].Depth)
+	require.Len(t, tree[0].Children, 2)
+	assert.Equal(t, cabinets.ID, tree[0].Children[0].ID)
+	assert.Equal(t, 2, tree[0].Children[0].Depth)
+	require.Len(t, tree[0].Children[0].Children, 2)
+	assert.Equal(t, 3, tree[0].Children[0].Children[0].Depth)
+
+	shallow := getCategoryTree(t, client, company.ID, "?depth=2")
+	require.Len(t, shallow[0].Children, 2)
+	assert.Empty(t, shallow[0].Children[0].Children)
+
+	client.Delete(t, fmt.Sprintf("/categories/%s?id=%s", worktops.ID, worktops.ID), http.StatusCreated, nil)
+	tree = getCategoryTree(t, client, company.ID, "")
+	require.Len(t, tree[0].Children, 1)
+	assert.Equal(t, cabinets.ID, tree[0].Children[0].ID)
+
+	// Only admins may see what was deleted
+	contributor.Get(t, fmt.Sprintf("/companies/%s/categories/tree?include_deleted=true", company.ID), http.StatusUnauthorized, nil)
+	tree = getCategoryTree(t, client, company.ID, "?include_deleted=true")
+	require.Len(t, tree[0].Children, 2)
+	assert.Equal(t, worktops.ID, tree[0].Children[1].ID)
+	assert.False(t, tree[0].Children[1].DeleteAt.IsZero())
+}
+
+func TestMoveCategory_KeepsTheHierarchy(t *testing.T) {
+	company := postCompany(t, client)
+	kitchen := postCategory(t, client, company.ID, "", "Kitchen", models.CategoryTypeCategory)
+	cabinets := postCategory(t, client, company.ID, kitchen.ID, "Cabinets", models.CategoryTypeSubCategory)
+	oakDoor := postCategory(t, client, company.ID, cabinets.ID, "Oak door", models.CategoryTypeDescription)
+	worktops := postCategory(t, client, company.ID, kitchen.ID, "Worktops", models.CategoryTypeSubCategory)
+	bathroom := postCategory(t, client, company.ID, "", "Bathroom", models.CategoryTypeCategory)
+	tiles := postCategory(t, client, company.ID, bathroom.ID, "Tiles", models.CategoryTypeSubCategory)
+
+	// Moving to the front of the new parent shifts its children down
+	var moved models.Category
+	client.Post(t, fmt.Sprintf("/companies/%s/categories/%s/move", company.ID, cabinets.ID), map[string]any{"parent_id": bathroom.ID, "position": 0}, http.StatusCreated, &moved)
+	assert.Equal(t, bathroom.ID, moved.CategoryID)
+
+	tree := getCategoryTree(t, client, company.ID, "")
+	require.Len(t, tree[0].Children, 1)
+	assert.Equal(t, worktops.ID, tree[0].Children[0].ID)
+	assert.Equal(t, 0, tree[0].Children[0].Position)
+	require.Len(t, tree[1].Children, 2)
+	assert.Equal(t, cabinets.ID, tree[1].Children[0].ID)
+	assert.Equal(t, tiles.ID, tree[1].Children[1].ID)
+	assert.Equal(t, 1, tree[1].Children[1].Position)
+	// The subtree moves along
+	require.Len(t, tree[1].Children[0].Children, 1)
+	assert.Equal(t, oakDoor.ID, tree[1].Children[0].Children[0].ID)
+
+	other := postCompany(t, client)
+	garden := postCategory(t, client, other.ID, "", "Garden", models.CategoryTypeCategory)
+	for name, tc := range map[string]struct {
+		category *models.Category
+		body     map[string]any
+	}{
+		"description to the top level":   {oakDoor, map[string]any{}},
+		"description under a category":   {oakDoor, map[string]any{"parent_id": kitchen.ID}},
+		"category under its own child":   {kitchen, map[string]any{"parent_id": worktops.ID}},
+		"sub category under itself":      {worktops, map[string]any{"parent_id": worktops.ID}},
+		"parent in another company":      {worktops, map[string]any{"parent_id": garden.ID}},
+		"position past the last sibling": {worktops, map[string]any{"parent_id": bathroom.ID, "position": 5}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client.Post(t, fmt.Sprintf("/companies/%s/categories/%s/move", company.ID, tc.category.ID), tc.body, http.StatusBadRequest, nil)
+		})
+	}
+}
+
+func TestReorderCategories_RequiresEverySiblingOnce(t *testing.T) {
+	company := postCompany(t, client)
+	kitchen := postCategory(t, client, company.ID, "", "Kitchen", models.CategoryTypeCategory).ID
+	bathroom := postCategory(t, client, company.ID, "", "Bathroom", models.CategoryTypeCategory).ID
+	garden := postCategory(t, client, company.ID, "", "Garden", models.CategoryTypeCategory).ID
+	reorder := func(categoryIDs []string, statusCode int) {
+		t.Helper()
+		client.Put(t, fmt.Sprintf("/companies/%s/categories/order", company.ID), api.PutCategoriesOrderRequestBody{CategoryIDs: categoryIDs}, statusCode, nil)
+	}
+
+	reorder([]string{garden, kitchen}, http.StatusBadRequest)
+	reorder([]string{garden, kitchen, kitchen}, http.StatusBadRequest)
+	reorder([]string{garden, kitchen, bathroom}, http.StatusCreated)
+
+	tree := getCategoryTree(t, client, company.ID, "")
+	require.Len(t, tree, 3)
+	assert.Equal(t, []string{garden, kitchen, bathroom}, []string{tree[0].ID, tree[1].ID, tree[2].ID})
+}
+
+func TestRenderContractsTemplate_ListsLineItemsInCatalogOrder(t *testing.T) {
+	company := postCompany(t, client)
+	kitchen := postCategory(t, client, company.ID, "", "Kitchen", models.CategoryTypeCategory)
+	cabinets := postCategory(t, client, company.ID, kitchen.ID, "Cabinets", models.CategoryTypeSubCategory)
+	oakDoor := postCategory(t, client, company.ID, cabinets.ID, "Oak door", models.CategoryTypeDescription)
+	worktops := postCategory(t, client, company.ID, kitchen.ID, "Worktops", models.CategoryTypeSubCategory)
+	bathroom := postCategory(t, client, company.ID, "", "Bathroom", models.CategoryTypeCategory)
+	contract := postContractTemplate(t, client, company.ID, `{{range .line_items}}{{.name}};{{end}}{{block "signature" .}}{{end}}`)
+
+	var rendered models.RenderedContract
+	client.Post(t, fmt.Sprintf("/companies/%s/contracts/%s/render", company.ID, contract.ID), api.PostRenderContractRequestBody{
+		Format: "text",
+		Arguments: map[string]any{"line_items": []any{
+			map[string]any{"name": "custom"},
+			map[string]any{"name": "bathroom", "category_id": bathroom.ID},
+			map[string]any{"name": "worktops", "category_id": worktops.ID},
+			map[string]any{"name": "oak door", "category_id": oakDoor.ID},
+		}},
+	}, http.StatusCreated, &rendered)
+	assert.Equal(t, "oak door;worktops;bathroom;custom;", rendered.Content)
+}
+
+func TestCopyCatalog_ConflictPolicies(t *testing.T) {
+	source := postCompany(t, client)
+	target := postCompany(t, client)
+	sourceTree := importCatalog(t, source.ID, "category,sub_category,description,unit,price\n"+
+		"Kitchen,Cabinets,Oak door,m,120\n"+
+		"Kitchen,Cabinets,Pine door,m,80\n")
+	targetTree := importCatalog(t, target.ID, "category,sub_category,description,unit,price\n"+
+		"kitchen,Cabinets,Oak door,m,100\n")
+	kitchen := sourceTree[0]
+	oakDoor, pineDoor := kitchen.Children[0].Children[0], kitchen.Children[0].Children[1]
+	targetOakDoor := targetTree[0].Children[0].Children[0]
+	copyURL := fmt.Sprintf("/companies/%s/categories/copy", source.ID)
+
+	var report models.CatalogCopyReport
+	client.Post(t, copyURL, api.PostCatalogCopyRequestBody{
+		TargetCompanyID: target.ID,
+		CategoryID:      kitchen.ID,
+		ConflictPolicy:  models.CatalogConflictSkip,
+	}, http.StatusCreated, &report)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 2, report.Merged)
+	assert.Equal(t, 1, report.Skipped)
+	// Categories that already exist are reused, with their IDs in the target
+	assert.Equal(t, targetTree[0].ID, report.Categories[kitchen.ID])
+	assert.Equal(t, targetOakDoor.ID, report.Categories[oakDoor.ID])
+	assert.NotEqual(t, pineDoor.ID, report.Categories[pineDoor.ID])
+
+	tree := getCategoryTree(t, client, target.ID, "")
+	require.Len(t, tree, 1)
+	descriptions := tree[0].Children[0].Children
+	require.Len(t, descriptions, 2)
+	assert.Equal(t, targetOakDoor.Price, descriptions[0].Price)
+	assert.Equal(t, pineDoor.Price, descriptions[1].Price)
+
+	report = models.CatalogCopyReport{}
+	client.Post(t, copyURL, api.PostCatalogCopyRequestBody{
+		TargetCompanyID: target.ID,
+		ConflictPolicy:  models.CatalogConflictMerge,
+	}, http.StatusCreated, &report)
+	assert.Equal(t, 0, report.Created)
+	assert.Equal(t, 1, report.Updated)
+	assert.Equal(t, 1, report.Unchanged)
+
+	tree = getCategoryTree(t, client, target.ID, "")
+	assert.Equal(t, oakDoor.Price, tree[0].Children[0].Children[0].Price)
+
+	client.Post(t, copyURL, api.PostCatalogCopyRequestBody{TargetCompanyID: target.ID, ConflictPolicy: "overwrite"}, http.StatusBadRequest, nil)
+}
+
+func TestCopyCatalog_RequiresAdminOfBothCompanies(t *testing.T) {
+	source := postCompany(t, client)
+	target := postCompany(t, client)
+	importCatalog(t, source.ID, "category\nKitchen\n")
+	admin, adminUser := signUp(t)
+	grantRole(t, source.ID, adminUser.ID, models.CompanyAdminRole)
+	grantRole(t, target.ID, adminUser.ID, models.CompanyContributorRole)
+
+	admin.Post(t, fmt.Sprintf("/companies/%s/categories/copy", source.ID), api.PostCatalogCopyRequestBody{TargetCompanyID: target.ID}, http.StatusUnauthorized, nil)
+	assert.Empty(t, getCategoryTree(t, client, target.ID, ""))
+
+	grantRole(t, target.ID, adminUser.ID, models.CompanyAdminRole)
+	admin.Post(t, fmt.Sprintf("/companies/%s/categories/copy", source.ID), api.PostCatalogCopyRequestBody{TargetCompanyID: target.ID}, http.StatusCreated, nil)
+	assert.Len(t, getCategoryTree(t, client, target.ID, ""), 1)
+}
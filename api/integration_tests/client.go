@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"testing"
 
@@ -66,11 +67,44 @@ func (c *ApiClient) ObtainAuthToken(email string, password string) error {
 
 func (c *ApiClient) Post(t *testing.T, url string, payload any, statusCode int, resp any) {
 	t.Helper()
+	c.Do(t, "POST", url, payload, statusCode, resp)
+}
 
-	bodyBytes, err := json.Marshal(payload)
-	require.NoError(t, err)
+func (c *ApiClient) Get(t *testing.T, url string, statusCode int, resp any) {
+	t.Helper()
+	c.Do(t, "GET", url, nil, statusCode, resp)
+}
+
+func (c *ApiClient) Put(t *testing.T, url string, payload any, statusCode int, resp any) {
+	t.Helper()
+	c.Do(t, "PUT", url, payload, statusCode, resp)
+}
+
+func (c *ApiClient) Patch(t *testing.T, url string, payload any, statusCode int, resp any) {
+	t.Helper()
+	c.Do(t, "PATCH", url, payload, statusCode, resp)
+}
 
-	req, err := http.NewRequest("POST", c.baseURL+url, bytes.NewBuffer(bodyBytes))
+func (c *ApiClient) Delete(t *testing.T, url string, statusCode int, resp any) {
+	t.Helper()
+	c.Do(t, "DELETE", url, nil, statusCode, resp)
+}
+
+// Do sends the payload as JSON, or as it is when it is a reader, checks the status code and
+// decodes the response into resp.
+func (c *ApiClient) Do(t *testing.T, method string, url string, payload any, statusCode int, resp any) {
+	t.Helper()
+
+	var body io.Reader
+	if reader, ok := payload.(io.Reader); ok {
+		body = reader
+	} else if payload != nil {
+		bodyBytes, err := json.Marshal(payload)
+		require.NoError(t, err)
+		body = bytes.NewBuffer(bodyBytes)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+url, body)
 	require.NoError(t, err)
 	req.Header.Add("Authorization", "Bearer "+c.authToken)
 
@@ -85,7 +119,3 @@ func (c *ApiClient) Post(t *testing.T, url string, payload any, statusCode int,
 		require.NoError(t, err)
 	}
 }
-
-func (c *ApiClient) Get(t *testing.T, url string, statusCode int, resp any) {
-	// TODO: Implement me
-}
@@ -0,0 +1,115 @@
+package integrationtests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/pro-posal/webserver/api"
+	"github.com/pro-posal/webserver/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// postCompanyRole defines a company role through the client.
+func postCompanyRole(t *testing.T, c *ApiClient, companyID string, name string, capabilities ...models.Capability) *models.CompanyRole {
+	t.Helper()
+	var role models.CompanyRole
+	c.Post(t, fmt.Sprintf("/companies/%s/roles", companyID), api.CompanyRoleRequestBody{
+		Name:         name,
+		Capabilities: capabilities,
+	}, http.StatusCreated, &role)
+	return &role
+}
+
+func TestCompanyRoles_CreateUpdateAndDelete(t *testing.T) {
+	company := postCompany(t, client)
+	roles := fmt.Sprintf("/companies/%s/roles", company.ID)
+
+	role := postCompanyRole(t, client, company.ID, "Pricing", models.CapabilityCatalogView, models.CapabilityCatalogEdit, models.CapabilityCatalogView)
+	assert.Equal(t, []models.Capability{models.CapabilityCatalogEdit, models.CapabilityCatalogView}, role.Capabilities)
+
+	client.Post(t, roles, api.CompanyRoleRequestBody{Name: "Pricing", Capabilities: []models.Capability{models.CapabilityCatalogView}}, http.StatusConflict, nil)
+	client.Post(t, roles, api.CompanyRoleRequestBody{Name: "Unknown", Capabilities: []models.Capability{"catalog.burn"}}, http.StatusBadRequest, nil)
+	client.Post(t, roles, api.CompanyRoleRequestBody{Name: "Empty"}, http.StatusBadRequest, nil)
+
+	var updated models.CompanyRole
+	client.Put(t, roles+"/"+role.ID, api.CompanyRoleRequestBody{
+		Name:         "Pricing manager",
+		Description:  "Keeps the catalog prices up to date",
+		Capabilities: []models.Capability{models.CapabilityCatalogView, models.CapabilityCatalogEdit, models.CapabilityOffersView},
+	}, http.StatusOK, &updated)
+	assert.Equal(t, "Pricing manager", updated.Name)
+	assert.Len(t, updated.Capabilities, 3)
+
+	var listed api.GetCompanyRolesResponseBody
+	client.Get(t, roles, http.StatusOK, &listed)
+	assert.Equal(t, 1, listed.TotalRoles)
+
+	// Roles can't be deleted while someone holds them
+	_, holder := signUp(t)
+	var permission models.Permission
+	client.Post(t, "/permissions/"+company.ID, map[string]string{
+		"user_id":         holder.ID,
+		"company_id":      company.ID,
+		"company_role_id": role.ID,
+	}, http.StatusCreated, &permission)
+	assert.Equal(t, models.CompanyCustomRole, permission.Role)
+	client.Delete(t, roles+"/"+role.ID, http.StatusConflict, nil)
+
+	client.Delete(t, "/permissions/"+permission.ID, http.StatusCreated, nil)
+	client.Delete(t, roles+"/"+role.ID, http.StatusNoContent, nil)
+	client.Delete(t, roles+"/"+role.ID, http.StatusNotFound, nil)
+}
+
+func TestCompanyRoles_OnlyGrantWhatTheCallerHolds(t *testing.T) {
+	company := postCompany(t, client)
+	roles := fmt.Sprintf("/companies/%s/roles", company.ID)
+	membersManager := postCompanyRole(t, client, company.ID, "Members manager",
+		models.CapabilityMembersView, models.CapabilityMembersManage, models.CapabilityCatalogView, models.CapabilityOffersCreate)
+
+	manager, managerUser := signUp(t)
+	client.Post(t, "/permissions/"+company.ID, map[string]string{
+		"user_id":         managerUser.ID,
+		"company_id":      company.ID,
+		"company_role_id": membersManager.ID,
+	}, http.StatusCreated, nil)
+
+	// Roles can only be made of capabilities the caller holds, except answering offers for whoever sends them
+	postCompanyRole(t, manager, company.ID, "Viewer", models.CapabilityCatalogView, models.CapabilityOffersRespond)
+	manager.Post(t, roles, api.CompanyRoleRequestBody{Name: "Editor", Capabilities: []models.Capability{models.CapabilityCatalogEdit}}, http.StatusUnauthorized, nil)
+	manager.Put(t, roles+"/"+membersManager.ID, api.CompanyRoleRequestBody{
+		Name:         "Members manager",
+		Capabilities: []models.Capability{models.CapabilityMembersManage, models.CapabilityCatalogEdit},
+	}, http.StatusUnauthorized, nil)
+
+	// Built-in roles are checked the same way
+	_, user := signUp(t)
+	grant := func(c *ApiClient, role models.Role, status int) {
+		c.Post(t, "/permissions/"+company.ID, map[string]string{
+			"user_id":    user.ID,
+			"company_id": company.ID,
+			"role":       string(role),
+		}, status, nil)
+	}
+	grant(manager, models.CompanyContributorRole, http.StatusUnauthorized)
+	grant(manager, models.AdminRole, http.StatusBadRequest)
+
+	// Holders get a role's new capabilities right away
+	client.Put(t, roles+"/"+membersManager.ID, api.CompanyRoleRequestBody{
+		Name:         "Members manager",
+		Capabilities: append(membersManager.Capabilities, models.CapabilityCatalogEdit),
+	}, http.StatusOK, nil)
+	postCompanyRole(t, manager, company.ID, "Editor", models.CapabilityCatalogEdit)
+
+	var capabilities api.GetCapabilitiesResponseBody
+	manager.Get(t, "/roles/capabilities", http.StatusOK, &capabilities)
+	assert.ElementsMatch(t, models.Capabilities, capabilities.Capabilities)
+	assert.Equal(t, []models.Capability{models.CapabilityOffersRespond}, capabilities.BuiltInRoles[models.ProspectRole])
+}
+
+func TestCompanyRoles_PlatformAdminsGrantInEveryCompany(t *testing.T) {
+	// The admin holds no role in a company someone else created
+	company, _ := seedCompany(t)
+	role := postCompanyRole(t, client, company.ID, "Pricing", models.CapabilityCatalogEdit, models.CapabilityMembersManage)
+	assert.Len(t, role.Capabilities, 2)
+}
@@ -0,0 +1,73 @@
+package integrationtests
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/pro-posal/webserver/api"
+	"github.com/pro-posal/webserver/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// verificationMode switches the email verification mode for the rest of the test.
+func verificationMode(t *testing.T, mode string) {
+	t.Helper()
+	previous := config.AppConfig.Auth.EmailVerificationMode
+	config.AppConfig.Auth.EmailVerificationMode = mode
+	t.Cleanup(func() { config.AppConfig.Auth.EmailVerificationMode = previous })
+}
+
+func TestEmailVerification_BlocksChangesUntilVerified(t *testing.T) {
+	verificationMode(t, config.EmailVerificationSensitive)
+	user, password := postUser(t)
+	c := asUser(logIn(t, user.Email, password).AccessToken)
+	changePassword := api.PutUsersPasswordRequestBody{CurrentPassword: password, NewPassword: password}
+
+	// Unverified users can read but not change anything
+	c.Get(t, "/users/"+user.ID, http.StatusCreated, nil)
+	c.Patch(t, "/users/updatePassword", changePassword, http.StatusForbidden, nil)
+
+	// The link is signed like an auth token, but doesn't sign anyone in
+	asUser(inbox.lastToken(t, user.Email)).Get(t, "/users/"+user.ID, http.StatusForbidden, nil)
+
+	client.Post(t, "/users/verifyEmail", api.PostVerifyEmailRequestBody{Token: "invalid"}, http.StatusForbidden, nil)
+	client.Post(t, "/users/verifyEmail", api.PostVerifyEmailRequestBody{Token: inbox.lastToken(t, user.Email)}, http.StatusCreated, nil)
+	c.Patch(t, "/users/updatePassword", changePassword, http.StatusCreated, nil)
+
+	// A new address has to be verified again, the link sent to the old one doesn't verify it
+	oldToken := inbox.lastToken(t, user.Email)
+	newEmail := gofakeit.Email()
+	c.Patch(t, "/users/updateEmail", api.PatchUsersEmailRequestBody{CurrentPassword: password, NewEmail: newEmail}, http.StatusCreated, nil)
+	c.Patch(t, "/users/updatePassword", changePassword, http.StatusForbidden, nil)
+	client.Post(t, "/users/verifyEmail", api.PostVerifyEmailRequestBody{Token: oldToken}, http.StatusForbidden, nil)
+
+	// Their route allows unverified users to correct a mistyped address
+	correctedEmail := gofakeit.Email()
+	c.Patch(t, "/users/updateEmail", api.PatchUsersEmailRequestBody{CurrentPassword: password, NewEmail: correctedEmail}, http.StatusCreated, nil)
+	client.Post(t, "/users/verifyEmail", api.PostVerifyEmailRequestBody{Token: inbox.lastToken(t, strings.ToLower(correctedEmail))}, http.StatusCreated, nil)
+	c.Patch(t, "/users/updatePassword", changePassword, http.StatusCreated, nil)
+}
+
+func TestEmailVerification_LoginMode(t *testing.T) {
+	verificationMode(t, config.EmailVerificationLogin)
+	user, password := postUser(t)
+
+	client.Post(t, "/users/login", api.PostUsersLoginRequestBody{Email: user.Email, Password: password}, http.StatusForbidden, nil)
+
+	client.Post(t, "/users/verifyEmail", api.PostVerifyEmailRequestBody{Token: inbox.lastToken(t, user.Email)}, http.StatusCreated, nil)
+	logIn(t, user.Email, password)
+}
+
+func TestResendVerification_IsRateLimited(t *testing.T) {
+	user, _ := postUser(t)
+	assert.Equal(t, 1, inbox.count(user.Email))
+
+	// The email sent on sign up is recent enough
+	client.Post(t, "/users/verifyEmail/resend", api.PostResendVerificationRequestBody{Email: user.Email}, http.StatusTooManyRequests, nil)
+	assert.Equal(t, 1, inbox.count(user.Email))
+
+	// Unknown addresses aren't told apart
+	client.Post(t, "/users/verifyEmail/resend", api.PostResendVerificationRequestBody{Email: gofakeit.Email()}, http.StatusAccepted, nil)
+}
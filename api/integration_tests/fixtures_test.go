@@ -0,0 +1,141 @@
+package integrationtests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/google/uuid"
+	"github.com/pro-posal/webserver/api"
+	"github.com/pro-posal/webserver/config"
+	"github.com/pro-posal/webserver/dao"
+	"github.com/pro-posal/webserver/internal/utils"
+	"github.com/pro-posal/webserver/models"
+	"github.com/pro-posal/webserver/services"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// postUser creates a user through the API and returns it with its password.
+func postUser(t *testing.T) (*models.User, string) {
+	t.Helper()
+	password := gofakeit.Password(true, true, true, true, false, 12)
+
+	var user models.User
+	client.Post(t, "/users", api.PostUsersRequestBody{
+		FirstName: gofakeit.FirstName(),
+		LastName:  gofakeit.LastName(),
+		Email:     gofakeit.Email(),
+		Password:  password,
+	}, http.StatusCreated, &user)
+	return &user, password
+}
+
+// logIn signs the user in and returns their tokens.
+func logIn(t *testing.T, email string, password string) api.PostUsersLoginResponseBody {
+	t.Helper()
+	var token api.PostUsersLoginResponseBody
+	client.Post(t, "/users/login", api.PostUsersLoginRequestBody{Email: email, Password: password}, http.StatusOK, &token)
+	return token
+}
+
+// asUser returns a client acting with the access token.
+func asUser(accessToken string) *ApiClient {
+	return &ApiClient{
+		client:    &http.Client{},
+		baseURL:   config.TestConfig.TestServer.URL,
+		authToken: accessToken,
+	}
+}
+
+// signUp creates a user through the API and signs them in.
+func signUp(t *testing.T) (*ApiClient, *models.User) {
+	t.Helper()
+	user, password := postUser(t)
+	return asUser(logIn(t, user.Email, password).AccessToken), user
+}
+
+// postCompany creates a company through the API, its contact is the client's user.
+func postCompany(t *testing.T, c *ApiClient) *models.Company {
+	t.Helper()
+	var company models.Company
+	c.Post(t, "/companies", map[string]string{
+		"name":    gofakeit.Company(),
+		"address": gofakeit.Address().Address,
+	}, http.StatusCreated, &company)
+	return &company
+}
+
+// grantRole gives the user a role in the company through the admin client.
+func grantRole(t *testing.T, companyID string, userID string, role models.Role) {
+	t.Helper()
+	client.Post(t, "/permissions/"+companyID, map[string]string{
+		"user_id":    userID,
+		"company_id": companyID,
+		"role":       string(role),
+	}, http.StatusCreated, nil)
+}
+
+// postContractTemplate adds an English contract template to the company through the client.
+func postContractTemplate(t *testing.T, c *ApiClient, companyID string, template string) *models.ContractTemplate {
+	t.Helper()
+	var contract models.ContractTemplate
+	c.Post(t, fmt.Sprintf("/companies/%s/contracts", companyID), map[string]string{
+		"name":       gofakeit.BeerName(),
+		"company_id": companyID,
+		"template":   template,
+		"language":   "en",
+	}, http.StatusCreated, &contract)
+	return &contract
+}
+
+// seedUser inserts a verified user straight into the database.
+func seedUser(t *testing.T) *dao.User {
+	t.Helper()
+	email := gofakeit.Email()
+	userDao := &dao.User{
+		ID:              uuid.NewString(),
+		FirstName:       gofakeit.FirstName(),
+		LastName:        gofakeit.LastName(),
+		Email:           email,
+		EmailHash:       utils.HashEmail(email),
+		EmailVerifiedAt: null.TimeFrom(time.Now()),
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+	require.NoError(t, userDao.Insert(context.Background(), testDB.Conn, boil.Infer()))
+	return userDao
+}
+
+// seedCompany creates a company owned by a new user, who becomes its company admin.
+func seedCompany(t *testing.T) (*models.Company, *dao.User) {
+	t.Helper()
+	owner := seedUser(t)
+	company, err := services.NewCompanyManagementService(testDB, inbox).CreateCompany(context.Background(), services.CreateCompanyRequest{
+		Name:      gofakeit.Company(),
+		ContactID: owner.ID,
+		Address:   gofakeit.Address().Address,
+	})
+	require.NoError(t, err)
+	return company, owner
+}
+
+// seedPermission grants the user a role in the company, valid until expiresAt when it is set.
+func seedPermission(t *testing.T, userID string, companyID string, role models.Role, expiresAt *time.Time) *dao.Permission {
+	t.Helper()
+	permissionDao := &dao.Permission{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		CompanyID: companyID,
+		Role:      string(role),
+		ExpiresAt: null.TimeFromPtr(expiresAt),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	require.NoError(t, permissionDao.Insert(context.Background(), testDB.Conn, boil.Infer()))
+	return permissionDao
+}
@@ -0,0 +1,62 @@
+package integrationtests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/pro-posal/webserver/api"
+	"github.com/pro-posal/webserver/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGallery_PublishAdoptAndUpdate(t *testing.T) {
+	company := postCompany(t, client)
+	companyAdmin, companyAdminUser := signUp(t)
+	grantRole(t, company.ID, companyAdminUser.ID, models.CompanyAdminRole)
+
+	published := api.PostGalleryTemplateRequestBody{
+		Name:       gofakeit.BeerName(),
+		Template:   `Hello {{.name}}{{block "signature" .}}{{end}}`,
+		Language:   "en",
+		Schema:     models.TemplateSchema{Arguments: []models.TemplateArgument{{Name: "name", Type: "string", Required: true}}},
+		Categories: []models.GalleryCategory{{Description: "Kitchen", Type: models.CategoryTypeCategory}},
+	}
+
+	// Only platform admins publish to the gallery
+	companyAdmin.Post(t, "/gallery", published, http.StatusUnauthorized, nil)
+	var galleryTemplate models.GalleryTemplate
+	client.Post(t, "/gallery", published, http.StatusCreated, &galleryTemplate)
+	assert.Equal(t, 1, galleryTemplate.Version)
+
+	var contract models.ContractTemplate
+	companyAdmin.Post(t, fmt.Sprintf("/companies/%s/gallery/%s/adopt", company.ID, galleryTemplate.ID), api.PostAdoptGalleryTemplateRequestBody{}, http.StatusCreated, &contract)
+	assert.Equal(t, published.Name, contract.Name)
+	assert.Equal(t, published.Template, contract.Template)
+	assert.Equal(t, galleryTemplate.ID, contract.SourceGalleryTemplateID)
+	assert.Equal(t, 1, contract.SourceVersion)
+
+	// Outsiders can't adopt into the company
+	outsider, _ := signUp(t)
+	outsider.Post(t, fmt.Sprintf("/companies/%s/gallery/%s/adopt", company.ID, galleryTemplate.ID), api.PostAdoptGalleryTemplateRequestBody{Name: gofakeit.BeerName()}, http.StatusUnauthorized, nil)
+
+	var outdated api.GetContractResponseBody
+	client.Get(t, fmt.Sprintf("/companies/%s/contracts/outdated", company.ID), http.StatusCreated, &outdated)
+	assert.Empty(t, outdated.ContractTemplate)
+
+	published.Template = `Hi {{.name}}{{block "signature" .}}{{end}}`
+	companyAdmin.Put(t, "/gallery/"+galleryTemplate.ID, published, http.StatusUnauthorized, nil)
+	client.Put(t, "/gallery/"+galleryTemplate.ID, published, http.StatusCreated, &galleryTemplate)
+	assert.Equal(t, 2, galleryTemplate.Version)
+
+	client.Get(t, fmt.Sprintf("/companies/%s/contracts/outdated", company.ID), http.StatusCreated, &outdated)
+	require.Len(t, outdated.ContractTemplate, 1)
+	assert.Equal(t, contract.ID, outdated.ContractTemplate[0].ID)
+	assert.True(t, outdated.ContractTemplate[0].UpdateAvailable)
+
+	companyAdmin.Delete(t, "/gallery/"+galleryTemplate.ID, http.StatusUnauthorized, nil)
+	client.Delete(t, "/gallery/"+galleryTemplate.ID, http.StatusCreated, nil)
+	client.Get(t, "/gallery/"+galleryTemplate.ID, http.StatusBadRequest, nil)
+}
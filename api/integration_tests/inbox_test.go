@@ -0,0 +1,60 @@
+package integrationtests
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/pro-posal/webserver/internal/mailer"
+	"github.com/stretchr/testify/require"
+)
+
+// inbox is the mailer of the test server, it keeps the emails so tests can follow the links in them.
+var inbox = &testInbox{}
+
+type testInbox struct {
+	mu       sync.Mutex
+	messages []mailer.Message
+}
+
+func (i *testInbox) Send(_ context.Context, message mailer.Message) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.messages = append(i.messages, message)
+	return nil
+}
+
+var linkToken = regexp.MustCompile(`token=(\S+)`)
+
+// lastToken returns the token of the last link emailed to the address.
+func (i *testInbox) lastToken(t *testing.T, to string) string {
+	t.Helper()
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for j := len(i.messages) - 1; j >= 0; j-- {
+		if i.messages[j].To != to {
+			continue
+		}
+		if match := linkToken.FindStringSubmatch(i.messages[j].Body); match != nil {
+			return match[1]
+		}
+	}
+	require.FailNow(t, "no link was emailed", "to %s", to)
+	return ""
+}
+
+// count returns how many emails were sent to the address.
+func (i *testInbox) count(to string) int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	count := 0
+	for _, message := range i.messages {
+		if message.To == to {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,136 @@
+package integrationtests
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/pro-posal/webserver/api"
+	"github.com/pro-posal/webserver/config"
+	"github.com/pro-posal/webserver/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// invite emails an invitation to the company through the client.
+func invite(t *testing.T, c *ApiClient, companyID string, email string, role models.Role, status int) *models.Invitation {
+	t.Helper()
+	url := fmt.Sprintf("/companies/%s/invitations", companyID)
+	if status != http.StatusCreated {
+		c.Post(t, url, api.PostInvitationRequestBody{Email: email, Role: role}, status, nil)
+		return nil
+	}
+	var invitation models.Invitation
+	c.Post(t, url, api.PostInvitationRequestBody{Email: email, Role: role}, status, &invitation)
+	return &invitation
+}
+
+func getInvitations(t *testing.T, c *ApiClient, companyID string) api.GetInvitationsResponseBody {
+	t.Helper()
+	var invitations api.GetInvitationsResponseBody
+	c.Get(t, fmt.Sprintf("/companies/%s/invitations", companyID), http.StatusOK, &invitations)
+	return invitations
+}
+
+func TestInvitation_SignsUpTheInvitee(t *testing.T) {
+	company := postCompany(t, client)
+	email := strings.ToLower(gofakeit.Email())
+
+	invitation := invite(t, client, company.ID, email, models.CompanyContributorRole, http.StatusCreated)
+	assert.Equal(t, models.CompanyContributorRole, invitation.Role)
+	token := inbox.lastToken(t, email)
+
+	accept := api.PostAcceptInvitationRequestBody{Token: token, FirstName: gofakeit.FirstName(), LastName: gofakeit.LastName(), Password: "password"}
+	var user models.User
+	client.Post(t, "/invitations/accept", accept, http.StatusOK, &user)
+	assert.Equal(t, email, user.Email)
+
+	// The invitee received the link, so their address counts as verified
+	verificationMode(t, config.EmailVerificationLogin)
+	logIn(t, email, "password")
+
+	// The link works once, and the invitation is no longer pending
+	client.Post(t, "/invitations/accept", accept, http.StatusBadRequest, nil)
+	assert.Empty(t, getInvitations(t, client, company.ID).Invitations)
+
+	invite(t, client, company.ID, email, models.CompanyContributorRole, http.StatusConflict)
+}
+
+func TestInvitation_ExistingAccountAcceptsWithItsPassword(t *testing.T) {
+	company := postCompany(t, client)
+	existing, password := postUser(t)
+
+	invite(t, client, company.ID, existing.Email, models.ProspectRole, http.StatusCreated)
+	token := inbox.lastToken(t, existing.Email)
+
+	client.Post(t, "/invitations/accept", api.PostAcceptInvitationRequestBody{Token: token, Password: "wrong"}, http.StatusForbidden, nil)
+
+	var user models.User
+	client.Post(t, "/invitations/accept", api.PostAcceptInvitationRequestBody{Token: token, Password: password}, http.StatusOK, &user)
+	assert.Equal(t, existing.ID, user.ID)
+
+	invite(t, client, company.ID, existing.Email, models.ProspectRole, http.StatusConflict)
+}
+
+func TestInvitation_ResendReplacesTheLinkAndRevokeEndsIt(t *testing.T) {
+	company := postCompany(t, client)
+	email := strings.ToLower(gofakeit.Email())
+	accept := func(token string, status int) {
+		client.Post(t, "/invitations/accept", api.PostAcceptInvitationRequestBody{
+			Token: token, FirstName: gofakeit.FirstName(), LastName: gofakeit.LastName(), Password: "password",
+		}, status, nil)
+	}
+
+	invitation := invite(t, client, company.ID, email, models.CompanyContributorRole, http.StatusCreated)
+	firstToken := inbox.lastToken(t, email)
+	resend := fmt.Sprintf("/companies/%s/invitations/%s/resend", company.ID, invitation.ID)
+
+	// The invitation was just sent
+	client.Post(t, resend, nil, http.StatusTooManyRequests, nil)
+
+	previous := config.AppConfig.Auth.InvitationResendIntervalMinutes
+	config.AppConfig.Auth.InvitationResendIntervalMinutes = 0
+	t.Cleanup(func() { config.AppConfig.Auth.InvitationResendIntervalMinutes = previous })
+
+	client.Post(t, resend, nil, http.StatusOK, nil)
+	secondToken := inbox.lastToken(t, email)
+	assert.NotEqual(t, firstToken, secondToken)
+	accept(firstToken, http.StatusBadRequest)
+
+	pending := getInvitations(t, client, company.ID)
+	require.Len(t, pending.Invitations, 1)
+	assert.Equal(t, invitation.ID, pending.Invitations[0].ID)
+
+	revoke := fmt.Sprintf("/companies/%s/invitations/%s", company.ID, invitation.ID)
+	client.Delete(t, revoke, http.StatusNoContent, nil)
+	accept(secondToken, http.StatusBadRequest)
+	client.Delete(t, revoke, http.StatusNotFound, nil)
+}
+
+func TestInvitation_OnlyMemberManagersInvite(t *testing.T) {
+	company := postCompany(t, client)
+	contributor, contributorUser := signUp(t)
+	grantRole(t, company.ID, contributorUser.ID, models.CompanyContributorRole)
+
+	invite(t, contributor, company.ID, gofakeit.Email(), models.CompanyContributorRole, http.StatusUnauthorized)
+	contributor.Get(t, fmt.Sprintf("/companies/%s/invitations", company.ID), http.StatusUnauthorized, nil)
+
+	invite(t, client, company.ID, gofakeit.Email(), models.AdminRole, http.StatusBadRequest)
+}
+
+func TestInvitation_FormerMembersCanBeInvitedBack(t *testing.T) {
+	company := postCompany(t, client)
+	former, password := postUser(t)
+	expired := time.Now().Add(-time.Hour)
+	seedPermission(t, former.ID, company.ID, models.CompanyContributorRole, &expired)
+
+	invite(t, client, company.ID, former.Email, models.CompanyContributorRole, http.StatusCreated)
+	token := inbox.lastToken(t, former.Email)
+	client.Post(t, "/invitations/accept", api.PostAcceptInvitationRequestBody{Token: token, Password: password}, http.StatusOK, nil)
+
+	// The accepted invitation granted the role again, so they are a member once more
+	invite(t, client, company.ID, former.Email, models.CompanyContributorRole, http.StatusConflict)
+}
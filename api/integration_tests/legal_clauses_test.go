@@ -0,0 +1,24 @@
+package integrationtests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/pro-posal/webserver/api"
+	"github.com/pro-posal/webserver/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLegalClauses_RejectEmptyContent(t *testing.T) {
+	company := postCompany(t, client)
+	clauses := fmt.Sprintf("/companies/%s/legalClauses", company.ID)
+
+	client.Post(t, clauses, api.PostLegalClauseRequestBody{Name: "privacy", Content: " \n\t"}, http.StatusBadRequest, nil)
+
+	var clause models.LegalClause
+	client.Post(t, clauses, api.PostLegalClauseRequestBody{Name: "privacy", Content: "We keep your data safe.", Mandatory: true}, http.StatusCreated, &clause)
+	assert.Equal(t, "privacy", clause.Name)
+
+	client.Put(t, clauses+"/"+clause.ID, api.PostLegalClauseRequestBody{Name: "privacy", Mandatory: true}, http.StatusBadRequest, nil)
+}
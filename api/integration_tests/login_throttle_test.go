@@ -0,0 +1,61 @@
+package integrationtests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/pro-posal/webserver/api"
+	"github.com/pro-posal/webserver/config"
+	"github.com/pro-posal/webserver/models"
+	"github.com/pro-posal/webserver/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// maxFailedLogins lowers how many wrong passwords lock an account for the rest of the test.
+func maxFailedLogins(t *testing.T, attempts int) {
+	t.Helper()
+	previous := config.AppConfig.Auth.Login.MaxFailedAttempts
+	config.AppConfig.Auth.Login.MaxFailedAttempts = attempts
+	t.Cleanup(func() { config.AppConfig.Auth.Login.MaxFailedAttempts = previous })
+}
+
+func TestLogin_LocksTheAccountUntilUnlocked(t *testing.T) {
+	maxFailedLogins(t, 1)
+	company := postCompany(t, client)
+	user, password := postUser(t)
+	grantRole(t, company.ID, user.ID, models.CompanyContributorRole)
+	companyAdmin, companyAdminUser := signUp(t)
+	grantRole(t, company.ID, companyAdminUser.ID, models.CompanyAdminRole)
+
+	client.Post(t, "/users/login", api.PostUsersLoginRequestBody{Email: user.Email, Password: "wrong password"}, http.StatusForbidden, nil)
+
+	// Even the right password is refused while the account is locked
+	client.Post(t, "/users/login", api.PostUsersLoginRequestBody{Email: user.Email, Password: password}, http.StatusTooManyRequests, nil)
+
+	// Only platform admins can lift the lock, not even the admins of the user's company
+	companyAdmin.Post(t, "/users/"+user.ID+"/unlock", nil, http.StatusUnauthorized, nil)
+	client.Post(t, "/users/"+user.ID+"/unlock", nil, http.StatusOK, nil)
+
+	logIn(t, user.Email, password)
+
+	auditLogs, err := services.NewAuditLogService(testDB).ExportAuditLogs(context.Background(), services.AuditLogFilter{
+		CompanyID: company.ID,
+		EntityID:  user.ID,
+		Action:    services.AuditActionUnlock,
+	})
+	require.NoError(t, err)
+	assert.Len(t, auditLogs, 1)
+}
+
+func TestUpdatePassword_ThrottlesWrongCurrentPasswords(t *testing.T) {
+	c, password := signUpWithPassword(t)
+	body := func(current string) api.PutUsersPasswordRequestBody {
+		return api.PutUsersPasswordRequestBody{CurrentPassword: current, NewPassword: "a brand new password"}
+	}
+
+	c.Patch(t, "/users/updatePassword", body("wrong password"), http.StatusForbidden, nil)
+	// The next attempt has to wait out the backoff, even with the right password
+	c.Patch(t, "/users/updatePassword", body(password), http.StatusTooManyRequests, nil)
+}
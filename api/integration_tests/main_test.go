@@ -10,14 +10,22 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/pro-posal/webserver/api"
 	"github.com/pro-posal/webserver/config"
+	"github.com/pro-posal/webserver/dao"
 	"github.com/pro-posal/webserver/internal/database"
+	"github.com/pro-posal/webserver/internal/keys"
+	"github.com/pro-posal/webserver/models"
 	"github.com/pro-posal/webserver/services"
+	"github.com/volatiletech/sqlboiler/v4/boil"
 )
 
 var client *ApiClient
 
+// testDB is the database of the test server, for tests calling services directly
+var testDB *database.DBConnector
+
 func TestMain(m *testing.M) {
 	ctx := context.Background()
 
@@ -31,6 +39,10 @@ func TestMain(m *testing.M) {
 	// Update the configuration to use the container's host and port
 	config.TestConfig.TestDatabase.Host = host
 	config.TestConfig.TestDatabase.Port = port
+	// The seeded user has no inbox to verify its email address from
+	config.AppConfig.Auth.EmailVerificationMode = config.EmailVerificationOff
+	// The mock identity provider listens on localhost
+	config.AppConfig.Auth.OIDCAllowPrivateIssuers = true
 
 	// Step 2: Run migrations
 	if err := database.RunMigrations(); err != nil {
@@ -42,19 +54,28 @@ func TestMain(m *testing.M) {
 
 	db := database.TestConnect()
 	defer db.Conn.Close()
+	testDB = db
+
+	keyStore, err := keys.NewKeyStore("", "", config.AppConfig.Auth.JWTSigningSecret)
+	if err != nil {
+		log.Fatalf("Failed loading signing keys: %v", err)
+	}
 
-	ums := services.NewUserManagementService(db)
-	auth := services.NewAuthService(db)
-	cms := services.NewCompanyManagementService(db)
-	pms := services.NewPermissionManagementService(db)
+	ums := services.NewUserManagementService(db, inbox, keyStore)
+	auth := services.NewAuthService(db, inbox, keyStore)
+	cms := services.NewCompanyManagementService(db, inbox)
+	pms := services.NewPermissionManagementService(db, inbox)
 	cams := services.NewCategoryManagementService(db)
 	ctms := services.NewContractTemplateManagementService(db)
 	oms := services.NewOfferManagementService(db)
+	gms := services.NewGalleryManagementService(db)
+	tms := services.NewTrashManagementService(db)
+	als := services.NewAuditLogService(db)
 
-	server := api.NewAPI(db, ums, auth, cms, pms, cams, ctms, oms)
+	server := api.NewAPI(db, ums, auth, cms, pms, cams, ctms, oms, gms, tms, als)
 
 	// Seed an admin user
-	_, err = ums.CreateUser(context.Background(), services.CreateUserRequest{
+	admin, err := ums.CreateUser(context.Background(), services.CreateUserRequest{
 		FirstName: config.TestConfig.User.FirstName,
 		LastName:  config.TestConfig.User.LastName,
 		Phone:     config.TestConfig.User.Phone,
@@ -64,6 +85,11 @@ func TestMain(m *testing.M) {
 	if err != nil && !strings.Contains(err.Error(), "users_email_hash_unique") {
 		log.Fatalf("Failed seeding admin user: %v", err)
 	}
+	if admin != nil {
+		if err := seedPlatformAdmin(ctx, cms, db, admin.ID); err != nil {
+			log.Fatalf("Failed seeding admin permission: %v", err)
+		}
+	}
 
 	addr := fmt.Sprintf(":%s", config.TestConfig.TestServer.Port)
 
@@ -92,6 +118,28 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
+// seedPlatformAdmin makes the user a platform admin, only they may create companies. The admin
+// permission lives in a company of their own like every other permission.
+func seedPlatformAdmin(ctx context.Context, cms services.CompanyManagementService, db *database.DBConnector, userID string) error {
+	company, err := cms.CreateCompany(ctx, services.CreateCompanyRequest{
+		Name:      "Platform",
+		ContactID: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	permissionDao := dao.Permission{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		CompanyID: company.ID,
+		Role:      string(models.AdminRole),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	return permissionDao.Insert(ctx, db.Conn, boil.Infer())
+}
+
 func waitForService(serviceURL string, timeout time.Duration) error {
 	deadline := time.Now().Add(timeout)
 	for time.Now().Before(deadline) {
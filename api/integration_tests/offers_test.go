@@ -0,0 +1,89 @@
+package integrationtests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/pro-posal/webserver/api"
+	"github.com/pro-posal/webserver/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const offerTemplate = `Offer{{block "signature" .}}{{end}}`
+
+// postOffer sends the contract template to the customer through the client.
+func postOffer(t *testing.T, c *ApiClient, companyID string, contractTemplateID string, customerID string) *models.Offer {
+	t.Helper()
+	var offer models.Offer
+	c.Post(t, fmt.Sprintf("/companies/%s/offers", companyID), api.PostOfferRequestBody{
+		ContractTemplateID: contractTemplateID,
+		CustomerID:         customerID,
+	}, http.StatusCreated, &offer)
+	return &offer
+}
+
+func offerURL(offer *models.Offer) string {
+	return fmt.Sprintf("/companies/%s/offers/%s", offer.CompanyID, offer.ID)
+}
+
+func TestOffers_ProspectsOnlyReachTheirOwnOffers(t *testing.T) {
+	company := postCompany(t, client)
+	kitchen := postContractTemplate(t, client, company.ID, offerTemplate)
+	garden := postContractTemplate(t, client, company.ID, offerTemplate)
+
+	customer, customerUser := signUp(t)
+	_, otherCustomerUser := signUp(t)
+	offer := postOffer(t, client, company.ID, kitchen.ID, customerUser.ID)
+	otherOffer := postOffer(t, client, company.ID, kitchen.ID, otherCustomerUser.ID)
+	// Each template the customer receives an offer for gives them a prospect permission for it
+	gardenOffer := postOffer(t, client, company.ID, garden.ID, customerUser.ID)
+
+	var opened models.Offer
+	customer.Get(t, offerURL(offer), http.StatusOK, &opened)
+	assert.NotNil(t, opened.OpenedAt)
+	customer.Get(t, offerURL(gardenOffer), http.StatusOK, nil)
+	customer.Get(t, offerURL(otherOffer), http.StatusUnauthorized, nil)
+
+	var offers api.GetOffersResponseBody
+	customer.Get(t, fmt.Sprintf("/companies/%s/offers", company.ID), http.StatusOK, &offers)
+	ids := []string{}
+	for _, listed := range offers.Offers {
+		ids = append(ids, listed.ID)
+	}
+	assert.ElementsMatch(t, []string{offer.ID, gardenOffer.ID}, ids)
+
+	// Prospects don't get to the contract templates themselves
+	customer.Get(t, fmt.Sprintf("/companies/%s/contracts", company.ID), http.StatusUnauthorized, nil)
+
+	accept := api.PostOfferResponseRequestBody{Accept: true}
+	customer.Post(t, offerURL(otherOffer)+"/respond", accept, http.StatusUnauthorized, nil)
+	var answered models.Offer
+	customer.Post(t, offerURL(offer)+"/respond", accept, http.StatusOK, &answered)
+	assert.NotNil(t, answered.AcceptedAt)
+	customer.Post(t, offerURL(offer)+"/respond", api.PostOfferResponseRequestBody{Accept: false}, http.StatusConflict, nil)
+}
+
+func TestOffers_StaffReachEveryOfferOfTheirCompany(t *testing.T) {
+	company := postCompany(t, client)
+	otherCompany := postCompany(t, client)
+	staff, staffUser := signUp(t)
+	grantRole(t, company.ID, staffUser.ID, models.CompanyContributorRole)
+
+	template := postContractTemplate(t, staff, company.ID, offerTemplate)
+	otherTemplate := postContractTemplate(t, client, otherCompany.ID, offerTemplate)
+	_, customerUser := signUp(t)
+	offer := postOffer(t, staff, company.ID, template.ID, customerUser.ID)
+	otherOffer := postOffer(t, client, otherCompany.ID, otherTemplate.ID, customerUser.ID)
+
+	var opened models.Offer
+	staff.Get(t, offerURL(offer), http.StatusOK, &opened)
+	// Only the customer opening the offer counts
+	assert.Nil(t, opened.OpenedAt)
+	staff.Get(t, offerURL(otherOffer), http.StatusUnauthorized, nil)
+	// An offer of another company is not reachable through one's own company either
+	staff.Get(t, fmt.Sprintf("/companies/%s/offers/%s", company.ID, otherOffer.ID), http.StatusNotFound, nil)
+
+	// Only the customer answers
+	staff.Post(t, offerURL(offer)+"/respond", api.PostOfferResponseRequestBody{Accept: true}, http.StatusUnauthorized, nil)
+}
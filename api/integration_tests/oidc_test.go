@@ -0,0 +1,92 @@
+package integrationtests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/pro-posal/webserver/api"
+	"github.com/pro-posal/webserver/config"
+	"github.com/pro-posal/webserver/internal/oidc/oidctest"
+	"github.com/pro-posal/webserver/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCLogin(t *testing.T) {
+	idp, err := oidctest.NewProvider("pro-posal", "secret")
+	require.NoError(t, err)
+	defer idp.Close()
+	config.AppConfig.Auth.OIDCRedirectURL = "http://localhost/sso/callback"
+
+	var company models.Company
+	client.Post(t, "/companies", map[string]string{
+		"name":    gofakeit.BeerName(),
+		"address": gofakeit.Address().Address,
+	}, http.StatusCreated, &company)
+
+	var provider models.IdentityProvider
+	client.Post(t, fmt.Sprintf("/companies/%s/identityProviders", company.ID), api.IdentityProviderRequestBody{
+		Name:         "Mock",
+		Issuer:       idp.Issuer,
+		ClientID:     idp.ClientID,
+		ClientSecret: idp.ClientSecret,
+		GroupRoles:   map[string]models.Role{"sales": models.CompanyContributorRole, "it": models.CompanyAdminRole},
+		Enabled:      true,
+	}, http.StatusCreated, &provider)
+
+	// signIn goes through the provider's sign in page from a sign in started with start.
+	signIn := func(start string, c *ApiClient, claims map[string]any, statusCode int) api.PostUsersLoginResponseBody {
+		idp.SetClaims(claims)
+
+		var login models.OIDCLogin
+		c.Post(t, start, api.PostOIDCStartRequestBody{ProviderID: provider.ID}, http.StatusOK, &login)
+		code, state, err := idp.Authorize(login.AuthorizationURL)
+		require.NoError(t, err)
+
+		var token api.PostUsersLoginResponseBody
+		var resp any
+		if statusCode == http.StatusOK {
+			resp = &token
+		}
+		client.Post(t, "/auth/oidc/callback", api.PostOIDCCallbackRequestBody{Code: code, State: state}, statusCode, resp)
+		return token
+	}
+	login := func(claims map[string]any, statusCode int) api.PostUsersLoginResponseBody {
+		return signIn("/auth/oidc/start", client, claims, statusCode)
+	}
+
+	email := gofakeit.Email()
+	token := login(map[string]any{"sub": gofakeit.UUID(), "email": email, "email_verified": true, "groups": []string{"sales", "it"}}, http.StatusOK)
+	assert.NotEmpty(t, token.AccessToken)
+	assert.NotEmpty(t, token.RefreshToken)
+
+	// Users in none of the mapped groups can't sign in without a default role
+	login(map[string]any{"sub": gofakeit.UUID(), "email": gofakeit.Email(), "email_verified": true, "groups": []string{"finance"}}, http.StatusForbidden)
+
+	// An unverified address can't take over an existing account
+	login(map[string]any{"sub": gofakeit.UUID(), "email": config.TestConfig.User.Email, "email_verified": false}, http.StatusForbidden)
+
+	// Nor can a verified one of a user who didn't join the provider's company through an invitation
+	outsider, outsiderPassword := postUser(t)
+	login(map[string]any{"sub": gofakeit.UUID(), "email": outsider.Email, "email_verified": true, "groups": []string{"it"}}, http.StatusForbidden)
+	login(map[string]any{"sub": gofakeit.UUID(), "email": config.TestConfig.User.Email, "email_verified": true, "groups": []string{"sales"}}, http.StatusForbidden)
+
+	// Users who accepted the company's invitation are linked to their existing account
+	invited, invitedPassword := postUser(t)
+	invite(t, client, company.ID, invited.Email, models.CompanyContributorRole, http.StatusCreated)
+	client.Post(t, "/invitations/accept", api.PostAcceptInvitationRequestBody{Token: inbox.lastToken(t, invited.Email), Password: invitedPassword}, http.StatusOK, nil)
+	member := login(map[string]any{"sub": gofakeit.UUID(), "email": invited.Email, "email_verified": true, "groups": []string{"sales"}}, http.StatusOK)
+	assert.NotEmpty(t, member.AccessToken)
+
+	// Anyone else links the identity from their own session, whatever its email address
+	outsiderClient := asUser(logIn(t, outsider.Email, outsiderPassword).AccessToken)
+	subject := gofakeit.UUID()
+	signIn("/auth/oidc/link", outsiderClient, map[string]any{"sub": subject, "email": gofakeit.Email(), "groups": []string{"sales"}}, http.StatusOK)
+	linked := login(map[string]any{"sub": subject, "groups": []string{"sales"}}, http.StatusOK)
+	assert.NotEmpty(t, linked.AccessToken)
+
+	// An identity already linked to an account can't be linked to another one
+	signIn("/auth/oidc/link", asUser(logIn(t, invited.Email, invitedPassword).AccessToken), map[string]any{"sub": subject, "groups": []string{"sales"}}, http.StatusForbidden)
+}
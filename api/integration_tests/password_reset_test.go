@@ -0,0 +1,46 @@
+package integrationtests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/pro-posal/webserver/api"
+	"github.com/pro-posal/webserver/dao"
+	"github.com/pro-posal/webserver/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForgotPassword_SendsOnePerInterval(t *testing.T) {
+	user := seedUser(t)
+
+	for i := 0; i < 3; i++ {
+		client.Post(t, "/users/forgotPassword", api.PostForgotPasswordRequestBody{Email: user.Email}, http.StatusAccepted, nil)
+	}
+
+	tokens, err := dao.PasswordResetTokens(dao.PasswordResetTokenWhere.UserID.EQ(user.ID)).Count(context.Background(), testDB.Conn)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, tokens)
+}
+
+func TestResetPassword_RevokesTheUsersAPIKeys(t *testing.T) {
+	company := postCompany(t, client)
+	c, user := signUp(t)
+	grantRole(t, company.ID, user.ID, models.CompanyContributorRole)
+	c.Post(t, fmt.Sprintf("/companies/%s/apiKeys", company.ID), api.PostAPIKeyRequestBody{
+		Name:   "Integration",
+		Scopes: []models.APIKeyScope{models.APIKeyScopeRead},
+	}, http.StatusCreated, nil)
+
+	client.Post(t, "/users/forgotPassword", api.PostForgotPasswordRequestBody{Email: user.Email}, http.StatusAccepted, nil)
+	client.Post(t, "/users/resetPassword", api.PostResetPasswordRequestBody{
+		Token:       inbox.lastToken(t, user.Email),
+		NewPassword: "a brand new password",
+	}, http.StatusNoContent, nil)
+
+	active, err := dao.APIKeys(dao.APIKeyWhere.UserID.EQ(user.ID), dao.APIKeyWhere.RevokedAt.IsNull()).Count(context.Background(), testDB.Conn)
+	require.NoError(t, err)
+	assert.Zero(t, active)
+}
@@ -0,0 +1,76 @@
+package integrationtests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pro-posal/webserver/dao"
+	"github.com/pro-posal/webserver/internal/mailer"
+	"github.com/pro-posal/webserver/models"
+	"github.com/pro-posal/webserver/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifyExpiringPermissions_OnlyWithinNoticeOnce(t *testing.T) {
+	ctx := context.Background()
+	pms := services.NewPermissionManagementService(testDB, mailer.NewLogMailer())
+	company, _ := seedCompany(t)
+
+	soon := time.Now().UTC().Add(time.Hour)
+	later := time.Now().UTC().Add(10 * 24 * time.Hour)
+	expiring := seedPermission(t, seedUser(t).ID, company.ID, models.CompanyContributorRole, &soon)
+	notYet := seedPermission(t, seedUser(t).ID, company.ID, models.CompanyContributorRole, &later)
+	forever := seedPermission(t, seedUser(t).ID, company.ID, models.CompanyContributorRole, nil)
+
+	_, err := pms.NotifyExpiringPermissions(ctx, 72*time.Hour)
+	require.NoError(t, err)
+
+	expiringDao, err := dao.FindPermission(ctx, testDB.Conn, expiring.ID)
+	require.NoError(t, err)
+	assert.True(t, expiringDao.ExpiryNotifiedAt.Valid)
+	for _, permission := range []*dao.Permission{notYet, forever} {
+		permissionDao, err := dao.FindPermission(ctx, testDB.Conn, permission.ID)
+		require.NoError(t, err)
+		assert.False(t, permissionDao.ExpiryNotifiedAt.Valid)
+	}
+
+	// The admins are told once per expiration
+	notifiedAt := expiringDao.ExpiryNotifiedAt.Time
+	_, err = pms.NotifyExpiringPermissions(ctx, 72*time.Hour)
+	require.NoError(t, err)
+	expiringDao, err = dao.FindPermission(ctx, testDB.Conn, expiring.ID)
+	require.NoError(t, err)
+	assert.Equal(t, notifiedAt, expiringDao.ExpiryNotifiedAt.Time)
+}
+
+func TestDeleteExpiredPermissions_DeletesAndAudits(t *testing.T) {
+	ctx := context.Background()
+	pms := services.NewPermissionManagementService(testDB, mailer.NewLogMailer())
+	company, _ := seedCompany(t)
+
+	past := time.Now().UTC().Add(-time.Minute)
+	future := time.Now().UTC().Add(time.Hour)
+	expired := seedPermission(t, seedUser(t).ID, company.ID, models.CompanyContributorRole, &past)
+	valid := seedPermission(t, seedUser(t).ID, company.ID, models.CompanyContributorRole, &future)
+
+	deleted, err := pms.DeleteExpiredPermissions(ctx)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, 1)
+
+	exists, err := dao.PermissionExists(ctx, testDB.Conn, expired.ID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	exists, err = dao.PermissionExists(ctx, testDB.Conn, valid.ID)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	audited, err := dao.AuditLogs(
+		dao.AuditLogWhere.CompanyID.EQ(company.ID),
+		dao.AuditLogWhere.EntityID.EQ(expired.ID),
+		dao.AuditLogWhere.Action.EQ(services.AuditActionExpire),
+	).Exists(ctx, testDB.Conn)
+	require.NoError(t, err)
+	assert.True(t, audited)
+}
@@ -0,0 +1,113 @@
+package integrationtests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pro-posal/webserver/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func refresh(t *testing.T, refreshToken string, statusCode int) api.PostUsersLoginResponseBody {
+	t.Helper()
+	var token api.PostUsersLoginResponseBody
+	var resp any
+	if statusCode == http.StatusOK {
+		resp = &token
+	}
+	client.Post(t, "/auth/refresh", api.PostAuthRefreshRequestBody{RefreshToken: refreshToken}, statusCode, resp)
+	return token
+}
+
+func TestRefreshAuthToken_RotatesTheRefreshToken(t *testing.T) {
+	user, password := postUser(t)
+	login := logIn(t, user.Email, password)
+
+	refreshed := refresh(t, login.RefreshToken, http.StatusOK)
+	assert.NotEqual(t, login.RefreshToken, refreshed.RefreshToken)
+	assert.NotEqual(t, login.AccessToken, refreshed.AccessToken)
+
+	asUser(refreshed.AccessToken).Get(t, "/users/"+user.ID, http.StatusCreated, nil)
+	// The rotated session is revoked
+	asUser(login.AccessToken).Get(t, "/users/"+user.ID, http.StatusForbidden, nil)
+
+	again := refresh(t, refreshed.RefreshToken, http.StatusOK)
+	asUser(again.AccessToken).Get(t, "/users/"+user.ID, http.StatusCreated, nil)
+}
+
+func TestRefreshAuthToken_ReuseRevokesTheFamily(t *testing.T) {
+	user, password := postUser(t)
+	login := logIn(t, user.Email, password)
+	other := logIn(t, user.Email, password)
+
+	refreshed := refresh(t, login.RefreshToken, http.StatusOK)
+
+	// Someone replaying the old refresh token signs everyone out of that login
+	refresh(t, login.RefreshToken, http.StatusForbidden)
+	asUser(refreshed.AccessToken).Get(t, "/users/"+user.ID, http.StatusForbidden, nil)
+	refresh(t, refreshed.RefreshToken, http.StatusForbidden)
+
+	// Other logins are left alone
+	asUser(other.AccessToken).Get(t, "/users/"+user.ID, http.StatusCreated, nil)
+
+	refresh(t, "unknown", http.StatusForbidden)
+}
+
+// currentSession returns the ID of the session the client is signed in with.
+func currentSession(t *testing.T, c *ApiClient) string {
+	t.Helper()
+	var sessions api.GetSessionsResponseBody
+	c.Get(t, "/auth/sessions", http.StatusCreated, &sessions)
+	for _, session := range sessions.Sessions {
+		if session.Current {
+			return session.ID.String()
+		}
+	}
+	require.FailNow(t, "no current session")
+	return ""
+}
+
+func TestLogout_EndsTheSession(t *testing.T) {
+	user, password := postUser(t)
+	login := logIn(t, user.Email, password)
+
+	asUser(login.AccessToken).Post(t, "/auth/logout", nil, http.StatusNoContent, nil)
+
+	asUser(login.AccessToken).Get(t, "/users/"+user.ID, http.StatusForbidden, nil)
+	refresh(t, login.RefreshToken, http.StatusForbidden)
+}
+
+func TestRevokeSessions_SignsOutOtherDevices(t *testing.T) {
+	user, password := postUser(t)
+	laptop := asUser(logIn(t, user.Email, password).AccessToken)
+	phone := asUser(logIn(t, user.Email, password).AccessToken)
+	tablet := asUser(logIn(t, user.Email, password).AccessToken)
+
+	var sessions api.GetSessionsResponseBody
+	laptop.Get(t, "/auth/sessions", http.StatusCreated, &sessions)
+	require.Len(t, sessions.Sessions, 3)
+	current := 0
+	for _, session := range sessions.Sessions {
+		if session.Current {
+			current++
+		}
+	}
+	assert.Equal(t, 1, current)
+
+	phoneSession := currentSession(t, phone)
+	laptop.Delete(t, "/auth/sessions/"+phoneSession, http.StatusNoContent, nil)
+	phone.Get(t, "/users/"+user.ID, http.StatusForbidden, nil)
+	laptop.Delete(t, "/auth/sessions/"+phoneSession, http.StatusBadRequest, nil)
+
+	// Sessions of other users can't be revoked
+	stranger, _ := signUp(t)
+	laptop.Delete(t, "/auth/sessions/"+currentSession(t, stranger), http.StatusBadRequest, nil)
+
+	var revoked api.PostRevokeOtherSessionsResponseBody
+	laptop.Post(t, "/auth/sessions/revokeOthers", nil, http.StatusCreated, &revoked)
+	assert.EqualValues(t, 1, revoked.RevokedSessions)
+	tablet.Get(t, "/users/"+user.ID, http.StatusForbidden, nil)
+	laptop.Get(t, "/users/"+user.ID, http.StatusCreated, nil)
+	currentSession(t, stranger)
+}
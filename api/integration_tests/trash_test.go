@@ -0,0 +1,101 @@
+package integrationtests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pro-posal/webserver/api"
+	"github.com/pro-posal/webserver/config"
+	"github.com/pro-posal/webserver/models"
+	"github.com/pro-posal/webserver/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getTrash(t *testing.T, c *ApiClient, companyID string) []*models.TrashItem {
+	t.Helper()
+	var trash api.GetTrashResponseBody
+	c.Get(t, fmt.Sprintf("/companies/%s/trash", companyID), http.StatusCreated, &trash)
+	return trash.Items
+}
+
+func TestTrash_RestoresACategoryWithItsChildren(t *testing.T) {
+	company := postCompany(t, client)
+	kitchen := postCategory(t, client, company.ID, "", "Kitchen", models.CategoryTypeCategory)
+	cabinets := postCategory(t, client, company.ID, kitchen.ID, "Cabinets", models.CategoryTypeSubCategory)
+	oakDoor := postCategory(t, client, company.ID, cabinets.ID, "Oak door", models.CategoryTypeDescription)
+
+	client.Delete(t, fmt.Sprintf("/categories/%s?id=%s", kitchen.ID, kitchen.ID), http.StatusCreated, nil)
+	assert.Empty(t, getCategoryTree(t, client, company.ID, ""))
+
+	// The children deleted along are listed through their parent
+	trash := getTrash(t, client, company.ID)
+	require.Len(t, trash, 1)
+	assert.Equal(t, kitchen.ID, trash[0].ID)
+	assert.Equal(t, models.TrashItemCategory, trash[0].Type)
+	assert.Equal(t, 2, trash[0].RestoresWith)
+	assert.Equal(t, config.TestConfig.User.FirstName+" "+config.TestConfig.User.LastName, trash[0].DeletedByName)
+
+	contributor, contributorUser := signUp(t)
+	grantRole(t, company.ID, contributorUser.ID, models.CompanyContributorRole)
+	contributor.Get(t, fmt.Sprintf("/companies/%s/trash", company.ID), http.StatusUnauthorized, nil)
+	contributor.Post(t, fmt.Sprintf("/companies/%s/trash/%s/restore", company.ID, trash[0].DeletionID), nil, http.StatusUnauthorized, nil)
+
+	var restored api.GetTrashResponseBody
+	client.Post(t, fmt.Sprintf("/companies/%s/trash/%s/restore", company.ID, trash[0].DeletionID), nil, http.StatusCreated, &restored)
+	assert.Len(t, restored.Items, 3)
+	assert.Empty(t, getTrash(t, client, company.ID))
+
+	tree := getCategoryTree(t, client, company.ID, "")
+	require.Len(t, tree, 1)
+	require.Len(t, tree[0].Children, 1)
+	require.Len(t, tree[0].Children[0].Children, 1)
+	assert.Equal(t, oakDoor.ID, tree[0].Children[0].Children[0].ID)
+}
+
+func TestTrash_RestoresADeletedUser(t *testing.T) {
+	company := postCompany(t, client)
+	member, memberUser := signUp(t)
+	grantRole(t, company.ID, memberUser.ID, models.CompanyContributorRole)
+
+	member.Delete(t, "/users/"+memberUser.ID, http.StatusCreated, nil)
+
+	trash := getTrash(t, client, company.ID)
+	require.Len(t, trash, 1)
+	assert.Equal(t, memberUser.ID, trash[0].ID)
+	assert.Equal(t, models.TrashItemUser, trash[0].Type)
+
+	// Company admins see the user in the trash but only platform admins can restore them
+	companyAdmin, companyAdminUser := signUp(t)
+	grantRole(t, company.ID, companyAdminUser.ID, models.CompanyAdminRole)
+	assert.Len(t, getTrash(t, companyAdmin, company.ID), 1)
+	companyAdmin.Post(t, fmt.Sprintf("/companies/%s/trash/%s/restore", company.ID, trash[0].DeletionID), nil, http.StatusUnauthorized, nil)
+
+	client.Post(t, fmt.Sprintf("/companies/%s/trash/%s/restore", company.ID, trash[0].DeletionID), nil, http.StatusCreated, nil)
+	assert.Empty(t, getTrash(t, client, company.ID))
+}
+
+func TestTrash_PurgeIsAudited(t *testing.T) {
+	ctx := context.Background()
+	company := postCompany(t, client)
+	kitchen := postCategory(t, client, company.ID, "", "Kitchen", models.CategoryTypeCategory)
+	client.Delete(t, fmt.Sprintf("/categories/%s?id=%s", kitchen.ID, kitchen.ID), http.StatusCreated, nil)
+
+	result, err := services.NewTrashManagementService(testDB).PurgeExpired(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, result.Categories, int64(1))
+	assert.Empty(t, getTrash(t, client, company.ID))
+
+	auditLogs, err := services.NewAuditLogService(testDB).ExportAuditLogs(ctx, services.AuditLogFilter{
+		CompanyID: company.ID,
+		EntityID:  kitchen.ID,
+		Action:    services.AuditActionPurge,
+	})
+	require.NoError(t, err)
+	require.Len(t, auditLogs, 1)
+	assert.Equal(t, services.AuditEntityCategory, auditLogs[0].EntityType)
+	assert.Empty(t, auditLogs[0].ActorID)
+}
@@ -0,0 +1,70 @@
+package integrationtests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pro-posal/webserver/api"
+	"github.com/pro-posal/webserver/internal/totp"
+	"github.com/pro-posal/webserver/models"
+	"github.com/pro-posal/webserver/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signUpWithPassword creates a user through the API and signs them in, keeping their password.
+func signUpWithPassword(t *testing.T) (*ApiClient, string) {
+	t.Helper()
+	user, password := postUser(t)
+	return asUser(logIn(t, user.Email, password).AccessToken), password
+}
+
+func currentCode(t *testing.T, secret string) string {
+	t.Helper()
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+	return code
+}
+
+func TestTwoFactor_EnrollingNeedsThePassword(t *testing.T) {
+	c, _ := signUpWithPassword(t)
+	c.Post(t, "/auth/2fa/enroll", api.PostTwoFactorEnrollRequestBody{CurrentPassword: "wrong password"}, http.StatusForbidden, nil)
+}
+
+func TestTwoFactor_ConfirmingNeedsThePassword(t *testing.T) {
+	c, password := signUpWithPassword(t)
+	var enrollment models.TwoFactorEnrollment
+	c.Post(t, "/auth/2fa/enroll", api.PostTwoFactorEnrollRequestBody{CurrentPassword: password}, http.StatusCreated, &enrollment)
+
+	c.Post(t, "/auth/2fa/confirm", api.PostTwoFactorConfirmRequestBody{
+		CurrentPassword: "wrong password",
+		Code:            currentCode(t, enrollment.Secret),
+	}, http.StatusForbidden, nil)
+}
+
+func TestTwoFactor_EnrollAndConfirm(t *testing.T) {
+	user, password := postUser(t)
+	company, _ := seedCompany(t)
+	seedPermission(t, user.ID, company.ID, models.CompanyContributorRole, nil)
+	c := asUser(logIn(t, user.Email, password).AccessToken)
+
+	var enrollment models.TwoFactorEnrollment
+	c.Post(t, "/auth/2fa/enroll", api.PostTwoFactorEnrollRequestBody{CurrentPassword: password}, http.StatusCreated, &enrollment)
+
+	var codes models.RecoveryCodes
+	c.Post(t, "/auth/2fa/confirm", api.PostTwoFactorConfirmRequestBody{
+		CurrentPassword: password,
+		Code:            currentCode(t, enrollment.Secret),
+	}, http.StatusCreated, &codes)
+	assert.Len(t, codes.Codes, 10)
+
+	auditLogs, err := services.NewAuditLogService(testDB).ExportAuditLogs(context.Background(), services.AuditLogFilter{
+		CompanyID: company.ID,
+		EntityID:  user.ID,
+		Action:    services.AuditActionTwoFactorEnable,
+	})
+	require.NoError(t, err)
+	assert.Len(t, auditLogs, 1)
+}
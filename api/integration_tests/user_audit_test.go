@@ -0,0 +1,69 @@
+package integrationtests
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/pro-posal/webserver/config"
+	"github.com/pro-posal/webserver/internal/keys"
+	"github.com/pro-posal/webserver/internal/mailer"
+	"github.com/pro-posal/webserver/internal/utils"
+	"github.com/pro-posal/webserver/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func TestUserAccountChanges_AreAuditedInTheirCompanies(t *testing.T) {
+	ctx := context.Background()
+	keyStore, err := keys.NewKeyStore("", "", config.AppConfig.Auth.JWTSigningSecret)
+	require.NoError(t, err)
+	ums := services.NewUserManagementService(testDB, mailer.NewLogMailer(), keyStore)
+	als := services.NewAuditLogService(testDB)
+	company, owner := seedCompany(t)
+
+	owner.PasswordHash, err = utils.HashPassword("old-password")
+	require.NoError(t, err)
+	_, err = owner.Update(ctx, testDB.Conn, boil.Infer())
+	require.NoError(t, err)
+
+	_, err = ums.UpdateUserPassword(ctx, services.ChangeUserPasswordRequest{
+		Uuid:            owner.ID,
+		CurrentPassword: "old-password",
+		NewPassword:     "new-password",
+	})
+	require.NoError(t, err)
+	newEmail := gofakeit.Email()
+	updated, err := ums.UpdateEmail(ctx, services.UpdateEmailRequest{
+		Uuid:            owner.ID,
+		CurrentPassword: "new-password",
+		NewEmail:        strings.ToUpper(newEmail),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, strings.ToLower(newEmail), updated.Email)
+	_, err = ums.DeleteUser(ctx, owner.ID, owner.ID)
+	require.NoError(t, err)
+
+	auditLogs, err := als.ExportAuditLogs(ctx, services.AuditLogFilter{
+		CompanyID:  company.ID,
+		EntityType: services.AuditEntityUser,
+		EntityID:   owner.ID,
+	})
+	require.NoError(t, err)
+
+	actions := map[string]bool{}
+	for _, auditLog := range auditLogs {
+		actions[auditLog.Action] = true
+		assert.Equal(t, owner.ID, auditLog.ActorID)
+		if auditLog.Action == services.AuditActionUpdate {
+			assert.Contains(t, string(auditLog.After), strings.ToLower(newEmail))
+		}
+	}
+	assert.Equal(t, map[string]bool{
+		services.AuditActionPasswordChange: true,
+		services.AuditActionUpdate:         true,
+		services.AuditActionDelete:         true,
+	}, actions)
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/pro-posal/webserver/internal/utils"
+	"github.com/pro-posal/webserver/models"
+	"github.com/pro-posal/webserver/services"
+)
+
+type PostInvitationRequestBody struct {
+	Email string      `json:"email"`
+	Role  models.Role `json:"role"`
+}
+
+type GetInvitationsResponseBody struct {
+	TotalInvitations int                  `json:"total_invitations"`
+	Invitations      []*models.Invitation `json:"invitations"`
+}
+
+// PostAcceptInvitationRequestBody only needs the token and password when the invited address already has an account.
+type PostAcceptInvitationRequestBody struct {
+	Token     string `json:"token"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Phone     string `json:"phone"`
+	Password  string `json:"password"`
+}
+
+func (a *API) PostInvitation(w http.ResponseWriter, r *http.Request) {
+	var request PostInvitationRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	invitation, err := a.userManagement.InviteUser(r.Context(), services.InviteUserRequest{
+		CompanyID:   mux.Vars(r)["companyId"],
+		Email:       request.Email,
+		Role:        request.Role,
+		RequestedBy: utils.GetUserIDFromSession(r).String(),
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrInvitationPending) || errors.Is(err, services.ErrAlreadyCompanyMember) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+
+		log.Printf("Error Creating Invitation: %v", err)
+		writeServiceError(w, err, "Error Creating Invitation")
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, invitation)
+}
+
+func (a *API) GetInvitations(w http.ResponseWriter, r *http.Request) {
+	invitations, err := a.userManagement.GetInvitations(r.Context(), mux.Vars(r)["companyId"], utils.GetUserIDFromSession(r).String())
+	if err != nil {
+		log.Printf("Error Getting Invitations: %v", err)
+		writeServiceError(w, err, "Error Getting Invitations")
+		return
+	}
+
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, GetInvitationsResponseBody{
+		TotalInvitations: len(invitations),
+		Invitations:      invitations,
+	})
+}
+
+func (a *API) PostResendInvitation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	invitation, err := a.userManagement.ResendInvitation(r.Context(), vars["companyId"], vars["invitationId"], utils.GetUserIDFromSession(r).String())
+	if err != nil {
+		var rateLimited *services.InvitationRateLimitedError
+		if errors.As(err, &rateLimited) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(rateLimited.RetryAfter.Seconds()))))
+			http.Error(w, "The invitation was sent recently", http.StatusTooManyRequests)
+			return
+		}
+		if errors.Is(err, services.ErrInvitationNotFound) {
+			http.Error(w, "Invitation not found", http.StatusNotFound)
+			return
+		}
+
+		log.Printf("Error Resending Invitation: %v", err)
+		writeServiceError(w, err, "Error Resending Invitation")
+		return
+	}
+
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, invitation)
+}
+
+func (a *API) DeleteInvitation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	err := a.userManagement.RevokeInvitation(r.Context(), vars["companyId"], vars["invitationId"], utils.GetUserIDFromSession(r).String())
+	if err != nil {
+		if errors.Is(err, services.ErrInvitationNotFound) {
+			http.Error(w, "Invitation not found", http.StatusNotFound)
+			return
+		}
+
+		log.Printf("Error Revoking Invitation: %v", err)
+		writeServiceError(w, err, "Error Revoking Invitation")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PostAcceptInvitation accepts an invitation with the emailed token. The user signs in afterwards as usual.
+func (a *API) PostAcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	var request PostAcceptInvitationRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := a.userManagement.AcceptInvitation(r.Context(), services.AcceptInvitationRequest{
+		Token:     request.Token,
+		FirstName: request.FirstName,
+		LastName:  request.LastName,
+		Phone:     request.Phone,
+		Password:  request.Password,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidInvitationToken) {
+			http.Error(w, "Invalid or expired invitation", http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, services.ErrInvitationPassword) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+
+		log.Printf("Error accepting invitation: %v", err)
+		http.Error(w, "Error accepting invitation", http.StatusBadRequest)
+		return
+	}
+
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, user)
+}
@@ -1 +1,135 @@
 package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pro-posal/webserver/internal/authz"
+	"github.com/pro-posal/webserver/internal/utils"
+	"github.com/pro-posal/webserver/models"
+	"github.com/pro-posal/webserver/services"
+)
+
+type PostOfferRequestBody struct {
+	ContractTemplateID string         `json:"contract_template_id"`
+	CustomerID         string         `json:"customer_id"`
+	Arguments          map[string]any `json:"arguments"`
+}
+
+type GetOffersResponseBody struct {
+	TotalOffers int             `json:"total_offers"`
+	Offers      []*models.Offer `json:"offers"`
+}
+
+type PostOfferResponseRequestBody struct {
+	Accept          bool   `json:"accept"`
+	RejectionReason string `json:"rejection_reason"`
+}
+
+func (a *API) PostOffer(w http.ResponseWriter, r *http.Request) {
+	var request PostOfferRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	offer, err := a.offerManagment.CreateOffer(r.Context(), services.CreateOfferRequest{
+		CompanyID:          mux.Vars(r)["companyId"],
+		ContractTemplateID: request.ContractTemplateID,
+		CustomerID:         request.CustomerID,
+		Arguments:          request.Arguments,
+		RequestedBy:        utils.GetUserIDFromSession(r).String(),
+	})
+	if err != nil {
+		log.Printf("Error Creating Offer: %v", err)
+		writeServiceError(w, err, "Error Creating Offer")
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, offer)
+}
+
+func (a *API) GetOffers(w http.ResponseWriter, r *http.Request) {
+	offers, err := a.offerManagment.GetOffers(r.Context(), mux.Vars(r)["companyId"], utils.GetUserIDFromSession(r).String())
+	if err != nil {
+		log.Printf("Error Getting Offers: %v", err)
+		writeServiceError(w, err, "Error Getting Offers")
+		return
+	}
+
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, GetOffersResponseBody{
+		TotalOffers: len(offers),
+		Offers:      offers,
+	})
+}
+
+func (a *API) GetOffer(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	offer, err := a.offerManagment.GetOffer(r.Context(), vars["companyId"], vars["offerId"], utils.GetUserIDFromSession(r).String())
+	if err != nil {
+		if errors.Is(err, services.ErrOfferNotFound) {
+			http.Error(w, "Offer not found", http.StatusNotFound)
+			return
+		}
+
+		log.Printf("Error Getting Offer: %v", err)
+		writeServiceError(w, err, "Error Getting Offer")
+		return
+	}
+
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, offer)
+}
+
+// PostOfferResponse lets the customer accept or reject an offer.
+func (a *API) PostOfferResponse(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var request PostOfferResponseRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	offer, err := a.offerManagment.RespondToOffer(r.Context(), services.RespondToOfferRequest{
+		CompanyID:       vars["companyId"],
+		OfferID:         vars["offerId"],
+		Accept:          request.Accept,
+		RejectionReason: request.RejectionReason,
+		RequestedBy:     utils.GetUserIDFromSession(r).String(),
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrOfferNotFound) {
+			http.Error(w, "Offer not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, services.ErrOfferAlreadyAnswered) {
+			http.Error(w, "The offer was already answered", http.StatusConflict)
+			return
+		}
+
+		log.Printf("Error Responding To Offer: %v", err)
+		writeServiceError(w, err, "Error Responding To Offer")
+		return
+	}
+
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, offer)
+}
+
+// authorizeOffer is the policy of the routes on a single offer, which its customer may call too.
+func (a *API) authorizeOffer(r *http.Request, caller *authz.Caller) error {
+	vars := mux.Vars(r)
+
+	err := a.authService.AuthorizeOffer(r.Context(), caller.UserID, caller.Permissions, vars["companyId"], vars["offerId"])
+	var unauthorized *services.UnauthorizedError
+	if errors.As(err, &unauthorized) {
+		return authz.Deny("offer %v wasn't sent to the caller", vars["offerId"])
+	}
+	return err
+}
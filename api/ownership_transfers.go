@@ -0,0 +1,128 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pro-posal/webserver/internal/utils"
+	"github.com/pro-posal/webserver/models"
+	"github.com/pro-posal/webserver/services"
+)
+
+// PostOwnershipTransferRequestBody names the member taking the company over and the role the
+// current owner keeps.
+type PostOwnershipTransferRequestBody struct {
+	ToUserID          string      `json:"to_user_id"`
+	PreviousOwnerRole models.Role `json:"previous_owner_role"`
+}
+
+type GetOwnershipTransfersResponseBody struct {
+	TotalOwnershipTransfers int                         `json:"total_ownership_transfers"`
+	OwnershipTransfers      []*models.OwnershipTransfer `json:"ownership_transfers"`
+}
+
+func (a *API) PostOwnershipTransfer(w http.ResponseWriter, r *http.Request) {
+	var request PostOwnershipTransferRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	transfer, err := a.companyManagement.TransferOwnership(r.Context(), services.TransferOwnershipRequest{
+		CompanyID:         mux.Vars(r)["companyId"],
+		ToUserID:          request.ToUserID,
+		PreviousOwnerRole: request.PreviousOwnerRole,
+		RequestedBy:       utils.GetUserIDFromSession(r).String(),
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrOwnershipTransferPending) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, services.ErrInvalidNewOwner) || errors.Is(err, services.ErrInvalidPreviousOwnerRole) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		log.Printf("Error Creating Ownership Transfer: %v", err)
+		writeServiceError(w, err, "Error Creating Ownership Transfer")
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, transfer)
+}
+
+func (a *API) DeleteOwnershipTransfer(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	err := a.companyManagement.CancelOwnershipTransfer(r.Context(), vars["companyId"], vars["transferId"], utils.GetUserIDFromSession(r).String())
+	if err != nil {
+		if errors.Is(err, services.ErrOwnershipTransferNotFound) {
+			http.Error(w, "Ownership transfer not found", http.StatusNotFound)
+			return
+		}
+
+		log.Printf("Error Cancelling Ownership Transfer: %v", err)
+		writeServiceError(w, err, "Error Cancelling Ownership Transfer")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetOwnershipTransfers lists the pending transfers of companies to the signed in user.
+func (a *API) GetOwnershipTransfers(w http.ResponseWriter, r *http.Request) {
+	transfers, err := a.companyManagement.GetOwnershipTransfers(r.Context(), utils.GetUserIDFromSession(r).String())
+	if err != nil {
+		log.Printf("Error Getting Ownership Transfers: %v", err)
+		http.Error(w, "Error Getting Ownership Transfers", http.StatusBadRequest)
+		return
+	}
+
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, GetOwnershipTransfersResponseBody{
+		TotalOwnershipTransfers: len(transfers),
+		OwnershipTransfers:      transfers,
+	})
+}
+
+func (a *API) PostAcceptOwnershipTransfer(w http.ResponseWriter, r *http.Request) {
+	company, err := a.companyManagement.AcceptOwnershipTransfer(r.Context(), mux.Vars(r)["transferId"], utils.GetUserIDFromSession(r).String())
+	if err != nil {
+		if errors.Is(err, services.ErrOwnershipTransferNotFound) {
+			http.Error(w, "Ownership transfer not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, services.ErrInvalidNewOwner) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, services.ErrPreviousOwnerNotAdmin) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+
+		log.Printf("Error Accepting Ownership Transfer: %v", err)
+		writeServiceError(w, err, "Error Accepting Ownership Transfer")
+		return
+	}
+
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, company)
+}
+
+func (a *API) PostDeclineOwnershipTransfer(w http.ResponseWriter, r *http.Request) {
+	err := a.companyManagement.DeclineOwnershipTransfer(r.Context(), mux.Vars(r)["transferId"], utils.GetUserIDFromSession(r).String())
+	if err != nil {
+		if errors.Is(err, services.ErrOwnershipTransferNotFound) {
+			http.Error(w, "Ownership transfer not found", http.StatusNotFound)
+			return
+		}
+
+		log.Printf("Error Declining Ownership Transfer: %v", err)
+		writeServiceError(w, err, "Error Declining Ownership Transfer")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
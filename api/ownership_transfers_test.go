@@ -0,0 +1,103 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/pro-posal/webserver/models"
+	"github.com/pro-posal/webserver/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostOwnershipTransfer_PassesRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	companyServiceMock := services.NewMockCompanyManagementService(ctrl)
+
+	api := NewAPI(nil, nil, nil, companyServiceMock, nil, nil, nil, nil, nil, nil, nil)
+
+	companyServiceMock.EXPECT().
+		TransferOwnership(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, req services.TransferOwnershipRequest) {
+			assert.Equal(t, TEST_COMPANY_ID, req.CompanyID)
+			assert.Equal(t, TEST_CONTRACT_ID, req.ToUserID)
+			assert.Equal(t, models.CompanyAdminRole, req.PreviousOwnerRole)
+		}).
+		Return(&models.OwnershipTransfer{ID: "transfer", CompanyID: TEST_COMPANY_ID}, nil)
+
+	body := bytes.NewBufferString(`{"to_user_id": "` + TEST_CONTRACT_ID + `", "previous_owner_role": "company_admin"}`)
+	req, err := http.NewRequest("POST", "/companies/"+TEST_COMPANY_ID+"/ownershipTransfers", body)
+	require.NoError(t, err)
+	req = mux.SetURLVars(req, map[string]string{"companyId": TEST_COMPANY_ID})
+
+	rr := httptest.NewRecorder()
+	api.PostOwnershipTransfer(rr, req)
+	assert.Equal(t, http.StatusCreated, rr.Code)
+}
+
+func TestPostOwnershipTransfer_MapsErrors(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{services.ErrOwnershipTransferPending, http.StatusConflict},
+		{services.ErrInvalidNewOwner, http.StatusBadRequest},
+		{&services.UnauthorizedError{}, http.StatusUnauthorized},
+	} {
+		ctrl := gomock.NewController(t)
+		companyServiceMock := services.NewMockCompanyManagementService(ctrl)
+		api := NewAPI(nil, nil, nil, companyServiceMock, nil, nil, nil, nil, nil, nil, nil)
+
+		companyServiceMock.EXPECT().TransferOwnership(gomock.Any(), gomock.Any()).Return(nil, tc.err)
+
+		req, err := http.NewRequest("POST", "/companies/"+TEST_COMPANY_ID+"/ownershipTransfers", bytes.NewBufferString(`{}`))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		api.PostOwnershipTransfer(rr, req)
+		assert.Equal(t, tc.code, rr.Code, tc.err.Error())
+	}
+}
+
+func TestPostAcceptOwnershipTransfer_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	companyServiceMock := services.NewMockCompanyManagementService(ctrl)
+
+	api := NewAPI(nil, nil, nil, companyServiceMock, nil, nil, nil, nil, nil, nil, nil)
+
+	companyServiceMock.EXPECT().
+		AcceptOwnershipTransfer(gomock.Any(), "transfer", gomock.Any()).
+		Return(nil, services.ErrOwnershipTransferNotFound)
+
+	req, err := http.NewRequest("POST", "/ownershipTransfers/transfer/accept", nil)
+	require.NoError(t, err)
+	req = mux.SetURLVars(req, map[string]string{"transferId": "transfer"})
+
+	rr := httptest.NewRecorder()
+	api.PostAcceptOwnershipTransfer(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestPostAcceptOwnershipTransfer_PreviousOwnerNotAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	companyServiceMock := services.NewMockCompanyManagementService(ctrl)
+
+	api := NewAPI(nil, nil, nil, companyServiceMock, nil, nil, nil, nil, nil, nil, nil)
+
+	companyServiceMock.EXPECT().
+		AcceptOwnershipTransfer(gomock.Any(), "transfer", gomock.Any()).
+		Return(nil, services.ErrPreviousOwnerNotAdmin)
+
+	req, err := http.NewRequest("POST", "/ownershipTransfers/transfer/accept", nil)
+	require.NoError(t, err)
+	req = mux.SetURLVars(req, map[string]string{"transferId": "transfer"})
+
+	rr := httptest.NewRecorder()
+	api.PostAcceptOwnershipTransfer(rr, req)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
@@ -4,6 +4,7 @@ import (
 	"log"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/pro-posal/webserver/internal/utils"
@@ -11,16 +12,24 @@ import (
 	"github.com/pro-posal/webserver/services"
 )
 
+// PostPremmisionRequestBody grants one of the company's own roles when CompanyRoleID is set,
+// Role then defaults to company_custom. Without StartsAt the role is granted right away, without
+// ExpiresAt it is granted until revoked. The company is the one in the path.
 type PostPremmisionRequestBody struct {
-	UserID     string `json:"user_id"`
-	CompanyID  string `json:"company_id"`
-	Role       string `json:"role"`
-	ContractID string `json:"contract_id"`
+	UserID        string     `json:"user_id"`
+	Role          string     `json:"role"`
+	ContractID    string     `json:"contract_id"`
+	CompanyRoleID string     `json:"company_role_id"`
+	StartsAt      *time.Time `json:"starts_at"`
+	ExpiresAt     *time.Time `json:"expires_at"`
 }
 
 type UpdatePremmisionRequestBody struct {
-	Role       string `json:"role"`
-	ContractID string `json:"contract_id"`
+	Role          string     `json:"role"`
+	ContractID    string     `json:"contract_id"`
+	CompanyRoleID string     `json:"company_role_id"`
+	StartsAt      *time.Time `json:"starts_at"`
+	ExpiresAt     *time.Time `json:"expires_at"`
 }
 
 type GetUsersPermissionsResponseBody struct {
@@ -28,6 +37,15 @@ type GetUsersPermissionsResponseBody struct {
 	UserPermissions []*models.UserPermission `json:"users_permissions"`
 }
 
+// allowedRoles are the roles the permissions API grants; platform admins aren't made through it.
+var allowedRoles = []string{
+	string(models.CompanyAdminRole),
+	string(models.CompanyContributorRole),
+	string(models.CompanyProjectManagerRole),
+	string(models.ProspectRole),
+	string(models.CompanyCustomRole),
+}
+
 func (a *API) PostPermmision(w http.ResponseWriter, r *http.Request) {
 	var request PostPremmisionRequestBody
 	err := utils.UnmarshalRequest(r, &request)
@@ -37,12 +55,8 @@ func (a *API) PostPermmision(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate the role provided
-	allowedRoles := []string{
-		string(models.CompanyAdminRole),
-		string(models.CompanyContributorRole),
-		string(models.CompanyProjectManagerRole),
-		string(models.ProspectRole),
+	if request.CompanyRoleID != "" && request.Role == "" {
+		request.Role = string(models.CompanyCustomRole)
 	}
 	// Check if the role provided is in the allowed roles
 	if !slices.Contains(allowedRoles, request.Role) {
@@ -52,14 +66,18 @@ func (a *API) PostPermmision(w http.ResponseWriter, r *http.Request) {
 	}
 
 	permission, err := a.permissionsManagement.CreatePermission(r.Context(), services.CreatePermissionRequest{
-		UserID:     request.UserID,
-		CompanyID:  request.CompanyID,
-		Role:       request.Role,
-		ContractID: request.ContractID,
+		UserID:        request.UserID,
+		CompanyID:     mux.Vars(r)["id"],
+		Role:          request.Role,
+		ContractID:    request.ContractID,
+		CompanyRoleID: request.CompanyRoleID,
+		StartsAt:      request.StartsAt,
+		ExpiresAt:     request.ExpiresAt,
+		RequestedBy:   utils.GetUserIDFromSession(r).String(),
 	})
 	if err != nil {
 		log.Printf("Error creating a user's permission for company: %v", err)
-		http.Error(w, "Error creating a user's permission for company", http.StatusBadRequest)
+		writeServiceError(w, err, "Error creating a user's permission for company")
 		return
 	}
 
@@ -111,14 +129,27 @@ func (a *API) UpdatePermission(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if request.CompanyRoleID != "" && request.Role == "" {
+		request.Role = string(models.CompanyCustomRole)
+	}
+	if !slices.Contains(allowedRoles, request.Role) {
+		log.Printf("Invalid role provided: %v", request.Role)
+		http.Error(w, "Invalid role provided", http.StatusBadRequest)
+		return
+	}
+
 	permission, err := a.permissionsManagement.UpdatePermission(r.Context(), services.UpdatePermissionRequest{
-		Id:         permissionId,
-		Role:       request.Role,
-		ContractID: request.ContractID,
+		Id:            permissionId,
+		Role:          request.Role,
+		ContractID:    request.ContractID,
+		CompanyRoleID: request.CompanyRoleID,
+		StartsAt:      request.StartsAt,
+		ExpiresAt:     request.ExpiresAt,
+		RequestedBy:   utils.GetUserIDFromSession(r).String(),
 	})
 	if err != nil {
 		log.Printf("Error updating a user's permission for company: %v", err)
-		http.Error(w, "Error updating a user's permission for company", http.StatusBadRequest)
+		writeServiceError(w, err, "Error updating a user's permission for company")
 		return
 	}
 
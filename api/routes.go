@@ -3,8 +3,11 @@ package api
 import (
 	"net/http"
 
+	"github.com/pro-posal/webserver/dao"
+	"github.com/pro-posal/webserver/internal/authz"
 	"github.com/pro-posal/webserver/internal/database"
 	"github.com/pro-posal/webserver/internal/middlewares"
+	"github.com/pro-posal/webserver/models"
 	"github.com/pro-posal/webserver/services"
 
 	"github.com/gorilla/mux"
@@ -20,6 +23,10 @@ type API struct {
 	categoryManagment     services.CategoryManagementService
 	contractManagment     services.ContractTemplateManagementService
 	offerManagment        services.OfferManagementService
+	galleryManagment      services.GalleryManagementService
+	trashManagment        services.TrashManagementService
+	auditLogs             services.AuditLogService
+	router                *mux.Router
 }
 
 func NewAPI(
@@ -31,6 +38,9 @@ func NewAPI(
 	categoryManagment services.CategoryManagementService,
 	contractManagment services.ContractTemplateManagementService,
 	offerManagment services.OfferManagementService,
+	galleryManagment services.GalleryManagementService,
+	trashManagment services.TrashManagementService,
+	auditLogs services.AuditLogService,
 
 ) *API {
 	return &API{
@@ -42,58 +52,188 @@ func NewAPI(
 		categoryManagment:     categoryManagment,
 		contractManagment:     contractManagment,
 		offerManagment:        offerManagment,
+		galleryManagment:      galleryManagment,
+		trashManagment:        trashManagment,
+		auditLogs:             auditLogs,
 	}
 }
 
 func (a *API) NewRouter() http.Handler {
 	router := mux.NewRouter()
+	a.router = router
 
+	router.Use(middlewares.RequestIDMiddleware)
 	router.Use(middlewares.AccessLogMiddleware)
 	router.Use(middlewares.PanicMiddleware)
 	router.Use(middlewares.JSONHeaderMiddleware)
 	router.Use(func(h http.Handler) http.Handler {
 		return middlewares.AuthenticationMiddleware(a.authService, h)
 	})
+	router.Use(middlewares.EmailVerificationMiddleware)
 	router.Use(func(h http.Handler) http.Handler {
-		return middlewares.AuthorizationMiddleware(a.authService, h, a.db)
+		return middlewares.AuthorizationMiddleware(h, a.db)
 	})
 
+	// Every route declares the permission it requires, see the authz package
+	companyVar := authz.Company(authz.Var("companyId"))
+	inCompany := func(capabilities ...models.Capability) authz.Policy {
+		return authz.InCompany(companyVar, capabilities...)
+	}
+	// Contract templates are looked up by their ID alone, so their own company is the one to check
+	inContractCompany := func(capability models.Capability) authz.Policy {
+		return authz.InCompany(authz.CompanyOf(dao.TableNames.ContractTemplates, authz.Var("contractId")), capability)
+	}
+
 	// Check API status
-	router.HandleFunc("/status", a.handleGetStatus).Methods("GET")
+	router.Handle("/status", authz.Protect(authz.Public(), a.handleGetStatus)).Methods("GET")
+	// GET /.well-known/jwks.json - Public keys to verify our auth tokens with
+	router.Handle("/.well-known/jwks.json", authz.Protect(authz.Public(), a.GetJWKS)).Methods("GET")
+	// POST /authz/explain - Explain whether a user may make a request, and why
+	router.Handle("/authz/explain", authz.Protect(authz.PlatformAdmin(), a.PostAuthzExplain)).Methods("POST")
 
 	// POST /users - Create a new user
 	// GET /users - List all users
-	router.HandleFunc("/users", a.PostUsers).Methods("POST")
-	router.HandleFunc("/users", a.GetUsers).Methods("GET")
+	router.Handle("/users", authz.Protect(authz.Public(), a.PostUsers)).Methods("POST")
+	router.Handle("/users", authz.Protect(authz.PlatformAdmin(), a.GetUsers)).Methods("GET")
 
 	// POST /users/login - Login user and obtain auth token
-	router.HandleFunc("/users/login", a.PostUsersLogin).Methods("POST")
+	router.Handle("/users/login", authz.Protect(authz.Public(), a.PostUsersLogin)).Methods("POST")
+	// POST /auth/refresh - Exchange a refresh token for a new auth token and refresh token
+	router.Handle("/auth/refresh", authz.Protect(authz.Public(), a.PostAuthRefresh)).Methods("POST")
+	// POST /auth/logout - End the current session
+	router.Handle("/auth/logout", authz.Protect(authz.SignedIn().AllowUnverified(), a.PostAuthLogout)).Methods("POST")
+	// GET /auth/sessions - List the devices the caller is signed in on
+	router.Handle("/auth/sessions", authz.Protect(authz.SignedIn(), a.GetSessions)).Methods("GET")
+	// POST /auth/sessions/revokeOthers - Sign out of every device except the current one
+	router.Handle("/auth/sessions/revokeOthers", authz.Protect(authz.SignedIn().AllowUnverified(), a.PostRevokeOtherSessions)).Methods("POST")
+	// DELETE /auth/sessions/{sessionId} - Sign out of one device
+	router.Handle("/auth/sessions/{sessionId}", authz.Protect(authz.SignedIn().AllowUnverified(), a.DeleteSession)).Methods("DELETE")
+	// POST /auth/oidc/start - Start a single sign-on with a company's identity provider
+	router.Handle("/auth/oidc/start", authz.Protect(authz.Public(), a.PostOIDCStart)).Methods("POST")
+	// POST /auth/oidc/link - Start a single sign-on that links the identity to the caller's account
+	router.Handle("/auth/oidc/link", authz.Protect(authz.SignedIn().AllowUnverified(), a.PostOIDCLink)).Methods("POST")
+	// POST /auth/oidc/callback - Complete a single sign-on and obtain auth token
+	router.Handle("/auth/oidc/callback", authz.Protect(authz.Public(), a.PostOIDCCallback)).Methods("POST")
+	// POST /auth/2fa - Complete a login challenge with an authenticator or recovery code
+	router.Handle("/auth/2fa", authz.Protect(authz.Public(), a.PostAuthTwoFactor)).Methods("POST")
+	// POST /auth/2fa/enroll - Start enrolling an authenticator app with the password, returns the secret and provisioning URI
+	router.Handle("/auth/2fa/enroll", authz.Protect(authz.SignedIn().AllowUnverified(), a.PostTwoFactorEnroll)).Methods("POST")
+	// POST /auth/2fa/confirm - Enable two-factor authentication with the password and a first code, returns the recovery codes
+	router.Handle("/auth/2fa/confirm", authz.Protect(authz.SignedIn().AllowUnverified(), a.PostTwoFactorConfirm)).Methods("POST")
+	// POST /auth/2fa/disable - Disable two-factor authentication
+	router.Handle("/auth/2fa/disable", authz.Protect(authz.SignedIn().AllowUnverified(), a.PostTwoFactorDisable)).Methods("POST")
+	// POST /auth/2fa/recoveryCodes - Replace the recovery codes
+	router.Handle("/auth/2fa/recoveryCodes", authz.Protect(authz.SignedIn().AllowUnverified(), a.PostTwoFactorRecoveryCodes)).Methods("POST")
 	// GET /users/{id} - Get user information
-	router.HandleFunc("/users/{id}", a.GetUser).Methods("GET")
+	router.Handle("/users/{id}", authz.Protect(authz.Self(authz.Var("id")), a.GetUser)).Methods("GET")
 	// PATCH /users/updatePassword - update the users password
-	router.HandleFunc("/users/updatePassword", a.UpdateUserPassword).Methods("PATCH")
+	router.Handle("/users/updatePassword", authz.Protect(authz.SignedIn(), a.UpdateUserPassword)).Methods("PATCH")
+	// PATCH /users/updateEmail - Change the caller's email address, which then has to be verified again
+	router.Handle("/users/updateEmail", authz.Protect(authz.SignedIn().AllowUnverified(), a.UpdateUserEmail)).Methods("PATCH")
+	// POST /users/verifyEmail - Verify an email address with the emailed token
+	router.Handle("/users/verifyEmail", authz.Protect(authz.Public(), a.PostVerifyEmail)).Methods("POST")
+	// POST /users/verifyEmail/resend - Send the verification email again
+	router.Handle("/users/verifyEmail/resend", authz.Protect(authz.Public(), a.PostResendVerification)).Methods("POST")
+	// POST /users/forgotPassword - Email a password reset link
+	router.Handle("/users/forgotPassword", authz.Protect(authz.Public(), a.PostForgotPassword)).Methods("POST")
+	// POST /users/resetPassword - Set a new password with the emailed reset token
+	router.Handle("/users/resetPassword", authz.Protect(authz.Public(), a.PostResetPassword)).Methods("POST")
+	// POST /invitations/accept - Accept a company invitation with the emailed token, signing up if needed
+	router.Handle("/invitations/accept", authz.Protect(authz.Public(), a.PostAcceptInvitation)).Methods("POST")
+	// POST /users/{id}/unlock - Lift a login lockout, for admins of a company the user belongs to
+	router.Handle("/users/{id}/unlock", authz.Protect(authz.PlatformAdmin(), a.PostUnlockUser)).Methods("POST")
 	// Delete /users/{id} - Delete user
-	router.HandleFunc("/users/{id}", a.DeleteUser).Methods("DELETE")
+	router.Handle("/users/{id}", authz.Protect(authz.Self(authz.Var("id")), a.DeleteUser)).Methods("DELETE")
 
 	// companies table
-	// POST /companies - Create a new company
-	router.HandleFunc("/companies", a.PostCompanies).Methods("POST")
-	// GET /companies - User ID input List all companies
-	router.HandleFunc("/companies/{companyId}", a.GetCompanies).Methods("GET")
+	// POST /companies - Create a new company, its contact becomes the company admin
+	router.Handle("/companies", authz.Protect(authz.PlatformAdmin(), a.PostCompanies)).Methods("POST")
+	// GET /companies/{companyId} - Get the company
+	router.Handle("/companies/{companyId}", authz.Protect(inCompany(models.CapabilityCompanyView), a.GetCompanies)).Methods("GET")
 	// PUT /companies/{id} - Company Id input Update company information
-	router.HandleFunc("/companies/{companyId}", a.UpdateCompanies).Methods("PUT")
-	// DELETE /companies/{id} - Company Id input Delete company
-	router.HandleFunc("/companies/{id}", a.DeleteCompany).Methods("DELETE")
+	router.Handle("/companies/{companyId}", authz.Protect(inCompany(models.CapabilityCompanyManage), a.UpdateCompanies)).Methods("PUT")
+	// DELETE /companies/{companyId} - Company Id input Delete company
+	router.Handle("/companies/{companyId}", authz.Protect(inCompany(models.CapabilityCompanyManage), a.DeleteCompany)).Methods("DELETE")
+
+	// ownership transfers
+	// POST /companies/{companyId}/ownershipTransfers - The owner nominates another member to take the company over
+	router.Handle("/companies/{companyId}/ownershipTransfers", authz.Protect(inCompany(models.CapabilityCompanyManage), a.PostOwnershipTransfer)).Methods("POST")
+	// DELETE /companies/{companyId}/ownershipTransfers/{transferId} - The owner cancels a pending transfer
+	router.Handle("/companies/{companyId}/ownershipTransfers/{transferId}", authz.Protect(inCompany(models.CapabilityCompanyManage), a.DeleteOwnershipTransfer)).Methods("DELETE")
+	// GET /ownershipTransfers - List the pending transfers of companies to the caller
+	router.Handle("/ownershipTransfers", authz.Protect(authz.SignedIn(), a.GetOwnershipTransfers)).Methods("GET")
+	// POST /ownershipTransfers/{transferId}/accept - The recipient becomes the company's owner
+	router.Handle("/ownershipTransfers/{transferId}/accept", authz.Protect(authz.SignedIn(), a.PostAcceptOwnershipTransfer)).Methods("POST")
+	// POST /ownershipTransfers/{transferId}/decline - The recipient turns the company down
+	router.Handle("/ownershipTransfers/{transferId}/decline", authz.Protect(authz.SignedIn(), a.PostDeclineOwnershipTransfer)).Methods("POST")
+
+	// trash
+	// GET /companies/{companyId}/trash -> list the company's soft-deleted records and who deleted them
+	router.Handle("/companies/{companyId}/trash", authz.Protect(inCompany(models.CapabilityCompanyManage), a.GetTrash)).Methods("GET")
+	// POST /companies/{companyId}/trash/{deletionId}/restore -> restore a deletion, including the children deleted with it
+	router.Handle("/companies/{companyId}/trash/{deletionId}/restore", authz.Protect(inCompany(models.CapabilityCompanyManage), a.RestoreTrash)).Methods("POST")
+
+	// audit log
+	// GET /companies/{companyId}/audit -> page through the company's audit log, newest first
+	router.Handle("/companies/{companyId}/audit", authz.Protect(inCompany(models.CapabilityAuditView), a.GetAuditLogs)).Methods("GET")
+	// GET /companies/{companyId}/audit/export -> download the matching audit log entries as CSV
+	router.Handle("/companies/{companyId}/audit/export", authz.Protect(inCompany(models.CapabilityAuditView), a.GetAuditLogsExport)).Methods("GET")
+
+	// api keys and service accounts
+	// POST /companies/{companyId}/apiKeys - Create an API key, the key is only returned once
+	router.Handle("/companies/{companyId}/apiKeys", authz.Protect(inCompany(models.CapabilityCompanyView), a.PostAPIKey)).Methods("POST")
+	// GET /companies/{companyId}/apiKeys - List API keys, admins see every key of the company
+	router.Handle("/companies/{companyId}/apiKeys", authz.Protect(inCompany(models.CapabilityCompanyView), a.GetAPIKeys)).Methods("GET")
+	// DELETE /companies/{companyId}/apiKeys/{keyId} - Revoke an API key
+	router.Handle("/companies/{companyId}/apiKeys/{keyId}", authz.Protect(inCompany(models.CapabilityCompanyView), a.DeleteAPIKey)).Methods("DELETE")
+	// POST /companies/{companyId}/serviceAccounts - Create a service account that acts through API keys
+	router.Handle("/companies/{companyId}/serviceAccounts", authz.Protect(inCompany(models.CapabilityIntegrationsManage), a.PostServiceAccount)).Methods("POST")
+	// GET /companies/{companyId}/serviceAccounts - List the company's service accounts
+	router.Handle("/companies/{companyId}/serviceAccounts", authz.Protect(inCompany(models.CapabilityIntegrationsManage), a.GetServiceAccounts)).Methods("GET")
+	// DELETE /companies/{companyId}/serviceAccounts/{userId} - Delete a service account and revoke its keys
+	router.Handle("/companies/{companyId}/serviceAccounts/{userId}", authz.Protect(inCompany(models.CapabilityIntegrationsManage), a.DeleteServiceAccount)).Methods("DELETE")
+
+	// invitations
+	// POST /companies/{companyId}/invitations - Invite an email address to the company with a role
+	router.Handle("/companies/{companyId}/invitations", authz.Protect(inCompany(models.CapabilityMembersManage), a.PostInvitation)).Methods("POST")
+	// GET /companies/{companyId}/invitations - List the invitations that are still pending
+	router.Handle("/companies/{companyId}/invitations", authz.Protect(inCompany(models.CapabilityMembersManage), a.GetInvitations)).Methods("GET")
+	// POST /companies/{companyId}/invitations/{invitationId}/resend - Email a new invitation link
+	router.Handle("/companies/{companyId}/invitations/{invitationId}/resend", authz.Protect(inCompany(models.CapabilityMembersManage), a.PostResendInvitation)).Methods("POST")
+	// DELETE /companies/{companyId}/invitations/{invitationId} - Revoke a pending invitation
+	router.Handle("/companies/{companyId}/invitations/{invitationId}", authz.Protect(inCompany(models.CapabilityMembersManage), a.DeleteInvitation)).Methods("DELETE")
+
+	// identity providers
+	// POST /companies/{companyId}/identityProviders - Add an OpenID Connect identity provider for single sign-on
+	router.Handle("/companies/{companyId}/identityProviders", authz.Protect(inCompany(models.CapabilityCompanyManage), a.PostIdentityProvider)).Methods("POST")
+	// GET /companies/{companyId}/identityProviders - List the company's identity providers
+	router.Handle("/companies/{companyId}/identityProviders", authz.Protect(inCompany(models.CapabilityCompanyManage), a.GetIdentityProviders)).Methods("GET")
+	// PUT /companies/{companyId}/identityProviders/{providerId} - Update an identity provider and its group to role mapping
+	router.Handle("/companies/{companyId}/identityProviders/{providerId}", authz.Protect(inCompany(models.CapabilityCompanyManage), a.PutIdentityProvider)).Methods("PUT")
+	// DELETE /companies/{companyId}/identityProviders/{providerId} - Delete an identity provider
+	router.Handle("/companies/{companyId}/identityProviders/{providerId}", authz.Protect(inCompany(models.CapabilityCompanyManage), a.DeleteIdentityProvider)).Methods("DELETE")
 
 	// premmisions table
-	// POST /premmisions/-> post a premmisions for company and email
-	router.HandleFunc("/permissions/{id}", a.PostPermmision).Methods("POST")
+	// POST /premmisions/{companyId} -> post a premmisions for company and email
+	router.Handle("/permissions/{id}", authz.Protect(authz.InCompany(authz.Company(authz.Var("id")), models.CapabilityMembersManage), a.PostPermmision)).Methods("POST")
 	// GET //premmisions/{companyId} -> Get All Company users premmisions
-	router.HandleFunc("/permissions/{id}", a.GetPermmisions).Methods("GET")
+	router.Handle("/permissions/{id}", authz.Protect(authz.InCompany(authz.Company(authz.Var("id")), models.CapabilityMembersView), a.GetPermmisions)).Methods("GET")
 	// PUT /premmision/{id} -> Update premmision info
-	router.HandleFunc("/permissions/{id}", a.UpdatePermission).Methods("PUT")
+	router.Handle("/permissions/{id}", authz.Protect(authz.InCompany(authz.CompanyOf(dao.TableNames.Permissions, authz.Var("id")), models.CapabilityMembersManage), a.UpdatePermission)).Methods("PUT")
 	// DELETE /premmision/{id} -> delete premmision
-	router.HandleFunc("/permissions/{id}", a.DeletePermission).Methods("DELETE")
+	router.Handle("/permissions/{id}", authz.Protect(authz.InCompany(authz.CompanyOf(dao.TableNames.Permissions, authz.Var("id")), models.CapabilityMembersManage), a.DeletePermission)).Methods("DELETE")
+
+	// company roles
+	// POST /companies/{companyId}/roles - Define a role out of capabilities, grant it with a company_custom permission
+	router.Handle("/companies/{companyId}/roles", authz.Protect(inCompany(models.CapabilityMembersManage), a.PostCompanyRole)).Methods("POST")
+	// GET /companies/{companyId}/roles - List the company's own roles
+	router.Handle("/companies/{companyId}/roles", authz.Protect(inCompany(models.CapabilityMembersView), a.GetCompanyRoles)).Methods("GET")
+	// GET /roles/capabilities - List the capabilities roles can be composed of and those of the built-in roles
+	router.Handle("/roles/capabilities", authz.Protect(authz.SignedIn(), a.GetCapabilities)).Methods("GET")
+	// PUT /companies/{companyId}/roles/{roleId} - Update a role, its holders get the new capabilities right away
+	router.Handle("/companies/{companyId}/roles/{roleId}", authz.Protect(inCompany(models.CapabilityMembersManage), a.PutCompanyRole)).Methods("PUT")
+	// DELETE /companies/{companyId}/roles/{roleId} - Delete a role nobody holds anymore
+	router.Handle("/companies/{companyId}/roles/{roleId}", authz.Protect(inCompany(models.CapabilityMembersManage), a.DeleteCompanyRole)).Methods("DELETE")
 
 	// DELETE /companies/{companyID} -> Delete comapny from user
 
@@ -102,33 +242,87 @@ func (a *API) NewRouter() http.Handler {
 	// TODO: Fix the rest of the routes and adjust the var names within the handlers
 
 	// POST /contractsTemplates/{companyId} -> Post a  Compnay contract Template
-	router.HandleFunc("/companies/{companyId}/contracts", a.PostContractsTemplates).Methods("POST")
+	router.Handle("/companies/{companyId}/contracts", authz.Protect(inCompany(models.CapabilityTemplatesEdit), a.PostContractsTemplates)).Methods("POST")
 	// GET //contractsTemplates/{companyId} -> Get All Company contracts templates
-	router.HandleFunc("/companies/{companyId}/contracts", a.GetContractsTemplates).Methods("GET")
+	router.Handle("/companies/{companyId}/contracts", authz.Protect(inCompany(models.CapabilityTemplatesView), a.GetContractsTemplates)).Methods("GET")
+	// GET /companies/{companyId}/contracts/outdated -> Get adopted contract templates whose gallery original was updated
+	router.Handle("/companies/{companyId}/contracts/outdated", authz.Protect(inCompany(models.CapabilityTemplatesView), a.GetOutdatedContractsTemplates)).Methods("GET")
 	// GET //contractsTemplates/{companyId}/{contractTemplateID} -> Get specific contract templates
-	router.HandleFunc("/companies/{companyId}/contracts/{contractId}", a.GetContractsTemplate).Methods("GET")
+	router.Handle("/companies/{companyId}/contracts/{contractId}", authz.Protect(inContractCompany(models.CapabilityTemplatesView), a.GetContractsTemplate)).Methods("GET")
+	// GET /companies/{companyId}/contracts/{contractId}/translations -> Get all language variants of a contract template
+	router.Handle("/companies/{companyId}/contracts/{contractId}/translations", authz.Protect(inContractCompany(models.CapabilityTemplatesView), a.GetContractsTemplateTranslations)).Methods("GET")
+	// POST /companies/{companyId}/contracts/{contractId}/render -> Render a contract template as HTML or text in its language
+	router.Handle("/companies/{companyId}/contracts/{contractId}/render", authz.Protect(inContractCompany(models.CapabilityTemplatesView), a.RenderContractsTemplate)).Methods("POST")
+	// POST /companies/{companyId}/legalClauses -> add a legal clause that contract templates can or must include
+	router.Handle("/companies/{companyId}/legalClauses", authz.Protect(inCompany(models.CapabilityTemplatesEdit), a.PostLegalClauses)).Methods("POST")
+	// GET /companies/{companyId}/legalClauses -> get the company legal clauses
+	router.Handle("/companies/{companyId}/legalClauses", authz.Protect(inCompany(models.CapabilityTemplatesView), a.GetLegalClauses)).Methods("GET")
+	// PUT /companies/{companyId}/legalClauses/{clauseId} -> update a legal clause
+	router.Handle("/companies/{companyId}/legalClauses/{clauseId}", authz.Protect(inCompany(models.CapabilityTemplatesEdit), a.UpdateLegalClause)).Methods("PUT")
+	// DELETE /companies/{companyId}/legalClauses/{clauseId} -> delete a legal clause
+	router.Handle("/companies/{companyId}/legalClauses/{clauseId}", authz.Protect(inCompany(models.CapabilityTemplatesEdit), a.DeleteLegalClause)).Methods("DELETE")
+
+	// offers
+	// POST /companies/{companyId}/offers -> render a contract template for a customer and send it to them
+	router.Handle("/companies/{companyId}/offers", authz.Protect(inCompany(models.CapabilityOffersCreate), a.PostOffer)).Methods("POST")
+	// GET /companies/{companyId}/offers -> list the company's offers, prospects only see their own
+	router.Handle("/companies/{companyId}/offers", authz.Protect(inCompany(models.CapabilityOffersView, models.CapabilityOffersRespond), a.GetOffers)).Methods("GET")
+	// GET /companies/{companyId}/offers/{offerId} -> get an offer, its customer opening it is recorded
+	router.Handle("/companies/{companyId}/offers/{offerId}", authz.Protect(authz.Custom(a.authorizeOffer), a.GetOffer)).Methods("GET")
+	// POST /companies/{companyId}/offers/{offerId}/respond -> the customer accepts or rejects the offer
+	router.Handle("/companies/{companyId}/offers/{offerId}/respond", authz.Protect(authz.Custom(a.authorizeOffer), a.PostOfferResponse)).Methods("POST")
+
 	// PUT /contractsTemplates/{companyId}/{contractTemplateID} -> Update contract template info
-	router.HandleFunc("/contractsTemplates/{id}", a.UpdateContractsTemplates).Methods("PUT")
+	router.Handle("/contractsTemplates/{id}", authz.Protect(authz.InCompany(authz.CompanyOf(dao.TableNames.ContractTemplates, authz.Var("id")), models.CapabilityTemplatesEdit), a.UpdateContractsTemplates)).Methods("PUT")
 	// DELETE //contractsTemplates/{contractTemplateID} -> delete specic contract templates
-	router.HandleFunc("/contractsTemplates/{id}", a.DeleteContractsTemplates).Methods("DELETE")
+	router.Handle("/contractsTemplates/{id}", authz.Protect(authz.InCompany(authz.CompanyOf(dao.TableNames.ContractTemplates, authz.Var("id")), models.CapabilityTemplatesEdit), a.DeleteContractsTemplates)).Methods("DELETE")
 
 	// PUT /contractsTemplates/{companyId}/{contractTemplateID} -> Update contract template info
 	// DELETE //contractsTemplates/{companyId}/{contractTemplateID} -> delete specic contract templates
 
+	// gallery table
+	// POST /gallery -> publish a starter template to the shared gallery (platform admins only)
+	router.Handle("/gallery", authz.Protect(authz.PlatformAdmin(), a.PostGalleryTemplates)).Methods("POST")
+	// GET /gallery -> list the shared gallery templates
+	router.Handle("/gallery", authz.Protect(authz.SignedIn(), a.GetGalleryTemplates)).Methods("GET")
+	// GET /gallery/{galleryTemplateId} -> get a specific gallery template
+	router.Handle("/gallery/{galleryTemplateId}", authz.Protect(authz.SignedIn(), a.GetGalleryTemplate)).Methods("GET")
+	// PUT /gallery/{galleryTemplateId} -> update a gallery template and bump its version
+	router.Handle("/gallery/{galleryTemplateId}", authz.Protect(authz.PlatformAdmin(), a.UpdateGalleryTemplate)).Methods("PUT")
+	// DELETE /gallery/{galleryTemplateId} -> remove a template from the gallery
+	router.Handle("/gallery/{galleryTemplateId}", authz.Protect(authz.PlatformAdmin(), a.DeleteGalleryTemplate)).Methods("DELETE")
+	// POST /companies/{companyId}/gallery/{galleryTemplateId}/adopt -> copy a gallery template into the company
+	router.Handle("/companies/{companyId}/gallery/{galleryTemplateId}/adopt", authz.Protect(inCompany(models.CapabilityTemplatesPublish), a.AdoptGalleryTemplate)).Methods("POST")
+
 	// categories table
+	// GET /companies/{companyId}/categories/tree -> get the whole category tree, optionally limited by ?depth= and with ?include_deleted=true for admins
+	router.Handle("/companies/{companyId}/categories/tree", authz.Protect(inCompany(models.CapabilityCatalogView), a.GetCategoryTree)).Methods("GET")
+	// PUT /companies/{companyId}/categories/order -> set the order of the categories under a parent
+	router.Handle("/companies/{companyId}/categories/order", authz.Protect(inCompany(models.CapabilityCatalogEdit), a.PutCategoriesOrder)).Methods("PUT")
+	// POST /companies/{companyId}/categories/import -> import a CSV or XLSX catalog sheet, ?dry_run=true only reports the changes
+	router.Handle("/companies/{companyId}/categories/import", authz.Protect(inCompany(models.CapabilityCatalogEdit), a.PostCatalogImport)).Methods("POST")
+	// GET /companies/{companyId}/categories/export -> export the catalog as ?format=csv or ?format=xlsx
+	router.Handle("/companies/{companyId}/categories/export", authz.Protect(inCompany(models.CapabilityCatalogView), a.GetCatalogExport)).Methods("GET")
+	// POST /companies/{companyId}/categories/copy -> copy a category subtree or the whole catalog into another company
+	router.Handle("/companies/{companyId}/categories/copy", authz.Protect(inCompany(models.CapabilityCatalogEdit), a.PostCatalogCopy)).Methods("POST")
+	// POST /companies/{companyId}/categories/{categoryId}/move -> move a category and its subtree under another parent
+	router.Handle("/companies/{companyId}/categories/{categoryId}/move", authz.Protect(inCompany(models.CapabilityCatalogEdit), a.PostMoveCategory)).Methods("POST")
 	// POST /categories/{companyId} -> add a category for company
-	router.HandleFunc("/categories", a.PostCategories).Methods("POST")
+	router.Handle("/categories", authz.Protect(authz.InCompany(authz.Company(authz.BodyField("company_id")), models.CapabilityCatalogEdit), a.PostCategories)).Methods("POST")
 	// POST /categories/{companyId}/{categoryId} ->  add a sub_category of description.
-	router.HandleFunc("/categories/{categoryId}", a.PostSub).Methods("POST")
+	router.Handle("/categories/{categoryId}", authz.Protect(authz.InCompany(authz.CompanyOf(dao.TableNames.Categories, authz.Var("categoryId")), models.CapabilityCatalogEdit), a.PostSub)).Methods("POST")
 	// GET /categories/{companyId} -> get categories for company
-	router.HandleFunc("/categories/{companyId}", a.GetCategories).Methods("GET")
+	router.Handle("/categories/{companyId}", authz.Protect(inCompany(models.CapabilityCatalogView), a.GetCategories)).Methods("GET")
 	// GET /categories/{companyId}/{categoryId}  -> Get sub_categories or description
-	router.HandleFunc("/categories/{categoryId}", a.GetSubCategories).Methods("GET")
+	router.Handle("/categories/{categoryId}", authz.Protect(authz.InCompany(authz.CompanyOf(dao.TableNames.Categories, authz.Var("categoryId")), models.CapabilityCatalogView), a.GetSubCategories)).Methods("GET")
 	// PUT /categories/{companyId}/{categoryId}/{ID} -> update sub category information
-	router.HandleFunc("/categories/{id}", a.PutCategories).Methods("PUT")
+	router.Handle("/categories/{id}", authz.Protect(authz.InCompany(authz.CompanyOf(dao.TableNames.Categories, authz.Var("id")), models.CapabilityCatalogEdit), a.PutCategories)).Methods("PUT")
 	// DELETE /categories/{companyId}/{categoryId}/{ID} -> delete sub category
-	router.HandleFunc("/categories/{id}", a.DeleteCategories).Methods("DELETE")
+	router.Handle("/categories/{id}", authz.Protect(authz.InCompany(authz.CompanyOf(dao.TableNames.Categories, authz.Var("id")), models.CapabilityCatalogEdit), a.DeleteCategories)).Methods("DELETE")
 
+	if err := authz.Verify(router); err != nil {
+		panic(err)
+	}
 	return router
 }
 
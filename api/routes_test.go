@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/pro-posal/webserver/internal/authz"
+	"github.com/pro-posal/webserver/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRouter_EveryRouteHasPolicy(t *testing.T) {
+	api := NewAPI(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	assert.NotPanics(t, func() { api.NewRouter() })
+}
+
+// authorizeRoute evaluates the policy of the route matching the request for the caller.
+func authorizeRoute(t *testing.T, router *mux.Router, method string, path string, caller *authz.Caller) error {
+	t.Helper()
+	r := httptest.NewRequest(method, path, nil)
+	var match mux.RouteMatch
+	require.True(t, router.Match(r, &match), "no route for %s %s", method, path)
+
+	policy, ok := authz.RoutePolicy(match.Route)
+	require.True(t, ok)
+	return policy.Authorize(mux.SetURLVars(r, match.Vars), nil, caller)
+}
+
+func TestNewRouter_CompanyCreatorStaysInTheirCompany(t *testing.T) {
+	api := NewAPI(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	api.NewRouter()
+
+	// The permission CreateCompany grants the company's contact
+	creator := &authz.Caller{
+		UserID:      "creator",
+		Permissions: []*models.Permission{{UserID: "creator", CompanyID: "acme", Role: models.CompanyAdminRole}},
+	}
+
+	assert.NoError(t, authorizeRoute(t, api.router, http.MethodPut, "/companies/acme", creator))
+	assert.NoError(t, authorizeRoute(t, api.router, http.MethodGet, "/companies/acme/contracts", creator))
+
+	var denied *authz.DeniedError
+	assert.ErrorAs(t, authorizeRoute(t, api.router, http.MethodPost, "/companies", creator), &denied)
+	assert.ErrorAs(t, authorizeRoute(t, api.router, http.MethodGet, "/companies/other", creator), &denied)
+	assert.ErrorAs(t, authorizeRoute(t, api.router, http.MethodPut, "/companies/other", creator), &denied)
+	assert.ErrorAs(t, authorizeRoute(t, api.router, http.MethodDelete, "/companies/other", creator), &denied)
+	assert.ErrorAs(t, authorizeRoute(t, api.router, http.MethodGet, "/companies/other/contracts", creator), &denied)
+	assert.ErrorAs(t, authorizeRoute(t, api.router, http.MethodGet, "/companies/other/audit", creator), &denied)
+}
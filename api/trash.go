@@ -0,0 +1,47 @@
+package api
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pro-posal/webserver/internal/utils"
+	"github.com/pro-posal/webserver/models"
+)
+
+type GetTrashResponseBody struct {
+	TotalItems int                 `json:"total_items"`
+	Items      []*models.TrashItem `json:"items"`
+}
+
+func (a *API) GetTrash(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	items, err := a.trashManagment.GetTrash(r.Context(), vars["companyId"], utils.GetUserIDFromSession(r).String())
+	if err != nil {
+		log.Printf("Error Getting Trash: %v", err)
+		writeServiceError(w, err, "Error Getting Trash")
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, GetTrashResponseBody{
+		TotalItems: len(items),
+		Items:      items,
+	})
+}
+
+func (a *API) RestoreTrash(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	items, err := a.trashManagment.RestoreDeletion(r.Context(), vars["companyId"], vars["deletionId"], utils.GetUserIDFromSession(r).String())
+	if err != nil {
+		log.Printf("Error Restoring Trash: %v", err)
+		writeServiceError(w, err, "Error Restoring Trash")
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, GetTrashResponseBody{
+		TotalItems: len(items),
+		Items:      items,
+	})
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/pro-posal/webserver/internal/utils"
+	"github.com/pro-posal/webserver/services"
+)
+
+type PostAuthTwoFactorRequestBody struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recovery_code"`
+}
+
+type PostTwoFactorCodeRequestBody struct {
+	Code string `json:"code"`
+}
+
+type PostTwoFactorEnrollRequestBody struct {
+	CurrentPassword string `json:"current_password"`
+}
+
+type PostTwoFactorConfirmRequestBody struct {
+	CurrentPassword string `json:"current_password"`
+	Code            string `json:"code"`
+}
+
+type PostTwoFactorDisableRequestBody struct {
+	CurrentPassword string `json:"current_password"`
+	Code            string `json:"code"`
+}
+
+// PostAuthTwoFactor completes a login that returned a challenge, with either an authenticator
+// code or a recovery code.
+func (a *API) PostAuthTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var request PostAuthTwoFactorRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	token, err := a.authService.CompleteTwoFactorLogin(r.Context(), services.CompleteTwoFactorLoginRequest{
+		ChallengeToken: request.ChallengeToken,
+		Code:           request.Code,
+		RecoveryCode:   request.RecoveryCode,
+		Client:         sessionClient(r),
+	})
+	if err != nil {
+		writeTwoFactorError(w, err, "Failed completing two-factor login")
+		return
+	}
+
+	writeAuthToken(w, token)
+}
+
+func (a *API) PostTwoFactorEnroll(w http.ResponseWriter, r *http.Request) {
+	var request PostTwoFactorEnrollRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	enrollment, err := a.authService.EnrollTwoFactor(r.Context(), services.EnrollTwoFactorRequest{
+		UserID:          utils.GetUserIDFromSession(r).String(),
+		CurrentPassword: request.CurrentPassword,
+	})
+	if err != nil {
+		writeTwoFactorError(w, err, "Error Enrolling Two-Factor Authentication")
+		return
+	}
+	utils.MarshalAndWriteResponse(w, enrollment)
+}
+
+func (a *API) PostTwoFactorConfirm(w http.ResponseWriter, r *http.Request) {
+	var request PostTwoFactorConfirmRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	codes, err := a.authService.ConfirmTwoFactor(r.Context(), services.ConfirmTwoFactorRequest{
+		UserID:          utils.GetUserIDFromSession(r).String(),
+		CurrentPassword: request.CurrentPassword,
+		Code:            request.Code,
+	})
+	if err != nil {
+		writeTwoFactorError(w, err, "Error Confirming Two-Factor Authentication")
+		return
+	}
+	utils.MarshalAndWriteResponse(w, codes)
+}
+
+func (a *API) PostTwoFactorDisable(w http.ResponseWriter, r *http.Request) {
+	var request PostTwoFactorDisableRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	err = a.authService.DisableTwoFactor(r.Context(), services.DisableTwoFactorRequest{
+		UserID:          utils.GetUserIDFromSession(r).String(),
+		CurrentPassword: request.CurrentPassword,
+		Code:            request.Code,
+	})
+	if err != nil {
+		writeTwoFactorError(w, err, "Error Disabling Two-Factor Authentication")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *API) PostTwoFactorRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	var request PostTwoFactorCodeRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	codes, err := a.authService.RegenerateRecoveryCodes(r.Context(), utils.GetUserIDFromSession(r).String(), request.Code)
+	if err != nil {
+		writeTwoFactorError(w, err, "Error Regenerating Recovery Codes")
+		return
+	}
+	utils.MarshalAndWriteResponse(w, codes)
+}
+
+// writeTwoFactorError answers 403 for wrong credentials, 429 while the account or address is
+// throttled and 409 when the request doesn't fit the user's current two-factor state.
+func writeTwoFactorError(w http.ResponseWriter, err error, msg string) {
+	log.Printf("%s: %v", msg, err)
+	var throttled *services.LoginThrottledError
+	switch {
+	case errors.As(err, &throttled):
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+		http.Error(w, "Too many failed attempts", http.StatusTooManyRequests)
+	case errors.Is(err, services.ErrInvalidLoginChallenge),
+		errors.Is(err, services.ErrInvalidTwoFactorCode),
+		errors.Is(err, services.ErrInvalidCurrentPassword):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, services.ErrTwoFactorAlreadyEnabled),
+		errors.Is(err, services.ErrTwoFactorNotEnabled),
+		errors.Is(err, services.ErrTwoFactorRequiredByCompany):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, msg, http.StatusInternalServerError)
+	}
+}
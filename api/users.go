@@ -1,8 +1,11 @@
 package api
 
 import (
+	"errors"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gorilla/mux"
@@ -20,6 +23,29 @@ type PostUsersRequestBody struct {
 }
 
 type PutUsersPasswordRequestBody struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+type PatchUsersEmailRequestBody struct {
+	CurrentPassword string `json:"current_password"`
+	NewEmail        string `json:"new_email"`
+}
+
+type PostVerifyEmailRequestBody struct {
+	Token string `json:"token"`
+}
+
+type PostResendVerificationRequestBody struct {
+	Email string `json:"email"`
+}
+
+type PostForgotPasswordRequestBody struct {
+	Email string `json:"email"`
+}
+
+type PostResetPasswordRequestBody struct {
+	Token       string `json:"token"`
 	NewPassword string `json:"new_password"`
 }
 
@@ -92,10 +118,22 @@ func (a *API) UpdateUserPassword(w http.ResponseWriter, r *http.Request) {
 	}
 
 	user, err := a.userManagement.UpdateUserPassword(r.Context(), services.ChangeUserPasswordRequest{
-		Uuid:        "SOMEUUID",
-		NewPassword: request.NewPassword,
+		Uuid:            utils.GetUserIDFromSession(r).String(),
+		CurrentPassword: request.CurrentPassword,
+		NewPassword:     request.NewPassword,
 	})
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidCurrentPassword) {
+			http.Error(w, "Current password is incorrect", http.StatusForbidden)
+			return
+		}
+		var throttled *services.LoginThrottledError
+		if errors.As(err, &throttled) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			http.Error(w, "Too many failed attempts", http.StatusTooManyRequests)
+			return
+		}
+
 		log.Printf("Error updating user password: %v", err)
 		http.Error(w, "Error updating user password", http.StatusInternalServerError)
 		return
@@ -104,11 +142,142 @@ func (a *API) UpdateUserPassword(w http.ResponseWriter, r *http.Request) {
 	utils.MarshalAndWriteResponse(w, user)
 }
 
+// PostForgotPassword emails a password reset link. It answers the same way whether or not the
+// email belongs to a user.
+func (a *API) PostForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var request PostForgotPasswordRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	err = a.userManagement.RequestPasswordReset(r.Context(), services.RequestPasswordResetRequest{
+		Email:     strings.ToLower(request.Email),
+		IPAddress: utils.GetClientIP(r),
+	})
+	// Answering differently would tell whether the address has an account
+	if errors.Is(err, services.ErrPasswordResetRateLimited) {
+		log.Printf("No password reset email sent: %v", err)
+		err = nil
+	}
+	if err != nil {
+		log.Printf("Error requesting password reset: %v", err)
+		http.Error(w, "Error requesting password reset", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (a *API) PostResetPassword(w http.ResponseWriter, r *http.Request) {
+	var request PostResetPasswordRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	err = a.userManagement.ResetPassword(r.Context(), services.ResetPasswordRequest{
+		Token:       request.Token,
+		NewPassword: request.NewPassword,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidPasswordResetToken) {
+			http.Error(w, "Invalid or expired password reset token", http.StatusForbidden)
+			return
+		}
+
+		log.Printf("Error resetting password: %v", err)
+		http.Error(w, "Error resetting password", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *API) UpdateUserEmail(w http.ResponseWriter, r *http.Request) {
+	var request PatchUsersEmailRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := a.userManagement.UpdateEmail(r.Context(), services.UpdateEmailRequest{
+		Uuid:            utils.GetUserIDFromSession(r).String(),
+		CurrentPassword: request.CurrentPassword,
+		NewEmail:        request.NewEmail,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCurrentPassword) {
+			http.Error(w, "Current password is incorrect", http.StatusForbidden)
+			return
+		}
+
+		log.Printf("Error updating user email: %v", err)
+		http.Error(w, "Error updating user email", http.StatusBadRequest)
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, user)
+}
+
+func (a *API) PostVerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var request PostVerifyEmailRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := a.userManagement.VerifyEmail(r.Context(), request.Token)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidVerificationToken) {
+			http.Error(w, "Invalid or expired email verification token", http.StatusForbidden)
+			return
+		}
+
+		log.Printf("Error verifying email: %v", err)
+		http.Error(w, "Error verifying email", http.StatusInternalServerError)
+		return
+	}
+
+	utils.MarshalAndWriteResponse(w, user)
+}
+
+func (a *API) PostResendVerification(w http.ResponseWriter, r *http.Request) {
+	var request PostResendVerificationRequestBody
+	err := utils.UnmarshalRequest(r, &request)
+	if err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		http.Error(w, "Error parsing request body", http.StatusBadRequest)
+		return
+	}
+
+	err = a.userManagement.ResendVerificationEmail(r.Context(), strings.ToLower(request.Email))
+	if err != nil {
+		var rateLimited *services.VerificationRateLimitedError
+		if errors.As(err, &rateLimited) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(rateLimited.RetryAfter.Seconds()))))
+			http.Error(w, "A verification email was sent recently", http.StatusTooManyRequests)
+			return
+		}
+
+		log.Printf("Error resending verification email: %v", err)
+		http.Error(w, "Error resending verification email", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
 func (a *API) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	userID := vars["id"]
 
-	user, err := a.userManagement.DeleteUser(r.Context(), userID)
+	user, err := a.userManagement.DeleteUser(r.Context(), userID, utils.GetUserIDFromSession(r).String())
 	if err != nil {
 		log.Printf("Error deleting user: %v", err)
 		http.Error(w, "Error deleting user", http.StatusInternalServerError)
@@ -118,6 +287,21 @@ func (a *API) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	utils.MarshalAndWriteResponse(w, user)
 }
 
+// PostUnlockUser lifts a login lockout before it expires.
+func (a *API) PostUnlockUser(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := vars["id"]
+
+	user, err := a.authService.UnlockUser(r.Context(), userID, utils.GetUserIDFromSession(r).String())
+	if err != nil {
+		log.Printf("Error unlocking user: %v", err)
+		writeServiceError(w, err, "Error unlocking user")
+		return
+	}
+
+	utils.MarshalAndWriteResponseWithStatus(w, http.StatusOK, user)
+}
+
 // Example - how do fetch the session and filter by context
 // session := r.Context().Value("session").(*models.Session)
 // log.Printf("Request is invoked by user %v", session.UserID)
@@ -1,13 +1,18 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/pro-posal/webserver/api"
 	"github.com/pro-posal/webserver/config"
 	"github.com/pro-posal/webserver/internal/database"
+	"github.com/pro-posal/webserver/internal/jobs"
+	"github.com/pro-posal/webserver/internal/keys"
+	"github.com/pro-posal/webserver/internal/mailer"
 	"github.com/pro-posal/webserver/services"
 )
 
@@ -15,15 +20,53 @@ func main() {
 	db := database.Connect()
 	defer db.Conn.Close()
 
-	ums := services.NewUserManagementService(db)
-	auth := services.NewAuthService(db)
-	cms := services.NewCompanyManagementService(db)
-	pms := services.NewPermissionManagementService(db)
+	mail := mailer.NewMailer(mailer.SMTPConfig{
+		Host:     config.AppConfig.Mail.SMTPHost,
+		Port:     config.AppConfig.Mail.SMTPPort,
+		Username: config.AppConfig.Mail.SMTPUsername,
+		Password: config.AppConfig.Mail.SMTPPassword,
+		From:     config.AppConfig.Mail.From,
+	})
+
+	keyStore, err := keys.NewKeyStore(config.AppConfig.Auth.JWTKeyDir, config.AppConfig.Auth.JWTKeyAlgorithm, config.AppConfig.Auth.JWTSigningSecret)
+	if err != nil {
+		log.Fatalf("Error loading signing keys: %v", err)
+	}
+
+	ums := services.NewUserManagementService(db, mail, keyStore)
+	auth := services.NewAuthService(db, mail, keyStore)
+	cms := services.NewCompanyManagementService(db, mail)
+	pms := services.NewPermissionManagementService(db, mail)
 	cams := services.NewCategoryManagementService(db)
 	ctms := services.NewContractTemplateManagementService(db)
 	oms := services.NewOfferManagementService(db)
+	gms := services.NewGalleryManagementService(db)
+	tms := services.NewTrashManagementService(db)
+	als := services.NewAuditLogService(db)
+
+	server := api.NewAPI(db, ums, auth, cms, pms, cams, ctms, oms, gms, tms, als)
 
-	server := api.NewAPI(db, ums, auth, cms, pms, cams, ctms, oms)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var backgroundJobs []jobs.Job
+	if config.AppConfig.Trash.RetentionDays > 0 {
+		backgroundJobs = append(backgroundJobs, jobs.NewTrashPurgeJob(tms,
+			time.Duration(config.AppConfig.Trash.RetentionDays)*24*time.Hour,
+			time.Duration(config.AppConfig.Trash.PurgeIntervalMinutes)*time.Minute))
+	}
+	backgroundJobs = append(backgroundJobs, jobs.NewPermissionExpiryJob(pms,
+		time.Duration(config.AppConfig.Permissions.ExpiryNoticeHours)*time.Hour,
+		time.Duration(config.AppConfig.Permissions.ExpiryCheckIntervalMinutes)*time.Minute))
+	backgroundJobs = append(backgroundJobs, jobs.NewLoginAttemptsCleanupJob(auth,
+		time.Duration(config.AppConfig.Auth.Login.AttemptRetentionDays)*24*time.Hour))
+	if config.AppConfig.Auth.JWTKeyDir != "" && config.AppConfig.Auth.JWTKeyRotationIntervalHours > 0 {
+		backgroundJobs = append(backgroundJobs, jobs.NewKeyRotationJob(keyStore,
+			time.Duration(config.AppConfig.Auth.JWTKeyRotationIntervalHours)*time.Hour,
+			time.Duration(config.AppConfig.Auth.JWTKeyPublishAheadHours)*time.Hour,
+			time.Duration(config.AppConfig.Auth.JWTKeyRetentionHours)*time.Hour))
+	}
+	jobs.Start(ctx, backgroundJobs...)
 
 	addr := fmt.Sprintf(":%s", config.AppConfig.Server.Port)
 
@@ -1,27 +1,142 @@
 package config
 
 import (
+	"net/netip"
 	"os"
 	"strconv"
+	"strings"
 )
 
 var AppConfig Config
 
 const DEFAULT_AUTH_EXPIRATION_TIME_MINUTES = "5"
+const DEFAULT_AUTH_REFRESH_EXPIRATION_TIME_MINUTES = "43200"
+const DEFAULT_PASSWORD_RESET_EXPIRATION_TIME_MINUTES = "30"
+const DEFAULT_SMTP_PORT = "587"
+const DEFAULT_EMAIL_VERIFICATION_MODE = EmailVerificationSensitive
+const DEFAULT_EMAIL_VERIFICATION_EXPIRATION_TIME_MINUTES = "1440"
+const DEFAULT_EMAIL_VERIFICATION_RESEND_INTERVAL_MINUTES = "5"
+const DEFAULT_PASSWORD_RESET_REQUEST_INTERVAL_MINUTES = "5"
+const DEFAULT_INVITATION_RESEND_INTERVAL_MINUTES = "5"
+const DEFAULT_TWO_FACTOR_ISSUER = "Pro-Posal"
+const DEFAULT_LOGIN_CHALLENGE_EXPIRATION_TIME_MINUTES = "5"
+const DEFAULT_LOGIN_MAX_FAILED_ATTEMPTS = "5"
+const DEFAULT_LOGIN_LOCKOUT_MINUTES = "15"
+const DEFAULT_LOGIN_IP_MAX_FAILED_ATTEMPTS = "20"
+const DEFAULT_LOGIN_IP_WINDOW_MINUTES = "15"
+const DEFAULT_LOGIN_ATTEMPTS_RETENTION_DAYS = "30"
+const DEFAULT_JWT_KEY_ALGORITHM = "RS256"
+const DEFAULT_OIDC_LOGIN_STATE_EXPIRATION_TIME_MINUTES = "10"
+const DEFAULT_INVITATION_EXPIRATION_TIME_HOURS = "168"
+const DEFAULT_OWNERSHIP_TRANSFER_EXPIRATION_TIME_HOURS = "168"
+const DEFAULT_JWT_KEY_ROTATION_INTERVAL_HOURS = "720"
+const DEFAULT_JWT_KEY_RETENTION_HOURS = "48"
+const DEFAULT_JWT_KEY_PUBLISH_AHEAD_HOURS = "24"
+
+// Email verification modes, set with EMAIL_VERIFICATION_MODE
+const (
+	// EmailVerificationOff lets unverified users do everything
+	EmailVerificationOff = "off"
+	// EmailVerificationSensitive lets unverified users sign in and read, but not change anything
+	EmailVerificationSensitive = "sensitive"
+	// EmailVerificationLogin does not let unverified users sign in at all
+	EmailVerificationLogin = "login"
+)
+const DEFAULT_TRASH_RETENTION_DAYS = "30"
+const DEFAULT_TRASH_PURGE_INTERVAL_MINUTES = "60"
+const DEFAULT_PERMISSION_EXPIRY_NOTICE_HOURS = "72"
+const DEFAULT_PERMISSION_EXPIRY_CHECK_INTERVAL_MINUTES = "15"
 
 type Config struct {
-	Database Database
-	Server   Server
-	Auth     Auth
+	Database    Database
+	Server      Server
+	Auth        Auth
+	Mail        Mail
+	Trash       Trash
+	Permissions Permissions
 }
 
 type Server struct {
 	Port string
+	// TrustedProxies are the addresses of the load balancers and proxies in front of the server, only
+	// they are believed about the client address in X-Forwarded-For and X-Real-IP
+	TrustedProxies []netip.Prefix
 }
 
 type Auth struct {
 	ExpirationTimeMinutes int
-	JWTSigningSecret      string
+	// RefreshExpirationTimeMinutes is how long a refresh token can be used, every refresh starts it over
+	RefreshExpirationTimeMinutes int
+	JWTSigningSecret             string
+	// JWTKeyDir holds the RS256 or EdDSA signing keys, tokens are signed with JWTSigningSecret when it is empty
+	JWTKeyDir       string
+	JWTKeyAlgorithm string
+	// JWTKeyRotationIntervalHours is how often a new signing key is generated, 0 never rotates
+	JWTKeyRotationIntervalHours int
+	// JWTKeyRetentionHours is how long a replaced key keeps verifying the tokens it signed
+	JWTKeyRetentionHours int
+	// JWTKeyPublishAheadHours is how long a new key is published in the JWKS before it signs, so
+	// verifiers caching the JWKS know it by the time its tokens reach them
+	JWTKeyPublishAheadHours int
+	// PasswordResetExpirationTimeMinutes is how long an emailed password reset link stays valid
+	PasswordResetExpirationTimeMinutes int
+	// PasswordResetRequestIntervalMinutes is the minimum time between two password reset emails to a user
+	PasswordResetRequestIntervalMinutes int
+	EmailVerificationMode               string
+	EmailVerificationExpirationMinutes  int
+	// EmailVerificationResendIntervalMinutes is the minimum time between two verification emails to a user
+	EmailVerificationResendIntervalMinutes int
+	// TwoFactorIssuer is the account issuer shown by authenticator apps
+	TwoFactorIssuer string
+	// LoginChallengeExpirationMinutes is how long a user has to enter their second factor after the password
+	LoginChallengeExpirationMinutes int
+	Login                           Login
+	// OIDCRedirectURL is where identity providers send users back to, APP_BASE_URL/sso/callback when empty
+	OIDCRedirectURL string
+	// OIDCLoginStateExpirationMinutes is how long a user has to sign in at their identity provider
+	OIDCLoginStateExpirationMinutes int
+	// OIDCAllowPrivateIssuers lets identity providers live on private or loopback addresses, only for
+	// development and tests since company admins could reach internal services through them
+	OIDCAllowPrivateIssuers bool
+	// InvitationExpirationHours is how long an invitation link stays valid, resending starts it over
+	InvitationExpirationHours int
+	// InvitationResendIntervalMinutes is the minimum time between two emails of the same invitation
+	InvitationResendIntervalMinutes int
+	// OwnershipTransferExpirationHours is how long the recipient of a company has to accept it
+	OwnershipTransferExpirationHours int
+}
+
+type Login struct {
+	// MaxFailedAttempts is how many wrong passwords in a row lock an account
+	MaxFailedAttempts int
+	LockoutMinutes    int
+	// IPMaxFailedAttempts is how many wrong passwords a single address may send per IPWindowMinutes
+	IPMaxFailedAttempts  int
+	IPWindowMinutes      int
+	AttemptRetentionDays int
+}
+
+type Mail struct {
+	// SMTPHost is left empty to only log outgoing emails
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	From         string
+	// AppBaseURL is the address of the web app, used to build the links sent by email
+	AppBaseURL string
+}
+
+type Trash struct {
+	// RetentionDays is how long soft-deleted records stay restorable, 0 keeps them forever
+	RetentionDays        int
+	PurgeIntervalMinutes int
+}
+
+type Permissions struct {
+	// ExpiryNoticeHours is how long before a permission expires the company's admins are emailed, 0 sends no notice
+	ExpiryNoticeHours          int
+	ExpiryCheckIntervalMinutes int
 }
 
 type Database struct {
@@ -37,10 +152,14 @@ func init() {
 	AppConfig.Server.loadConfig()
 	AppConfig.Database.loadConfig()
 	AppConfig.Auth.loadConfig()
+	AppConfig.Mail.loadConfig()
+	AppConfig.Trash.loadConfig()
+	AppConfig.Permissions.loadConfig()
 }
 
 func (s *Server) loadConfig() {
 	s.Port = os.Getenv("SERVER_PORT")
+	s.TrustedProxies = getPrefixes("TRUSTED_PROXIES")
 }
 
 func (d *Database) loadConfig() {
@@ -60,7 +179,141 @@ func (a *Auth) loadConfig() {
 	}
 	a.ExpirationTimeMinutes = expirationTime
 
+	refreshExpirationTime, err := strconv.Atoi(getValueOrDefault("AUTH_REFRESH_EXPIRATION_TIME_MIN", DEFAULT_AUTH_REFRESH_EXPIRATION_TIME_MINUTES))
+	if err != nil || refreshExpirationTime <= 0 {
+		panic("Invalid AUTH_REFRESH_EXPIRATION_TIME_MIN")
+	}
+	a.RefreshExpirationTimeMinutes = refreshExpirationTime
+
 	a.JWTSigningSecret = os.Getenv("JWT_SIGNING_SECRET")
+	a.JWTKeyDir = os.Getenv("JWT_KEY_DIR")
+	a.JWTKeyAlgorithm = getValueOrDefault("JWT_KEY_ALGORITHM", DEFAULT_JWT_KEY_ALGORITHM)
+	if a.JWTKeyAlgorithm != "RS256" && a.JWTKeyAlgorithm != "EdDSA" {
+		panic("Invalid JWT_KEY_ALGORITHM")
+	}
+	a.JWTKeyRotationIntervalHours = getIntOrDefault("JWT_KEY_ROTATION_INTERVAL_HOURS", DEFAULT_JWT_KEY_ROTATION_INTERVAL_HOURS, 0)
+	a.JWTKeyRetentionHours = getIntOrDefault("JWT_KEY_RETENTION_HOURS", DEFAULT_JWT_KEY_RETENTION_HOURS, 1)
+	a.JWTKeyPublishAheadHours = getIntOrDefault("JWT_KEY_PUBLISH_AHEAD_HOURS", DEFAULT_JWT_KEY_PUBLISH_AHEAD_HOURS, 0)
+	if a.JWTKeyRotationIntervalHours > 0 && a.JWTKeyPublishAheadHours >= a.JWTKeyRotationIntervalHours {
+		panic("Invalid JWT_KEY_PUBLISH_AHEAD_HOURS, it has to be shorter than JWT_KEY_ROTATION_INTERVAL_HOURS")
+	}
+
+	resetExpirationTime, err := strconv.Atoi(getValueOrDefault("PASSWORD_RESET_EXPIRATION_TIME_MIN", DEFAULT_PASSWORD_RESET_EXPIRATION_TIME_MINUTES))
+	if err != nil || resetExpirationTime <= 0 {
+		panic("Invalid PASSWORD_RESET_EXPIRATION_TIME_MIN")
+	}
+	a.PasswordResetExpirationTimeMinutes = resetExpirationTime
+	a.PasswordResetRequestIntervalMinutes = getIntOrDefault("PASSWORD_RESET_REQUEST_INTERVAL_MIN", DEFAULT_PASSWORD_RESET_REQUEST_INTERVAL_MINUTES, 0)
+
+	a.EmailVerificationMode = getValueOrDefault("EMAIL_VERIFICATION_MODE", DEFAULT_EMAIL_VERIFICATION_MODE)
+	switch a.EmailVerificationMode {
+	case EmailVerificationOff, EmailVerificationSensitive, EmailVerificationLogin:
+	default:
+		panic("Invalid EMAIL_VERIFICATION_MODE")
+	}
+
+	verificationExpirationTime, err := strconv.Atoi(getValueOrDefault("EMAIL_VERIFICATION_EXPIRATION_TIME_MIN", DEFAULT_EMAIL_VERIFICATION_EXPIRATION_TIME_MINUTES))
+	if err != nil || verificationExpirationTime <= 0 {
+		panic("Invalid EMAIL_VERIFICATION_EXPIRATION_TIME_MIN")
+	}
+	a.EmailVerificationExpirationMinutes = verificationExpirationTime
+
+	resendInterval, err := strconv.Atoi(getValueOrDefault("EMAIL_VERIFICATION_RESEND_INTERVAL_MIN", DEFAULT_EMAIL_VERIFICATION_RESEND_INTERVAL_MINUTES))
+	if err != nil || resendInterval < 0 {
+		panic("Invalid EMAIL_VERIFICATION_RESEND_INTERVAL_MIN")
+	}
+	a.EmailVerificationResendIntervalMinutes = resendInterval
+
+	a.TwoFactorIssuer = getValueOrDefault("TWO_FACTOR_ISSUER", DEFAULT_TWO_FACTOR_ISSUER)
+
+	challengeExpirationTime, err := strconv.Atoi(getValueOrDefault("LOGIN_CHALLENGE_EXPIRATION_TIME_MIN", DEFAULT_LOGIN_CHALLENGE_EXPIRATION_TIME_MINUTES))
+	if err != nil || challengeExpirationTime <= 0 {
+		panic("Invalid LOGIN_CHALLENGE_EXPIRATION_TIME_MIN")
+	}
+	a.LoginChallengeExpirationMinutes = challengeExpirationTime
+
+	// A retired key has to outlive every token it signed, the email verification links live the longest
+	if a.JWTKeyRetentionHours*60 < a.EmailVerificationExpirationMinutes ||
+		a.JWTKeyRetentionHours*60 < a.PasswordResetExpirationTimeMinutes ||
+		a.JWTKeyRetentionHours*60 < a.ExpirationTimeMinutes {
+		panic("Invalid JWT_KEY_RETENTION_HOURS, it is shorter than the tokens it has to verify")
+	}
+
+	a.OIDCRedirectURL = os.Getenv("OIDC_REDIRECT_URL")
+	a.OIDCLoginStateExpirationMinutes = getIntOrDefault("OIDC_LOGIN_STATE_EXPIRATION_TIME_MIN", DEFAULT_OIDC_LOGIN_STATE_EXPIRATION_TIME_MINUTES, 1)
+	a.OIDCAllowPrivateIssuers = os.Getenv("OIDC_ALLOW_PRIVATE_ISSUERS") == "true"
+
+	a.InvitationExpirationHours = getIntOrDefault("INVITATION_EXPIRATION_TIME_HOURS", DEFAULT_INVITATION_EXPIRATION_TIME_HOURS, 1)
+	a.InvitationResendIntervalMinutes = getIntOrDefault("INVITATION_RESEND_INTERVAL_MIN", DEFAULT_INVITATION_RESEND_INTERVAL_MINUTES, 0)
+	a.OwnershipTransferExpirationHours = getIntOrDefault("OWNERSHIP_TRANSFER_EXPIRATION_TIME_HOURS", DEFAULT_OWNERSHIP_TRANSFER_EXPIRATION_TIME_HOURS, 1)
+
+	a.Login.MaxFailedAttempts = getIntOrDefault("LOGIN_MAX_FAILED_ATTEMPTS", DEFAULT_LOGIN_MAX_FAILED_ATTEMPTS, 1)
+	a.Login.LockoutMinutes = getIntOrDefault("LOGIN_LOCKOUT_MIN", DEFAULT_LOGIN_LOCKOUT_MINUTES, 1)
+	a.Login.IPMaxFailedAttempts = getIntOrDefault("LOGIN_IP_MAX_FAILED_ATTEMPTS", DEFAULT_LOGIN_IP_MAX_FAILED_ATTEMPTS, 1)
+	a.Login.IPWindowMinutes = getIntOrDefault("LOGIN_IP_WINDOW_MIN", DEFAULT_LOGIN_IP_WINDOW_MINUTES, 1)
+	a.Login.AttemptRetentionDays = getIntOrDefault("LOGIN_ATTEMPTS_RETENTION_DAYS", DEFAULT_LOGIN_ATTEMPTS_RETENTION_DAYS, 1)
+}
+
+func (m *Mail) loadConfig() {
+	m.SMTPHost = os.Getenv("SMTP_HOST")
+	m.SMTPPort = getValueOrDefault("SMTP_PORT", DEFAULT_SMTP_PORT)
+	m.SMTPUsername = os.Getenv("SMTP_USERNAME")
+	m.SMTPPassword = os.Getenv("SMTP_PASSWORD")
+	m.From = os.Getenv("MAIL_FROM")
+	m.AppBaseURL = strings.TrimSuffix(os.Getenv("APP_BASE_URL"), "/")
+}
+
+func (t *Trash) loadConfig() {
+	retentionDays, err := strconv.Atoi(getValueOrDefault("TRASH_RETENTION_DAYS", DEFAULT_TRASH_RETENTION_DAYS))
+	if err != nil || retentionDays < 0 {
+		panic("Invalid TRASH_RETENTION_DAYS")
+	}
+	t.RetentionDays = retentionDays
+
+	purgeInterval, err := strconv.Atoi(getValueOrDefault("TRASH_PURGE_INTERVAL_MIN", DEFAULT_TRASH_PURGE_INTERVAL_MINUTES))
+	if err != nil || purgeInterval <= 0 {
+		panic("Invalid TRASH_PURGE_INTERVAL_MIN")
+	}
+	t.PurgeIntervalMinutes = purgeInterval
+}
+
+func (p *Permissions) loadConfig() {
+	p.ExpiryNoticeHours = getIntOrDefault("PERMISSION_EXPIRY_NOTICE_HOURS", DEFAULT_PERMISSION_EXPIRY_NOTICE_HOURS, 0)
+	p.ExpiryCheckIntervalMinutes = getIntOrDefault("PERMISSION_EXPIRY_CHECK_INTERVAL_MIN", DEFAULT_PERMISSION_EXPIRY_CHECK_INTERVAL_MINUTES, 1)
+}
+
+// getIntOrDefault reads an integer setting and panics when it is not a number of at least min.
+func getIntOrDefault(keyName string, defaultValue string, min int) int {
+	value, err := strconv.Atoi(getValueOrDefault(keyName, defaultValue))
+	if err != nil || value < min {
+		panic("Invalid " + keyName)
+	}
+	return value
+}
+
+// getPrefixes reads a comma separated list of addresses and CIDR ranges.
+func getPrefixes(keyName string) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, value := range strings.Split(os.Getenv(keyName), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if !strings.Contains(value, "/") {
+			addr, err := netip.ParseAddr(value)
+			if err != nil {
+				panic("Invalid " + keyName)
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			panic("Invalid " + keyName)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes
 }
 
 func getValueOrDefault(keyName string, defaultValue string) string {
@@ -4,21 +4,49 @@
 package dao
 
 var TableNames = struct {
-	Categories        string
-	Companies         string
-	ContractTemplates string
-	GooseDBVersion    string
-	Offers            string
-	Permissions       string
-	Session           string
-	Users             string
+	APIKeys                string
+	AuditLogs              string
+	Categories             string
+	Companies              string
+	CompanyLegalClauses    string
+	CompanyRoles           string
+	ContractTemplates      string
+	GalleryTemplates       string
+	GooseDBVersion         string
+	IdentityProviders      string
+	Invitations            string
+	LoginAttempts          string
+	LoginChallenges        string
+	Offers                 string
+	OidcLoginStates        string
+	OwnershipTransfers     string
+	PasswordResetTokens    string
+	Permissions            string
+	Session                string
+	TwoFactorRecoveryCodes string
+	UserIdentities         string
+	Users                  string
 }{
-	Categories:        "categories",
-	Companies:         "companies",
-	ContractTemplates: "contract_templates",
-	GooseDBVersion:    "goose_db_version",
-	Offers:            "offers",
-	Permissions:       "permissions",
-	Session:           "session",
-	Users:             "users",
+	APIKeys:                "api_keys",
+	AuditLogs:              "audit_logs",
+	Categories:             "categories",
+	Companies:              "companies",
+	CompanyLegalClauses:    "company_legal_clauses",
+	CompanyRoles:           "company_roles",
+	ContractTemplates:      "contract_templates",
+	GalleryTemplates:       "gallery_templates",
+	GooseDBVersion:         "goose_db_version",
+	IdentityProviders:      "identity_providers",
+	Invitations:            "invitations",
+	LoginAttempts:          "login_attempts",
+	LoginChallenges:        "login_challenges",
+	Offers:                 "offers",
+	OidcLoginStates:        "oidc_login_states",
+	OwnershipTransfers:     "ownership_transfers",
+	PasswordResetTokens:    "password_reset_tokens",
+	Permissions:            "permissions",
+	Session:                "session",
+	TwoFactorRecoveryCodes: "two_factor_recovery_codes",
+	UserIdentities:         "user_identities",
+	Users:                  "users",
 }
@@ -19,19 +19,25 @@ import (
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
 	"github.com/volatiletech/strmangle"
 )
 
 // Category is an object representing the database table.
 type Category struct {
-	ID          string      `boil:"id" json:"id" toml:"id" yaml:"id"`
-	CompanyID   string      `boil:"company_id" json:"company_id" toml:"company_id" yaml:"company_id"`
-	CategoryID  null.String `boil:"category_id" json:"category_id,omitempty" toml:"category_id" yaml:"category_id,omitempty"`
-	Description string      `boil:"description" json:"description" toml:"description" yaml:"description"`
-	CreatedAt   time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt   time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
-	Type        string      `boil:"type" json:"type" toml:"type" yaml:"type"`
-	DeletedAt   null.Time   `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	ID          string            `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CompanyID   string            `boil:"company_id" json:"company_id" toml:"company_id" yaml:"company_id"`
+	CategoryID  null.String       `boil:"category_id" json:"category_id,omitempty" toml:"category_id" yaml:"category_id,omitempty"`
+	Description string            `boil:"description" json:"description" toml:"description" yaml:"description"`
+	CreatedAt   time.Time         `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt   time.Time         `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	Type        string            `boil:"type" json:"type" toml:"type" yaml:"type"`
+	DeletedAt   null.Time         `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	Position    int               `boil:"position" json:"position" toml:"position" yaml:"position"`
+	Unit        null.String       `boil:"unit" json:"unit,omitempty" toml:"unit" yaml:"unit,omitempty"`
+	Price       types.NullDecimal `boil:"price" json:"price,omitempty" toml:"price" yaml:"price,omitempty"`
+	DeletedBy   null.String       `boil:"deleted_by" json:"deleted_by,omitempty" toml:"deleted_by" yaml:"deleted_by,omitempty"`
+	DeletionID  null.String       `boil:"deletion_id" json:"deletion_id,omitempty" toml:"deletion_id" yaml:"deletion_id,omitempty"`
 
 	R *categoryR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L categoryL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -46,6 +52,11 @@ var CategoryColumns = struct {
 	UpdatedAt   string
 	Type        string
 	DeletedAt   string
+	Position    string
+	Unit        string
+	Price       string
+	DeletedBy   string
+	DeletionID  string
 }{
 	ID:          "id",
 	CompanyID:   "company_id",
@@ -55,6 +66,11 @@ var CategoryColumns = struct {
 	UpdatedAt:   "updated_at",
 	Type:        "type",
 	DeletedAt:   "deleted_at",
+	Position:    "position",
+	Unit:        "unit",
+	Price:       "price",
+	DeletedBy:   "deleted_by",
+	DeletionID:  "deletion_id",
 }
 
 var CategoryTableColumns = struct {
@@ -66,6 +82,11 @@ var CategoryTableColumns = struct {
 	UpdatedAt   string
 	Type        string
 	DeletedAt   string
+	Position    string
+	Unit        string
+	Price       string
+	DeletedBy   string
+	DeletionID  string
 }{
 	ID:          "categories.id",
 	CompanyID:   "categories.company_id",
@@ -75,77 +96,31 @@ var CategoryTableColumns = struct {
 	UpdatedAt:   "categories.updated_at",
 	Type:        "categories.type",
 	DeletedAt:   "categories.deleted_at",
+	Position:    "categories.position",
+	Unit:        "categories.unit",
+	Price:       "categories.price",
+	DeletedBy:   "categories.deleted_by",
+	DeletionID:  "categories.deletion_id",
 }
 
 // Generated where
 
-type whereHelperstring struct{ field string }
-
-func (w whereHelperstring) EQ(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) LIKE(x string) qm.QueryMod   { return qm.Where(w.field+" LIKE ?", x) }
-func (w whereHelperstring) NLIKE(x string) qm.QueryMod  { return qm.Where(w.field+" NOT LIKE ?", x) }
-func (w whereHelperstring) ILIKE(x string) qm.QueryMod  { return qm.Where(w.field+" ILIKE ?", x) }
-func (w whereHelperstring) NILIKE(x string) qm.QueryMod { return qm.Where(w.field+" NOT ILIKE ?", x) }
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-type whereHelpernull_String struct{ field string }
+type whereHelperint struct{ field string }
 
-func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-func (w whereHelpernull_String) LIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" LIKE ?", x)
-}
-func (w whereHelpernull_String) NLIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" NOT LIKE ?", x)
-}
-func (w whereHelpernull_String) ILIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" ILIKE ?", x)
-}
-func (w whereHelpernull_String) NILIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" NOT ILIKE ?", x)
-}
-func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
+func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint) IN(slice []int) qm.QueryMod {
 	values := make([]interface{}, 0, len(slice))
 	for _, value := range slice {
 		values = append(values, value)
 	}
 	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
 }
-func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
+func (w whereHelperint) NIN(slice []int) qm.QueryMod {
 	values := make([]interface{}, 0, len(slice))
 	for _, value := range slice {
 		values = append(values, value)
@@ -153,53 +128,31 @@ func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
 	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
 }
 
-func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
-type whereHelpertime_Time struct{ field string }
+type whereHelpertypes_NullDecimal struct{ field string }
 
-func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
-}
-func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
-}
-func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-type whereHelpernull_Time struct{ field string }
-
-func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+func (w whereHelpertypes_NullDecimal) EQ(x types.NullDecimal) qm.QueryMod {
 	return qmhelper.WhereNullEQ(w.field, false, x)
 }
-func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+func (w whereHelpertypes_NullDecimal) NEQ(x types.NullDecimal) qm.QueryMod {
 	return qmhelper.WhereNullEQ(w.field, true, x)
 }
-func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
+func (w whereHelpertypes_NullDecimal) LT(x types.NullDecimal) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LT, x)
 }
-func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
+func (w whereHelpertypes_NullDecimal) LTE(x types.NullDecimal) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LTE, x)
 }
-func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
+func (w whereHelpertypes_NullDecimal) GT(x types.NullDecimal) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GT, x)
 }
-func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
+func (w whereHelpertypes_NullDecimal) GTE(x types.NullDecimal) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
-func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+func (w whereHelpertypes_NullDecimal) IsNull() qm.QueryMod { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpertypes_NullDecimal) IsNotNull() qm.QueryMod {
+	return qmhelper.WhereIsNotNull(w.field)
+}
 
 var CategoryWhere = struct {
 	ID          whereHelperstring
@@ -210,6 +163,11 @@ var CategoryWhere = struct {
 	UpdatedAt   whereHelpertime_Time
 	Type        whereHelperstring
 	DeletedAt   whereHelpernull_Time
+	Position    whereHelperint
+	Unit        whereHelpernull_String
+	Price       whereHelpertypes_NullDecimal
+	DeletedBy   whereHelpernull_String
+	DeletionID  whereHelpernull_String
 }{
 	ID:          whereHelperstring{field: "\"categories\".\"id\""},
 	CompanyID:   whereHelperstring{field: "\"categories\".\"company_id\""},
@@ -219,6 +177,11 @@ var CategoryWhere = struct {
 	UpdatedAt:   whereHelpertime_Time{field: "\"categories\".\"updated_at\""},
 	Type:        whereHelperstring{field: "\"categories\".\"type\""},
 	DeletedAt:   whereHelpernull_Time{field: "\"categories\".\"deleted_at\""},
+	Position:    whereHelperint{field: "\"categories\".\"position\""},
+	Unit:        whereHelpernull_String{field: "\"categories\".\"unit\""},
+	Price:       whereHelpertypes_NullDecimal{field: "\"categories\".\"price\""},
+	DeletedBy:   whereHelpernull_String{field: "\"categories\".\"deleted_by\""},
+	DeletionID:  whereHelpernull_String{field: "\"categories\".\"deletion_id\""},
 }
 
 // CategoryRels is where relationship names are stored.
@@ -249,9 +212,9 @@ func (r *categoryR) GetCompany() *Company {
 type categoryL struct{}
 
 var (
-	categoryAllColumns            = []string{"id", "company_id", "category_id", "description", "created_at", "updated_at", "type", "deleted_at"}
+	categoryAllColumns            = []string{"id", "company_id", "category_id", "description", "created_at", "updated_at", "type", "deleted_at", "position", "unit", "price", "deleted_by", "deletion_id"}
 	categoryColumnsWithoutDefault = []string{"id", "company_id", "description", "created_at", "updated_at", "type"}
-	categoryColumnsWithDefault    = []string{"category_id", "deleted_at"}
+	categoryColumnsWithDefault    = []string{"category_id", "deleted_at", "position", "unit", "price", "deleted_by", "deletion_id"}
 	categoryPrimaryKeyColumns     = []string{"id"}
 	categoryGeneratedColumns      = []string{}
 )
@@ -24,103 +24,151 @@ import (
 
 // Company is an object representing the database table.
 type Company struct {
-	ID         string    `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Name       string    `boil:"name" json:"name" toml:"name" yaml:"name"`
-	ContactID  string    `boil:"contact_id" json:"contact_id" toml:"contact_id" yaml:"contact_id"`
-	Address    string    `boil:"address" json:"address" toml:"address" yaml:"address"`
-	LogoBase64 string    `boil:"logo_base64" json:"logo_base64" toml:"logo_base64" yaml:"logo_base64"`
-	CreatedAt  time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt  time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
-	DeletedAt  null.Time `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	ID               string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Name             string      `boil:"name" json:"name" toml:"name" yaml:"name"`
+	ContactID        string      `boil:"contact_id" json:"contact_id" toml:"contact_id" yaml:"contact_id"`
+	Address          string      `boil:"address" json:"address" toml:"address" yaml:"address"`
+	LogoBase64       string      `boil:"logo_base64" json:"logo_base64" toml:"logo_base64" yaml:"logo_base64"`
+	CreatedAt        time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt        time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	DeletedAt        null.Time   `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	DeletedBy        null.String `boil:"deleted_by" json:"deleted_by,omitempty" toml:"deleted_by" yaml:"deleted_by,omitempty"`
+	DeletionID       null.String `boil:"deletion_id" json:"deletion_id,omitempty" toml:"deletion_id" yaml:"deletion_id,omitempty"`
+	RequireTwoFactor bool        `boil:"require_two_factor" json:"require_two_factor" toml:"require_two_factor" yaml:"require_two_factor"`
 
 	R *companyR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L companyL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var CompanyColumns = struct {
-	ID         string
-	Name       string
-	ContactID  string
-	Address    string
-	LogoBase64 string
-	CreatedAt  string
-	UpdatedAt  string
-	DeletedAt  string
+	ID               string
+	Name             string
+	ContactID        string
+	Address          string
+	LogoBase64       string
+	CreatedAt        string
+	UpdatedAt        string
+	DeletedAt        string
+	DeletedBy        string
+	DeletionID       string
+	RequireTwoFactor string
 }{
-	ID:         "id",
-	Name:       "name",
-	ContactID:  "contact_id",
-	Address:    "address",
-	LogoBase64: "logo_base64",
-	CreatedAt:  "created_at",
-	UpdatedAt:  "updated_at",
-	DeletedAt:  "deleted_at",
+	ID:               "id",
+	Name:             "name",
+	ContactID:        "contact_id",
+	Address:          "address",
+	LogoBase64:       "logo_base64",
+	CreatedAt:        "created_at",
+	UpdatedAt:        "updated_at",
+	DeletedAt:        "deleted_at",
+	DeletedBy:        "deleted_by",
+	DeletionID:       "deletion_id",
+	RequireTwoFactor: "require_two_factor",
 }
 
 var CompanyTableColumns = struct {
-	ID         string
-	Name       string
-	ContactID  string
-	Address    string
-	LogoBase64 string
-	CreatedAt  string
-	UpdatedAt  string
-	DeletedAt  string
+	ID               string
+	Name             string
+	ContactID        string
+	Address          string
+	LogoBase64       string
+	CreatedAt        string
+	UpdatedAt        string
+	DeletedAt        string
+	DeletedBy        string
+	DeletionID       string
+	RequireTwoFactor string
 }{
-	ID:         "companies.id",
-	Name:       "companies.name",
-	ContactID:  "companies.contact_id",
-	Address:    "companies.address",
-	LogoBase64: "companies.logo_base64",
-	CreatedAt:  "companies.created_at",
-	UpdatedAt:  "companies.updated_at",
-	DeletedAt:  "companies.deleted_at",
+	ID:               "companies.id",
+	Name:             "companies.name",
+	ContactID:        "companies.contact_id",
+	Address:          "companies.address",
+	LogoBase64:       "companies.logo_base64",
+	CreatedAt:        "companies.created_at",
+	UpdatedAt:        "companies.updated_at",
+	DeletedAt:        "companies.deleted_at",
+	DeletedBy:        "companies.deleted_by",
+	DeletionID:       "companies.deletion_id",
+	RequireTwoFactor: "companies.require_two_factor",
 }
 
 // Generated where
 
+type whereHelperbool struct{ field string }
+
+func (w whereHelperbool) EQ(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperbool) NEQ(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperbool) LT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
 var CompanyWhere = struct {
-	ID         whereHelperstring
-	Name       whereHelperstring
-	ContactID  whereHelperstring
-	Address    whereHelperstring
-	LogoBase64 whereHelperstring
-	CreatedAt  whereHelpertime_Time
-	UpdatedAt  whereHelpertime_Time
-	DeletedAt  whereHelpernull_Time
+	ID               whereHelperstring
+	Name             whereHelperstring
+	ContactID        whereHelperstring
+	Address          whereHelperstring
+	LogoBase64       whereHelperstring
+	CreatedAt        whereHelpertime_Time
+	UpdatedAt        whereHelpertime_Time
+	DeletedAt        whereHelpernull_Time
+	DeletedBy        whereHelpernull_String
+	DeletionID       whereHelpernull_String
+	RequireTwoFactor whereHelperbool
 }{
-	ID:         whereHelperstring{field: "\"companies\".\"id\""},
-	Name:       whereHelperstring{field: "\"companies\".\"name\""},
-	ContactID:  whereHelperstring{field: "\"companies\".\"contact_id\""},
-	Address:    whereHelperstring{field: "\"companies\".\"address\""},
-	LogoBase64: whereHelperstring{field: "\"companies\".\"logo_base64\""},
-	CreatedAt:  whereHelpertime_Time{field: "\"companies\".\"created_at\""},
-	UpdatedAt:  whereHelpertime_Time{field: "\"companies\".\"updated_at\""},
-	DeletedAt:  whereHelpernull_Time{field: "\"companies\".\"deleted_at\""},
+	ID:               whereHelperstring{field: "\"companies\".\"id\""},
+	Name:             whereHelperstring{field: "\"companies\".\"name\""},
+	ContactID:        whereHelperstring{field: "\"companies\".\"contact_id\""},
+	Address:          whereHelperstring{field: "\"companies\".\"address\""},
+	LogoBase64:       whereHelperstring{field: "\"companies\".\"logo_base64\""},
+	CreatedAt:        whereHelpertime_Time{field: "\"companies\".\"created_at\""},
+	UpdatedAt:        whereHelpertime_Time{field: "\"companies\".\"updated_at\""},
+	DeletedAt:        whereHelpernull_Time{field: "\"companies\".\"deleted_at\""},
+	DeletedBy:        whereHelpernull_String{field: "\"companies\".\"deleted_by\""},
+	DeletionID:       whereHelpernull_String{field: "\"companies\".\"deletion_id\""},
+	RequireTwoFactor: whereHelperbool{field: "\"companies\".\"require_two_factor\""},
 }
 
 // CompanyRels is where relationship names are stored.
 var CompanyRels = struct {
-	Contact           string
-	Categories        string
-	ContractTemplates string
-	Offers            string
-	Permissions       string
+	Contact             string
+	APIKeys             string
+	Categories          string
+	CompanyLegalClauses string
+	CompanyRoles        string
+	ContractTemplates   string
+	IdentityProviders   string
+	Invitations         string
+	Offers              string
+	OwnershipTransfers  string
+	Permissions         string
 }{
-	Contact:           "Contact",
-	Categories:        "Categories",
-	ContractTemplates: "ContractTemplates",
-	Offers:            "Offers",
-	Permissions:       "Permissions",
+	Contact:             "Contact",
+	APIKeys:             "APIKeys",
+	Categories:          "Categories",
+	CompanyLegalClauses: "CompanyLegalClauses",
+	CompanyRoles:        "CompanyRoles",
+	ContractTemplates:   "ContractTemplates",
+	IdentityProviders:   "IdentityProviders",
+	Invitations:         "Invitations",
+	Offers:              "Offers",
+	OwnershipTransfers:  "OwnershipTransfers",
+	Permissions:         "Permissions",
 }
 
 // companyR is where relationships are stored.
 type companyR struct {
-	Contact           *User                 `boil:"Contact" json:"Contact" toml:"Contact" yaml:"Contact"`
-	Categories        CategorySlice         `boil:"Categories" json:"Categories" toml:"Categories" yaml:"Categories"`
-	ContractTemplates ContractTemplateSlice `boil:"ContractTemplates" json:"ContractTemplates" toml:"ContractTemplates" yaml:"ContractTemplates"`
-	Offers            OfferSlice            `boil:"Offers" json:"Offers" toml:"Offers" yaml:"Offers"`
-	Permissions       PermissionSlice       `boil:"Permissions" json:"Permissions" toml:"Permissions" yaml:"Permissions"`
+	Contact             *User                   `boil:"Contact" json:"Contact" toml:"Contact" yaml:"Contact"`
+	APIKeys             APIKeySlice             `boil:"APIKeys" json:"APIKeys" toml:"APIKeys" yaml:"APIKeys"`
+	Categories          CategorySlice           `boil:"Categories" json:"Categories" toml:"Categories" yaml:"Categories"`
+	CompanyLegalClauses CompanyLegalClauseSlice `boil:"CompanyLegalClauses" json:"CompanyLegalClauses" toml:"CompanyLegalClauses" yaml:"CompanyLegalClauses"`
+	CompanyRoles        CompanyRoleSlice        `boil:"CompanyRoles" json:"CompanyRoles" toml:"CompanyRoles" yaml:"CompanyRoles"`
+	ContractTemplates   ContractTemplateSlice   `boil:"ContractTemplates" json:"ContractTemplates" toml:"ContractTemplates" yaml:"ContractTemplates"`
+	IdentityProviders   IdentityProviderSlice   `boil:"IdentityProviders" json:"IdentityProviders" toml:"IdentityProviders" yaml:"IdentityProviders"`
+	Invitations         InvitationSlice         `boil:"Invitations" json:"Invitations" toml:"Invitations" yaml:"Invitations"`
+	Offers              OfferSlice              `boil:"Offers" json:"Offers" toml:"Offers" yaml:"Offers"`
+	OwnershipTransfers  OwnershipTransferSlice  `boil:"OwnershipTransfers" json:"OwnershipTransfers" toml:"OwnershipTransfers" yaml:"OwnershipTransfers"`
+	Permissions         PermissionSlice         `boil:"Permissions" json:"Permissions" toml:"Permissions" yaml:"Permissions"`
 }
 
 // NewStruct creates a new relationship struct
@@ -135,6 +183,13 @@ func (r *companyR) GetContact() *User {
 	return r.Contact
 }
 
+func (r *companyR) GetAPIKeys() APIKeySlice {
+	if r == nil {
+		return nil
+	}
+	return r.APIKeys
+}
+
 func (r *companyR) GetCategories() CategorySlice {
 	if r == nil {
 		return nil
@@ -142,6 +197,20 @@ func (r *companyR) GetCategories() CategorySlice {
 	return r.Categories
 }
 
+func (r *companyR) GetCompanyLegalClauses() CompanyLegalClauseSlice {
+	if r == nil {
+		return nil
+	}
+	return r.CompanyLegalClauses
+}
+
+func (r *companyR) GetCompanyRoles() CompanyRoleSlice {
+	if r == nil {
+		return nil
+	}
+	return r.CompanyRoles
+}
+
 func (r *companyR) GetContractTemplates() ContractTemplateSlice {
 	if r == nil {
 		return nil
@@ -149,6 +218,20 @@ func (r *companyR) GetContractTemplates() ContractTemplateSlice {
 	return r.ContractTemplates
 }
 
+func (r *companyR) GetIdentityProviders() IdentityProviderSlice {
+	if r == nil {
+		return nil
+	}
+	return r.IdentityProviders
+}
+
+func (r *companyR) GetInvitations() InvitationSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Invitations
+}
+
 func (r *companyR) GetOffers() OfferSlice {
 	if r == nil {
 		return nil
@@ -156,6 +239,13 @@ func (r *companyR) GetOffers() OfferSlice {
 	return r.Offers
 }
 
+func (r *companyR) GetOwnershipTransfers() OwnershipTransferSlice {
+	if r == nil {
+		return nil
+	}
+	return r.OwnershipTransfers
+}
+
 func (r *companyR) GetPermissions() PermissionSlice {
 	if r == nil {
 		return nil
@@ -167,9 +257,9 @@ func (r *companyR) GetPermissions() PermissionSlice {
 type companyL struct{}
 
 var (
-	companyAllColumns            = []string{"id", "name", "contact_id", "address", "logo_base64", "created_at", "updated_at", "deleted_at"}
+	companyAllColumns            = []string{"id", "name", "contact_id", "address", "logo_base64", "created_at", "updated_at", "deleted_at", "deleted_by", "deletion_id", "require_two_factor"}
 	companyColumnsWithoutDefault = []string{"id", "name", "contact_id", "address", "logo_base64", "created_at", "updated_at"}
-	companyColumnsWithDefault    = []string{"deleted_at"}
+	companyColumnsWithDefault    = []string{"deleted_at", "deleted_by", "deletion_id", "require_two_factor"}
 	companyPrimaryKeyColumns     = []string{"id"}
 	companyGeneratedColumns      = []string{}
 )
@@ -276,6 +366,20 @@ func (o *Company) Contact(mods ...qm.QueryMod) userQuery {
 	return Users(queryMods...)
 }
 
+// APIKeys retrieves all the api_key's APIKeys with an executor.
+func (o *Company) APIKeys(mods ...qm.QueryMod) apiKeyQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"api_keys\".\"company_id\"=?", o.ID),
+	)
+
+	return APIKeys(queryMods...)
+}
+
 // Categories retrieves all the category's Categories with an executor.
 func (o *Company) Categories(mods ...qm.QueryMod) categoryQuery {
 	var queryMods []qm.QueryMod
@@ -290,6 +394,34 @@ func (o *Company) Categories(mods ...qm.QueryMod) categoryQuery {
 	return Categories(queryMods...)
 }
 
+// CompanyLegalClauses retrieves all the company_legal_clause's CompanyLegalClauses with an executor.
+func (o *Company) CompanyLegalClauses(mods ...qm.QueryMod) companyLegalClauseQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"company_legal_clauses\".\"company_id\"=?", o.ID),
+	)
+
+	return CompanyLegalClauses(queryMods...)
+}
+
+// CompanyRoles retrieves all the company_role's CompanyRoles with an executor.
+func (o *Company) CompanyRoles(mods ...qm.QueryMod) companyRoleQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"company_roles\".\"company_id\"=?", o.ID),
+	)
+
+	return CompanyRoles(queryMods...)
+}
+
 // ContractTemplates retrieves all the contract_template's ContractTemplates with an executor.
 func (o *Company) ContractTemplates(mods ...qm.QueryMod) contractTemplateQuery {
 	var queryMods []qm.QueryMod
@@ -304,6 +436,34 @@ func (o *Company) ContractTemplates(mods ...qm.QueryMod) contractTemplateQuery {
 	return ContractTemplates(queryMods...)
 }
 
+// IdentityProviders retrieves all the identity_provider's IdentityProviders with an executor.
+func (o *Company) IdentityProviders(mods ...qm.QueryMod) identityProviderQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"identity_providers\".\"company_id\"=?", o.ID),
+	)
+
+	return IdentityProviders(queryMods...)
+}
+
+// Invitations retrieves all the invitation's Invitations with an executor.
+func (o *Company) Invitations(mods ...qm.QueryMod) invitationQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"invitations\".\"company_id\"=?", o.ID),
+	)
+
+	return Invitations(queryMods...)
+}
+
 // Offers retrieves all the offer's Offers with an executor.
 func (o *Company) Offers(mods ...qm.QueryMod) offerQuery {
 	var queryMods []qm.QueryMod
@@ -318,6 +478,20 @@ func (o *Company) Offers(mods ...qm.QueryMod) offerQuery {
 	return Offers(queryMods...)
 }
 
+// OwnershipTransfers retrieves all the ownership_transfer's OwnershipTransfers with an executor.
+func (o *Company) OwnershipTransfers(mods ...qm.QueryMod) ownershipTransferQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"ownership_transfers\".\"company_id\"=?", o.ID),
+	)
+
+	return OwnershipTransfers(queryMods...)
+}
+
 // Permissions retrieves all the permission's Permissions with an executor.
 func (o *Company) Permissions(mods ...qm.QueryMod) permissionQuery {
 	var queryMods []qm.QueryMod
@@ -444,6 +618,112 @@ func (companyL) LoadContact(ctx context.Context, e boil.ContextExecutor, singula
 	return nil
 }
 
+// LoadAPIKeys allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (companyL) LoadAPIKeys(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCompany interface{}, mods queries.Applicator) error {
+	var slice []*Company
+	var object *Company
+
+	if singular {
+		var ok bool
+		object, ok = maybeCompany.(*Company)
+		if !ok {
+			object = new(Company)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeCompany)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeCompany))
+			}
+		}
+	} else {
+		s, ok := maybeCompany.(*[]*Company)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeCompany)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeCompany))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &companyR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &companyR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`api_keys`),
+		qm.WhereIn(`api_keys.company_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load api_keys")
+	}
+
+	var resultSlice []*APIKey
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice api_keys")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on api_keys")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for api_keys")
+	}
+
+	if singular {
+		object.R.APIKeys = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &apiKeyR{}
+			}
+			foreign.R.Company = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.CompanyID {
+				local.R.APIKeys = append(local.R.APIKeys, foreign)
+				if foreign.R == nil {
+					foreign.R = &apiKeyR{}
+				}
+				foreign.R.Company = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadCategories allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (companyL) LoadCategories(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCompany interface{}, mods queries.Applicator) error {
@@ -550,9 +830,9 @@ func (companyL) LoadCategories(ctx context.Context, e boil.ContextExecutor, sing
 	return nil
 }
 
-// LoadContractTemplates allows an eager lookup of values, cached into the
+// LoadCompanyLegalClauses allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (companyL) LoadContractTemplates(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCompany interface{}, mods queries.Applicator) error {
+func (companyL) LoadCompanyLegalClauses(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCompany interface{}, mods queries.Applicator) error {
 	var slice []*Company
 	var object *Company
 
@@ -605,8 +885,8 @@ func (companyL) LoadContractTemplates(ctx context.Context, e boil.ContextExecuto
 	}
 
 	query := NewQuery(
-		qm.From(`contract_templates`),
-		qm.WhereIn(`contract_templates.company_id in ?`, argsSlice...),
+		qm.From(`company_legal_clauses`),
+		qm.WhereIn(`company_legal_clauses.company_id in ?`, argsSlice...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -614,26 +894,26 @@ func (companyL) LoadContractTemplates(ctx context.Context, e boil.ContextExecuto
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load contract_templates")
+		return errors.Wrap(err, "failed to eager load company_legal_clauses")
 	}
 
-	var resultSlice []*ContractTemplate
+	var resultSlice []*CompanyLegalClause
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice contract_templates")
+		return errors.Wrap(err, "failed to bind eager loaded slice company_legal_clauses")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on contract_templates")
+		return errors.Wrap(err, "failed to close results in eager load on company_legal_clauses")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for contract_templates")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for company_legal_clauses")
 	}
 
 	if singular {
-		object.R.ContractTemplates = resultSlice
+		object.R.CompanyLegalClauses = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &contractTemplateR{}
+				foreign.R = &companyLegalClauseR{}
 			}
 			foreign.R.Company = object
 		}
@@ -643,9 +923,9 @@ func (companyL) LoadContractTemplates(ctx context.Context, e boil.ContextExecuto
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
 			if local.ID == foreign.CompanyID {
-				local.R.ContractTemplates = append(local.R.ContractTemplates, foreign)
+				local.R.CompanyLegalClauses = append(local.R.CompanyLegalClauses, foreign)
 				if foreign.R == nil {
-					foreign.R = &contractTemplateR{}
+					foreign.R = &companyLegalClauseR{}
 				}
 				foreign.R.Company = local
 				break
@@ -656,9 +936,9 @@ func (companyL) LoadContractTemplates(ctx context.Context, e boil.ContextExecuto
 	return nil
 }
 
-// LoadOffers allows an eager lookup of values, cached into the
+// LoadCompanyRoles allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (companyL) LoadOffers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCompany interface{}, mods queries.Applicator) error {
+func (companyL) LoadCompanyRoles(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCompany interface{}, mods queries.Applicator) error {
 	var slice []*Company
 	var object *Company
 
@@ -711,8 +991,8 @@ func (companyL) LoadOffers(ctx context.Context, e boil.ContextExecutor, singular
 	}
 
 	query := NewQuery(
-		qm.From(`offers`),
-		qm.WhereIn(`offers.company_id in ?`, argsSlice...),
+		qm.From(`company_roles`),
+		qm.WhereIn(`company_roles.company_id in ?`, argsSlice...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -720,26 +1000,26 @@ func (companyL) LoadOffers(ctx context.Context, e boil.ContextExecutor, singular
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load offers")
+		return errors.Wrap(err, "failed to eager load company_roles")
 	}
 
-	var resultSlice []*Offer
+	var resultSlice []*CompanyRole
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice offers")
+		return errors.Wrap(err, "failed to bind eager loaded slice company_roles")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on offers")
+		return errors.Wrap(err, "failed to close results in eager load on company_roles")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for offers")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for company_roles")
 	}
 
 	if singular {
-		object.R.Offers = resultSlice
+		object.R.CompanyRoles = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &offerR{}
+				foreign.R = &companyRoleR{}
 			}
 			foreign.R.Company = object
 		}
@@ -749,9 +1029,9 @@ func (companyL) LoadOffers(ctx context.Context, e boil.ContextExecutor, singular
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
 			if local.ID == foreign.CompanyID {
-				local.R.Offers = append(local.R.Offers, foreign)
+				local.R.CompanyRoles = append(local.R.CompanyRoles, foreign)
 				if foreign.R == nil {
-					foreign.R = &offerR{}
+					foreign.R = &companyRoleR{}
 				}
 				foreign.R.Company = local
 				break
@@ -762,9 +1042,9 @@ func (companyL) LoadOffers(ctx context.Context, e boil.ContextExecutor, singular
 	return nil
 }
 
-// LoadPermissions allows an eager lookup of values, cached into the
+// LoadContractTemplates allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (companyL) LoadPermissions(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCompany interface{}, mods queries.Applicator) error {
+func (companyL) LoadContractTemplates(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCompany interface{}, mods queries.Applicator) error {
 	var slice []*Company
 	var object *Company
 
@@ -817,8 +1097,8 @@ func (companyL) LoadPermissions(ctx context.Context, e boil.ContextExecutor, sin
 	}
 
 	query := NewQuery(
-		qm.From(`permissions`),
-		qm.WhereIn(`permissions.company_id in ?`, argsSlice...),
+		qm.From(`contract_templates`),
+		qm.WhereIn(`contract_templates.company_id in ?`, argsSlice...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -826,26 +1106,26 @@ func (companyL) LoadPermissions(ctx context.Context, e boil.ContextExecutor, sin
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load permissions")
+		return errors.Wrap(err, "failed to eager load contract_templates")
 	}
 
-	var resultSlice []*Permission
+	var resultSlice []*ContractTemplate
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice permissions")
+		return errors.Wrap(err, "failed to bind eager loaded slice contract_templates")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on permissions")
+		return errors.Wrap(err, "failed to close results in eager load on contract_templates")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for permissions")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for contract_templates")
 	}
 
 	if singular {
-		object.R.Permissions = resultSlice
+		object.R.ContractTemplates = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &permissionR{}
+				foreign.R = &contractTemplateR{}
 			}
 			foreign.R.Company = object
 		}
@@ -855,9 +1135,9 @@ func (companyL) LoadPermissions(ctx context.Context, e boil.ContextExecutor, sin
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
 			if local.ID == foreign.CompanyID {
-				local.R.Permissions = append(local.R.Permissions, foreign)
+				local.R.ContractTemplates = append(local.R.ContractTemplates, foreign)
 				if foreign.R == nil {
-					foreign.R = &permissionR{}
+					foreign.R = &contractTemplateR{}
 				}
 				foreign.R.Company = local
 				break
@@ -868,50 +1148,633 @@ func (companyL) LoadPermissions(ctx context.Context, e boil.ContextExecutor, sin
 	return nil
 }
 
-// SetContact of the company to the related item.
-// Sets o.R.Contact to related.
-// Adds o to related.R.ContactCompanies.
-func (o *Company) SetContact(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
-	var err error
-	if insert {
-		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
-			return errors.Wrap(err, "failed to insert into foreign table")
-		}
-	}
+// LoadIdentityProviders allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (companyL) LoadIdentityProviders(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCompany interface{}, mods queries.Applicator) error {
+	var slice []*Company
+	var object *Company
 
-	updateQuery := fmt.Sprintf(
-		"UPDATE \"companies\" SET %s WHERE %s",
-		strmangle.SetParamNames("\"", "\"", 1, []string{"contact_id"}),
-		strmangle.WhereClause("\"", "\"", 2, companyPrimaryKeyColumns),
-	)
-	values := []interface{}{related.ID, o.ID}
+	if singular {
+		var ok bool
+		object, ok = maybeCompany.(*Company)
+		if !ok {
+			object = new(Company)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeCompany)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeCompany))
+			}
+		}
+	} else {
+		s, ok := maybeCompany.(*[]*Company)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeCompany)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeCompany))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &companyR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &companyR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`identity_providers`),
+		qm.WhereIn(`identity_providers.company_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load identity_providers")
+	}
+
+	var resultSlice []*IdentityProvider
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice identity_providers")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on identity_providers")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for identity_providers")
+	}
+
+	if singular {
+		object.R.IdentityProviders = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &identityProviderR{}
+			}
+			foreign.R.Company = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.CompanyID {
+				local.R.IdentityProviders = append(local.R.IdentityProviders, foreign)
+				if foreign.R == nil {
+					foreign.R = &identityProviderR{}
+				}
+				foreign.R.Company = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadInvitations allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (companyL) LoadInvitations(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCompany interface{}, mods queries.Applicator) error {
+	var slice []*Company
+	var object *Company
+
+	if singular {
+		var ok bool
+		object, ok = maybeCompany.(*Company)
+		if !ok {
+			object = new(Company)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeCompany)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeCompany))
+			}
+		}
+	} else {
+		s, ok := maybeCompany.(*[]*Company)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeCompany)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeCompany))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &companyR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &companyR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`invitations`),
+		qm.WhereIn(`invitations.company_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load invitations")
+	}
+
+	var resultSlice []*Invitation
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice invitations")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on invitations")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for invitations")
+	}
+
+	if singular {
+		object.R.Invitations = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &invitationR{}
+			}
+			foreign.R.Company = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.CompanyID {
+				local.R.Invitations = append(local.R.Invitations, foreign)
+				if foreign.R == nil {
+					foreign.R = &invitationR{}
+				}
+				foreign.R.Company = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadOffers allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (companyL) LoadOffers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCompany interface{}, mods queries.Applicator) error {
+	var slice []*Company
+	var object *Company
+
+	if singular {
+		var ok bool
+		object, ok = maybeCompany.(*Company)
+		if !ok {
+			object = new(Company)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeCompany)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeCompany))
+			}
+		}
+	} else {
+		s, ok := maybeCompany.(*[]*Company)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeCompany)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeCompany))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &companyR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &companyR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`offers`),
+		qm.WhereIn(`offers.company_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load offers")
+	}
+
+	var resultSlice []*Offer
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice offers")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on offers")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for offers")
+	}
+
+	if singular {
+		object.R.Offers = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &offerR{}
+			}
+			foreign.R.Company = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.CompanyID {
+				local.R.Offers = append(local.R.Offers, foreign)
+				if foreign.R == nil {
+					foreign.R = &offerR{}
+				}
+				foreign.R.Company = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadOwnershipTransfers allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (companyL) LoadOwnershipTransfers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCompany interface{}, mods queries.Applicator) error {
+	var slice []*Company
+	var object *Company
+
+	if singular {
+		var ok bool
+		object, ok = maybeCompany.(*Company)
+		if !ok {
+			object = new(Company)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeCompany)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeCompany))
+			}
+		}
+	} else {
+		s, ok := maybeCompany.(*[]*Company)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeCompany)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeCompany))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &companyR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &companyR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`ownership_transfers`),
+		qm.WhereIn(`ownership_transfers.company_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load ownership_transfers")
+	}
+
+	var resultSlice []*OwnershipTransfer
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice ownership_transfers")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on ownership_transfers")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for ownership_transfers")
+	}
+
+	if singular {
+		object.R.OwnershipTransfers = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &ownershipTransferR{}
+			}
+			foreign.R.Company = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.CompanyID {
+				local.R.OwnershipTransfers = append(local.R.OwnershipTransfers, foreign)
+				if foreign.R == nil {
+					foreign.R = &ownershipTransferR{}
+				}
+				foreign.R.Company = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadPermissions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (companyL) LoadPermissions(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCompany interface{}, mods queries.Applicator) error {
+	var slice []*Company
+	var object *Company
+
+	if singular {
+		var ok bool
+		object, ok = maybeCompany.(*Company)
+		if !ok {
+			object = new(Company)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeCompany)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeCompany))
+			}
+		}
+	} else {
+		s, ok := maybeCompany.(*[]*Company)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeCompany)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeCompany))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &companyR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &companyR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`permissions`),
+		qm.WhereIn(`permissions.company_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load permissions")
+	}
+
+	var resultSlice []*Permission
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice permissions")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on permissions")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for permissions")
+	}
+
+	if singular {
+		object.R.Permissions = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &permissionR{}
+			}
+			foreign.R.Company = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.CompanyID {
+				local.R.Permissions = append(local.R.Permissions, foreign)
+				if foreign.R == nil {
+					foreign.R = &permissionR{}
+				}
+				foreign.R.Company = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetContact of the company to the related item.
+// Sets o.R.Contact to related.
+// Adds o to related.R.ContactCompanies.
+func (o *Company) SetContact(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"companies\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"contact_id"}),
+		strmangle.WhereClause("\"", "\"", 2, companyPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ContactID = related.ID
+	if o.R == nil {
+		o.R = &companyR{
+			Contact: related,
+		}
+	} else {
+		o.R.Contact = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			ContactCompanies: CompanySlice{o},
+		}
+	} else {
+		related.R.ContactCompanies = append(related.R.ContactCompanies, o)
+	}
+
+	return nil
+}
+
+// AddAPIKeys adds the given related objects to the existing relationships
+// of the company, optionally inserting them as new records.
+// Appends related to o.R.APIKeys.
+// Sets related.R.Company appropriately.
+func (o *Company) AddAPIKeys(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*APIKey) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.CompanyID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"api_keys\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"company_id"}),
+				strmangle.WhereClause("\"", "\"", 2, apiKeyPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
 
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, updateQuery)
-		fmt.Fprintln(writer, values)
-	}
-	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
-		return errors.Wrap(err, "failed to update local table")
+			rel.CompanyID = o.ID
+		}
 	}
 
-	o.ContactID = related.ID
 	if o.R == nil {
 		o.R = &companyR{
-			Contact: related,
+			APIKeys: related,
 		}
 	} else {
-		o.R.Contact = related
+		o.R.APIKeys = append(o.R.APIKeys, related...)
 	}
 
-	if related.R == nil {
-		related.R = &userR{
-			ContactCompanies: CompanySlice{o},
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &apiKeyR{
+				Company: o,
+			}
+		} else {
+			rel.R.Company = o
 		}
-	} else {
-		related.R.ContactCompanies = append(related.R.ContactCompanies, o)
 	}
-
 	return nil
 }
 
@@ -968,6 +1831,112 @@ func (o *Company) AddCategories(ctx context.Context, exec boil.ContextExecutor,
 	return nil
 }
 
+// AddCompanyLegalClauses adds the given related objects to the existing relationships
+// of the company, optionally inserting them as new records.
+// Appends related to o.R.CompanyLegalClauses.
+// Sets related.R.Company appropriately.
+func (o *Company) AddCompanyLegalClauses(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*CompanyLegalClause) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.CompanyID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"company_legal_clauses\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"company_id"}),
+				strmangle.WhereClause("\"", "\"", 2, companyLegalClausePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.CompanyID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &companyR{
+			CompanyLegalClauses: related,
+		}
+	} else {
+		o.R.CompanyLegalClauses = append(o.R.CompanyLegalClauses, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &companyLegalClauseR{
+				Company: o,
+			}
+		} else {
+			rel.R.Company = o
+		}
+	}
+	return nil
+}
+
+// AddCompanyRoles adds the given related objects to the existing relationships
+// of the company, optionally inserting them as new records.
+// Appends related to o.R.CompanyRoles.
+// Sets related.R.Company appropriately.
+func (o *Company) AddCompanyRoles(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*CompanyRole) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.CompanyID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"company_roles\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"company_id"}),
+				strmangle.WhereClause("\"", "\"", 2, companyRolePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.CompanyID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &companyR{
+			CompanyRoles: related,
+		}
+	} else {
+		o.R.CompanyRoles = append(o.R.CompanyRoles, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &companyRoleR{
+				Company: o,
+			}
+		} else {
+			rel.R.Company = o
+		}
+	}
+	return nil
+}
+
 // AddContractTemplates adds the given related objects to the existing relationships
 // of the company, optionally inserting them as new records.
 // Appends related to o.R.ContractTemplates.
@@ -1021,6 +1990,112 @@ func (o *Company) AddContractTemplates(ctx context.Context, exec boil.ContextExe
 	return nil
 }
 
+// AddIdentityProviders adds the given related objects to the existing relationships
+// of the company, optionally inserting them as new records.
+// Appends related to o.R.IdentityProviders.
+// Sets related.R.Company appropriately.
+func (o *Company) AddIdentityProviders(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*IdentityProvider) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.CompanyID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"identity_providers\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"company_id"}),
+				strmangle.WhereClause("\"", "\"", 2, identityProviderPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.CompanyID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &companyR{
+			IdentityProviders: related,
+		}
+	} else {
+		o.R.IdentityProviders = append(o.R.IdentityProviders, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &identityProviderR{
+				Company: o,
+			}
+		} else {
+			rel.R.Company = o
+		}
+	}
+	return nil
+}
+
+// AddInvitations adds the given related objects to the existing relationships
+// of the company, optionally inserting them as new records.
+// Appends related to o.R.Invitations.
+// Sets related.R.Company appropriately.
+func (o *Company) AddInvitations(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Invitation) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.CompanyID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"invitations\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"company_id"}),
+				strmangle.WhereClause("\"", "\"", 2, invitationPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.CompanyID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &companyR{
+			Invitations: related,
+		}
+	} else {
+		o.R.Invitations = append(o.R.Invitations, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &invitationR{
+				Company: o,
+			}
+		} else {
+			rel.R.Company = o
+		}
+	}
+	return nil
+}
+
 // AddOffers adds the given related objects to the existing relationships
 // of the company, optionally inserting them as new records.
 // Appends related to o.R.Offers.
@@ -1074,6 +2149,59 @@ func (o *Company) AddOffers(ctx context.Context, exec boil.ContextExecutor, inse
 	return nil
 }
 
+// AddOwnershipTransfers adds the given related objects to the existing relationships
+// of the company, optionally inserting them as new records.
+// Appends related to o.R.OwnershipTransfers.
+// Sets related.R.Company appropriately.
+func (o *Company) AddOwnershipTransfers(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*OwnershipTransfer) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.CompanyID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"ownership_transfers\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"company_id"}),
+				strmangle.WhereClause("\"", "\"", 2, ownershipTransferPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.CompanyID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &companyR{
+			OwnershipTransfers: related,
+		}
+	} else {
+		o.R.OwnershipTransfers = append(o.R.OwnershipTransfers, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &ownershipTransferR{
+				Company: o,
+			}
+		} else {
+			rel.R.Company = o
+		}
+	}
+	return nil
+}
+
 // AddPermissions adds the given related objects to the existing relationships
 // of the company, optionally inserting them as new records.
 // Appends related to o.R.Permissions.
@@ -0,0 +1,923 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// CompanyLegalClause is an object representing the database table.
+type CompanyLegalClause struct {
+	ID        string    `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CompanyID string    `boil:"company_id" json:"company_id" toml:"company_id" yaml:"company_id"`
+	Name      string    `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Content   string    `boil:"content" json:"content" toml:"content" yaml:"content"`
+	Mandatory bool      `boil:"mandatory" json:"mandatory" toml:"mandatory" yaml:"mandatory"`
+	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	DeletedAt null.Time `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+
+	R *companyLegalClauseR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L companyLegalClauseL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var CompanyLegalClauseColumns = struct {
+	ID        string
+	CompanyID string
+	Name      string
+	Content   string
+	Mandatory string
+	CreatedAt string
+	UpdatedAt string
+	DeletedAt string
+}{
+	ID:        "id",
+	CompanyID: "company_id",
+	Name:      "name",
+	Content:   "content",
+	Mandatory: "mandatory",
+	CreatedAt: "created_at",
+	UpdatedAt: "updated_at",
+	DeletedAt: "deleted_at",
+}
+
+var CompanyLegalClauseTableColumns = struct {
+	ID        string
+	CompanyID string
+	Name      string
+	Content   string
+	Mandatory string
+	CreatedAt string
+	UpdatedAt string
+	DeletedAt string
+}{
+	ID:        "company_legal_clauses.id",
+	CompanyID: "company_legal_clauses.company_id",
+	Name:      "company_legal_clauses.name",
+	Content:   "company_legal_clauses.content",
+	Mandatory: "company_legal_clauses.mandatory",
+	CreatedAt: "company_legal_clauses.created_at",
+	UpdatedAt: "company_legal_clauses.updated_at",
+	DeletedAt: "company_legal_clauses.deleted_at",
+}
+
+// Generated where
+
+var CompanyLegalClauseWhere = struct {
+	ID        whereHelperstring
+	CompanyID whereHelperstring
+	Name      whereHelperstring
+	Content   whereHelperstring
+	Mandatory whereHelperbool
+	CreatedAt whereHelpertime_Time
+	UpdatedAt whereHelpertime_Time
+	DeletedAt whereHelpernull_Time
+}{
+	ID:        whereHelperstring{field: "\"company_legal_clauses\".\"id\""},
+	CompanyID: whereHelperstring{field: "\"company_legal_clauses\".\"company_id\""},
+	Name:      whereHelperstring{field: "\"company_legal_clauses\".\"name\""},
+	Content:   whereHelperstring{field: "\"company_legal_clauses\".\"content\""},
+	Mandatory: whereHelperbool{field: "\"company_legal_clauses\".\"mandatory\""},
+	CreatedAt: whereHelpertime_Time{field: "\"company_legal_clauses\".\"created_at\""},
+	UpdatedAt: whereHelpertime_Time{field: "\"company_legal_clauses\".\"updated_at\""},
+	DeletedAt: whereHelpernull_Time{field: "\"company_legal_clauses\".\"deleted_at\""},
+}
+
+// CompanyLegalClauseRels is where relationship names are stored.
+var CompanyLegalClauseRels = struct {
+	Company string
+}{
+	Company: "Company",
+}
+
+// companyLegalClauseR is where relationships are stored.
+type companyLegalClauseR struct {
+	Company *Company `boil:"Company" json:"Company" toml:"Company" yaml:"Company"`
+}
+
+// NewStruct creates a new relationship struct
+func (*companyLegalClauseR) NewStruct() *companyLegalClauseR {
+	return &companyLegalClauseR{}
+}
+
+func (r *companyLegalClauseR) GetCompany() *Company {
+	if r == nil {
+		return nil
+	}
+	return r.Company
+}
+
+// companyLegalClauseL is where Load methods for each relationship are stored.
+type companyLegalClauseL struct{}
+
+var (
+	companyLegalClauseAllColumns            = []string{"id", "company_id", "name", "content", "mandatory", "created_at", "updated_at", "deleted_at"}
+	companyLegalClauseColumnsWithoutDefault = []string{"id", "company_id", "name", "content", "created_at", "updated_at"}
+	companyLegalClauseColumnsWithDefault    = []string{"mandatory", "deleted_at"}
+	companyLegalClausePrimaryKeyColumns     = []string{"id"}
+	companyLegalClauseGeneratedColumns      = []string{}
+)
+
+type (
+	// CompanyLegalClauseSlice is an alias for a slice of pointers to CompanyLegalClause.
+	// This should almost always be used instead of []CompanyLegalClause.
+	CompanyLegalClauseSlice []*CompanyLegalClause
+
+	companyLegalClauseQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	companyLegalClauseType                 = reflect.TypeOf(&CompanyLegalClause{})
+	companyLegalClauseMapping              = queries.MakeStructMapping(companyLegalClauseType)
+	companyLegalClausePrimaryKeyMapping, _ = queries.BindMapping(companyLegalClauseType, companyLegalClauseMapping, companyLegalClausePrimaryKeyColumns)
+	companyLegalClauseInsertCacheMut       sync.RWMutex
+	companyLegalClauseInsertCache          = make(map[string]insertCache)
+	companyLegalClauseUpdateCacheMut       sync.RWMutex
+	companyLegalClauseUpdateCache          = make(map[string]updateCache)
+	companyLegalClauseUpsertCacheMut       sync.RWMutex
+	companyLegalClauseUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single companyLegalClause record from the query.
+func (q companyLegalClauseQuery) One(ctx context.Context, exec boil.ContextExecutor) (*CompanyLegalClause, error) {
+	o := &CompanyLegalClause{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dao: failed to execute a one query for company_legal_clauses")
+	}
+
+	return o, nil
+}
+
+// All returns all CompanyLegalClause records from the query.
+func (q companyLegalClauseQuery) All(ctx context.Context, exec boil.ContextExecutor) (CompanyLegalClauseSlice, error) {
+	var o []*CompanyLegalClause
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "dao: failed to assign all query results to CompanyLegalClause slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all CompanyLegalClause records in the query.
+func (q companyLegalClauseQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to count company_legal_clauses rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q companyLegalClauseQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "dao: failed to check if company_legal_clauses exists")
+	}
+
+	return count > 0, nil
+}
+
+// Company pointed to by the foreign key.
+func (o *CompanyLegalClause) Company(mods ...qm.QueryMod) companyQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.CompanyID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Companies(queryMods...)
+}
+
+// LoadCompany allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (companyLegalClauseL) LoadCompany(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCompanyLegalClause interface{}, mods queries.Applicator) error {
+	var slice []*CompanyLegalClause
+	var object *CompanyLegalClause
+
+	if singular {
+		var ok bool
+		object, ok = maybeCompanyLegalClause.(*CompanyLegalClause)
+		if !ok {
+			object = new(CompanyLegalClause)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeCompanyLegalClause)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeCompanyLegalClause))
+			}
+		}
+	} else {
+		s, ok := maybeCompanyLegalClause.(*[]*CompanyLegalClause)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeCompanyLegalClause)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeCompanyLegalClause))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &companyLegalClauseR{}
+		}
+		args[object.CompanyID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &companyLegalClauseR{}
+			}
+
+			args[obj.CompanyID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`companies`),
+		qm.WhereIn(`companies.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Company")
+	}
+
+	var resultSlice []*Company
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Company")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for companies")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for companies")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Company = foreign
+		if foreign.R == nil {
+			foreign.R = &companyR{}
+		}
+		foreign.R.CompanyLegalClauses = append(foreign.R.CompanyLegalClauses, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.CompanyID == foreign.ID {
+				local.R.Company = foreign
+				if foreign.R == nil {
+					foreign.R = &companyR{}
+				}
+				foreign.R.CompanyLegalClauses = append(foreign.R.CompanyLegalClauses, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetCompany of the companyLegalClause to the related item.
+// Sets o.R.Company to related.
+// Adds o to related.R.CompanyLegalClauses.
+func (o *CompanyLegalClause) SetCompany(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Company) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"company_legal_clauses\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"company_id"}),
+		strmangle.WhereClause("\"", "\"", 2, companyLegalClausePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.CompanyID = related.ID
+	if o.R == nil {
+		o.R = &companyLegalClauseR{
+			Company: related,
+		}
+	} else {
+		o.R.Company = related
+	}
+
+	if related.R == nil {
+		related.R = &companyR{
+			CompanyLegalClauses: CompanyLegalClauseSlice{o},
+		}
+	} else {
+		related.R.CompanyLegalClauses = append(related.R.CompanyLegalClauses, o)
+	}
+
+	return nil
+}
+
+// CompanyLegalClauses retrieves all the records using an executor.
+func CompanyLegalClauses(mods ...qm.QueryMod) companyLegalClauseQuery {
+	mods = append(mods, qm.From("\"company_legal_clauses\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"company_legal_clauses\".*"})
+	}
+
+	return companyLegalClauseQuery{q}
+}
+
+// FindCompanyLegalClause retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindCompanyLegalClause(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*CompanyLegalClause, error) {
+	companyLegalClauseObj := &CompanyLegalClause{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"company_legal_clauses\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, companyLegalClauseObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dao: unable to select from company_legal_clauses")
+	}
+
+	return companyLegalClauseObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *CompanyLegalClause) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("dao: no company_legal_clauses provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(companyLegalClauseColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	companyLegalClauseInsertCacheMut.RLock()
+	cache, cached := companyLegalClauseInsertCache[key]
+	companyLegalClauseInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			companyLegalClauseAllColumns,
+			companyLegalClauseColumnsWithDefault,
+			companyLegalClauseColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(companyLegalClauseType, companyLegalClauseMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(companyLegalClauseType, companyLegalClauseMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"company_legal_clauses\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"company_legal_clauses\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to insert into company_legal_clauses")
+	}
+
+	if !cached {
+		companyLegalClauseInsertCacheMut.Lock()
+		companyLegalClauseInsertCache[key] = cache
+		companyLegalClauseInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the CompanyLegalClause.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *CompanyLegalClause) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	key := makeCacheKey(columns, nil)
+	companyLegalClauseUpdateCacheMut.RLock()
+	cache, cached := companyLegalClauseUpdateCache[key]
+	companyLegalClauseUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			companyLegalClauseAllColumns,
+			companyLegalClausePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("dao: unable to update company_legal_clauses, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"company_legal_clauses\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, companyLegalClausePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(companyLegalClauseType, companyLegalClauseMapping, append(wl, companyLegalClausePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update company_legal_clauses row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by update for company_legal_clauses")
+	}
+
+	if !cached {
+		companyLegalClauseUpdateCacheMut.Lock()
+		companyLegalClauseUpdateCache[key] = cache
+		companyLegalClauseUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q companyLegalClauseQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update all for company_legal_clauses")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to retrieve rows affected for company_legal_clauses")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o CompanyLegalClauseSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("dao: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), companyLegalClausePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"company_legal_clauses\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, companyLegalClausePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update all in companyLegalClause slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to retrieve rows affected all in update all companyLegalClause")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *CompanyLegalClause) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("dao: no company_legal_clauses provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		o.UpdatedAt = currTime
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(companyLegalClauseColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	companyLegalClauseUpsertCacheMut.RLock()
+	cache, cached := companyLegalClauseUpsertCache[key]
+	companyLegalClauseUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			companyLegalClauseAllColumns,
+			companyLegalClauseColumnsWithDefault,
+			companyLegalClauseColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			companyLegalClauseAllColumns,
+			companyLegalClausePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("dao: unable to upsert company_legal_clauses, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(companyLegalClauseAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(companyLegalClausePrimaryKeyColumns) == 0 {
+				return errors.New("dao: unable to upsert company_legal_clauses, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(companyLegalClausePrimaryKeyColumns))
+			copy(conflict, companyLegalClausePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"company_legal_clauses\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(companyLegalClauseType, companyLegalClauseMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(companyLegalClauseType, companyLegalClauseMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to upsert company_legal_clauses")
+	}
+
+	if !cached {
+		companyLegalClauseUpsertCacheMut.Lock()
+		companyLegalClauseUpsertCache[key] = cache
+		companyLegalClauseUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single CompanyLegalClause record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *CompanyLegalClause) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("dao: no CompanyLegalClause provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), companyLegalClausePrimaryKeyMapping)
+	sql := "DELETE FROM \"company_legal_clauses\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete from company_legal_clauses")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by delete for company_legal_clauses")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q companyLegalClauseQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("dao: no companyLegalClauseQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete all from company_legal_clauses")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by deleteall for company_legal_clauses")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o CompanyLegalClauseSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), companyLegalClausePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"company_legal_clauses\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, companyLegalClausePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete all from companyLegalClause slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by deleteall for company_legal_clauses")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *CompanyLegalClause) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindCompanyLegalClause(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *CompanyLegalClauseSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := CompanyLegalClauseSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), companyLegalClausePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"company_legal_clauses\".* FROM \"company_legal_clauses\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, companyLegalClausePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to reload all in CompanyLegalClauseSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// CompanyLegalClauseExists checks if the CompanyLegalClause row exists.
+func CompanyLegalClauseExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"company_legal_clauses\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "dao: unable to check if company_legal_clauses exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the CompanyLegalClause row exists.
+func (o *CompanyLegalClause) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return CompanyLegalClauseExists(ctx, exec, o.ID)
+}
@@ -0,0 +1,1195 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// CompanyRole is an object representing the database table.
+type CompanyRole struct {
+	ID           string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CompanyID    string      `boil:"company_id" json:"company_id" toml:"company_id" yaml:"company_id"`
+	Name         string      `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Description  null.String `boil:"description" json:"description,omitempty" toml:"description" yaml:"description,omitempty"`
+	Capabilities types.JSON  `boil:"capabilities" json:"capabilities" toml:"capabilities" yaml:"capabilities"`
+	CreatedAt    time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt    time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+
+	R *companyRoleR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L companyRoleL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var CompanyRoleColumns = struct {
+	ID           string
+	CompanyID    string
+	Name         string
+	Description  string
+	Capabilities string
+	CreatedAt    string
+	UpdatedAt    string
+}{
+	ID:           "id",
+	CompanyID:    "company_id",
+	Name:         "name",
+	Description:  "description",
+	Capabilities: "capabilities",
+	CreatedAt:    "created_at",
+	UpdatedAt:    "updated_at",
+}
+
+var CompanyRoleTableColumns = struct {
+	ID           string
+	CompanyID    string
+	Name         string
+	Description  string
+	Capabilities string
+	CreatedAt    string
+	UpdatedAt    string
+}{
+	ID:           "company_roles.id",
+	CompanyID:    "company_roles.company_id",
+	Name:         "company_roles.name",
+	Description:  "company_roles.description",
+	Capabilities: "company_roles.capabilities",
+	CreatedAt:    "company_roles.created_at",
+	UpdatedAt:    "company_roles.updated_at",
+}
+
+// Generated where
+
+type whereHelpertypes_JSON struct{ field string }
+
+func (w whereHelpertypes_JSON) EQ(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertypes_JSON) NEQ(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertypes_JSON) LT(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertypes_JSON) LTE(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertypes_JSON) GT(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertypes_JSON) GTE(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var CompanyRoleWhere = struct {
+	ID           whereHelperstring
+	CompanyID    whereHelperstring
+	Name         whereHelperstring
+	Description  whereHelpernull_String
+	Capabilities whereHelpertypes_JSON
+	CreatedAt    whereHelpertime_Time
+	UpdatedAt    whereHelpertime_Time
+}{
+	ID:           whereHelperstring{field: "\"company_roles\".\"id\""},
+	CompanyID:    whereHelperstring{field: "\"company_roles\".\"company_id\""},
+	Name:         whereHelperstring{field: "\"company_roles\".\"name\""},
+	Description:  whereHelpernull_String{field: "\"company_roles\".\"description\""},
+	Capabilities: whereHelpertypes_JSON{field: "\"company_roles\".\"capabilities\""},
+	CreatedAt:    whereHelpertime_Time{field: "\"company_roles\".\"created_at\""},
+	UpdatedAt:    whereHelpertime_Time{field: "\"company_roles\".\"updated_at\""},
+}
+
+// CompanyRoleRels is where relationship names are stored.
+var CompanyRoleRels = struct {
+	Company     string
+	Permissions string
+}{
+	Company:     "Company",
+	Permissions: "Permissions",
+}
+
+// companyRoleR is where relationships are stored.
+type companyRoleR struct {
+	Company     *Company        `boil:"Company" json:"Company" toml:"Company" yaml:"Company"`
+	Permissions PermissionSlice `boil:"Permissions" json:"Permissions" toml:"Permissions" yaml:"Permissions"`
+}
+
+// NewStruct creates a new relationship struct
+func (*companyRoleR) NewStruct() *companyRoleR {
+	return &companyRoleR{}
+}
+
+func (r *companyRoleR) GetCompany() *Company {
+	if r == nil {
+		return nil
+	}
+	return r.Company
+}
+
+func (r *companyRoleR) GetPermissions() PermissionSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Permissions
+}
+
+// companyRoleL is where Load methods for each relationship are stored.
+type companyRoleL struct{}
+
+var (
+	companyRoleAllColumns            = []string{"id", "company_id", "name", "description", "capabilities", "created_at", "updated_at"}
+	companyRoleColumnsWithoutDefault = []string{"id", "company_id", "name", "capabilities", "created_at", "updated_at"}
+	companyRoleColumnsWithDefault    = []string{"description"}
+	companyRolePrimaryKeyColumns     = []string{"id"}
+	companyRoleGeneratedColumns      = []string{}
+)
+
+type (
+	// CompanyRoleSlice is an alias for a slice of pointers to CompanyRole.
+	// This should almost always be used instead of []CompanyRole.
+	CompanyRoleSlice []*CompanyRole
+
+	companyRoleQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	companyRoleType                 = reflect.TypeOf(&CompanyRole{})
+	companyRoleMapping              = queries.MakeStructMapping(companyRoleType)
+	companyRolePrimaryKeyMapping, _ = queries.BindMapping(companyRoleType, companyRoleMapping, companyRolePrimaryKeyColumns)
+	companyRoleInsertCacheMut       sync.RWMutex
+	companyRoleInsertCache          = make(map[string]insertCache)
+	companyRoleUpdateCacheMut       sync.RWMutex
+	companyRoleUpdateCache          = make(map[string]updateCache)
+	companyRoleUpsertCacheMut       sync.RWMutex
+	companyRoleUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single companyRole record from the query.
+func (q companyRoleQuery) One(ctx context.Context, exec boil.ContextExecutor) (*CompanyRole, error) {
+	o := &CompanyRole{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dao: failed to execute a one query for company_roles")
+	}
+
+	return o, nil
+}
+
+// All returns all CompanyRole records from the query.
+func (q companyRoleQuery) All(ctx context.Context, exec boil.ContextExecutor) (CompanyRoleSlice, error) {
+	var o []*CompanyRole
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "dao: failed to assign all query results to CompanyRole slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all CompanyRole records in the query.
+func (q companyRoleQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to count company_roles rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q companyRoleQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "dao: failed to check if company_roles exists")
+	}
+
+	return count > 0, nil
+}
+
+// Company pointed to by the foreign key.
+func (o *CompanyRole) Company(mods ...qm.QueryMod) companyQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.CompanyID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Companies(queryMods...)
+}
+
+// Permissions retrieves all the permission's Permissions with an executor.
+func (o *CompanyRole) Permissions(mods ...qm.QueryMod) permissionQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"permissions\".\"company_role_id\"=?", o.ID),
+	)
+
+	return Permissions(queryMods...)
+}
+
+// LoadCompany allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (companyRoleL) LoadCompany(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCompanyRole interface{}, mods queries.Applicator) error {
+	var slice []*CompanyRole
+	var object *CompanyRole
+
+	if singular {
+		var ok bool
+		object, ok = maybeCompanyRole.(*CompanyRole)
+		if !ok {
+			object = new(CompanyRole)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeCompanyRole)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeCompanyRole))
+			}
+		}
+	} else {
+		s, ok := maybeCompanyRole.(*[]*CompanyRole)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeCompanyRole)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeCompanyRole))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &companyRoleR{}
+		}
+		args[object.CompanyID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &companyRoleR{}
+			}
+
+			args[obj.CompanyID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`companies`),
+		qm.WhereIn(`companies.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Company")
+	}
+
+	var resultSlice []*Company
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Company")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for companies")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for companies")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Company = foreign
+		if foreign.R == nil {
+			foreign.R = &companyR{}
+		}
+		foreign.R.CompanyRoles = append(foreign.R.CompanyRoles, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.CompanyID == foreign.ID {
+				local.R.Company = foreign
+				if foreign.R == nil {
+					foreign.R = &companyR{}
+				}
+				foreign.R.CompanyRoles = append(foreign.R.CompanyRoles, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadPermissions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (companyRoleL) LoadPermissions(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCompanyRole interface{}, mods queries.Applicator) error {
+	var slice []*CompanyRole
+	var object *CompanyRole
+
+	if singular {
+		var ok bool
+		object, ok = maybeCompanyRole.(*CompanyRole)
+		if !ok {
+			object = new(CompanyRole)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeCompanyRole)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeCompanyRole))
+			}
+		}
+	} else {
+		s, ok := maybeCompanyRole.(*[]*CompanyRole)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeCompanyRole)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeCompanyRole))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &companyRoleR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &companyRoleR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`permissions`),
+		qm.WhereIn(`permissions.company_role_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load permissions")
+	}
+
+	var resultSlice []*Permission
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice permissions")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on permissions")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for permissions")
+	}
+
+	if singular {
+		object.R.Permissions = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &permissionR{}
+			}
+			foreign.R.CompanyRole = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.CompanyRoleID) {
+				local.R.Permissions = append(local.R.Permissions, foreign)
+				if foreign.R == nil {
+					foreign.R = &permissionR{}
+				}
+				foreign.R.CompanyRole = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetCompany of the companyRole to the related item.
+// Sets o.R.Company to related.
+// Adds o to related.R.CompanyRoles.
+func (o *CompanyRole) SetCompany(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Company) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"company_roles\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"company_id"}),
+		strmangle.WhereClause("\"", "\"", 2, companyRolePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.CompanyID = related.ID
+	if o.R == nil {
+		o.R = &companyRoleR{
+			Company: related,
+		}
+	} else {
+		o.R.Company = related
+	}
+
+	if related.R == nil {
+		related.R = &companyR{
+			CompanyRoles: CompanyRoleSlice{o},
+		}
+	} else {
+		related.R.CompanyRoles = append(related.R.CompanyRoles, o)
+	}
+
+	return nil
+}
+
+// AddPermissions adds the given related objects to the existing relationships
+// of the company_role, optionally inserting them as new records.
+// Appends related to o.R.Permissions.
+// Sets related.R.CompanyRole appropriately.
+func (o *CompanyRole) AddPermissions(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Permission) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.CompanyRoleID, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"permissions\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"company_role_id"}),
+				strmangle.WhereClause("\"", "\"", 2, permissionPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.CompanyRoleID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &companyRoleR{
+			Permissions: related,
+		}
+	} else {
+		o.R.Permissions = append(o.R.Permissions, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &permissionR{
+				CompanyRole: o,
+			}
+		} else {
+			rel.R.CompanyRole = o
+		}
+	}
+	return nil
+}
+
+// SetPermissions removes all previously related items of the
+// company_role replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.CompanyRole's Permissions accordingly.
+// Replaces o.R.Permissions with related.
+// Sets related.R.CompanyRole's Permissions accordingly.
+func (o *CompanyRole) SetPermissions(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Permission) error {
+	query := "update \"permissions\" set \"company_role_id\" = null where \"company_role_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.Permissions {
+			queries.SetScanner(&rel.CompanyRoleID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.CompanyRole = nil
+		}
+		o.R.Permissions = nil
+	}
+
+	return o.AddPermissions(ctx, exec, insert, related...)
+}
+
+// RemovePermissions relationships from objects passed in.
+// Removes related items from R.Permissions (uses pointer comparison, removal does not keep order)
+// Sets related.R.CompanyRole.
+func (o *CompanyRole) RemovePermissions(ctx context.Context, exec boil.ContextExecutor, related ...*Permission) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.CompanyRoleID, nil)
+		if rel.R != nil {
+			rel.R.CompanyRole = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("company_role_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Permissions {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Permissions)
+			if ln > 1 && i < ln-1 {
+				o.R.Permissions[i] = o.R.Permissions[ln-1]
+			}
+			o.R.Permissions = o.R.Permissions[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// CompanyRoles retrieves all the records using an executor.
+func CompanyRoles(mods ...qm.QueryMod) companyRoleQuery {
+	mods = append(mods, qm.From("\"company_roles\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"company_roles\".*"})
+	}
+
+	return companyRoleQuery{q}
+}
+
+// FindCompanyRole retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindCompanyRole(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*CompanyRole, error) {
+	companyRoleObj := &CompanyRole{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"company_roles\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, companyRoleObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dao: unable to select from company_roles")
+	}
+
+	return companyRoleObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *CompanyRole) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("dao: no company_roles provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(companyRoleColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	companyRoleInsertCacheMut.RLock()
+	cache, cached := companyRoleInsertCache[key]
+	companyRoleInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			companyRoleAllColumns,
+			companyRoleColumnsWithDefault,
+			companyRoleColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(companyRoleType, companyRoleMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(companyRoleType, companyRoleMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"company_roles\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"company_roles\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to insert into company_roles")
+	}
+
+	if !cached {
+		companyRoleInsertCacheMut.Lock()
+		companyRoleInsertCache[key] = cache
+		companyRoleInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the CompanyRole.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *CompanyRole) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	key := makeCacheKey(columns, nil)
+	companyRoleUpdateCacheMut.RLock()
+	cache, cached := companyRoleUpdateCache[key]
+	companyRoleUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			companyRoleAllColumns,
+			companyRolePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("dao: unable to update company_roles, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"company_roles\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, companyRolePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(companyRoleType, companyRoleMapping, append(wl, companyRolePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update company_roles row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by update for company_roles")
+	}
+
+	if !cached {
+		companyRoleUpdateCacheMut.Lock()
+		companyRoleUpdateCache[key] = cache
+		companyRoleUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q companyRoleQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update all for company_roles")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to retrieve rows affected for company_roles")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o CompanyRoleSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("dao: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), companyRolePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"company_roles\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, companyRolePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update all in companyRole slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to retrieve rows affected all in update all companyRole")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *CompanyRole) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("dao: no company_roles provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		o.UpdatedAt = currTime
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(companyRoleColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	companyRoleUpsertCacheMut.RLock()
+	cache, cached := companyRoleUpsertCache[key]
+	companyRoleUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			companyRoleAllColumns,
+			companyRoleColumnsWithDefault,
+			companyRoleColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			companyRoleAllColumns,
+			companyRolePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("dao: unable to upsert company_roles, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(companyRoleAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(companyRolePrimaryKeyColumns) == 0 {
+				return errors.New("dao: unable to upsert company_roles, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(companyRolePrimaryKeyColumns))
+			copy(conflict, companyRolePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"company_roles\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(companyRoleType, companyRoleMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(companyRoleType, companyRoleMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to upsert company_roles")
+	}
+
+	if !cached {
+		companyRoleUpsertCacheMut.Lock()
+		companyRoleUpsertCache[key] = cache
+		companyRoleUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single CompanyRole record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *CompanyRole) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("dao: no CompanyRole provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), companyRolePrimaryKeyMapping)
+	sql := "DELETE FROM \"company_roles\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete from company_roles")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by delete for company_roles")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q companyRoleQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("dao: no companyRoleQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete all from company_roles")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by deleteall for company_roles")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o CompanyRoleSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), companyRolePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"company_roles\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, companyRolePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete all from companyRole slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by deleteall for company_roles")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *CompanyRole) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindCompanyRole(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *CompanyRoleSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := CompanyRoleSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), companyRolePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"company_roles\".* FROM \"company_roles\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, companyRolePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to reload all in CompanyRoleSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// CompanyRoleExists checks if the CompanyRole row exists.
+func CompanyRoleExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"company_roles\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "dao: unable to check if company_roles exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the CompanyRole row exists.
+func (o *CompanyRole) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return CompanyRoleExists(ctx, exec, o.ID)
+}
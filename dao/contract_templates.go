@@ -19,92 +19,190 @@ import (
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
 	"github.com/volatiletech/strmangle"
 )
 
 // ContractTemplate is an object representing the database table.
 type ContractTemplate struct {
-	ID        string    `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Name      string    `boil:"name" json:"name" toml:"name" yaml:"name"`
-	CompanyID string    `boil:"company_id" json:"company_id" toml:"company_id" yaml:"company_id"`
-	Template  string    `boil:"template" json:"template" toml:"template" yaml:"template"`
-	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
-	DeletedAt null.Time `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	ID                      string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Name                    string      `boil:"name" json:"name" toml:"name" yaml:"name"`
+	CompanyID               string      `boil:"company_id" json:"company_id" toml:"company_id" yaml:"company_id"`
+	Template                string      `boil:"template" json:"template" toml:"template" yaml:"template"`
+	CreatedAt               time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt               time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	DeletedAt               null.Time   `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	Language                string      `boil:"language" json:"language" toml:"language" yaml:"language"`
+	Direction               string      `boil:"direction" json:"direction" toml:"direction" yaml:"direction"`
+	TranslationGroupID      null.String `boil:"translation_group_id" json:"translation_group_id,omitempty" toml:"translation_group_id" yaml:"translation_group_id,omitempty"`
+	ArgumentsSchema         types.JSON  `boil:"arguments_schema" json:"arguments_schema" toml:"arguments_schema" yaml:"arguments_schema"`
+	SourceGalleryTemplateID null.String `boil:"source_gallery_template_id" json:"source_gallery_template_id,omitempty" toml:"source_gallery_template_id" yaml:"source_gallery_template_id,omitempty"`
+	SourceVersion           null.Int    `boil:"source_version" json:"source_version,omitempty" toml:"source_version" yaml:"source_version,omitempty"`
+	DeletedBy               null.String `boil:"deleted_by" json:"deleted_by,omitempty" toml:"deleted_by" yaml:"deleted_by,omitempty"`
+	DeletionID              null.String `boil:"deletion_id" json:"deletion_id,omitempty" toml:"deletion_id" yaml:"deletion_id,omitempty"`
 
 	R *contractTemplateR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L contractTemplateL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var ContractTemplateColumns = struct {
-	ID        string
-	Name      string
-	CompanyID string
-	Template  string
-	CreatedAt string
-	UpdatedAt string
-	DeletedAt string
+	ID                      string
+	Name                    string
+	CompanyID               string
+	Template                string
+	CreatedAt               string
+	UpdatedAt               string
+	DeletedAt               string
+	Language                string
+	Direction               string
+	TranslationGroupID      string
+	ArgumentsSchema         string
+	SourceGalleryTemplateID string
+	SourceVersion           string
+	DeletedBy               string
+	DeletionID              string
 }{
-	ID:        "id",
-	Name:      "name",
-	CompanyID: "company_id",
-	Template:  "template",
-	CreatedAt: "created_at",
-	UpdatedAt: "updated_at",
-	DeletedAt: "deleted_at",
+	ID:                      "id",
+	Name:                    "name",
+	CompanyID:               "company_id",
+	Template:                "template",
+	CreatedAt:               "created_at",
+	UpdatedAt:               "updated_at",
+	DeletedAt:               "deleted_at",
+	Language:                "language",
+	Direction:               "direction",
+	TranslationGroupID:      "translation_group_id",
+	ArgumentsSchema:         "arguments_schema",
+	SourceGalleryTemplateID: "source_gallery_template_id",
+	SourceVersion:           "source_version",
+	DeletedBy:               "deleted_by",
+	DeletionID:              "deletion_id",
 }
 
 var ContractTemplateTableColumns = struct {
-	ID        string
-	Name      string
-	CompanyID string
-	Template  string
-	CreatedAt string
-	UpdatedAt string
-	DeletedAt string
+	ID                      string
+	Name                    string
+	CompanyID               string
+	Template                string
+	CreatedAt               string
+	UpdatedAt               string
+	DeletedAt               string
+	Language                string
+	Direction               string
+	TranslationGroupID      string
+	ArgumentsSchema         string
+	SourceGalleryTemplateID string
+	SourceVersion           string
+	DeletedBy               string
+	DeletionID              string
 }{
-	ID:        "contract_templates.id",
-	Name:      "contract_templates.name",
-	CompanyID: "contract_templates.company_id",
-	Template:  "contract_templates.template",
-	CreatedAt: "contract_templates.created_at",
-	UpdatedAt: "contract_templates.updated_at",
-	DeletedAt: "contract_templates.deleted_at",
+	ID:                      "contract_templates.id",
+	Name:                    "contract_templates.name",
+	CompanyID:               "contract_templates.company_id",
+	Template:                "contract_templates.template",
+	CreatedAt:               "contract_templates.created_at",
+	UpdatedAt:               "contract_templates.updated_at",
+	DeletedAt:               "contract_templates.deleted_at",
+	Language:                "contract_templates.language",
+	Direction:               "contract_templates.direction",
+	TranslationGroupID:      "contract_templates.translation_group_id",
+	ArgumentsSchema:         "contract_templates.arguments_schema",
+	SourceGalleryTemplateID: "contract_templates.source_gallery_template_id",
+	SourceVersion:           "contract_templates.source_version",
+	DeletedBy:               "contract_templates.deleted_by",
+	DeletionID:              "contract_templates.deletion_id",
 }
 
 // Generated where
 
+type whereHelpernull_Int struct{ field string }
+
+func (w whereHelpernull_Int) EQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int) NEQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int) LT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int) LTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int) GT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int) GTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_Int) IN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Int) NIN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Int) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var ContractTemplateWhere = struct {
-	ID        whereHelperstring
-	Name      whereHelperstring
-	CompanyID whereHelperstring
-	Template  whereHelperstring
-	CreatedAt whereHelpertime_Time
-	UpdatedAt whereHelpertime_Time
-	DeletedAt whereHelpernull_Time
+	ID                      whereHelperstring
+	Name                    whereHelperstring
+	CompanyID               whereHelperstring
+	Template                whereHelperstring
+	CreatedAt               whereHelpertime_Time
+	UpdatedAt               whereHelpertime_Time
+	DeletedAt               whereHelpernull_Time
+	Language                whereHelperstring
+	Direction               whereHelperstring
+	TranslationGroupID      whereHelpernull_String
+	ArgumentsSchema         whereHelpertypes_JSON
+	SourceGalleryTemplateID whereHelpernull_String
+	SourceVersion           whereHelpernull_Int
+	DeletedBy               whereHelpernull_String
+	DeletionID              whereHelpernull_String
 }{
-	ID:        whereHelperstring{field: "\"contract_templates\".\"id\""},
-	Name:      whereHelperstring{field: "\"contract_templates\".\"name\""},
-	CompanyID: whereHelperstring{field: "\"contract_templates\".\"company_id\""},
-	Template:  whereHelperstring{field: "\"contract_templates\".\"template\""},
-	CreatedAt: whereHelpertime_Time{field: "\"contract_templates\".\"created_at\""},
-	UpdatedAt: whereHelpertime_Time{field: "\"contract_templates\".\"updated_at\""},
-	DeletedAt: whereHelpernull_Time{field: "\"contract_templates\".\"deleted_at\""},
+	ID:                      whereHelperstring{field: "\"contract_templates\".\"id\""},
+	Name:                    whereHelperstring{field: "\"contract_templates\".\"name\""},
+	CompanyID:               whereHelperstring{field: "\"contract_templates\".\"company_id\""},
+	Template:                whereHelperstring{field: "\"contract_templates\".\"template\""},
+	CreatedAt:               whereHelpertime_Time{field: "\"contract_templates\".\"created_at\""},
+	UpdatedAt:               whereHelpertime_Time{field: "\"contract_templates\".\"updated_at\""},
+	DeletedAt:               whereHelpernull_Time{field: "\"contract_templates\".\"deleted_at\""},
+	Language:                whereHelperstring{field: "\"contract_templates\".\"language\""},
+	Direction:               whereHelperstring{field: "\"contract_templates\".\"direction\""},
+	TranslationGroupID:      whereHelpernull_String{field: "\"contract_templates\".\"translation_group_id\""},
+	ArgumentsSchema:         whereHelpertypes_JSON{field: "\"contract_templates\".\"arguments_schema\""},
+	SourceGalleryTemplateID: whereHelpernull_String{field: "\"contract_templates\".\"source_gallery_template_id\""},
+	SourceVersion:           whereHelpernull_Int{field: "\"contract_templates\".\"source_version\""},
+	DeletedBy:               whereHelpernull_String{field: "\"contract_templates\".\"deleted_by\""},
+	DeletionID:              whereHelpernull_String{field: "\"contract_templates\".\"deletion_id\""},
 }
 
 // ContractTemplateRels is where relationship names are stored.
 var ContractTemplateRels = struct {
-	Company string
-	Offers  string
+	Company               string
+	SourceGalleryTemplate string
+	Offers                string
 }{
-	Company: "Company",
-	Offers:  "Offers",
+	Company:               "Company",
+	SourceGalleryTemplate: "SourceGalleryTemplate",
+	Offers:                "Offers",
 }
 
 // contractTemplateR is where relationships are stored.
 type contractTemplateR struct {
-	Company *Company   `boil:"Company" json:"Company" toml:"Company" yaml:"Company"`
-	Offers  OfferSlice `boil:"Offers" json:"Offers" toml:"Offers" yaml:"Offers"`
+	Company               *Company         `boil:"Company" json:"Company" toml:"Company" yaml:"Company"`
+	SourceGalleryTemplate *GalleryTemplate `boil:"SourceGalleryTemplate" json:"SourceGalleryTemplate" toml:"SourceGalleryTemplate" yaml:"SourceGalleryTemplate"`
+	Offers                OfferSlice       `boil:"Offers" json:"Offers" toml:"Offers" yaml:"Offers"`
 }
 
 // NewStruct creates a new relationship struct
@@ -119,6 +217,13 @@ func (r *contractTemplateR) GetCompany() *Company {
 	return r.Company
 }
 
+func (r *contractTemplateR) GetSourceGalleryTemplate() *GalleryTemplate {
+	if r == nil {
+		return nil
+	}
+	return r.SourceGalleryTemplate
+}
+
 func (r *contractTemplateR) GetOffers() OfferSlice {
 	if r == nil {
 		return nil
@@ -130,9 +235,9 @@ func (r *contractTemplateR) GetOffers() OfferSlice {
 type contractTemplateL struct{}
 
 var (
-	contractTemplateAllColumns            = []string{"id", "name", "company_id", "template", "created_at", "updated_at", "deleted_at"}
+	contractTemplateAllColumns            = []string{"id", "name", "company_id", "template", "created_at", "updated_at", "deleted_at", "language", "direction", "translation_group_id", "arguments_schema", "source_gallery_template_id", "source_version", "deleted_by", "deletion_id"}
 	contractTemplateColumnsWithoutDefault = []string{"id", "name", "company_id", "template", "created_at", "updated_at"}
-	contractTemplateColumnsWithDefault    = []string{"deleted_at"}
+	contractTemplateColumnsWithDefault    = []string{"deleted_at", "language", "direction", "translation_group_id", "arguments_schema", "source_gallery_template_id", "source_version", "deleted_by", "deletion_id"}
 	contractTemplatePrimaryKeyColumns     = []string{"id"}
 	contractTemplateGeneratedColumns      = []string{}
 )
@@ -239,6 +344,17 @@ func (o *ContractTemplate) Company(mods ...qm.QueryMod) companyQuery {
 	return Companies(queryMods...)
 }
 
+// SourceGalleryTemplate pointed to by the foreign key.
+func (o *ContractTemplate) SourceGalleryTemplate(mods ...qm.QueryMod) galleryTemplateQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.SourceGalleryTemplateID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return GalleryTemplates(queryMods...)
+}
+
 // Offers retrieves all the offer's Offers with an executor.
 func (o *ContractTemplate) Offers(mods ...qm.QueryMod) offerQuery {
 	var queryMods []qm.QueryMod
@@ -365,6 +481,122 @@ func (contractTemplateL) LoadCompany(ctx context.Context, e boil.ContextExecutor
 	return nil
 }
 
+// LoadSourceGalleryTemplate allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (contractTemplateL) LoadSourceGalleryTemplate(ctx context.Context, e boil.ContextExecutor, singular bool, maybeContractTemplate interface{}, mods queries.Applicator) error {
+	var slice []*ContractTemplate
+	var object *ContractTemplate
+
+	if singular {
+		var ok bool
+		object, ok = maybeContractTemplate.(*ContractTemplate)
+		if !ok {
+			object = new(ContractTemplate)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeContractTemplate)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeContractTemplate))
+			}
+		}
+	} else {
+		s, ok := maybeContractTemplate.(*[]*ContractTemplate)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeContractTemplate)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeContractTemplate))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &contractTemplateR{}
+		}
+		if !queries.IsNil(object.SourceGalleryTemplateID) {
+			args[object.SourceGalleryTemplateID] = struct{}{}
+		}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &contractTemplateR{}
+			}
+
+			if !queries.IsNil(obj.SourceGalleryTemplateID) {
+				args[obj.SourceGalleryTemplateID] = struct{}{}
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`gallery_templates`),
+		qm.WhereIn(`gallery_templates.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load GalleryTemplate")
+	}
+
+	var resultSlice []*GalleryTemplate
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice GalleryTemplate")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for gallery_templates")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for gallery_templates")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.SourceGalleryTemplate = foreign
+		if foreign.R == nil {
+			foreign.R = &galleryTemplateR{}
+		}
+		foreign.R.SourceGalleryTemplateContractTemplates = append(foreign.R.SourceGalleryTemplateContractTemplates, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.SourceGalleryTemplateID, foreign.ID) {
+				local.R.SourceGalleryTemplate = foreign
+				if foreign.R == nil {
+					foreign.R = &galleryTemplateR{}
+				}
+				foreign.R.SourceGalleryTemplateContractTemplates = append(foreign.R.SourceGalleryTemplateContractTemplates, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadOffers allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (contractTemplateL) LoadOffers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeContractTemplate interface{}, mods queries.Applicator) error {
@@ -518,6 +750,86 @@ func (o *ContractTemplate) SetCompany(ctx context.Context, exec boil.ContextExec
 	return nil
 }
 
+// SetSourceGalleryTemplate of the contractTemplate to the related item.
+// Sets o.R.SourceGalleryTemplate to related.
+// Adds o to related.R.SourceGalleryTemplateContractTemplates.
+func (o *ContractTemplate) SetSourceGalleryTemplate(ctx context.Context, exec boil.ContextExecutor, insert bool, related *GalleryTemplate) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"contract_templates\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"source_gallery_template_id"}),
+		strmangle.WhereClause("\"", "\"", 2, contractTemplatePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.SourceGalleryTemplateID, related.ID)
+	if o.R == nil {
+		o.R = &contractTemplateR{
+			SourceGalleryTemplate: related,
+		}
+	} else {
+		o.R.SourceGalleryTemplate = related
+	}
+
+	if related.R == nil {
+		related.R = &galleryTemplateR{
+			SourceGalleryTemplateContractTemplates: ContractTemplateSlice{o},
+		}
+	} else {
+		related.R.SourceGalleryTemplateContractTemplates = append(related.R.SourceGalleryTemplateContractTemplates, o)
+	}
+
+	return nil
+}
+
+// RemoveSourceGalleryTemplate relationship.
+// Sets o.R.SourceGalleryTemplate to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *ContractTemplate) RemoveSourceGalleryTemplate(ctx context.Context, exec boil.ContextExecutor, related *GalleryTemplate) error {
+	var err error
+
+	queries.SetScanner(&o.SourceGalleryTemplateID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("source_gallery_template_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.SourceGalleryTemplate = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.SourceGalleryTemplateContractTemplates {
+		if queries.Equal(o.SourceGalleryTemplateID, ri.SourceGalleryTemplateID) {
+			continue
+		}
+
+		ln := len(related.R.SourceGalleryTemplateContractTemplates)
+		if ln > 1 && i < ln-1 {
+			related.R.SourceGalleryTemplateContractTemplates[i] = related.R.SourceGalleryTemplateContractTemplates[ln-1]
+		}
+		related.R.SourceGalleryTemplateContractTemplates = related.R.SourceGalleryTemplateContractTemplates[:ln-1]
+		break
+	}
+	return nil
+}
+
 // AddOffers adds the given related objects to the existing relationships
 // of the contract_template, optionally inserting them as new records.
 // Appends related to o.R.Offers.
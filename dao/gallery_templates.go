@@ -0,0 +1,1216 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// GalleryTemplate is an object representing the database table.
+type GalleryTemplate struct {
+	ID              string     `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Name            string     `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Description     string     `boil:"description" json:"description" toml:"description" yaml:"description"`
+	Template        string     `boil:"template" json:"template" toml:"template" yaml:"template"`
+	Language        string     `boil:"language" json:"language" toml:"language" yaml:"language"`
+	Direction       string     `boil:"direction" json:"direction" toml:"direction" yaml:"direction"`
+	ArgumentsSchema types.JSON `boil:"arguments_schema" json:"arguments_schema" toml:"arguments_schema" yaml:"arguments_schema"`
+	Categories      types.JSON `boil:"categories" json:"categories" toml:"categories" yaml:"categories"`
+	Version         int        `boil:"version" json:"version" toml:"version" yaml:"version"`
+	PublishedBy     string     `boil:"published_by" json:"published_by" toml:"published_by" yaml:"published_by"`
+	CreatedAt       time.Time  `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt       time.Time  `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	DeletedAt       null.Time  `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+
+	R *galleryTemplateR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L galleryTemplateL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var GalleryTemplateColumns = struct {
+	ID              string
+	Name            string
+	Description     string
+	Template        string
+	Language        string
+	Direction       string
+	ArgumentsSchema string
+	Categories      string
+	Version         string
+	PublishedBy     string
+	CreatedAt       string
+	UpdatedAt       string
+	DeletedAt       string
+}{
+	ID:              "id",
+	Name:            "name",
+	Description:     "description",
+	Template:        "template",
+	Language:        "language",
+	Direction:       "direction",
+	ArgumentsSchema: "arguments_schema",
+	Categories:      "categories",
+	Version:         "version",
+	PublishedBy:     "published_by",
+	CreatedAt:       "created_at",
+	UpdatedAt:       "updated_at",
+	DeletedAt:       "deleted_at",
+}
+
+var GalleryTemplateTableColumns = struct {
+	ID              string
+	Name            string
+	Description     string
+	Template        string
+	Language        string
+	Direction       string
+	ArgumentsSchema string
+	Categories      string
+	Version         string
+	PublishedBy     string
+	CreatedAt       string
+	UpdatedAt       string
+	DeletedAt       string
+}{
+	ID:              "gallery_templates.id",
+	Name:            "gallery_templates.name",
+	Description:     "gallery_templates.description",
+	Template:        "gallery_templates.template",
+	Language:        "gallery_templates.language",
+	Direction:       "gallery_templates.direction",
+	ArgumentsSchema: "gallery_templates.arguments_schema",
+	Categories:      "gallery_templates.categories",
+	Version:         "gallery_templates.version",
+	PublishedBy:     "gallery_templates.published_by",
+	CreatedAt:       "gallery_templates.created_at",
+	UpdatedAt:       "gallery_templates.updated_at",
+	DeletedAt:       "gallery_templates.deleted_at",
+}
+
+// Generated where
+
+var GalleryTemplateWhere = struct {
+	ID              whereHelperstring
+	Name            whereHelperstring
+	Description     whereHelperstring
+	Template        whereHelperstring
+	Language        whereHelperstring
+	Direction       whereHelperstring
+	ArgumentsSchema whereHelpertypes_JSON
+	Categories      whereHelpertypes_JSON
+	Version         whereHelperint
+	PublishedBy     whereHelperstring
+	CreatedAt       whereHelpertime_Time
+	UpdatedAt       whereHelpertime_Time
+	DeletedAt       whereHelpernull_Time
+}{
+	ID:              whereHelperstring{field: "\"gallery_templates\".\"id\""},
+	Name:            whereHelperstring{field: "\"gallery_templates\".\"name\""},
+	Description:     whereHelperstring{field: "\"gallery_templates\".\"description\""},
+	Template:        whereHelperstring{field: "\"gallery_templates\".\"template\""},
+	Language:        whereHelperstring{field: "\"gallery_templates\".\"language\""},
+	Direction:       whereHelperstring{field: "\"gallery_templates\".\"direction\""},
+	ArgumentsSchema: whereHelpertypes_JSON{field: "\"gallery_templates\".\"arguments_schema\""},
+	Categories:      whereHelpertypes_JSON{field: "\"gallery_templates\".\"categories\""},
+	Version:         whereHelperint{field: "\"gallery_templates\".\"version\""},
+	PublishedBy:     whereHelperstring{field: "\"gallery_templates\".\"published_by\""},
+	CreatedAt:       whereHelpertime_Time{field: "\"gallery_templates\".\"created_at\""},
+	UpdatedAt:       whereHelpertime_Time{field: "\"gallery_templates\".\"updated_at\""},
+	DeletedAt:       whereHelpernull_Time{field: "\"gallery_templates\".\"deleted_at\""},
+}
+
+// GalleryTemplateRels is where relationship names are stored.
+var GalleryTemplateRels = struct {
+	PublishedByUser                        string
+	SourceGalleryTemplateContractTemplates string
+}{
+	PublishedByUser:                        "PublishedByUser",
+	SourceGalleryTemplateContractTemplates: "SourceGalleryTemplateContractTemplates",
+}
+
+// galleryTemplateR is where relationships are stored.
+type galleryTemplateR struct {
+	PublishedByUser                        *User                 `boil:"PublishedByUser" json:"PublishedByUser" toml:"PublishedByUser" yaml:"PublishedByUser"`
+	SourceGalleryTemplateContractTemplates ContractTemplateSlice `boil:"SourceGalleryTemplateContractTemplates" json:"SourceGalleryTemplateContractTemplates" toml:"SourceGalleryTemplateContractTemplates" yaml:"SourceGalleryTemplateContractTemplates"`
+}
+
+// NewStruct creates a new relationship struct
+func (*galleryTemplateR) NewStruct() *galleryTemplateR {
+	return &galleryTemplateR{}
+}
+
+func (r *galleryTemplateR) GetPublishedByUser() *User {
+	if r == nil {
+		return nil
+	}
+	return r.PublishedByUser
+}
+
+func (r *galleryTemplateR) GetSourceGalleryTemplateContractTemplates() ContractTemplateSlice {
+	if r == nil {
+		return nil
+	}
+	return r.SourceGalleryTemplateContractTemplates
+}
+
+// galleryTemplateL is where Load methods for each relationship are stored.
+type galleryTemplateL struct{}
+
+var (
+	galleryTemplateAllColumns            = []string{"id", "name", "description", "template", "language", "direction", "arguments_schema", "categories", "version", "published_by", "created_at", "updated_at", "deleted_at"}
+	galleryTemplateColumnsWithoutDefault = []string{"id", "name", "description", "template", "published_by", "created_at", "updated_at"}
+	galleryTemplateColumnsWithDefault    = []string{"language", "direction", "arguments_schema", "categories", "version", "deleted_at"}
+	galleryTemplatePrimaryKeyColumns     = []string{"id"}
+	galleryTemplateGeneratedColumns      = []string{}
+)
+
+type (
+	// GalleryTemplateSlice is an alias for a slice of pointers to GalleryTemplate.
+	// This should almost always be used instead of []GalleryTemplate.
+	GalleryTemplateSlice []*GalleryTemplate
+
+	galleryTemplateQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	galleryTemplateType                 = reflect.TypeOf(&GalleryTemplate{})
+	galleryTemplateMapping              = queries.MakeStructMapping(galleryTemplateType)
+	galleryTemplatePrimaryKeyMapping, _ = queries.BindMapping(galleryTemplateType, galleryTemplateMapping, galleryTemplatePrimaryKeyColumns)
+	galleryTemplateInsertCacheMut       sync.RWMutex
+	galleryTemplateInsertCache          = make(map[string]insertCache)
+	galleryTemplateUpdateCacheMut       sync.RWMutex
+	galleryTemplateUpdateCache          = make(map[string]updateCache)
+	galleryTemplateUpsertCacheMut       sync.RWMutex
+	galleryTemplateUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single galleryTemplate record from the query.
+func (q galleryTemplateQuery) One(ctx context.Context, exec boil.ContextExecutor) (*GalleryTemplate, error) {
+	o := &GalleryTemplate{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dao: failed to execute a one query for gallery_templates")
+	}
+
+	return o, nil
+}
+
+// All returns all GalleryTemplate records from the query.
+func (q galleryTemplateQuery) All(ctx context.Context, exec boil.ContextExecutor) (GalleryTemplateSlice, error) {
+	var o []*GalleryTemplate
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "dao: failed to assign all query results to GalleryTemplate slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all GalleryTemplate records in the query.
+func (q galleryTemplateQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to count gallery_templates rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q galleryTemplateQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "dao: failed to check if gallery_templates exists")
+	}
+
+	return count > 0, nil
+}
+
+// PublishedByUser pointed to by the foreign key.
+func (o *GalleryTemplate) PublishedByUser(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.PublishedBy),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Users(queryMods...)
+}
+
+// SourceGalleryTemplateContractTemplates retrieves all the contract_template's ContractTemplates with an executor via source_gallery_template_id column.
+func (o *GalleryTemplate) SourceGalleryTemplateContractTemplates(mods ...qm.QueryMod) contractTemplateQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"contract_templates\".\"source_gallery_template_id\"=?", o.ID),
+	)
+
+	return ContractTemplates(queryMods...)
+}
+
+// LoadPublishedByUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (galleryTemplateL) LoadPublishedByUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeGalleryTemplate interface{}, mods queries.Applicator) error {
+	var slice []*GalleryTemplate
+	var object *GalleryTemplate
+
+	if singular {
+		var ok bool
+		object, ok = maybeGalleryTemplate.(*GalleryTemplate)
+		if !ok {
+			object = new(GalleryTemplate)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeGalleryTemplate)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeGalleryTemplate))
+			}
+		}
+	} else {
+		s, ok := maybeGalleryTemplate.(*[]*GalleryTemplate)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeGalleryTemplate)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeGalleryTemplate))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &galleryTemplateR{}
+		}
+		args[object.PublishedBy] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &galleryTemplateR{}
+			}
+
+			args[obj.PublishedBy] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`users`),
+		qm.WhereIn(`users.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.PublishedByUser = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.PublishedByGalleryTemplates = append(foreign.R.PublishedByGalleryTemplates, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.PublishedBy == foreign.ID {
+				local.R.PublishedByUser = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.PublishedByGalleryTemplates = append(foreign.R.PublishedByGalleryTemplates, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadSourceGalleryTemplateContractTemplates allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (galleryTemplateL) LoadSourceGalleryTemplateContractTemplates(ctx context.Context, e boil.ContextExecutor, singular bool, maybeGalleryTemplate interface{}, mods queries.Applicator) error {
+	var slice []*GalleryTemplate
+	var object *GalleryTemplate
+
+	if singular {
+		var ok bool
+		object, ok = maybeGalleryTemplate.(*GalleryTemplate)
+		if !ok {
+			object = new(GalleryTemplate)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeGalleryTemplate)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeGalleryTemplate))
+			}
+		}
+	} else {
+		s, ok := maybeGalleryTemplate.(*[]*GalleryTemplate)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeGalleryTemplate)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeGalleryTemplate))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &galleryTemplateR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &galleryTemplateR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`contract_templates`),
+		qm.WhereIn(`contract_templates.source_gallery_template_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load contract_templates")
+	}
+
+	var resultSlice []*ContractTemplate
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice contract_templates")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on contract_templates")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for contract_templates")
+	}
+
+	if singular {
+		object.R.SourceGalleryTemplateContractTemplates = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &contractTemplateR{}
+			}
+			foreign.R.SourceGalleryTemplate = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.SourceGalleryTemplateID) {
+				local.R.SourceGalleryTemplateContractTemplates = append(local.R.SourceGalleryTemplateContractTemplates, foreign)
+				if foreign.R == nil {
+					foreign.R = &contractTemplateR{}
+				}
+				foreign.R.SourceGalleryTemplate = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetPublishedByUser of the galleryTemplate to the related item.
+// Sets o.R.PublishedByUser to related.
+// Adds o to related.R.PublishedByGalleryTemplates.
+func (o *GalleryTemplate) SetPublishedByUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"gallery_templates\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"published_by"}),
+		strmangle.WhereClause("\"", "\"", 2, galleryTemplatePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.PublishedBy = related.ID
+	if o.R == nil {
+		o.R = &galleryTemplateR{
+			PublishedByUser: related,
+		}
+	} else {
+		o.R.PublishedByUser = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			PublishedByGalleryTemplates: GalleryTemplateSlice{o},
+		}
+	} else {
+		related.R.PublishedByGalleryTemplates = append(related.R.PublishedByGalleryTemplates, o)
+	}
+
+	return nil
+}
+
+// AddSourceGalleryTemplateContractTemplates adds the given related objects to the existing relationships
+// of the gallery_template, optionally inserting them as new records.
+// Appends related to o.R.SourceGalleryTemplateContractTemplates.
+// Sets related.R.SourceGalleryTemplate appropriately.
+func (o *GalleryTemplate) AddSourceGalleryTemplateContractTemplates(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*ContractTemplate) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.SourceGalleryTemplateID, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"contract_templates\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"source_gallery_template_id"}),
+				strmangle.WhereClause("\"", "\"", 2, contractTemplatePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.SourceGalleryTemplateID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &galleryTemplateR{
+			SourceGalleryTemplateContractTemplates: related,
+		}
+	} else {
+		o.R.SourceGalleryTemplateContractTemplates = append(o.R.SourceGalleryTemplateContractTemplates, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &contractTemplateR{
+				SourceGalleryTemplate: o,
+			}
+		} else {
+			rel.R.SourceGalleryTemplate = o
+		}
+	}
+	return nil
+}
+
+// SetSourceGalleryTemplateContractTemplates removes all previously related items of the
+// gallery_template replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.SourceGalleryTemplate's SourceGalleryTemplateContractTemplates accordingly.
+// Replaces o.R.SourceGalleryTemplateContractTemplates with related.
+// Sets related.R.SourceGalleryTemplate's SourceGalleryTemplateContractTemplates accordingly.
+func (o *GalleryTemplate) SetSourceGalleryTemplateContractTemplates(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*ContractTemplate) error {
+	query := "update \"contract_templates\" set \"source_gallery_template_id\" = null where \"source_gallery_template_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.SourceGalleryTemplateContractTemplates {
+			queries.SetScanner(&rel.SourceGalleryTemplateID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.SourceGalleryTemplate = nil
+		}
+		o.R.SourceGalleryTemplateContractTemplates = nil
+	}
+
+	return o.AddSourceGalleryTemplateContractTemplates(ctx, exec, insert, related...)
+}
+
+// RemoveSourceGalleryTemplateContractTemplates relationships from objects passed in.
+// Removes related items from R.SourceGalleryTemplateContractTemplates (uses pointer comparison, removal does not keep order)
+// Sets related.R.SourceGalleryTemplate.
+func (o *GalleryTemplate) RemoveSourceGalleryTemplateContractTemplates(ctx context.Context, exec boil.ContextExecutor, related ...*ContractTemplate) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.SourceGalleryTemplateID, nil)
+		if rel.R != nil {
+			rel.R.SourceGalleryTemplate = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("source_gallery_template_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.SourceGalleryTemplateContractTemplates {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.SourceGalleryTemplateContractTemplates)
+			if ln > 1 && i < ln-1 {
+				o.R.SourceGalleryTemplateContractTemplates[i] = o.R.SourceGalleryTemplateContractTemplates[ln-1]
+			}
+			o.R.SourceGalleryTemplateContractTemplates = o.R.SourceGalleryTemplateContractTemplates[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// GalleryTemplates retrieves all the records using an executor.
+func GalleryTemplates(mods ...qm.QueryMod) galleryTemplateQuery {
+	mods = append(mods, qm.From("\"gallery_templates\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"gallery_templates\".*"})
+	}
+
+	return galleryTemplateQuery{q}
+}
+
+// FindGalleryTemplate retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindGalleryTemplate(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*GalleryTemplate, error) {
+	galleryTemplateObj := &GalleryTemplate{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"gallery_templates\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, galleryTemplateObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dao: unable to select from gallery_templates")
+	}
+
+	return galleryTemplateObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *GalleryTemplate) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("dao: no gallery_templates provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(galleryTemplateColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	galleryTemplateInsertCacheMut.RLock()
+	cache, cached := galleryTemplateInsertCache[key]
+	galleryTemplateInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			galleryTemplateAllColumns,
+			galleryTemplateColumnsWithDefault,
+			galleryTemplateColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(galleryTemplateType, galleryTemplateMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(galleryTemplateType, galleryTemplateMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"gallery_templates\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"gallery_templates\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to insert into gallery_templates")
+	}
+
+	if !cached {
+		galleryTemplateInsertCacheMut.Lock()
+		galleryTemplateInsertCache[key] = cache
+		galleryTemplateInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the GalleryTemplate.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *GalleryTemplate) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	key := makeCacheKey(columns, nil)
+	galleryTemplateUpdateCacheMut.RLock()
+	cache, cached := galleryTemplateUpdateCache[key]
+	galleryTemplateUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			galleryTemplateAllColumns,
+			galleryTemplatePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("dao: unable to update gallery_templates, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"gallery_templates\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, galleryTemplatePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(galleryTemplateType, galleryTemplateMapping, append(wl, galleryTemplatePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update gallery_templates row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by update for gallery_templates")
+	}
+
+	if !cached {
+		galleryTemplateUpdateCacheMut.Lock()
+		galleryTemplateUpdateCache[key] = cache
+		galleryTemplateUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q galleryTemplateQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update all for gallery_templates")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to retrieve rows affected for gallery_templates")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o GalleryTemplateSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("dao: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), galleryTemplatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"gallery_templates\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, galleryTemplatePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update all in galleryTemplate slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to retrieve rows affected all in update all galleryTemplate")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *GalleryTemplate) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("dao: no gallery_templates provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		o.UpdatedAt = currTime
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(galleryTemplateColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	galleryTemplateUpsertCacheMut.RLock()
+	cache, cached := galleryTemplateUpsertCache[key]
+	galleryTemplateUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			galleryTemplateAllColumns,
+			galleryTemplateColumnsWithDefault,
+			galleryTemplateColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			galleryTemplateAllColumns,
+			galleryTemplatePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("dao: unable to upsert gallery_templates, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(galleryTemplateAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(galleryTemplatePrimaryKeyColumns) == 0 {
+				return errors.New("dao: unable to upsert gallery_templates, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(galleryTemplatePrimaryKeyColumns))
+			copy(conflict, galleryTemplatePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"gallery_templates\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(galleryTemplateType, galleryTemplateMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(galleryTemplateType, galleryTemplateMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to upsert gallery_templates")
+	}
+
+	if !cached {
+		galleryTemplateUpsertCacheMut.Lock()
+		galleryTemplateUpsertCache[key] = cache
+		galleryTemplateUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single GalleryTemplate record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *GalleryTemplate) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("dao: no GalleryTemplate provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), galleryTemplatePrimaryKeyMapping)
+	sql := "DELETE FROM \"gallery_templates\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete from gallery_templates")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by delete for gallery_templates")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q galleryTemplateQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("dao: no galleryTemplateQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete all from gallery_templates")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by deleteall for gallery_templates")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o GalleryTemplateSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), galleryTemplatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"gallery_templates\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, galleryTemplatePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete all from galleryTemplate slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by deleteall for gallery_templates")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *GalleryTemplate) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindGalleryTemplate(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *GalleryTemplateSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := GalleryTemplateSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), galleryTemplatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"gallery_templates\".* FROM \"gallery_templates\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, galleryTemplatePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to reload all in GalleryTemplateSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// GalleryTemplateExists checks if the GalleryTemplate row exists.
+func GalleryTemplateExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"gallery_templates\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "dao: unable to check if gallery_templates exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the GalleryTemplate row exists.
+func (o *GalleryTemplate) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return GalleryTemplateExists(ctx, exec, o.ID)
+}
@@ -0,0 +1,1324 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// IdentityProvider is an object representing the database table.
+type IdentityProvider struct {
+	ID           string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CompanyID    string      `boil:"company_id" json:"company_id" toml:"company_id" yaml:"company_id"`
+	Name         string      `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Issuer       string      `boil:"issuer" json:"issuer" toml:"issuer" yaml:"issuer"`
+	ClientID     string      `boil:"client_id" json:"client_id" toml:"client_id" yaml:"client_id"`
+	ClientSecret string      `boil:"client_secret" json:"client_secret" toml:"client_secret" yaml:"client_secret"`
+	Scopes       string      `boil:"scopes" json:"scopes" toml:"scopes" yaml:"scopes"`
+	GroupsClaim  string      `boil:"groups_claim" json:"groups_claim" toml:"groups_claim" yaml:"groups_claim"`
+	GroupRoles   string      `boil:"group_roles" json:"group_roles" toml:"group_roles" yaml:"group_roles"`
+	DefaultRole  null.String `boil:"default_role" json:"default_role,omitempty" toml:"default_role" yaml:"default_role,omitempty"`
+	Enabled      bool        `boil:"enabled" json:"enabled" toml:"enabled" yaml:"enabled"`
+	CreatedAt    time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt    time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+
+	R *identityProviderR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L identityProviderL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var IdentityProviderColumns = struct {
+	ID           string
+	CompanyID    string
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       string
+	GroupsClaim  string
+	GroupRoles   string
+	DefaultRole  string
+	Enabled      string
+	CreatedAt    string
+	UpdatedAt    string
+}{
+	ID:           "id",
+	CompanyID:    "company_id",
+	Name:         "name",
+	Issuer:       "issuer",
+	ClientID:     "client_id",
+	ClientSecret: "client_secret",
+	Scopes:       "scopes",
+	GroupsClaim:  "groups_claim",
+	GroupRoles:   "group_roles",
+	DefaultRole:  "default_role",
+	Enabled:      "enabled",
+	CreatedAt:    "created_at",
+	UpdatedAt:    "updated_at",
+}
+
+var IdentityProviderTableColumns = struct {
+	ID           string
+	CompanyID    string
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       string
+	GroupsClaim  string
+	GroupRoles   string
+	DefaultRole  string
+	Enabled      string
+	CreatedAt    string
+	UpdatedAt    string
+}{
+	ID:           "identity_providers.id",
+	CompanyID:    "identity_providers.company_id",
+	Name:         "identity_providers.name",
+	Issuer:       "identity_providers.issuer",
+	ClientID:     "identity_providers.client_id",
+	ClientSecret: "identity_providers.client_secret",
+	Scopes:       "identity_providers.scopes",
+	GroupsClaim:  "identity_providers.groups_claim",
+	GroupRoles:   "identity_providers.group_roles",
+	DefaultRole:  "identity_providers.default_role",
+	Enabled:      "identity_providers.enabled",
+	CreatedAt:    "identity_providers.created_at",
+	UpdatedAt:    "identity_providers.updated_at",
+}
+
+// Generated where
+
+var IdentityProviderWhere = struct {
+	ID           whereHelperstring
+	CompanyID    whereHelperstring
+	Name         whereHelperstring
+	Issuer       whereHelperstring
+	ClientID     whereHelperstring
+	ClientSecret whereHelperstring
+	Scopes       whereHelperstring
+	GroupsClaim  whereHelperstring
+	GroupRoles   whereHelperstring
+	DefaultRole  whereHelpernull_String
+	Enabled      whereHelperbool
+	CreatedAt    whereHelpertime_Time
+	UpdatedAt    whereHelpertime_Time
+}{
+	ID:           whereHelperstring{field: "\"identity_providers\".\"id\""},
+	CompanyID:    whereHelperstring{field: "\"identity_providers\".\"company_id\""},
+	Name:         whereHelperstring{field: "\"identity_providers\".\"name\""},
+	Issuer:       whereHelperstring{field: "\"identity_providers\".\"issuer\""},
+	ClientID:     whereHelperstring{field: "\"identity_providers\".\"client_id\""},
+	ClientSecret: whereHelperstring{field: "\"identity_providers\".\"client_secret\""},
+	Scopes:       whereHelperstring{field: "\"identity_providers\".\"scopes\""},
+	GroupsClaim:  whereHelperstring{field: "\"identity_providers\".\"groups_claim\""},
+	GroupRoles:   whereHelperstring{field: "\"identity_providers\".\"group_roles\""},
+	DefaultRole:  whereHelpernull_String{field: "\"identity_providers\".\"default_role\""},
+	Enabled:      whereHelperbool{field: "\"identity_providers\".\"enabled\""},
+	CreatedAt:    whereHelpertime_Time{field: "\"identity_providers\".\"created_at\""},
+	UpdatedAt:    whereHelpertime_Time{field: "\"identity_providers\".\"updated_at\""},
+}
+
+// IdentityProviderRels is where relationship names are stored.
+var IdentityProviderRels = struct {
+	Company                 string
+	ProviderOidcLoginStates string
+	ProviderUserIdentities  string
+}{
+	Company:                 "Company",
+	ProviderOidcLoginStates: "ProviderOidcLoginStates",
+	ProviderUserIdentities:  "ProviderUserIdentities",
+}
+
+// identityProviderR is where relationships are stored.
+type identityProviderR struct {
+	Company                 *Company            `boil:"Company" json:"Company" toml:"Company" yaml:"Company"`
+	ProviderOidcLoginStates OidcLoginStateSlice `boil:"ProviderOidcLoginStates" json:"ProviderOidcLoginStates" toml:"ProviderOidcLoginStates" yaml:"ProviderOidcLoginStates"`
+	ProviderUserIdentities  UserIdentitySlice   `boil:"ProviderUserIdentities" json:"ProviderUserIdentities" toml:"ProviderUserIdentities" yaml:"ProviderUserIdentities"`
+}
+
+// NewStruct creates a new relationship struct
+func (*identityProviderR) NewStruct() *identityProviderR {
+	return &identityProviderR{}
+}
+
+func (r *identityProviderR) GetCompany() *Company {
+	if r == nil {
+		return nil
+	}
+	return r.Company
+}
+
+func (r *identityProviderR) GetProviderOidcLoginStates() OidcLoginStateSlice {
+	if r == nil {
+		return nil
+	}
+	return r.ProviderOidcLoginStates
+}
+
+func (r *identityProviderR) GetProviderUserIdentities() UserIdentitySlice {
+	if r == nil {
+		return nil
+	}
+	return r.ProviderUserIdentities
+}
+
+// identityProviderL is where Load methods for each relationship are stored.
+type identityProviderL struct{}
+
+var (
+	identityProviderAllColumns            = []string{"id", "company_id", "name", "issuer", "client_id", "client_secret", "scopes", "groups_claim", "group_roles", "default_role", "enabled", "created_at", "updated_at"}
+	identityProviderColumnsWithoutDefault = []string{"id", "company_id", "name", "issuer", "client_id", "client_secret", "scopes", "groups_claim", "group_roles", "created_at", "updated_at"}
+	identityProviderColumnsWithDefault    = []string{"default_role", "enabled"}
+	identityProviderPrimaryKeyColumns     = []string{"id"}
+	identityProviderGeneratedColumns      = []string{}
+)
+
+type (
+	// IdentityProviderSlice is an alias for a slice of pointers to IdentityProvider.
+	// This should almost always be used instead of []IdentityProvider.
+	IdentityProviderSlice []*IdentityProvider
+
+	identityProviderQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	identityProviderType                 = reflect.TypeOf(&IdentityProvider{})
+	identityProviderMapping              = queries.MakeStructMapping(identityProviderType)
+	identityProviderPrimaryKeyMapping, _ = queries.BindMapping(identityProviderType, identityProviderMapping, identityProviderPrimaryKeyColumns)
+	identityProviderInsertCacheMut       sync.RWMutex
+	identityProviderInsertCache          = make(map[string]insertCache)
+	identityProviderUpdateCacheMut       sync.RWMutex
+	identityProviderUpdateCache          = make(map[string]updateCache)
+	identityProviderUpsertCacheMut       sync.RWMutex
+	identityProviderUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single identityProvider record from the query.
+func (q identityProviderQuery) One(ctx context.Context, exec boil.ContextExecutor) (*IdentityProvider, error) {
+	o := &IdentityProvider{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dao: failed to execute a one query for identity_providers")
+	}
+
+	return o, nil
+}
+
+// All returns all IdentityProvider records from the query.
+func (q identityProviderQuery) All(ctx context.Context, exec boil.ContextExecutor) (IdentityProviderSlice, error) {
+	var o []*IdentityProvider
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "dao: failed to assign all query results to IdentityProvider slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all IdentityProvider records in the query.
+func (q identityProviderQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to count identity_providers rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q identityProviderQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "dao: failed to check if identity_providers exists")
+	}
+
+	return count > 0, nil
+}
+
+// Company pointed to by the foreign key.
+func (o *IdentityProvider) Company(mods ...qm.QueryMod) companyQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.CompanyID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Companies(queryMods...)
+}
+
+// ProviderOidcLoginStates retrieves all the oidc_login_state's OidcLoginStates with an executor via provider_id column.
+func (o *IdentityProvider) ProviderOidcLoginStates(mods ...qm.QueryMod) oidcLoginStateQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"oidc_login_states\".\"provider_id\"=?", o.ID),
+	)
+
+	return OidcLoginStates(queryMods...)
+}
+
+// ProviderUserIdentities retrieves all the user_identity's UserIdentities with an executor via provider_id column.
+func (o *IdentityProvider) ProviderUserIdentities(mods ...qm.QueryMod) userIdentityQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"user_identities\".\"provider_id\"=?", o.ID),
+	)
+
+	return UserIdentities(queryMods...)
+}
+
+// LoadCompany allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (identityProviderL) LoadCompany(ctx context.Context, e boil.ContextExecutor, singular bool, maybeIdentityProvider interface{}, mods queries.Applicator) error {
+	var slice []*IdentityProvider
+	var object *IdentityProvider
+
+	if singular {
+		var ok bool
+		object, ok = maybeIdentityProvider.(*IdentityProvider)
+		if !ok {
+			object = new(IdentityProvider)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeIdentityProvider)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeIdentityProvider))
+			}
+		}
+	} else {
+		s, ok := maybeIdentityProvider.(*[]*IdentityProvider)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeIdentityProvider)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeIdentityProvider))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &identityProviderR{}
+		}
+		args[object.CompanyID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &identityProviderR{}
+			}
+
+			args[obj.CompanyID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`companies`),
+		qm.WhereIn(`companies.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Company")
+	}
+
+	var resultSlice []*Company
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Company")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for companies")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for companies")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Company = foreign
+		if foreign.R == nil {
+			foreign.R = &companyR{}
+		}
+		foreign.R.IdentityProviders = append(foreign.R.IdentityProviders, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.CompanyID == foreign.ID {
+				local.R.Company = foreign
+				if foreign.R == nil {
+					foreign.R = &companyR{}
+				}
+				foreign.R.IdentityProviders = append(foreign.R.IdentityProviders, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadProviderOidcLoginStates allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (identityProviderL) LoadProviderOidcLoginStates(ctx context.Context, e boil.ContextExecutor, singular bool, maybeIdentityProvider interface{}, mods queries.Applicator) error {
+	var slice []*IdentityProvider
+	var object *IdentityProvider
+
+	if singular {
+		var ok bool
+		object, ok = maybeIdentityProvider.(*IdentityProvider)
+		if !ok {
+			object = new(IdentityProvider)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeIdentityProvider)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeIdentityProvider))
+			}
+		}
+	} else {
+		s, ok := maybeIdentityProvider.(*[]*IdentityProvider)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeIdentityProvider)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeIdentityProvider))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &identityProviderR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &identityProviderR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`oidc_login_states`),
+		qm.WhereIn(`oidc_login_states.provider_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load oidc_login_states")
+	}
+
+	var resultSlice []*OidcLoginState
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice oidc_login_states")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on oidc_login_states")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for oidc_login_states")
+	}
+
+	if singular {
+		object.R.ProviderOidcLoginStates = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &oidcLoginStateR{}
+			}
+			foreign.R.Provider = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ProviderID {
+				local.R.ProviderOidcLoginStates = append(local.R.ProviderOidcLoginStates, foreign)
+				if foreign.R == nil {
+					foreign.R = &oidcLoginStateR{}
+				}
+				foreign.R.Provider = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadProviderUserIdentities allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (identityProviderL) LoadProviderUserIdentities(ctx context.Context, e boil.ContextExecutor, singular bool, maybeIdentityProvider interface{}, mods queries.Applicator) error {
+	var slice []*IdentityProvider
+	var object *IdentityProvider
+
+	if singular {
+		var ok bool
+		object, ok = maybeIdentityProvider.(*IdentityProvider)
+		if !ok {
+			object = new(IdentityProvider)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeIdentityProvider)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeIdentityProvider))
+			}
+		}
+	} else {
+		s, ok := maybeIdentityProvider.(*[]*IdentityProvider)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeIdentityProvider)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeIdentityProvider))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &identityProviderR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &identityProviderR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`user_identities`),
+		qm.WhereIn(`user_identities.provider_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load user_identities")
+	}
+
+	var resultSlice []*UserIdentity
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice user_identities")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on user_identities")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_identities")
+	}
+
+	if singular {
+		object.R.ProviderUserIdentities = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &userIdentityR{}
+			}
+			foreign.R.Provider = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ProviderID {
+				local.R.ProviderUserIdentities = append(local.R.ProviderUserIdentities, foreign)
+				if foreign.R == nil {
+					foreign.R = &userIdentityR{}
+				}
+				foreign.R.Provider = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetCompany of the identityProvider to the related item.
+// Sets o.R.Company to related.
+// Adds o to related.R.IdentityProviders.
+func (o *IdentityProvider) SetCompany(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Company) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"identity_providers\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"company_id"}),
+		strmangle.WhereClause("\"", "\"", 2, identityProviderPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.CompanyID = related.ID
+	if o.R == nil {
+		o.R = &identityProviderR{
+			Company: related,
+		}
+	} else {
+		o.R.Company = related
+	}
+
+	if related.R == nil {
+		related.R = &companyR{
+			IdentityProviders: IdentityProviderSlice{o},
+		}
+	} else {
+		related.R.IdentityProviders = append(related.R.IdentityProviders, o)
+	}
+
+	return nil
+}
+
+// AddProviderOidcLoginStates adds the given related objects to the existing relationships
+// of the identity_provider, optionally inserting them as new records.
+// Appends related to o.R.ProviderOidcLoginStates.
+// Sets related.R.Provider appropriately.
+func (o *IdentityProvider) AddProviderOidcLoginStates(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*OidcLoginState) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ProviderID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"oidc_login_states\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"provider_id"}),
+				strmangle.WhereClause("\"", "\"", 2, oidcLoginStatePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ProviderID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &identityProviderR{
+			ProviderOidcLoginStates: related,
+		}
+	} else {
+		o.R.ProviderOidcLoginStates = append(o.R.ProviderOidcLoginStates, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &oidcLoginStateR{
+				Provider: o,
+			}
+		} else {
+			rel.R.Provider = o
+		}
+	}
+	return nil
+}
+
+// AddProviderUserIdentities adds the given related objects to the existing relationships
+// of the identity_provider, optionally inserting them as new records.
+// Appends related to o.R.ProviderUserIdentities.
+// Sets related.R.Provider appropriately.
+func (o *IdentityProvider) AddProviderUserIdentities(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*UserIdentity) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ProviderID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"user_identities\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"provider_id"}),
+				strmangle.WhereClause("\"", "\"", 2, userIdentityPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ProviderID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &identityProviderR{
+			ProviderUserIdentities: related,
+		}
+	} else {
+		o.R.ProviderUserIdentities = append(o.R.ProviderUserIdentities, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &userIdentityR{
+				Provider: o,
+			}
+		} else {
+			rel.R.Provider = o
+		}
+	}
+	return nil
+}
+
+// IdentityProviders retrieves all the records using an executor.
+func IdentityProviders(mods ...qm.QueryMod) identityProviderQuery {
+	mods = append(mods, qm.From("\"identity_providers\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"identity_providers\".*"})
+	}
+
+	return identityProviderQuery{q}
+}
+
+// FindIdentityProvider retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindIdentityProvider(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*IdentityProvider, error) {
+	identityProviderObj := &IdentityProvider{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"identity_providers\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, identityProviderObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dao: unable to select from identity_providers")
+	}
+
+	return identityProviderObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *IdentityProvider) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("dao: no identity_providers provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(identityProviderColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	identityProviderInsertCacheMut.RLock()
+	cache, cached := identityProviderInsertCache[key]
+	identityProviderInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			identityProviderAllColumns,
+			identityProviderColumnsWithDefault,
+			identityProviderColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(identityProviderType, identityProviderMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(identityProviderType, identityProviderMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"identity_providers\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"identity_providers\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to insert into identity_providers")
+	}
+
+	if !cached {
+		identityProviderInsertCacheMut.Lock()
+		identityProviderInsertCache[key] = cache
+		identityProviderInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the IdentityProvider.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *IdentityProvider) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	key := makeCacheKey(columns, nil)
+	identityProviderUpdateCacheMut.RLock()
+	cache, cached := identityProviderUpdateCache[key]
+	identityProviderUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			identityProviderAllColumns,
+			identityProviderPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("dao: unable to update identity_providers, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"identity_providers\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, identityProviderPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(identityProviderType, identityProviderMapping, append(wl, identityProviderPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update identity_providers row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by update for identity_providers")
+	}
+
+	if !cached {
+		identityProviderUpdateCacheMut.Lock()
+		identityProviderUpdateCache[key] = cache
+		identityProviderUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q identityProviderQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update all for identity_providers")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to retrieve rows affected for identity_providers")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o IdentityProviderSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("dao: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), identityProviderPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"identity_providers\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, identityProviderPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update all in identityProvider slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to retrieve rows affected all in update all identityProvider")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *IdentityProvider) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("dao: no identity_providers provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		o.UpdatedAt = currTime
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(identityProviderColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	identityProviderUpsertCacheMut.RLock()
+	cache, cached := identityProviderUpsertCache[key]
+	identityProviderUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			identityProviderAllColumns,
+			identityProviderColumnsWithDefault,
+			identityProviderColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			identityProviderAllColumns,
+			identityProviderPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("dao: unable to upsert identity_providers, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(identityProviderAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(identityProviderPrimaryKeyColumns) == 0 {
+				return errors.New("dao: unable to upsert identity_providers, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(identityProviderPrimaryKeyColumns))
+			copy(conflict, identityProviderPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"identity_providers\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(identityProviderType, identityProviderMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(identityProviderType, identityProviderMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to upsert identity_providers")
+	}
+
+	if !cached {
+		identityProviderUpsertCacheMut.Lock()
+		identityProviderUpsertCache[key] = cache
+		identityProviderUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single IdentityProvider record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *IdentityProvider) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("dao: no IdentityProvider provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), identityProviderPrimaryKeyMapping)
+	sql := "DELETE FROM \"identity_providers\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete from identity_providers")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by delete for identity_providers")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q identityProviderQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("dao: no identityProviderQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete all from identity_providers")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by deleteall for identity_providers")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o IdentityProviderSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), identityProviderPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"identity_providers\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, identityProviderPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete all from identityProvider slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by deleteall for identity_providers")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *IdentityProvider) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindIdentityProvider(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *IdentityProviderSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := IdentityProviderSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), identityProviderPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"identity_providers\".* FROM \"identity_providers\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, identityProviderPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to reload all in IdentityProviderSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// IdentityProviderExists checks if the IdentityProvider row exists.
+func IdentityProviderExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"identity_providers\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "dao: unable to check if identity_providers exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the IdentityProvider row exists.
+func (o *IdentityProvider) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return IdentityProviderExists(ctx, exec, o.ID)
+}
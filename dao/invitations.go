@@ -0,0 +1,1362 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// Invitation is an object representing the database table.
+type Invitation struct {
+	ID         string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CompanyID  string      `boil:"company_id" json:"company_id" toml:"company_id" yaml:"company_id"`
+	Email      string      `boil:"email" json:"email" toml:"email" yaml:"email"`
+	EmailHash  string      `boil:"email_hash" json:"email_hash" toml:"email_hash" yaml:"email_hash"`
+	Role       string      `boil:"role" json:"role" toml:"role" yaml:"role"`
+	TokenHash  string      `boil:"token_hash" json:"token_hash" toml:"token_hash" yaml:"token_hash"`
+	InvitedBy  string      `boil:"invited_by" json:"invited_by" toml:"invited_by" yaml:"invited_by"`
+	ExpiresAt  time.Time   `boil:"expires_at" json:"expires_at" toml:"expires_at" yaml:"expires_at"`
+	SentAt     time.Time   `boil:"sent_at" json:"sent_at" toml:"sent_at" yaml:"sent_at"`
+	AcceptedAt null.Time   `boil:"accepted_at" json:"accepted_at,omitempty" toml:"accepted_at" yaml:"accepted_at,omitempty"`
+	AcceptedBy null.String `boil:"accepted_by" json:"accepted_by,omitempty" toml:"accepted_by" yaml:"accepted_by,omitempty"`
+	RevokedAt  null.Time   `boil:"revoked_at" json:"revoked_at,omitempty" toml:"revoked_at" yaml:"revoked_at,omitempty"`
+	CreatedAt  time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt  time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+
+	R *invitationR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L invitationL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var InvitationColumns = struct {
+	ID         string
+	CompanyID  string
+	Email      string
+	EmailHash  string
+	Role       string
+	TokenHash  string
+	InvitedBy  string
+	ExpiresAt  string
+	SentAt     string
+	AcceptedAt string
+	AcceptedBy string
+	RevokedAt  string
+	CreatedAt  string
+	UpdatedAt  string
+}{
+	ID:         "id",
+	CompanyID:  "company_id",
+	Email:      "email",
+	EmailHash:  "email_hash",
+	Role:       "role",
+	TokenHash:  "token_hash",
+	InvitedBy:  "invited_by",
+	ExpiresAt:  "expires_at",
+	SentAt:     "sent_at",
+	AcceptedAt: "accepted_at",
+	AcceptedBy: "accepted_by",
+	RevokedAt:  "revoked_at",
+	CreatedAt:  "created_at",
+	UpdatedAt:  "updated_at",
+}
+
+var InvitationTableColumns = struct {
+	ID         string
+	CompanyID  string
+	Email      string
+	EmailHash  string
+	Role       string
+	TokenHash  string
+	InvitedBy  string
+	ExpiresAt  string
+	SentAt     string
+	AcceptedAt string
+	AcceptedBy string
+	RevokedAt  string
+	CreatedAt  string
+	UpdatedAt  string
+}{
+	ID:         "invitations.id",
+	CompanyID:  "invitations.company_id",
+	Email:      "invitations.email",
+	EmailHash:  "invitations.email_hash",
+	Role:       "invitations.role",
+	TokenHash:  "invitations.token_hash",
+	InvitedBy:  "invitations.invited_by",
+	ExpiresAt:  "invitations.expires_at",
+	SentAt:     "invitations.sent_at",
+	AcceptedAt: "invitations.accepted_at",
+	AcceptedBy: "invitations.accepted_by",
+	RevokedAt:  "invitations.revoked_at",
+	CreatedAt:  "invitations.created_at",
+	UpdatedAt:  "invitations.updated_at",
+}
+
+// Generated where
+
+var InvitationWhere = struct {
+	ID         whereHelperstring
+	CompanyID  whereHelperstring
+	Email      whereHelperstring
+	EmailHash  whereHelperstring
+	Role       whereHelperstring
+	TokenHash  whereHelperstring
+	InvitedBy  whereHelperstring
+	ExpiresAt  whereHelpertime_Time
+	SentAt     whereHelpertime_Time
+	AcceptedAt whereHelpernull_Time
+	AcceptedBy whereHelpernull_String
+	RevokedAt  whereHelpernull_Time
+	CreatedAt  whereHelpertime_Time
+	UpdatedAt  whereHelpertime_Time
+}{
+	ID:         whereHelperstring{field: "\"invitations\".\"id\""},
+	CompanyID:  whereHelperstring{field: "\"invitations\".\"company_id\""},
+	Email:      whereHelperstring{field: "\"invitations\".\"email\""},
+	EmailHash:  whereHelperstring{field: "\"invitations\".\"email_hash\""},
+	Role:       whereHelperstring{field: "\"invitations\".\"role\""},
+	TokenHash:  whereHelperstring{field: "\"invitations\".\"token_hash\""},
+	InvitedBy:  whereHelperstring{field: "\"invitations\".\"invited_by\""},
+	ExpiresAt:  whereHelpertime_Time{field: "\"invitations\".\"expires_at\""},
+	SentAt:     whereHelpertime_Time{field: "\"invitations\".\"sent_at\""},
+	AcceptedAt: whereHelpernull_Time{field: "\"invitations\".\"accepted_at\""},
+	AcceptedBy: whereHelpernull_String{field: "\"invitations\".\"accepted_by\""},
+	RevokedAt:  whereHelpernull_Time{field: "\"invitations\".\"revoked_at\""},
+	CreatedAt:  whereHelpertime_Time{field: "\"invitations\".\"created_at\""},
+	UpdatedAt:  whereHelpertime_Time{field: "\"invitations\".\"updated_at\""},
+}
+
+// InvitationRels is where relationship names are stored.
+var InvitationRels = struct {
+	AcceptedByUser string
+	Company        string
+	InvitedByUser  string
+}{
+	AcceptedByUser: "AcceptedByUser",
+	Company:        "Company",
+	InvitedByUser:  "InvitedByUser",
+}
+
+// invitationR is where relationships are stored.
+type invitationR struct {
+	AcceptedByUser *User    `boil:"AcceptedByUser" json:"AcceptedByUser" toml:"AcceptedByUser" yaml:"AcceptedByUser"`
+	Company        *Company `boil:"Company" json:"Company" toml:"Company" yaml:"Company"`
+	InvitedByUser  *User    `boil:"InvitedByUser" json:"InvitedByUser" toml:"InvitedByUser" yaml:"InvitedByUser"`
+}
+
+// NewStruct creates a new relationship struct
+func (*invitationR) NewStruct() *invitationR {
+	return &invitationR{}
+}
+
+func (r *invitationR) GetAcceptedByUser() *User {
+	if r == nil {
+		return nil
+	}
+	return r.AcceptedByUser
+}
+
+func (r *invitationR) GetCompany() *Company {
+	if r == nil {
+		return nil
+	}
+	return r.Company
+}
+
+func (r *invitationR) GetInvitedByUser() *User {
+	if r == nil {
+		return nil
+	}
+	return r.InvitedByUser
+}
+
+// invitationL is where Load methods for each relationship are stored.
+type invitationL struct{}
+
+var (
+	invitationAllColumns            = []string{"id", "company_id", "email", "email_hash", "role", "token_hash", "invited_by", "expires_at", "sent_at", "accepted_at", "accepted_by", "revoked_at", "created_at", "updated_at"}
+	invitationColumnsWithoutDefault = []string{"id", "company_id", "email", "email_hash", "role", "token_hash", "invited_by", "expires_at", "sent_at", "created_at", "updated_at"}
+	invitationColumnsWithDefault    = []string{"accepted_at", "accepted_by", "revoked_at"}
+	invitationPrimaryKeyColumns     = []string{"id"}
+	invitationGeneratedColumns      = []string{}
+)
+
+type (
+	// InvitationSlice is an alias for a slice of pointers to Invitation.
+	// This should almost always be used instead of []Invitation.
+	InvitationSlice []*Invitation
+
+	invitationQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	invitationType                 = reflect.TypeOf(&Invitation{})
+	invitationMapping              = queries.MakeStructMapping(invitationType)
+	invitationPrimaryKeyMapping, _ = queries.BindMapping(invitationType, invitationMapping, invitationPrimaryKeyColumns)
+	invitationInsertCacheMut       sync.RWMutex
+	invitationInsertCache          = make(map[string]insertCache)
+	invitationUpdateCacheMut       sync.RWMutex
+	invitationUpdateCache          = make(map[string]updateCache)
+	invitationUpsertCacheMut       sync.RWMutex
+	invitationUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single invitation record from the query.
+func (q invitationQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Invitation, error) {
+	o := &Invitation{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dao: failed to execute a one query for invitations")
+	}
+
+	return o, nil
+}
+
+// All returns all Invitation records from the query.
+func (q invitationQuery) All(ctx context.Context, exec boil.ContextExecutor) (InvitationSlice, error) {
+	var o []*Invitation
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "dao: failed to assign all query results to Invitation slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Invitation records in the query.
+func (q invitationQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to count invitations rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q invitationQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "dao: failed to check if invitations exists")
+	}
+
+	return count > 0, nil
+}
+
+// AcceptedByUser pointed to by the foreign key.
+func (o *Invitation) AcceptedByUser(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.AcceptedBy),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Users(queryMods...)
+}
+
+// Company pointed to by the foreign key.
+func (o *Invitation) Company(mods ...qm.QueryMod) companyQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.CompanyID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Companies(queryMods...)
+}
+
+// InvitedByUser pointed to by the foreign key.
+func (o *Invitation) InvitedByUser(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.InvitedBy),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Users(queryMods...)
+}
+
+// LoadAcceptedByUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (invitationL) LoadAcceptedByUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInvitation interface{}, mods queries.Applicator) error {
+	var slice []*Invitation
+	var object *Invitation
+
+	if singular {
+		var ok bool
+		object, ok = maybeInvitation.(*Invitation)
+		if !ok {
+			object = new(Invitation)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeInvitation)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeInvitation))
+			}
+		}
+	} else {
+		s, ok := maybeInvitation.(*[]*Invitation)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeInvitation)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeInvitation))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &invitationR{}
+		}
+		if !queries.IsNil(object.AcceptedBy) {
+			args[object.AcceptedBy] = struct{}{}
+		}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &invitationR{}
+			}
+
+			if !queries.IsNil(obj.AcceptedBy) {
+				args[obj.AcceptedBy] = struct{}{}
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`users`),
+		qm.WhereIn(`users.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.AcceptedByUser = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.AcceptedByInvitations = append(foreign.R.AcceptedByInvitations, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.AcceptedBy, foreign.ID) {
+				local.R.AcceptedByUser = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.AcceptedByInvitations = append(foreign.R.AcceptedByInvitations, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadCompany allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (invitationL) LoadCompany(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInvitation interface{}, mods queries.Applicator) error {
+	var slice []*Invitation
+	var object *Invitation
+
+	if singular {
+		var ok bool
+		object, ok = maybeInvitation.(*Invitation)
+		if !ok {
+			object = new(Invitation)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeInvitation)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeInvitation))
+			}
+		}
+	} else {
+		s, ok := maybeInvitation.(*[]*Invitation)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeInvitation)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeInvitation))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &invitationR{}
+		}
+		args[object.CompanyID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &invitationR{}
+			}
+
+			args[obj.CompanyID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`companies`),
+		qm.WhereIn(`companies.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Company")
+	}
+
+	var resultSlice []*Company
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Company")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for companies")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for companies")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Company = foreign
+		if foreign.R == nil {
+			foreign.R = &companyR{}
+		}
+		foreign.R.Invitations = append(foreign.R.Invitations, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.CompanyID == foreign.ID {
+				local.R.Company = foreign
+				if foreign.R == nil {
+					foreign.R = &companyR{}
+				}
+				foreign.R.Invitations = append(foreign.R.Invitations, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadInvitedByUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (invitationL) LoadInvitedByUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInvitation interface{}, mods queries.Applicator) error {
+	var slice []*Invitation
+	var object *Invitation
+
+	if singular {
+		var ok bool
+		object, ok = maybeInvitation.(*Invitation)
+		if !ok {
+			object = new(Invitation)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeInvitation)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeInvitation))
+			}
+		}
+	} else {
+		s, ok := maybeInvitation.(*[]*Invitation)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeInvitation)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeInvitation))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &invitationR{}
+		}
+		args[object.InvitedBy] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &invitationR{}
+			}
+
+			args[obj.InvitedBy] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`users`),
+		qm.WhereIn(`users.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.InvitedByUser = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.InvitedByInvitations = append(foreign.R.InvitedByInvitations, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.InvitedBy == foreign.ID {
+				local.R.InvitedByUser = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.InvitedByInvitations = append(foreign.R.InvitedByInvitations, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetAcceptedByUser of the invitation to the related item.
+// Sets o.R.AcceptedByUser to related.
+// Adds o to related.R.AcceptedByInvitations.
+func (o *Invitation) SetAcceptedByUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"invitations\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"accepted_by"}),
+		strmangle.WhereClause("\"", "\"", 2, invitationPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.AcceptedBy, related.ID)
+	if o.R == nil {
+		o.R = &invitationR{
+			AcceptedByUser: related,
+		}
+	} else {
+		o.R.AcceptedByUser = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			AcceptedByInvitations: InvitationSlice{o},
+		}
+	} else {
+		related.R.AcceptedByInvitations = append(related.R.AcceptedByInvitations, o)
+	}
+
+	return nil
+}
+
+// RemoveAcceptedByUser relationship.
+// Sets o.R.AcceptedByUser to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *Invitation) RemoveAcceptedByUser(ctx context.Context, exec boil.ContextExecutor, related *User) error {
+	var err error
+
+	queries.SetScanner(&o.AcceptedBy, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("accepted_by")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.AcceptedByUser = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.AcceptedByInvitations {
+		if queries.Equal(o.AcceptedBy, ri.AcceptedBy) {
+			continue
+		}
+
+		ln := len(related.R.AcceptedByInvitations)
+		if ln > 1 && i < ln-1 {
+			related.R.AcceptedByInvitations[i] = related.R.AcceptedByInvitations[ln-1]
+		}
+		related.R.AcceptedByInvitations = related.R.AcceptedByInvitations[:ln-1]
+		break
+	}
+	return nil
+}
+
+// SetCompany of the invitation to the related item.
+// Sets o.R.Company to related.
+// Adds o to related.R.Invitations.
+func (o *Invitation) SetCompany(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Company) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"invitations\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"company_id"}),
+		strmangle.WhereClause("\"", "\"", 2, invitationPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.CompanyID = related.ID
+	if o.R == nil {
+		o.R = &invitationR{
+			Company: related,
+		}
+	} else {
+		o.R.Company = related
+	}
+
+	if related.R == nil {
+		related.R = &companyR{
+			Invitations: InvitationSlice{o},
+		}
+	} else {
+		related.R.Invitations = append(related.R.Invitations, o)
+	}
+
+	return nil
+}
+
+// SetInvitedByUser of the invitation to the related item.
+// Sets o.R.InvitedByUser to related.
+// Adds o to related.R.InvitedByInvitations.
+func (o *Invitation) SetInvitedByUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"invitations\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"invited_by"}),
+		strmangle.WhereClause("\"", "\"", 2, invitationPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.InvitedBy = related.ID
+	if o.R == nil {
+		o.R = &invitationR{
+			InvitedByUser: related,
+		}
+	} else {
+		o.R.InvitedByUser = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			InvitedByInvitations: InvitationSlice{o},
+		}
+	} else {
+		related.R.InvitedByInvitations = append(related.R.InvitedByInvitations, o)
+	}
+
+	return nil
+}
+
+// Invitations retrieves all the records using an executor.
+func Invitations(mods ...qm.QueryMod) invitationQuery {
+	mods = append(mods, qm.From("\"invitations\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"invitations\".*"})
+	}
+
+	return invitationQuery{q}
+}
+
+// FindInvitation retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindInvitation(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*Invitation, error) {
+	invitationObj := &Invitation{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"invitations\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, invitationObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dao: unable to select from invitations")
+	}
+
+	return invitationObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Invitation) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("dao: no invitations provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(invitationColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	invitationInsertCacheMut.RLock()
+	cache, cached := invitationInsertCache[key]
+	invitationInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			invitationAllColumns,
+			invitationColumnsWithDefault,
+			invitationColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(invitationType, invitationMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(invitationType, invitationMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"invitations\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"invitations\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to insert into invitations")
+	}
+
+	if !cached {
+		invitationInsertCacheMut.Lock()
+		invitationInsertCache[key] = cache
+		invitationInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the Invitation.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Invitation) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	key := makeCacheKey(columns, nil)
+	invitationUpdateCacheMut.RLock()
+	cache, cached := invitationUpdateCache[key]
+	invitationUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			invitationAllColumns,
+			invitationPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("dao: unable to update invitations, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"invitations\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, invitationPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(invitationType, invitationMapping, append(wl, invitationPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update invitations row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by update for invitations")
+	}
+
+	if !cached {
+		invitationUpdateCacheMut.Lock()
+		invitationUpdateCache[key] = cache
+		invitationUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q invitationQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update all for invitations")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to retrieve rows affected for invitations")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o InvitationSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("dao: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invitationPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"invitations\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, invitationPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update all in invitation slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to retrieve rows affected all in update all invitation")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Invitation) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("dao: no invitations provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		o.UpdatedAt = currTime
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(invitationColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	invitationUpsertCacheMut.RLock()
+	cache, cached := invitationUpsertCache[key]
+	invitationUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			invitationAllColumns,
+			invitationColumnsWithDefault,
+			invitationColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			invitationAllColumns,
+			invitationPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("dao: unable to upsert invitations, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(invitationAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(invitationPrimaryKeyColumns) == 0 {
+				return errors.New("dao: unable to upsert invitations, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(invitationPrimaryKeyColumns))
+			copy(conflict, invitationPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"invitations\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(invitationType, invitationMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(invitationType, invitationMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to upsert invitations")
+	}
+
+	if !cached {
+		invitationUpsertCacheMut.Lock()
+		invitationUpsertCache[key] = cache
+		invitationUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single Invitation record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Invitation) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("dao: no Invitation provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), invitationPrimaryKeyMapping)
+	sql := "DELETE FROM \"invitations\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete from invitations")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by delete for invitations")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q invitationQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("dao: no invitationQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete all from invitations")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by deleteall for invitations")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o InvitationSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invitationPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"invitations\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, invitationPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete all from invitation slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by deleteall for invitations")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Invitation) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindInvitation(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *InvitationSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := InvitationSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invitationPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"invitations\".* FROM \"invitations\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, invitationPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to reload all in InvitationSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// InvitationExists checks if the Invitation row exists.
+func InvitationExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"invitations\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "dao: unable to check if invitations exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the Invitation row exists.
+func (o *Invitation) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return InvitationExists(ctx, exec, o.ID)
+}
@@ -0,0 +1,920 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// LoginChallenge is an object representing the database table.
+type LoginChallenge struct {
+	ID        string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	UserID    string      `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	TokenHash string      `boil:"token_hash" json:"token_hash" toml:"token_hash" yaml:"token_hash"`
+	UserAgent null.String `boil:"user_agent" json:"user_agent,omitempty" toml:"user_agent" yaml:"user_agent,omitempty"`
+	IPAddress null.String `boil:"ip_address" json:"ip_address,omitempty" toml:"ip_address" yaml:"ip_address,omitempty"`
+	Attempts  int         `boil:"attempts" json:"attempts" toml:"attempts" yaml:"attempts"`
+	ExpiresAt time.Time   `boil:"expires_at" json:"expires_at" toml:"expires_at" yaml:"expires_at"`
+	UsedAt    null.Time   `boil:"used_at" json:"used_at,omitempty" toml:"used_at" yaml:"used_at,omitempty"`
+	CreatedAt time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+
+	R *loginChallengeR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L loginChallengeL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var LoginChallengeColumns = struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	UserAgent string
+	IPAddress string
+	Attempts  string
+	ExpiresAt string
+	UsedAt    string
+	CreatedAt string
+}{
+	ID:        "id",
+	UserID:    "user_id",
+	TokenHash: "token_hash",
+	UserAgent: "user_agent",
+	IPAddress: "ip_address",
+	Attempts:  "attempts",
+	ExpiresAt: "expires_at",
+	UsedAt:    "used_at",
+	CreatedAt: "created_at",
+}
+
+var LoginChallengeTableColumns = struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	UserAgent string
+	IPAddress string
+	Attempts  string
+	ExpiresAt string
+	UsedAt    string
+	CreatedAt string
+}{
+	ID:        "login_challenges.id",
+	UserID:    "login_challenges.user_id",
+	TokenHash: "login_challenges.token_hash",
+	UserAgent: "login_challenges.user_agent",
+	IPAddress: "login_challenges.ip_address",
+	Attempts:  "login_challenges.attempts",
+	ExpiresAt: "login_challenges.expires_at",
+	UsedAt:    "login_challenges.used_at",
+	CreatedAt: "login_challenges.created_at",
+}
+
+// Generated where
+
+var LoginChallengeWhere = struct {
+	ID        whereHelperstring
+	UserID    whereHelperstring
+	TokenHash whereHelperstring
+	UserAgent whereHelpernull_String
+	IPAddress whereHelpernull_String
+	Attempts  whereHelperint
+	ExpiresAt whereHelpertime_Time
+	UsedAt    whereHelpernull_Time
+	CreatedAt whereHelpertime_Time
+}{
+	ID:        whereHelperstring{field: "\"login_challenges\".\"id\""},
+	UserID:    whereHelperstring{field: "\"login_challenges\".\"user_id\""},
+	TokenHash: whereHelperstring{field: "\"login_challenges\".\"token_hash\""},
+	UserAgent: whereHelpernull_String{field: "\"login_challenges\".\"user_agent\""},
+	IPAddress: whereHelpernull_String{field: "\"login_challenges\".\"ip_address\""},
+	Attempts:  whereHelperint{field: "\"login_challenges\".\"attempts\""},
+	ExpiresAt: whereHelpertime_Time{field: "\"login_challenges\".\"expires_at\""},
+	UsedAt:    whereHelpernull_Time{field: "\"login_challenges\".\"used_at\""},
+	CreatedAt: whereHelpertime_Time{field: "\"login_challenges\".\"created_at\""},
+}
+
+// LoginChallengeRels is where relationship names are stored.
+var LoginChallengeRels = struct {
+	User string
+}{
+	User: "User",
+}
+
+// loginChallengeR is where relationships are stored.
+type loginChallengeR struct {
+	User *User `boil:"User" json:"User" toml:"User" yaml:"User"`
+}
+
+// NewStruct creates a new relationship struct
+func (*loginChallengeR) NewStruct() *loginChallengeR {
+	return &loginChallengeR{}
+}
+
+func (r *loginChallengeR) GetUser() *User {
+	if r == nil {
+		return nil
+	}
+	return r.User
+}
+
+// loginChallengeL is where Load methods for each relationship are stored.
+type loginChallengeL struct{}
+
+var (
+	loginChallengeAllColumns            = []string{"id", "user_id", "token_hash", "user_agent", "ip_address", "attempts", "expires_at", "used_at", "created_at"}
+	loginChallengeColumnsWithoutDefault = []string{"id", "user_id", "token_hash", "expires_at", "created_at"}
+	loginChallengeColumnsWithDefault    = []string{"user_agent", "ip_address", "attempts", "used_at"}
+	loginChallengePrimaryKeyColumns     = []string{"id"}
+	loginChallengeGeneratedColumns      = []string{}
+)
+
+type (
+	// LoginChallengeSlice is an alias for a slice of pointers to LoginChallenge.
+	// This should almost always be used instead of []LoginChallenge.
+	LoginChallengeSlice []*LoginChallenge
+
+	loginChallengeQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	loginChallengeType                 = reflect.TypeOf(&LoginChallenge{})
+	loginChallengeMapping              = queries.MakeStructMapping(loginChallengeType)
+	loginChallengePrimaryKeyMapping, _ = queries.BindMapping(loginChallengeType, loginChallengeMapping, loginChallengePrimaryKeyColumns)
+	loginChallengeInsertCacheMut       sync.RWMutex
+	loginChallengeInsertCache          = make(map[string]insertCache)
+	loginChallengeUpdateCacheMut       sync.RWMutex
+	loginChallengeUpdateCache          = make(map[string]updateCache)
+	loginChallengeUpsertCacheMut       sync.RWMutex
+	loginChallengeUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single loginChallenge record from the query.
+func (q loginChallengeQuery) One(ctx context.Context, exec boil.ContextExecutor) (*LoginChallenge, error) {
+	o := &LoginChallenge{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dao: failed to execute a one query for login_challenges")
+	}
+
+	return o, nil
+}
+
+// All returns all LoginChallenge records from the query.
+func (q loginChallengeQuery) All(ctx context.Context, exec boil.ContextExecutor) (LoginChallengeSlice, error) {
+	var o []*LoginChallenge
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "dao: failed to assign all query results to LoginChallenge slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all LoginChallenge records in the query.
+func (q loginChallengeQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to count login_challenges rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q loginChallengeQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "dao: failed to check if login_challenges exists")
+	}
+
+	return count > 0, nil
+}
+
+// User pointed to by the foreign key.
+func (o *LoginChallenge) User(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.UserID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Users(queryMods...)
+}
+
+// LoadUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (loginChallengeL) LoadUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeLoginChallenge interface{}, mods queries.Applicator) error {
+	var slice []*LoginChallenge
+	var object *LoginChallenge
+
+	if singular {
+		var ok bool
+		object, ok = maybeLoginChallenge.(*LoginChallenge)
+		if !ok {
+			object = new(LoginChallenge)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeLoginChallenge)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeLoginChallenge))
+			}
+		}
+	} else {
+		s, ok := maybeLoginChallenge.(*[]*LoginChallenge)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeLoginChallenge)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeLoginChallenge))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &loginChallengeR{}
+		}
+		args[object.UserID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &loginChallengeR{}
+			}
+
+			args[obj.UserID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`users`),
+		qm.WhereIn(`users.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.User = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.LoginChallenges = append(foreign.R.LoginChallenges, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.UserID == foreign.ID {
+				local.R.User = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.LoginChallenges = append(foreign.R.LoginChallenges, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetUser of the loginChallenge to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.LoginChallenges.
+func (o *LoginChallenge) SetUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"login_challenges\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, loginChallengePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.UserID = related.ID
+	if o.R == nil {
+		o.R = &loginChallengeR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			LoginChallenges: LoginChallengeSlice{o},
+		}
+	} else {
+		related.R.LoginChallenges = append(related.R.LoginChallenges, o)
+	}
+
+	return nil
+}
+
+// LoginChallenges retrieves all the records using an executor.
+func LoginChallenges(mods ...qm.QueryMod) loginChallengeQuery {
+	mods = append(mods, qm.From("\"login_challenges\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"login_challenges\".*"})
+	}
+
+	return loginChallengeQuery{q}
+}
+
+// FindLoginChallenge retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindLoginChallenge(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*LoginChallenge, error) {
+	loginChallengeObj := &LoginChallenge{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"login_challenges\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, loginChallengeObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dao: unable to select from login_challenges")
+	}
+
+	return loginChallengeObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *LoginChallenge) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("dao: no login_challenges provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(loginChallengeColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	loginChallengeInsertCacheMut.RLock()
+	cache, cached := loginChallengeInsertCache[key]
+	loginChallengeInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			loginChallengeAllColumns,
+			loginChallengeColumnsWithDefault,
+			loginChallengeColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(loginChallengeType, loginChallengeMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(loginChallengeType, loginChallengeMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"login_challenges\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"login_challenges\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to insert into login_challenges")
+	}
+
+	if !cached {
+		loginChallengeInsertCacheMut.Lock()
+		loginChallengeInsertCache[key] = cache
+		loginChallengeInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the LoginChallenge.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *LoginChallenge) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	key := makeCacheKey(columns, nil)
+	loginChallengeUpdateCacheMut.RLock()
+	cache, cached := loginChallengeUpdateCache[key]
+	loginChallengeUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			loginChallengeAllColumns,
+			loginChallengePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("dao: unable to update login_challenges, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"login_challenges\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, loginChallengePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(loginChallengeType, loginChallengeMapping, append(wl, loginChallengePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update login_challenges row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by update for login_challenges")
+	}
+
+	if !cached {
+		loginChallengeUpdateCacheMut.Lock()
+		loginChallengeUpdateCache[key] = cache
+		loginChallengeUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q loginChallengeQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update all for login_challenges")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to retrieve rows affected for login_challenges")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o LoginChallengeSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("dao: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), loginChallengePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"login_challenges\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, loginChallengePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update all in loginChallenge slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to retrieve rows affected all in update all loginChallenge")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *LoginChallenge) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("dao: no login_challenges provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(loginChallengeColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	loginChallengeUpsertCacheMut.RLock()
+	cache, cached := loginChallengeUpsertCache[key]
+	loginChallengeUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			loginChallengeAllColumns,
+			loginChallengeColumnsWithDefault,
+			loginChallengeColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			loginChallengeAllColumns,
+			loginChallengePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("dao: unable to upsert login_challenges, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(loginChallengeAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(loginChallengePrimaryKeyColumns) == 0 {
+				return errors.New("dao: unable to upsert login_challenges, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(loginChallengePrimaryKeyColumns))
+			copy(conflict, loginChallengePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"login_challenges\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(loginChallengeType, loginChallengeMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(loginChallengeType, loginChallengeMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to upsert login_challenges")
+	}
+
+	if !cached {
+		loginChallengeUpsertCacheMut.Lock()
+		loginChallengeUpsertCache[key] = cache
+		loginChallengeUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single LoginChallenge record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *LoginChallenge) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("dao: no LoginChallenge provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), loginChallengePrimaryKeyMapping)
+	sql := "DELETE FROM \"login_challenges\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete from login_challenges")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by delete for login_challenges")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q loginChallengeQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("dao: no loginChallengeQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete all from login_challenges")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by deleteall for login_challenges")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o LoginChallengeSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), loginChallengePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"login_challenges\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, loginChallengePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete all from loginChallenge slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by deleteall for login_challenges")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *LoginChallenge) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindLoginChallenge(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *LoginChallengeSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := LoginChallengeSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), loginChallengePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"login_challenges\".* FROM \"login_challenges\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, loginChallengePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to reload all in LoginChallengeSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// LoginChallengeExists checks if the LoginChallenge row exists.
+func LoginChallengeExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"login_challenges\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "dao: unable to check if login_challenges exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the LoginChallenge row exists.
+func (o *LoginChallenge) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return LoginChallengeExists(ctx, exec, o.ID)
+}
@@ -0,0 +1,934 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// OidcLoginState is an object representing the database table.
+type OidcLoginState struct {
+	ID           string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	ProviderID   string      `boil:"provider_id" json:"provider_id" toml:"provider_id" yaml:"provider_id"`
+	StateHash    string      `boil:"state_hash" json:"state_hash" toml:"state_hash" yaml:"state_hash"`
+	Nonce        string      `boil:"nonce" json:"nonce" toml:"nonce" yaml:"nonce"`
+	CodeVerifier string      `boil:"code_verifier" json:"code_verifier" toml:"code_verifier" yaml:"code_verifier"`
+	UserAgent    null.String `boil:"user_agent" json:"user_agent,omitempty" toml:"user_agent" yaml:"user_agent,omitempty"`
+	IPAddress    null.String `boil:"ip_address" json:"ip_address,omitempty" toml:"ip_address" yaml:"ip_address,omitempty"`
+	ExpiresAt    time.Time   `boil:"expires_at" json:"expires_at" toml:"expires_at" yaml:"expires_at"`
+	UsedAt       null.Time   `boil:"used_at" json:"used_at,omitempty" toml:"used_at" yaml:"used_at,omitempty"`
+	CreatedAt    time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	LinkUserID   null.String `boil:"link_user_id" json:"link_user_id,omitempty" toml:"link_user_id" yaml:"link_user_id,omitempty"`
+
+	R *oidcLoginStateR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L oidcLoginStateL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var OidcLoginStateColumns = struct {
+	ID           string
+	ProviderID   string
+	StateHash    string
+	Nonce        string
+	CodeVerifier string
+	UserAgent    string
+	IPAddress    string
+	ExpiresAt    string
+	UsedAt       string
+	CreatedAt    string
+	LinkUserID   string
+}{
+	ID:           "id",
+	ProviderID:   "provider_id",
+	StateHash:    "state_hash",
+	Nonce:        "nonce",
+	CodeVerifier: "code_verifier",
+	UserAgent:    "user_agent",
+	IPAddress:    "ip_address",
+	ExpiresAt:    "expires_at",
+	UsedAt:       "used_at",
+	CreatedAt:    "created_at",
+	LinkUserID:   "link_user_id",
+}
+
+var OidcLoginStateTableColumns = struct {
+	ID           string
+	ProviderID   string
+	StateHash    string
+	Nonce        string
+	CodeVerifier string
+	UserAgent    string
+	IPAddress    string
+	ExpiresAt    string
+	UsedAt       string
+	CreatedAt    string
+	LinkUserID   string
+}{
+	ID:           "oidc_login_states.id",
+	ProviderID:   "oidc_login_states.provider_id",
+	StateHash:    "oidc_login_states.state_hash",
+	Nonce:        "oidc_login_states.nonce",
+	CodeVerifier: "oidc_login_states.code_verifier",
+	UserAgent:    "oidc_login_states.user_agent",
+	IPAddress:    "oidc_login_states.ip_address",
+	ExpiresAt:    "oidc_login_states.expires_at",
+	UsedAt:       "oidc_login_states.used_at",
+	CreatedAt:    "oidc_login_states.created_at",
+	LinkUserID:   "oidc_login_states.link_user_id",
+}
+
+// Generated where
+
+var OidcLoginStateWhere = struct {
+	ID           whereHelperstring
+	ProviderID   whereHelperstring
+	StateHash    whereHelperstring
+	Nonce        whereHelperstring
+	CodeVerifier whereHelperstring
+	UserAgent    whereHelpernull_String
+	IPAddress    whereHelpernull_String
+	ExpiresAt    whereHelpertime_Time
+	UsedAt       whereHelpernull_Time
+	CreatedAt    whereHelpertime_Time
+	LinkUserID   whereHelpernull_String
+}{
+	ID:           whereHelperstring{field: "\"oidc_login_states\".\"id\""},
+	ProviderID:   whereHelperstring{field: "\"oidc_login_states\".\"provider_id\""},
+	StateHash:    whereHelperstring{field: "\"oidc_login_states\".\"state_hash\""},
+	Nonce:        whereHelperstring{field: "\"oidc_login_states\".\"nonce\""},
+	CodeVerifier: whereHelperstring{field: "\"oidc_login_states\".\"code_verifier\""},
+	UserAgent:    whereHelpernull_String{field: "\"oidc_login_states\".\"user_agent\""},
+	IPAddress:    whereHelpernull_String{field: "\"oidc_login_states\".\"ip_address\""},
+	ExpiresAt:    whereHelpertime_Time{field: "\"oidc_login_states\".\"expires_at\""},
+	UsedAt:       whereHelpernull_Time{field: "\"oidc_login_states\".\"used_at\""},
+	CreatedAt:    whereHelpertime_Time{field: "\"oidc_login_states\".\"created_at\""},
+	LinkUserID:   whereHelpernull_String{field: "\"oidc_login_states\".\"link_user_id\""},
+}
+
+// OidcLoginStateRels is where relationship names are stored.
+var OidcLoginStateRels = struct {
+	Provider string
+}{
+	Provider: "Provider",
+}
+
+// oidcLoginStateR is where relationships are stored.
+type oidcLoginStateR struct {
+	Provider *IdentityProvider `boil:"Provider" json:"Provider" toml:"Provider" yaml:"Provider"`
+}
+
+// NewStruct creates a new relationship struct
+func (*oidcLoginStateR) NewStruct() *oidcLoginStateR {
+	return &oidcLoginStateR{}
+}
+
+func (r *oidcLoginStateR) GetProvider() *IdentityProvider {
+	if r == nil {
+		return nil
+	}
+	return r.Provider
+}
+
+// oidcLoginStateL is where Load methods for each relationship are stored.
+type oidcLoginStateL struct{}
+
+var (
+	oidcLoginStateAllColumns            = []string{"id", "provider_id", "state_hash", "nonce", "code_verifier", "user_agent", "ip_address", "expires_at", "used_at", "created_at", "link_user_id"}
+	oidcLoginStateColumnsWithoutDefault = []string{"id", "provider_id", "state_hash", "nonce", "code_verifier", "expires_at", "created_at"}
+	oidcLoginStateColumnsWithDefault    = []string{"user_agent", "ip_address", "used_at", "link_user_id"}
+	oidcLoginStatePrimaryKeyColumns     = []string{"id"}
+	oidcLoginStateGeneratedColumns      = []string{}
+)
+
+type (
+	// OidcLoginStateSlice is an alias for a slice of pointers to OidcLoginState.
+	// This should almost always be used instead of []OidcLoginState.
+	OidcLoginStateSlice []*OidcLoginState
+
+	oidcLoginStateQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	oidcLoginStateType                 = reflect.TypeOf(&OidcLoginState{})
+	oidcLoginStateMapping              = queries.MakeStructMapping(oidcLoginStateType)
+	oidcLoginStatePrimaryKeyMapping, _ = queries.BindMapping(oidcLoginStateType, oidcLoginStateMapping, oidcLoginStatePrimaryKeyColumns)
+	oidcLoginStateInsertCacheMut       sync.RWMutex
+	oidcLoginStateInsertCache          = make(map[string]insertCache)
+	oidcLoginStateUpdateCacheMut       sync.RWMutex
+	oidcLoginStateUpdateCache          = make(map[string]updateCache)
+	oidcLoginStateUpsertCacheMut       sync.RWMutex
+	oidcLoginStateUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single oidcLoginState record from the query.
+func (q oidcLoginStateQuery) One(ctx context.Context, exec boil.ContextExecutor) (*OidcLoginState, error) {
+	o := &OidcLoginState{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dao: failed to execute a one query for oidc_login_states")
+	}
+
+	return o, nil
+}
+
+// All returns all OidcLoginState records from the query.
+func (q oidcLoginStateQuery) All(ctx context.Context, exec boil.ContextExecutor) (OidcLoginStateSlice, error) {
+	var o []*OidcLoginState
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "dao: failed to assign all query results to OidcLoginState slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all OidcLoginState records in the query.
+func (q oidcLoginStateQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to count oidc_login_states rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q oidcLoginStateQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "dao: failed to check if oidc_login_states exists")
+	}
+
+	return count > 0, nil
+}
+
+// Provider pointed to by the foreign key.
+func (o *OidcLoginState) Provider(mods ...qm.QueryMod) identityProviderQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ProviderID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return IdentityProviders(queryMods...)
+}
+
+// LoadProvider allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (oidcLoginStateL) LoadProvider(ctx context.Context, e boil.ContextExecutor, singular bool, maybeOidcLoginState interface{}, mods queries.Applicator) error {
+	var slice []*OidcLoginState
+	var object *OidcLoginState
+
+	if singular {
+		var ok bool
+		object, ok = maybeOidcLoginState.(*OidcLoginState)
+		if !ok {
+			object = new(OidcLoginState)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeOidcLoginState)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeOidcLoginState))
+			}
+		}
+	} else {
+		s, ok := maybeOidcLoginState.(*[]*OidcLoginState)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeOidcLoginState)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeOidcLoginState))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &oidcLoginStateR{}
+		}
+		args[object.ProviderID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &oidcLoginStateR{}
+			}
+
+			args[obj.ProviderID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`identity_providers`),
+		qm.WhereIn(`identity_providers.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load IdentityProvider")
+	}
+
+	var resultSlice []*IdentityProvider
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice IdentityProvider")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for identity_providers")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for identity_providers")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Provider = foreign
+		if foreign.R == nil {
+			foreign.R = &identityProviderR{}
+		}
+		foreign.R.ProviderOidcLoginStates = append(foreign.R.ProviderOidcLoginStates, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ProviderID == foreign.ID {
+				local.R.Provider = foreign
+				if foreign.R == nil {
+					foreign.R = &identityProviderR{}
+				}
+				foreign.R.ProviderOidcLoginStates = append(foreign.R.ProviderOidcLoginStates, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetProvider of the oidcLoginState to the related item.
+// Sets o.R.Provider to related.
+// Adds o to related.R.ProviderOidcLoginStates.
+func (o *OidcLoginState) SetProvider(ctx context.Context, exec boil.ContextExecutor, insert bool, related *IdentityProvider) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"oidc_login_states\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"provider_id"}),
+		strmangle.WhereClause("\"", "\"", 2, oidcLoginStatePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ProviderID = related.ID
+	if o.R == nil {
+		o.R = &oidcLoginStateR{
+			Provider: related,
+		}
+	} else {
+		o.R.Provider = related
+	}
+
+	if related.R == nil {
+		related.R = &identityProviderR{
+			ProviderOidcLoginStates: OidcLoginStateSlice{o},
+		}
+	} else {
+		related.R.ProviderOidcLoginStates = append(related.R.ProviderOidcLoginStates, o)
+	}
+
+	return nil
+}
+
+// OidcLoginStates retrieves all the records using an executor.
+func OidcLoginStates(mods ...qm.QueryMod) oidcLoginStateQuery {
+	mods = append(mods, qm.From("\"oidc_login_states\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"oidc_login_states\".*"})
+	}
+
+	return oidcLoginStateQuery{q}
+}
+
+// FindOidcLoginState retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindOidcLoginState(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*OidcLoginState, error) {
+	oidcLoginStateObj := &OidcLoginState{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"oidc_login_states\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, oidcLoginStateObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dao: unable to select from oidc_login_states")
+	}
+
+	return oidcLoginStateObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *OidcLoginState) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("dao: no oidc_login_states provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(oidcLoginStateColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	oidcLoginStateInsertCacheMut.RLock()
+	cache, cached := oidcLoginStateInsertCache[key]
+	oidcLoginStateInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			oidcLoginStateAllColumns,
+			oidcLoginStateColumnsWithDefault,
+			oidcLoginStateColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(oidcLoginStateType, oidcLoginStateMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(oidcLoginStateType, oidcLoginStateMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"oidc_login_states\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"oidc_login_states\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to insert into oidc_login_states")
+	}
+
+	if !cached {
+		oidcLoginStateInsertCacheMut.Lock()
+		oidcLoginStateInsertCache[key] = cache
+		oidcLoginStateInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the OidcLoginState.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *OidcLoginState) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	key := makeCacheKey(columns, nil)
+	oidcLoginStateUpdateCacheMut.RLock()
+	cache, cached := oidcLoginStateUpdateCache[key]
+	oidcLoginStateUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			oidcLoginStateAllColumns,
+			oidcLoginStatePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("dao: unable to update oidc_login_states, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"oidc_login_states\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, oidcLoginStatePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(oidcLoginStateType, oidcLoginStateMapping, append(wl, oidcLoginStatePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update oidc_login_states row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by update for oidc_login_states")
+	}
+
+	if !cached {
+		oidcLoginStateUpdateCacheMut.Lock()
+		oidcLoginStateUpdateCache[key] = cache
+		oidcLoginStateUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q oidcLoginStateQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update all for oidc_login_states")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to retrieve rows affected for oidc_login_states")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o OidcLoginStateSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("dao: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), oidcLoginStatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"oidc_login_states\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, oidcLoginStatePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update all in oidcLoginState slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to retrieve rows affected all in update all oidcLoginState")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *OidcLoginState) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("dao: no oidc_login_states provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(oidcLoginStateColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	oidcLoginStateUpsertCacheMut.RLock()
+	cache, cached := oidcLoginStateUpsertCache[key]
+	oidcLoginStateUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			oidcLoginStateAllColumns,
+			oidcLoginStateColumnsWithDefault,
+			oidcLoginStateColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			oidcLoginStateAllColumns,
+			oidcLoginStatePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("dao: unable to upsert oidc_login_states, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(oidcLoginStateAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(oidcLoginStatePrimaryKeyColumns) == 0 {
+				return errors.New("dao: unable to upsert oidc_login_states, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(oidcLoginStatePrimaryKeyColumns))
+			copy(conflict, oidcLoginStatePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"oidc_login_states\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(oidcLoginStateType, oidcLoginStateMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(oidcLoginStateType, oidcLoginStateMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to upsert oidc_login_states")
+	}
+
+	if !cached {
+		oidcLoginStateUpsertCacheMut.Lock()
+		oidcLoginStateUpsertCache[key] = cache
+		oidcLoginStateUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single OidcLoginState record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *OidcLoginState) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("dao: no OidcLoginState provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), oidcLoginStatePrimaryKeyMapping)
+	sql := "DELETE FROM \"oidc_login_states\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete from oidc_login_states")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by delete for oidc_login_states")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q oidcLoginStateQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("dao: no oidcLoginStateQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete all from oidc_login_states")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by deleteall for oidc_login_states")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o OidcLoginStateSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), oidcLoginStatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"oidc_login_states\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, oidcLoginStatePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete all from oidcLoginState slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by deleteall for oidc_login_states")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *OidcLoginState) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindOidcLoginState(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *OidcLoginStateSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := OidcLoginStateSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), oidcLoginStatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"oidc_login_states\".* FROM \"oidc_login_states\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, oidcLoginStatePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to reload all in OidcLoginStateSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// OidcLoginStateExists checks if the OidcLoginState row exists.
+func OidcLoginStateExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"oidc_login_states\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "dao: unable to check if oidc_login_states exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the OidcLoginState row exists.
+func (o *OidcLoginState) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return OidcLoginStateExists(ctx, exec, o.ID)
+}
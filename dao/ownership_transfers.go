@@ -0,0 +1,1304 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// OwnershipTransfer is an object representing the database table.
+type OwnershipTransfer struct {
+	ID                string    `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CompanyID         string    `boil:"company_id" json:"company_id" toml:"company_id" yaml:"company_id"`
+	FromUserID        string    `boil:"from_user_id" json:"from_user_id" toml:"from_user_id" yaml:"from_user_id"`
+	ToUserID          string    `boil:"to_user_id" json:"to_user_id" toml:"to_user_id" yaml:"to_user_id"`
+	PreviousOwnerRole string    `boil:"previous_owner_role" json:"previous_owner_role" toml:"previous_owner_role" yaml:"previous_owner_role"`
+	ExpiresAt         time.Time `boil:"expires_at" json:"expires_at" toml:"expires_at" yaml:"expires_at"`
+	AcceptedAt        null.Time `boil:"accepted_at" json:"accepted_at,omitempty" toml:"accepted_at" yaml:"accepted_at,omitempty"`
+	DeclinedAt        null.Time `boil:"declined_at" json:"declined_at,omitempty" toml:"declined_at" yaml:"declined_at,omitempty"`
+	CancelledAt       null.Time `boil:"cancelled_at" json:"cancelled_at,omitempty" toml:"cancelled_at" yaml:"cancelled_at,omitempty"`
+	CreatedAt         time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt         time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+
+	R *ownershipTransferR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L ownershipTransferL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var OwnershipTransferColumns = struct {
+	ID                string
+	CompanyID         string
+	FromUserID        string
+	ToUserID          string
+	PreviousOwnerRole string
+	ExpiresAt         string
+	AcceptedAt        string
+	DeclinedAt        string
+	CancelledAt       string
+	CreatedAt         string
+	UpdatedAt         string
+}{
+	ID:                "id",
+	CompanyID:         "company_id",
+	FromUserID:        "from_user_id",
+	ToUserID:          "to_user_id",
+	PreviousOwnerRole: "previous_owner_role",
+	ExpiresAt:         "expires_at",
+	AcceptedAt:        "accepted_at",
+	DeclinedAt:        "declined_at",
+	CancelledAt:       "cancelled_at",
+	CreatedAt:         "created_at",
+	UpdatedAt:         "updated_at",
+}
+
+var OwnershipTransferTableColumns = struct {
+	ID                string
+	CompanyID         string
+	FromUserID        string
+	ToUserID          string
+	PreviousOwnerRole string
+	ExpiresAt         string
+	AcceptedAt        string
+	DeclinedAt        string
+	CancelledAt       string
+	CreatedAt         string
+	UpdatedAt         string
+}{
+	ID:                "ownership_transfers.id",
+	CompanyID:         "ownership_transfers.company_id",
+	FromUserID:        "ownership_transfers.from_user_id",
+	ToUserID:          "ownership_transfers.to_user_id",
+	PreviousOwnerRole: "ownership_transfers.previous_owner_role",
+	ExpiresAt:         "ownership_transfers.expires_at",
+	AcceptedAt:        "ownership_transfers.accepted_at",
+	DeclinedAt:        "ownership_transfers.declined_at",
+	CancelledAt:       "ownership_transfers.cancelled_at",
+	CreatedAt:         "ownership_transfers.created_at",
+	UpdatedAt:         "ownership_transfers.updated_at",
+}
+
+// Generated where
+
+var OwnershipTransferWhere = struct {
+	ID                whereHelperstring
+	CompanyID         whereHelperstring
+	FromUserID        whereHelperstring
+	ToUserID          whereHelperstring
+	PreviousOwnerRole whereHelperstring
+	ExpiresAt         whereHelpertime_Time
+	AcceptedAt        whereHelpernull_Time
+	DeclinedAt        whereHelpernull_Time
+	CancelledAt       whereHelpernull_Time
+	CreatedAt         whereHelpertime_Time
+	UpdatedAt         whereHelpertime_Time
+}{
+	ID:                whereHelperstring{field: "\"ownership_transfers\".\"id\""},
+	CompanyID:         whereHelperstring{field: "\"ownership_transfers\".\"company_id\""},
+	FromUserID:        whereHelperstring{field: "\"ownership_transfers\".\"from_user_id\""},
+	ToUserID:          whereHelperstring{field: "\"ownership_transfers\".\"to_user_id\""},
+	PreviousOwnerRole: whereHelperstring{field: "\"ownership_transfers\".\"previous_owner_role\""},
+	ExpiresAt:         whereHelpertime_Time{field: "\"ownership_transfers\".\"expires_at\""},
+	AcceptedAt:        whereHelpernull_Time{field: "\"ownership_transfers\".\"accepted_at\""},
+	DeclinedAt:        whereHelpernull_Time{field: "\"ownership_transfers\".\"declined_at\""},
+	CancelledAt:       whereHelpernull_Time{field: "\"ownership_transfers\".\"cancelled_at\""},
+	CreatedAt:         whereHelpertime_Time{field: "\"ownership_transfers\".\"created_at\""},
+	UpdatedAt:         whereHelpertime_Time{field: "\"ownership_transfers\".\"updated_at\""},
+}
+
+// OwnershipTransferRels is where relationship names are stored.
+var OwnershipTransferRels = struct {
+	Company  string
+	FromUser string
+	ToUser   string
+}{
+	Company:  "Company",
+	FromUser: "FromUser",
+	ToUser:   "ToUser",
+}
+
+// ownershipTransferR is where relationships are stored.
+type ownershipTransferR struct {
+	Company  *Company `boil:"Company" json:"Company" toml:"Company" yaml:"Company"`
+	FromUser *User    `boil:"FromUser" json:"FromUser" toml:"FromUser" yaml:"FromUser"`
+	ToUser   *User    `boil:"ToUser" json:"ToUser" toml:"ToUser" yaml:"ToUser"`
+}
+
+// NewStruct creates a new relationship struct
+func (*ownershipTransferR) NewStruct() *ownershipTransferR {
+	return &ownershipTransferR{}
+}
+
+func (r *ownershipTransferR) GetCompany() *Company {
+	if r == nil {
+		return nil
+	}
+	return r.Company
+}
+
+func (r *ownershipTransferR) GetFromUser() *User {
+	if r == nil {
+		return nil
+	}
+	return r.FromUser
+}
+
+func (r *ownershipTransferR) GetToUser() *User {
+	if r == nil {
+		return nil
+	}
+	return r.ToUser
+}
+
+// ownershipTransferL is where Load methods for each relationship are stored.
+type ownershipTransferL struct{}
+
+var (
+	ownershipTransferAllColumns            = []string{"id", "company_id", "from_user_id", "to_user_id", "previous_owner_role", "expires_at", "accepted_at", "declined_at", "cancelled_at", "created_at", "updated_at"}
+	ownershipTransferColumnsWithoutDefault = []string{"id", "company_id", "from_user_id", "to_user_id", "previous_owner_role", "expires_at", "created_at", "updated_at"}
+	ownershipTransferColumnsWithDefault    = []string{"accepted_at", "declined_at", "cancelled_at"}
+	ownershipTransferPrimaryKeyColumns     = []string{"id"}
+	ownershipTransferGeneratedColumns      = []string{}
+)
+
+type (
+	// OwnershipTransferSlice is an alias for a slice of pointers to OwnershipTransfer.
+	// This should almost always be used instead of []OwnershipTransfer.
+	OwnershipTransferSlice []*OwnershipTransfer
+
+	ownershipTransferQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	ownershipTransferType                 = reflect.TypeOf(&OwnershipTransfer{})
+	ownershipTransferMapping              = queries.MakeStructMapping(ownershipTransferType)
+	ownershipTransferPrimaryKeyMapping, _ = queries.BindMapping(ownershipTransferType, ownershipTransferMapping, ownershipTransferPrimaryKeyColumns)
+	ownershipTransferInsertCacheMut       sync.RWMutex
+	ownershipTransferInsertCache          = make(map[string]insertCache)
+	ownershipTransferUpdateCacheMut       sync.RWMutex
+	ownershipTransferUpdateCache          = make(map[string]updateCache)
+	ownershipTransferUpsertCacheMut       sync.RWMutex
+	ownershipTransferUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single ownershipTransfer record from the query.
+func (q ownershipTransferQuery) One(ctx context.Context, exec boil.ContextExecutor) (*OwnershipTransfer, error) {
+	o := &OwnershipTransfer{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dao: failed to execute a one query for ownership_transfers")
+	}
+
+	return o, nil
+}
+
+// All returns all OwnershipTransfer records from the query.
+func (q ownershipTransferQuery) All(ctx context.Context, exec boil.ContextExecutor) (OwnershipTransferSlice, error) {
+	var o []*OwnershipTransfer
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "dao: failed to assign all query results to OwnershipTransfer slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all OwnershipTransfer records in the query.
+func (q ownershipTransferQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to count ownership_transfers rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q ownershipTransferQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "dao: failed to check if ownership_transfers exists")
+	}
+
+	return count > 0, nil
+}
+
+// Company pointed to by the foreign key.
+func (o *OwnershipTransfer) Company(mods ...qm.QueryMod) companyQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.CompanyID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Companies(queryMods...)
+}
+
+// FromUser pointed to by the foreign key.
+func (o *OwnershipTransfer) FromUser(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.FromUserID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Users(queryMods...)
+}
+
+// ToUser pointed to by the foreign key.
+func (o *OwnershipTransfer) ToUser(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ToUserID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Users(queryMods...)
+}
+
+// LoadCompany allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (ownershipTransferL) LoadCompany(ctx context.Context, e boil.ContextExecutor, singular bool, maybeOwnershipTransfer interface{}, mods queries.Applicator) error {
+	var slice []*OwnershipTransfer
+	var object *OwnershipTransfer
+
+	if singular {
+		var ok bool
+		object, ok = maybeOwnershipTransfer.(*OwnershipTransfer)
+		if !ok {
+			object = new(OwnershipTransfer)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeOwnershipTransfer)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeOwnershipTransfer))
+			}
+		}
+	} else {
+		s, ok := maybeOwnershipTransfer.(*[]*OwnershipTransfer)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeOwnershipTransfer)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeOwnershipTransfer))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &ownershipTransferR{}
+		}
+		args[object.CompanyID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &ownershipTransferR{}
+			}
+
+			args[obj.CompanyID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`companies`),
+		qm.WhereIn(`companies.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Company")
+	}
+
+	var resultSlice []*Company
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Company")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for companies")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for companies")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Company = foreign
+		if foreign.R == nil {
+			foreign.R = &companyR{}
+		}
+		foreign.R.OwnershipTransfers = append(foreign.R.OwnershipTransfers, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.CompanyID == foreign.ID {
+				local.R.Company = foreign
+				if foreign.R == nil {
+					foreign.R = &companyR{}
+				}
+				foreign.R.OwnershipTransfers = append(foreign.R.OwnershipTransfers, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadFromUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (ownershipTransferL) LoadFromUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeOwnershipTransfer interface{}, mods queries.Applicator) error {
+	var slice []*OwnershipTransfer
+	var object *OwnershipTransfer
+
+	if singular {
+		var ok bool
+		object, ok = maybeOwnershipTransfer.(*OwnershipTransfer)
+		if !ok {
+			object = new(OwnershipTransfer)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeOwnershipTransfer)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeOwnershipTransfer))
+			}
+		}
+	} else {
+		s, ok := maybeOwnershipTransfer.(*[]*OwnershipTransfer)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeOwnershipTransfer)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeOwnershipTransfer))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &ownershipTransferR{}
+		}
+		args[object.FromUserID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &ownershipTransferR{}
+			}
+
+			args[obj.FromUserID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`users`),
+		qm.WhereIn(`users.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.FromUser = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.FromUserOwnershipTransfers = append(foreign.R.FromUserOwnershipTransfers, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.FromUserID == foreign.ID {
+				local.R.FromUser = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.FromUserOwnershipTransfers = append(foreign.R.FromUserOwnershipTransfers, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadToUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (ownershipTransferL) LoadToUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeOwnershipTransfer interface{}, mods queries.Applicator) error {
+	var slice []*OwnershipTransfer
+	var object *OwnershipTransfer
+
+	if singular {
+		var ok bool
+		object, ok = maybeOwnershipTransfer.(*OwnershipTransfer)
+		if !ok {
+			object = new(OwnershipTransfer)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeOwnershipTransfer)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeOwnershipTransfer))
+			}
+		}
+	} else {
+		s, ok := maybeOwnershipTransfer.(*[]*OwnershipTransfer)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeOwnershipTransfer)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeOwnershipTransfer))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &ownershipTransferR{}
+		}
+		args[object.ToUserID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &ownershipTransferR{}
+			}
+
+			args[obj.ToUserID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`users`),
+		qm.WhereIn(`users.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.ToUser = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.ToUserOwnershipTransfers = append(foreign.R.ToUserOwnershipTransfers, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ToUserID == foreign.ID {
+				local.R.ToUser = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.ToUserOwnershipTransfers = append(foreign.R.ToUserOwnershipTransfers, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetCompany of the ownershipTransfer to the related item.
+// Sets o.R.Company to related.
+// Adds o to related.R.OwnershipTransfers.
+func (o *OwnershipTransfer) SetCompany(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Company) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"ownership_transfers\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"company_id"}),
+		strmangle.WhereClause("\"", "\"", 2, ownershipTransferPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.CompanyID = related.ID
+	if o.R == nil {
+		o.R = &ownershipTransferR{
+			Company: related,
+		}
+	} else {
+		o.R.Company = related
+	}
+
+	if related.R == nil {
+		related.R = &companyR{
+			OwnershipTransfers: OwnershipTransferSlice{o},
+		}
+	} else {
+		related.R.OwnershipTransfers = append(related.R.OwnershipTransfers, o)
+	}
+
+	return nil
+}
+
+// SetFromUser of the ownershipTransfer to the related item.
+// Sets o.R.FromUser to related.
+// Adds o to related.R.FromUserOwnershipTransfers.
+func (o *OwnershipTransfer) SetFromUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"ownership_transfers\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"from_user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, ownershipTransferPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.FromUserID = related.ID
+	if o.R == nil {
+		o.R = &ownershipTransferR{
+			FromUser: related,
+		}
+	} else {
+		o.R.FromUser = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			FromUserOwnershipTransfers: OwnershipTransferSlice{o},
+		}
+	} else {
+		related.R.FromUserOwnershipTransfers = append(related.R.FromUserOwnershipTransfers, o)
+	}
+
+	return nil
+}
+
+// SetToUser of the ownershipTransfer to the related item.
+// Sets o.R.ToUser to related.
+// Adds o to related.R.ToUserOwnershipTransfers.
+func (o *OwnershipTransfer) SetToUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"ownership_transfers\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"to_user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, ownershipTransferPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ToUserID = related.ID
+	if o.R == nil {
+		o.R = &ownershipTransferR{
+			ToUser: related,
+		}
+	} else {
+		o.R.ToUser = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			ToUserOwnershipTransfers: OwnershipTransferSlice{o},
+		}
+	} else {
+		related.R.ToUserOwnershipTransfers = append(related.R.ToUserOwnershipTransfers, o)
+	}
+
+	return nil
+}
+
+// OwnershipTransfers retrieves all the records using an executor.
+func OwnershipTransfers(mods ...qm.QueryMod) ownershipTransferQuery {
+	mods = append(mods, qm.From("\"ownership_transfers\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"ownership_transfers\".*"})
+	}
+
+	return ownershipTransferQuery{q}
+}
+
+// FindOwnershipTransfer retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindOwnershipTransfer(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*OwnershipTransfer, error) {
+	ownershipTransferObj := &OwnershipTransfer{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"ownership_transfers\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, ownershipTransferObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dao: unable to select from ownership_transfers")
+	}
+
+	return ownershipTransferObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *OwnershipTransfer) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("dao: no ownership_transfers provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(ownershipTransferColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	ownershipTransferInsertCacheMut.RLock()
+	cache, cached := ownershipTransferInsertCache[key]
+	ownershipTransferInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			ownershipTransferAllColumns,
+			ownershipTransferColumnsWithDefault,
+			ownershipTransferColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(ownershipTransferType, ownershipTransferMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(ownershipTransferType, ownershipTransferMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"ownership_transfers\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"ownership_transfers\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to insert into ownership_transfers")
+	}
+
+	if !cached {
+		ownershipTransferInsertCacheMut.Lock()
+		ownershipTransferInsertCache[key] = cache
+		ownershipTransferInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the OwnershipTransfer.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *OwnershipTransfer) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	key := makeCacheKey(columns, nil)
+	ownershipTransferUpdateCacheMut.RLock()
+	cache, cached := ownershipTransferUpdateCache[key]
+	ownershipTransferUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			ownershipTransferAllColumns,
+			ownershipTransferPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("dao: unable to update ownership_transfers, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"ownership_transfers\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, ownershipTransferPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(ownershipTransferType, ownershipTransferMapping, append(wl, ownershipTransferPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update ownership_transfers row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by update for ownership_transfers")
+	}
+
+	if !cached {
+		ownershipTransferUpdateCacheMut.Lock()
+		ownershipTransferUpdateCache[key] = cache
+		ownershipTransferUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q ownershipTransferQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update all for ownership_transfers")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to retrieve rows affected for ownership_transfers")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o OwnershipTransferSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("dao: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), ownershipTransferPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"ownership_transfers\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, ownershipTransferPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to update all in ownershipTransfer slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to retrieve rows affected all in update all ownershipTransfer")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *OwnershipTransfer) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("dao: no ownership_transfers provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		o.UpdatedAt = currTime
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(ownershipTransferColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	ownershipTransferUpsertCacheMut.RLock()
+	cache, cached := ownershipTransferUpsertCache[key]
+	ownershipTransferUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			ownershipTransferAllColumns,
+			ownershipTransferColumnsWithDefault,
+			ownershipTransferColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			ownershipTransferAllColumns,
+			ownershipTransferPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("dao: unable to upsert ownership_transfers, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(ownershipTransferAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(ownershipTransferPrimaryKeyColumns) == 0 {
+				return errors.New("dao: unable to upsert ownership_transfers, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(ownershipTransferPrimaryKeyColumns))
+			copy(conflict, ownershipTransferPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"ownership_transfers\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(ownershipTransferType, ownershipTransferMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(ownershipTransferType, ownershipTransferMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to upsert ownership_transfers")
+	}
+
+	if !cached {
+		ownershipTransferUpsertCacheMut.Lock()
+		ownershipTransferUpsertCache[key] = cache
+		ownershipTransferUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single OwnershipTransfer record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *OwnershipTransfer) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("dao: no OwnershipTransfer provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), ownershipTransferPrimaryKeyMapping)
+	sql := "DELETE FROM \"ownership_transfers\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete from ownership_transfers")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by delete for ownership_transfers")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q ownershipTransferQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("dao: no ownershipTransferQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete all from ownership_transfers")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by deleteall for ownership_transfers")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o OwnershipTransferSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), ownershipTransferPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"ownership_transfers\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, ownershipTransferPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: unable to delete all from ownershipTransfer slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dao: failed to get rows affected by deleteall for ownership_transfers")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *OwnershipTransfer) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindOwnershipTransfer(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *OwnershipTransferSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := OwnershipTransferSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), ownershipTransferPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"ownership_transfers\".* FROM \"ownership_transfers\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, ownershipTransferPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "dao: unable to reload all in OwnershipTransferSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// OwnershipTransferExists checks if the OwnershipTransfer row exists.
+func OwnershipTransferExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"ownership_transfers\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "dao: unable to check if ownership_transfers exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the OwnershipTransfer row exists.
+func (o *OwnershipTransfer) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return OwnershipTransferExists(ctx, exec, o.ID)
+}
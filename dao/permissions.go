@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,87 +24,118 @@ import (
 
 // Permission is an object representing the database table.
 type Permission struct {
-	ID         string    `boil:"id" json:"id" toml:"id" yaml:"id"`
-	UserID     string    `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
-	CompanyID  string    `boil:"company_id" json:"company_id" toml:"company_id" yaml:"company_id"`
-	Role       string    `boil:"role" json:"role" toml:"role" yaml:"role"`
-	ContractID string    `boil:"contract_id" json:"contract_id" toml:"contract_id" yaml:"contract_id"`
-	CreatedAt  time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt  time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ID               string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	UserID           string      `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	CompanyID        string      `boil:"company_id" json:"company_id" toml:"company_id" yaml:"company_id"`
+	Role             string      `boil:"role" json:"role" toml:"role" yaml:"role"`
+	ContractID       string      `boil:"contract_id" json:"contract_id" toml:"contract_id" yaml:"contract_id"`
+	CreatedAt        time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt        time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	CompanyRoleID    null.String `boil:"company_role_id" json:"company_role_id,omitempty" toml:"company_role_id" yaml:"company_role_id,omitempty"`
+	StartsAt         null.Time   `boil:"starts_at" json:"starts_at,omitempty" toml:"starts_at" yaml:"starts_at,omitempty"`
+	ExpiresAt        null.Time   `boil:"expires_at" json:"expires_at,omitempty" toml:"expires_at" yaml:"expires_at,omitempty"`
+	ExpiryNotifiedAt null.Time   `boil:"expiry_notified_at" json:"expiry_notified_at,omitempty" toml:"expiry_notified_at" yaml:"expiry_notified_at,omitempty"`
 
 	R *permissionR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L permissionL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var PermissionColumns = struct {
-	ID         string
-	UserID     string
-	CompanyID  string
-	Role       string
-	ContractID string
-	CreatedAt  string
-	UpdatedAt  string
+	ID               string
+	UserID           string
+	CompanyID        string
+	Role             string
+	ContractID       string
+	CreatedAt        string
+	UpdatedAt        string
+	CompanyRoleID    string
+	StartsAt         string
+	ExpiresAt        string
+	ExpiryNotifiedAt string
 }{
-	ID:         "id",
-	UserID:     "user_id",
-	CompanyID:  "company_id",
-	Role:       "role",
-	ContractID: "contract_id",
-	CreatedAt:  "created_at",
-	UpdatedAt:  "updated_at",
+	ID:               "id",
+	UserID:           "user_id",
+	CompanyID:        "company_id",
+	Role:             "role",
+	ContractID:       "contract_id",
+	CreatedAt:        "created_at",
+	UpdatedAt:        "updated_at",
+	CompanyRoleID:    "company_role_id",
+	StartsAt:         "starts_at",
+	ExpiresAt:        "expires_at",
+	ExpiryNotifiedAt: "expiry_notified_at",
 }
 
 var PermissionTableColumns = struct {
-	ID         string
-	UserID     string
-	CompanyID  string
-	Role       string
-	ContractID string
-	CreatedAt  string
-	UpdatedAt  string
+	ID               string
+	UserID           string
+	CompanyID        string
+	Role             string
+	ContractID       string
+	CreatedAt        string
+	UpdatedAt        string
+	CompanyRoleID    string
+	StartsAt         string
+	ExpiresAt        string
+	ExpiryNotifiedAt string
 }{
-	ID:         "permissions.id",
-	UserID:     "permissions.user_id",
-	CompanyID:  "permissions.company_id",
-	Role:       "permissions.role",
-	ContractID: "permissions.contract_id",
-	CreatedAt:  "permissions.created_at",
-	UpdatedAt:  "permissions.updated_at",
+	ID:               "permissions.id",
+	UserID:           "permissions.user_id",
+	CompanyID:        "permissions.company_id",
+	Role:             "permissions.role",
+	ContractID:       "permissions.contract_id",
+	CreatedAt:        "permissions.created_at",
+	UpdatedAt:        "permissions.updated_at",
+	CompanyRoleID:    "permissions.company_role_id",
+	StartsAt:         "permissions.starts_at",
+	ExpiresAt:        "permissions.expires_at",
+	ExpiryNotifiedAt: "permissions.expiry_notified_at",
 }
 
 // Generated where
 
 var PermissionWhere = struct {
-	ID         whereHelperstring
-	UserID     whereHelperstring
-	CompanyID  whereHelperstring
-	Role       whereHelperstring
-	ContractID whereHelperstring
-	CreatedAt  whereHelpertime_Time
-	UpdatedAt  whereHelpertime_Time
+	ID               whereHelperstring
+	UserID           whereHelperstring
+	CompanyID        whereHelperstring
+	Role             whereHelperstring
+	ContractID       whereHelperstring
+	CreatedAt        whereHelpertime_Time
+	UpdatedAt        whereHelpertime_Time
+	CompanyRoleID    whereHelpernull_String
+	StartsAt         whereHelpernull_Time
+	ExpiresAt        whereHelpernull_Time
+	ExpiryNotifiedAt whereHelpernull_Time
 }{
-	ID:         whereHelperstring{field: "\"permissions\".\"id\""},
-	UserID:     whereHelperstring{field: "\"permissions\".\"user_id\""},
-	CompanyID:  whereHelperstring{field: "\"permissions\".\"company_id\""},
-	Role:       whereHelperstring{field: "\"permissions\".\"role\""},
-	ContractID: whereHelperstring{field: "\"permissions\".\"contract_id\""},
-	CreatedAt:  whereHelpertime_Time{field: "\"permissions\".\"created_at\""},
-	UpdatedAt:  whereHelpertime_Time{field: "\"permissions\".\"updated_at\""},
+	ID:               whereHelperstring{field: "\"permissions\".\"id\""},
+	UserID:           whereHelperstring{field: "\"permissions\".\"user_id\""},
+	CompanyID:        whereHelperstring{field: "\"permissions\".\"company_id\""},
+	Role:             whereHelperstring{field: "\"permissions\".\"role\""},
+	ContractID:       whereHelperstring{field: "\"permissions\".\"contract_id\""},
+	CreatedAt:        whereHelpertime_Time{field: "\"permissions\".\"created_at\""},
+	UpdatedAt:        whereHelpertime_Time{field: "\"permissions\".\"updated_at\""},
+	CompanyRoleID:    whereHelpernull_String{field: "\"permissions\".\"company_role_id\""},
+	StartsAt:         whereHelpernull_Time{field: "\"permissions\".\"starts_at\""},
+	ExpiresAt:        whereHelpernull_Time{field: "\"permissions\".\"expires_at\""},
+	ExpiryNotifiedAt: whereHelpernull_Time{field: "\"permissions\".\"expiry_notified_at\""},
 }
 
 // PermissionRels is where relationship names are stored.
 var PermissionRels = struct {
-	Company string
-	User    string
+	Company     string
+	CompanyRole string
+	User        string
 }{
-	Company: "Company",
-	User:    "User",
+	Company:     "Company",
+	CompanyRole: "CompanyRole",
+	User:        "User",
 }
 
 // permissionR is where relationships are stored.
 type permissionR struct {
-	Company *Company `boil:"Company" json:"Company" toml:"Company" yaml:"Company"`
-	User    *User    `boil:"User" json:"User" toml:"User" yaml:"User"`
+	Company     *Company     `boil:"Company" json:"Company" toml:"Company" yaml:"Company"`
+	CompanyRole *CompanyRole `boil:"CompanyRole" json:"CompanyRole" toml:"CompanyRole" yaml:"CompanyRole"`
+	User        *User        `boil:"User" json:"User" toml:"User" yaml:"User"`
 }
 
 // NewStruct creates a new relationship struct
@@ -118,6 +150,13 @@ func (r *permissionR) GetCompany() *Company {
 	return r.Company
 }
 
+func (r *permissionR) GetCompanyRole() *CompanyRole {
+	if r == nil {
+		return nil
+	}
+	return r.CompanyRole
+}
+
 func (r *permissionR) GetUser() *User {
 	if r == nil {
 		return nil
@@ -129,9 +168,9 @@ func (r *permissionR) GetUser() *User {
 type permissionL struct{}
 
 var (
-	permissionAllColumns            = []string{"id", "user_id", "company_id", "role", "contract_id", "created_at", "updated_at"}
+	permissionAllColumns            = []string{"id", "user_id", "company_id", "role", "contract_id", "created_at", "updated_at", "company_role_id", "starts_at", "expires_at", "expiry_notified_at"}
 	permissionColumnsWithoutDefault = []string{"id", "user_id", "company_id", "role", "contract_id", "created_at", "updated_at"}
-	permissionColumnsWithDefault    = []string{}
+	permissionColumnsWithDefault    = []string{"company_role_id", "starts_at", "expires_at", "expiry_notified_at"}
 	permissionPrimaryKeyColumns     = []string{"id"}
 	permissionGeneratedColumns      = []string{}
 )
@@ -238,6 +277,17 @@ func (o *Permission) Company(mods ...qm.QueryMod) companyQuery {
 	return Companies(queryMods...)
 }
 
+// CompanyRole pointed to by the foreign key.
+func (o *Permission) CompanyRole(mods ...qm.QueryMod) companyRoleQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.CompanyRoleID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return CompanyRoles(queryMods...)
+}
+
 // User pointed to by the foreign key.
 func (o *Permission) User(mods ...qm.QueryMod) userQuery {
 	queryMods := []qm.QueryMod{
@@ -361,6 +411,122 @@ func (permissionL) LoadCompany(ctx context.Context, e boil.ContextExecutor, sing
 	return nil
 }
 
+// LoadCompanyRole allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (permissionL) LoadCompanyRole(ctx context.Context, e boil.ContextExecutor, singular bool, maybePermission interface{}, mods queries.Applicator) error {
+	var slice []*Permission
+	var object *Permission
+
+	if singular {
+		var ok bool
+		object, ok = maybePermission.(*Permission)
+		if !ok {
+			object = new(Permission)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybePermission)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybePermission))
+			}
+		}
+	} else {
+		s, ok := maybePermission.(*[]*Permission)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybePermission)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybePermission))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &permissionR{}
+		}
+		if !queries.IsNil(object.CompanyRoleID) {
+			args[object.CompanyRoleID] = struct{}{}
+		}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &permissionR{}
+			}
+
+			if !queries.IsNil(obj.CompanyRoleID) {
+				args[obj.CompanyRoleID] = struct{}{}
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`company_roles`),
+		qm.WhereIn(`company_roles.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load CompanyRole")
+	}
+
+	var resultSlice []*CompanyRole
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice CompanyRole")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for company_roles")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for company_roles")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.CompanyRole = foreign
+		if foreign.R == nil {
+			foreign.R = &companyRoleR{}
+		}
+		foreign.R.Permissions = append(foreign.R.Permissions, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.CompanyRoleID, foreign.ID) {
+				local.R.CompanyRole = foreign
+				if foreign.R == nil {
+					foreign.R = &companyRoleR{}
+				}
+				foreign.R.Permissions = append(foreign.R.Permissions, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadUser allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func (permissionL) LoadUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybePermission interface{}, mods queries.Applicator) error {
@@ -520,6 +686,86 @@ func (o *Permission) SetCompany(ctx context.Context, exec boil.ContextExecutor,
 	return nil
 }
 
+// SetCompanyRole of the permission to the related item.
+// Sets o.R.CompanyRole to related.
+// Adds o to related.R.Permissions.
+func (o *Permission) SetCompanyRole(ctx context.Context, exec boil.ContextExecutor, insert bool, related *CompanyRole) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"permissions\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"company_role_id"}),
+		strmangle.WhereClause("\"", "\"", 2, permissionPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.CompanyRoleID, related.ID)
+	if o.R == nil {
+		o.R = &permissionR{
+			CompanyRole: related,
+		}
+	} else {
+		o.R.CompanyRole = related
+	}
+
+	if related.R == nil {
+		related.R = &companyRoleR{
+			Permissions: PermissionSlice{o},
+		}
+	} else {
+		related.R.Permissions = append(related.R.Permissions, o)
+	}
+
+	return nil
+}
+
+// RemoveCompanyRole relationship.
+// Sets o.R.CompanyRole to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *Permission) RemoveCompanyRole(ctx context.Context, exec boil.ContextExecutor, related *CompanyRole) error {
+	var err error
+
+	queries.SetScanner(&o.CompanyRoleID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("company_role_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.CompanyRole = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.Permissions {
+		if queries.Equal(o.CompanyRoleID, ri.CompanyRoleID) {
+			continue
+		}
+
+		ln := len(related.R.Permissions)
+		if ln > 1 && i < ln-1 {
+			related.R.Permissions[i] = related.R.Permissions[ln-1]
+		}
+		related.R.Permissions = related.R.Permissions[:ln-1]
+		break
+	}
+	return nil
+}
+
 // SetUser of the permission to the related item.
 // Sets o.R.User to related.
 // Adds o to related.R.Permissions.
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,51 +24,107 @@ import (
 
 // Session is an object representing the database table.
 type Session struct {
-	ID        string    `boil:"id" json:"id" toml:"id" yaml:"id"`
-	UserID    string    `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
-	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	ExpiresAt time.Time `boil:"expires_at" json:"expires_at" toml:"expires_at" yaml:"expires_at"`
+	ID               string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	UserID           string      `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	CreatedAt        time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ExpiresAt        time.Time   `boil:"expires_at" json:"expires_at" toml:"expires_at" yaml:"expires_at"`
+	RefreshTokenHash null.String `boil:"refresh_token_hash" json:"refresh_token_hash,omitempty" toml:"refresh_token_hash" yaml:"refresh_token_hash,omitempty"`
+	RefreshExpiresAt null.Time   `boil:"refresh_expires_at" json:"refresh_expires_at,omitempty" toml:"refresh_expires_at" yaml:"refresh_expires_at,omitempty"`
+	FamilyID         string      `boil:"family_id" json:"family_id" toml:"family_id" yaml:"family_id"`
+	ReplacedBy       null.String `boil:"replaced_by" json:"replaced_by,omitempty" toml:"replaced_by" yaml:"replaced_by,omitempty"`
+	RevokedAt        null.Time   `boil:"revoked_at" json:"revoked_at,omitempty" toml:"revoked_at" yaml:"revoked_at,omitempty"`
+	UserAgent        null.String `boil:"user_agent" json:"user_agent,omitempty" toml:"user_agent" yaml:"user_agent,omitempty"`
+	IPAddress        null.String `boil:"ip_address" json:"ip_address,omitempty" toml:"ip_address" yaml:"ip_address,omitempty"`
+	LastSeenAt       null.Time   `boil:"last_seen_at" json:"last_seen_at,omitempty" toml:"last_seen_at" yaml:"last_seen_at,omitempty"`
 
 	R *sessionR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L sessionL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var SessionColumns = struct {
-	ID        string
-	UserID    string
-	CreatedAt string
-	ExpiresAt string
+	ID               string
+	UserID           string
+	CreatedAt        string
+	ExpiresAt        string
+	RefreshTokenHash string
+	RefreshExpiresAt string
+	FamilyID         string
+	ReplacedBy       string
+	RevokedAt        string
+	UserAgent        string
+	IPAddress        string
+	LastSeenAt       string
 }{
-	ID:        "id",
-	UserID:    "user_id",
-	CreatedAt: "created_at",
-	ExpiresAt: "expires_at",
+	ID:               "id",
+	UserID:           "user_id",
+	CreatedAt:        "created_at",
+	ExpiresAt:        "expires_at",
+	RefreshTokenHash: "refresh_token_hash",
+	RefreshExpiresAt: "refresh_expires_at",
+	FamilyID:         "family_id",
+	ReplacedBy:       "replaced_by",
+	RevokedAt:        "revoked_at",
+	UserAgent:        "user_agent",
+	IPAddress:        "ip_address",
+	LastSeenAt:       "last_seen_at",
 }
 
 var SessionTableColumns = struct {
-	ID        string
-	UserID    string
-	CreatedAt string
-	ExpiresAt string
+	ID               string
+	UserID           string
+	CreatedAt        string
+	ExpiresAt        string
+	RefreshTokenHash string
+	RefreshExpiresAt string
+	FamilyID         string
+	ReplacedBy       string
+	RevokedAt        string
+	UserAgent        string
+	IPAddress        string
+	LastSeenAt       string
 }{
-	ID:        "session.id",
-	UserID:    "session.user_id",
-	CreatedAt: "session.created_at",
-	ExpiresAt: "session.expires_at",
+	ID:               "session.id",
+	UserID:           "session.user_id",
+	CreatedAt:        "session.created_at",
+	ExpiresAt:        "session.expires_at",
+	RefreshTokenHash: "session.refresh_token_hash",
+	RefreshExpiresAt: "session.refresh_expires_at",
+	FamilyID:         "session.family_id",
+	ReplacedBy:       "session.replaced_by",
+	RevokedAt:        "session.revoked_at",
+	UserAgent:        "session.user_agent",
+	IPAddress:        "session.ip_address",
+	LastSeenAt:       "session.last_seen_at",
 }
 
 // Generated where
 
 var SessionWhere = struct {
-	ID        whereHelperstring
-	UserID    whereHelperstring
-	CreatedAt whereHelpertime_Time
-	ExpiresAt whereHelpertime_Time
+	ID               whereHelperstring
+	UserID           whereHelperstring
+	CreatedAt        whereHelpertime_Time
+	ExpiresAt        whereHelpertime_Time
+	RefreshTokenHash whereHelpernull_String
+	RefreshExpiresAt whereHelpernull_Time
+	FamilyID         whereHelperstring
+	ReplacedBy       whereHelpernull_String
+	RevokedAt        whereHelpernull_Time
+	UserAgent        whereHelpernull_String
+	IPAddress        whereHelpernull_String
+	LastSeenAt       whereHelpernull_Time
 }{
-	ID:        whereHelperstring{field: "\"session\".\"id\""},
-	UserID:    whereHelperstring{field: "\"session\".\"user_id\""},
-	CreatedAt: whereHelpertime_Time{field: "\"session\".\"created_at\""},
-	ExpiresAt: whereHelpertime_Time{field: "\"session\".\"expires_at\""},
+	ID:               whereHelperstring{field: "\"session\".\"id\""},
+	UserID:           whereHelperstring{field: "\"session\".\"user_id\""},
+	CreatedAt:        whereHelpertime_Time{field: "\"session\".\"created_at\""},
+	ExpiresAt:        whereHelpertime_Time{field: "\"session\".\"expires_at\""},
+	RefreshTokenHash: whereHelpernull_String{field: "\"session\".\"refresh_token_hash\""},
+	RefreshExpiresAt: whereHelpernull_Time{field: "\"session\".\"refresh_expires_at\""},
+	FamilyID:         whereHelperstring{field: "\"session\".\"family_id\""},
+	ReplacedBy:       whereHelpernull_String{field: "\"session\".\"replaced_by\""},
+	RevokedAt:        whereHelpernull_Time{field: "\"session\".\"revoked_at\""},
+	UserAgent:        whereHelpernull_String{field: "\"session\".\"user_agent\""},
+	IPAddress:        whereHelpernull_String{field: "\"session\".\"ip_address\""},
+	LastSeenAt:       whereHelpernull_Time{field: "\"session\".\"last_seen_at\""},
 }
 
 // SessionRels is where relationship names are stored.
@@ -87,9 +144,9 @@ func (*sessionR) NewStruct() *sessionR {
 type sessionL struct{}
 
 var (
-	sessionAllColumns            = []string{"id", "user_id", "created_at", "expires_at"}
-	sessionColumnsWithoutDefault = []string{"id", "user_id", "created_at", "expires_at"}
-	sessionColumnsWithDefault    = []string{}
+	sessionAllColumns            = []string{"id", "user_id", "created_at", "expires_at", "refresh_token_hash", "refresh_expires_at", "family_id", "replaced_by", "revoked_at", "user_agent", "ip_address", "last_seen_at"}
+	sessionColumnsWithoutDefault = []string{"id", "user_id", "created_at", "expires_at", "family_id"}
+	sessionColumnsWithDefault    = []string{"refresh_token_hash", "refresh_expires_at", "replaced_by", "revoked_at", "user_agent", "ip_address", "last_seen_at"}
 	sessionPrimaryKeyColumns     = []string{"id"}
 	sessionGeneratedColumns      = []string{}
 )
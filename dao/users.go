@@ -24,121 +24,272 @@ import (
 
 // User is an object representing the database table.
 type User struct {
-	ID           string      `boil:"id" json:"id" toml:"id" yaml:"id"`
-	FirstName    string      `boil:"first_name" json:"first_name" toml:"first_name" yaml:"first_name"`
-	LastName     string      `boil:"last_name" json:"last_name" toml:"last_name" yaml:"last_name"`
-	Phone        string      `boil:"phone" json:"phone" toml:"phone" yaml:"phone"`
-	Email        string      `boil:"email" json:"email" toml:"email" yaml:"email"`
-	EmailHash    string      `boil:"email_hash" json:"email_hash" toml:"email_hash" yaml:"email_hash"`
-	PasswordHash string      `boil:"password_hash" json:"password_hash" toml:"password_hash" yaml:"password_hash"`
-	InvitedBy    null.String `boil:"invited_by" json:"invited_by,omitempty" toml:"invited_by" yaml:"invited_by,omitempty"`
-	CreatedAt    time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt    time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
-	DeletedAt    null.Time   `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	ID                 string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	FirstName          string      `boil:"first_name" json:"first_name" toml:"first_name" yaml:"first_name"`
+	LastName           string      `boil:"last_name" json:"last_name" toml:"last_name" yaml:"last_name"`
+	Phone              string      `boil:"phone" json:"phone" toml:"phone" yaml:"phone"`
+	Email              string      `boil:"email" json:"email" toml:"email" yaml:"email"`
+	EmailHash          string      `boil:"email_hash" json:"email_hash" toml:"email_hash" yaml:"email_hash"`
+	PasswordHash       string      `boil:"password_hash" json:"password_hash" toml:"password_hash" yaml:"password_hash"`
+	InvitedBy          null.String `boil:"invited_by" json:"invited_by,omitempty" toml:"invited_by" yaml:"invited_by,omitempty"`
+	CreatedAt          time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt          time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	DeletedAt          null.Time   `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	DeletedBy          null.String `boil:"deleted_by" json:"deleted_by,omitempty" toml:"deleted_by" yaml:"deleted_by,omitempty"`
+	DeletionID         null.String `boil:"deletion_id" json:"deletion_id,omitempty" toml:"deletion_id" yaml:"deletion_id,omitempty"`
+	EmailVerifiedAt    null.Time   `boil:"email_verified_at" json:"email_verified_at,omitempty" toml:"email_verified_at" yaml:"email_verified_at,omitempty"`
+	VerificationSentAt null.Time   `boil:"verification_sent_at" json:"verification_sent_at,omitempty" toml:"verification_sent_at" yaml:"verification_sent_at,omitempty"`
+	TotpSecret         null.String `boil:"totp_secret" json:"totp_secret,omitempty" toml:"totp_secret" yaml:"totp_secret,omitempty"`
+	TotpEnabledAt      null.Time   `boil:"totp_enabled_at" json:"totp_enabled_at,omitempty" toml:"totp_enabled_at" yaml:"totp_enabled_at,omitempty"`
+	TotpLastUsedStep   null.Int64  `boil:"totp_last_used_step" json:"totp_last_used_step,omitempty" toml:"totp_last_used_step" yaml:"totp_last_used_step,omitempty"`
+	FailedLoginCount   int         `boil:"failed_login_count" json:"failed_login_count" toml:"failed_login_count" yaml:"failed_login_count"`
+	LastFailedLoginAt  null.Time   `boil:"last_failed_login_at" json:"last_failed_login_at,omitempty" toml:"last_failed_login_at" yaml:"last_failed_login_at,omitempty"`
+	LockedUntil        null.Time   `boil:"locked_until" json:"locked_until,omitempty" toml:"locked_until" yaml:"locked_until,omitempty"`
+	IsServiceAccount   bool        `boil:"is_service_account" json:"is_service_account" toml:"is_service_account" yaml:"is_service_account"`
 
 	R *userR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L userL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var UserColumns = struct {
-	ID           string
-	FirstName    string
-	LastName     string
-	Phone        string
-	Email        string
-	EmailHash    string
-	PasswordHash string
-	InvitedBy    string
-	CreatedAt    string
-	UpdatedAt    string
-	DeletedAt    string
+	ID                 string
+	FirstName          string
+	LastName           string
+	Phone              string
+	Email              string
+	EmailHash          string
+	PasswordHash       string
+	InvitedBy          string
+	CreatedAt          string
+	UpdatedAt          string
+	DeletedAt          string
+	DeletedBy          string
+	DeletionID         string
+	EmailVerifiedAt    string
+	VerificationSentAt string
+	TotpSecret         string
+	TotpEnabledAt      string
+	TotpLastUsedStep   string
+	FailedLoginCount   string
+	LastFailedLoginAt  string
+	LockedUntil        string
+	IsServiceAccount   string
 }{
-	ID:           "id",
-	FirstName:    "first_name",
-	LastName:     "last_name",
-	Phone:        "phone",
-	Email:        "email",
-	EmailHash:    "email_hash",
-	PasswordHash: "password_hash",
-	InvitedBy:    "invited_by",
-	CreatedAt:    "created_at",
-	UpdatedAt:    "updated_at",
-	DeletedAt:    "deleted_at",
+	ID:                 "id",
+	FirstName:          "first_name",
+	LastName:           "last_name",
+	Phone:              "phone",
+	Email:              "email",
+	EmailHash:          "email_hash",
+	PasswordHash:       "password_hash",
+	InvitedBy:          "invited_by",
+	CreatedAt:          "created_at",
+	UpdatedAt:          "updated_at",
+	DeletedAt:          "deleted_at",
+	DeletedBy:          "deleted_by",
+	DeletionID:         "deletion_id",
+	EmailVerifiedAt:    "email_verified_at",
+	VerificationSentAt: "verification_sent_at",
+	TotpSecret:         "totp_secret",
+	TotpEnabledAt:      "totp_enabled_at",
+	TotpLastUsedStep:   "totp_last_used_step",
+	FailedLoginCount:   "failed_login_count",
+	LastFailedLoginAt:  "last_failed_login_at",
+	LockedUntil:        "locked_until",
+	IsServiceAccount:   "is_service_account",
 }
 
 var UserTableColumns = struct {
-	ID           string
-	FirstName    string
-	LastName     string
-	Phone        string
-	Email        string
-	EmailHash    string
-	PasswordHash string
-	InvitedBy    string
-	CreatedAt    string
-	UpdatedAt    string
-	DeletedAt    string
+	ID                 string
+	FirstName          string
+	LastName           string
+	Phone              string
+	Email              string
+	EmailHash          string
+	PasswordHash       string
+	InvitedBy          string
+	CreatedAt          string
+	UpdatedAt          string
+	DeletedAt          string
+	DeletedBy          string
+	DeletionID         string
+	EmailVerifiedAt    string
+	VerificationSentAt string
+	TotpSecret         string
+	TotpEnabledAt      string
+	TotpLastUsedStep   string
+	FailedLoginCount   string
+	LastFailedLoginAt  string
+	LockedUntil        string
+	IsServiceAccount   string
 }{
-	ID:           "users.id",
-	FirstName:    "users.first_name",
-	LastName:     "users.last_name",
-	Phone:        "users.phone",
-	Email:        "users.email",
-	EmailHash:    "users.email_hash",
-	PasswordHash: "users.password_hash",
-	InvitedBy:    "users.invited_by",
-	CreatedAt:    "users.created_at",
-	UpdatedAt:    "users.updated_at",
-	DeletedAt:    "users.deleted_at",
+	ID:                 "users.id",
+	FirstName:          "users.first_name",
+	LastName:           "users.last_name",
+	Phone:              "users.phone",
+	Email:              "users.email",
+	EmailHash:          "users.email_hash",
+	PasswordHash:       "users.password_hash",
+	InvitedBy:          "users.invited_by",
+	CreatedAt:          "users.created_at",
+	UpdatedAt:          "users.updated_at",
+	DeletedAt:          "users.deleted_at",
+	DeletedBy:          "users.deleted_by",
+	DeletionID:         "users.deletion_id",
+	EmailVerifiedAt:    "users.email_verified_at",
+	VerificationSentAt: "users.verification_sent_at",
+	TotpSecret:         "users.totp_secret",
+	TotpEnabledAt:      "users.totp_enabled_at",
+	TotpLastUsedStep:   "users.totp_last_used_step",
+	FailedLoginCount:   "users.failed_login_count",
+	LastFailedLoginAt:  "users.last_failed_login_at",
+	LockedUntil:        "users.locked_until",
+	IsServiceAccount:   "users.is_service_account",
 }
 
 // Generated where
 
+type whereHelpernull_Int64 struct{ field string }
+
+func (w whereHelpernull_Int64) EQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int64) NEQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int64) LT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int64) LTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int64) GT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int64) GTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_Int64) IN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Int64) NIN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var UserWhere = struct {
-	ID           whereHelperstring
-	FirstName    whereHelperstring
-	LastName     whereHelperstring
-	Phone        whereHelperstring
-	Email        whereHelperstring
-	EmailHash    whereHelperstring
-	PasswordHash whereHelperstring
-	InvitedBy    whereHelpernull_String
-	CreatedAt    whereHelpertime_Time
-	UpdatedAt    whereHelpertime_Time
-	DeletedAt    whereHelpernull_Time
+	ID                 whereHelperstring
+	FirstName          whereHelperstring
+	LastName           whereHelperstring
+	Phone              whereHelperstring
+	Email              whereHelperstring
+	EmailHash          whereHelperstring
+	PasswordHash       whereHelperstring
+	InvitedBy          whereHelpernull_String
+	CreatedAt          whereHelpertime_Time
+	UpdatedAt          whereHelpertime_Time
+	DeletedAt          whereHelpernull_Time
+	DeletedBy          whereHelpernull_String
+	DeletionID         whereHelpernull_String
+	EmailVerifiedAt    whereHelpernull_Time
+	VerificationSentAt whereHelpernull_Time
+	TotpSecret         whereHelpernull_String
+	TotpEnabledAt      whereHelpernull_Time
+	TotpLastUsedStep   whereHelpernull_Int64
+	FailedLoginCount   whereHelperint
+	LastFailedLoginAt  whereHelpernull_Time
+	LockedUntil        whereHelpernull_Time
+	IsServiceAccount   whereHelperbool
 }{
-	ID:           whereHelperstring{field: "\"users\".\"id\""},
-	FirstName:    whereHelperstring{field: "\"users\".\"first_name\""},
-	LastName:     whereHelperstring{field: "\"users\".\"last_name\""},
-	Phone:        whereHelperstring{field: "\"users\".\"phone\""},
-	Email:        whereHelperstring{field: "\"users\".\"email\""},
-	EmailHash:    whereHelperstring{field: "\"users\".\"email_hash\""},
-	PasswordHash: whereHelperstring{field: "\"users\".\"password_hash\""},
-	InvitedBy:    whereHelpernull_String{field: "\"users\".\"invited_by\""},
-	CreatedAt:    whereHelpertime_Time{field: "\"users\".\"created_at\""},
-	UpdatedAt:    whereHelpertime_Time{field: "\"users\".\"updated_at\""},
-	DeletedAt:    whereHelpernull_Time{field: "\"users\".\"deleted_at\""},
+	ID:                 whereHelperstring{field: "\"users\".\"id\""},
+	FirstName:          whereHelperstring{field: "\"users\".\"first_name\""},
+	LastName:           whereHelperstring{field: "\"users\".\"last_name\""},
+	Phone:              whereHelperstring{field: "\"users\".\"phone\""},
+	Email:              whereHelperstring{field: "\"users\".\"email\""},
+	EmailHash:          whereHelperstring{field: "\"users\".\"email_hash\""},
+	PasswordHash:       whereHelperstring{field: "\"users\".\"password_hash\""},
+	InvitedBy:          whereHelpernull_String{field: "\"users\".\"invited_by\""},
+	CreatedAt:          whereHelpertime_Time{field: "\"users\".\"created_at\""},
+	UpdatedAt:          whereHelpertime_Time{field: "\"users\".\"updated_at\""},
+	DeletedAt:          whereHelpernull_Time{field: "\"users\".\"deleted_at\""},
+	DeletedBy:          whereHelpernull_String{field: "\"users\".\"deleted_by\""},
+	DeletionID:         whereHelpernull_String{field: "\"users\".\"deletion_id\""},
+	EmailVerifiedAt:    whereHelpernull_Time{field: "\"users\".\"email_verified_at\""},
+	VerificationSentAt: whereHelpernull_Time{field: "\"users\".\"verification_sent_at\""},
+	TotpSecret:         whereHelpernull_String{field: "\"users\".\"totp_secret\""},
+	TotpEnabledAt:      whereHelpernull_Time{field: "\"users\".\"totp_enabled_at\""},
+	TotpLastUsedStep:   whereHelpernull_Int64{field: "\"users\".\"totp_last_used_step\""},
+	FailedLoginCount:   whereHelperint{field: "\"users\".\"failed_login_count\""},
+	LastFailedLoginAt:  whereHelpernull_Time{field: "\"users\".\"last_failed_login_at\""},
+	LockedUntil:        whereHelpernull_Time{field: "\"users\".\"locked_until\""},
+	IsServiceAccount:   whereHelperbool{field: "\"users\".\"is_service_account\""},
 }
 
 // UserRels is where relationship names are stored.
 var UserRels = struct {
-	ContactCompanies string
-	CreatedByOffers  string
-	CustomerOffers   string
-	Permissions      string
+	CreatedByAPIKeys            string
+	APIKeys                     string
+	ContactCompanies            string
+	PublishedByGalleryTemplates string
+	AcceptedByInvitations       string
+	InvitedByInvitations        string
+	LoginAttempts               string
+	LoginChallenges             string
+	CreatedByOffers             string
+	CustomerOffers              string
+	FromUserOwnershipTransfers  string
+	ToUserOwnershipTransfers    string
+	PasswordResetTokens         string
+	Permissions                 string
+	TwoFactorRecoveryCodes      string
+	UserIdentities              string
 }{
-	ContactCompanies: "ContactCompanies",
-	CreatedByOffers:  "CreatedByOffers",
-	CustomerOffers:   "CustomerOffers",
-	Permissions:      "Permissions",
+	CreatedByAPIKeys:            "CreatedByAPIKeys",
+	APIKeys:                     "APIKeys",
+	ContactCompanies:            "ContactCompanies",
+	PublishedByGalleryTemplates: "PublishedByGalleryTemplates",
+	AcceptedByInvitations:       "AcceptedByInvitations",
+	InvitedByInvitations:        "InvitedByInvitations",
+	LoginAttempts:               "LoginAttempts",
+	LoginChallenges:             "LoginChallenges",
+	CreatedByOffers:             "CreatedByOffers",
+	CustomerOffers:              "CustomerOffers",
+	FromUserOwnershipTransfers:  "FromUserOwnershipTransfers",
+	ToUserOwnershipTransfers:    "ToUserOwnershipTransfers",
+	PasswordResetTokens:         "PasswordResetTokens",
+	Permissions:                 "Permissions",
+	TwoFactorRecoveryCodes:      "TwoFactorRecoveryCodes",
+	UserIdentities:              "UserIdentities",
 }
 
 // userR is where relationships are stored.
 type userR struct {
-	ContactCompanies CompanySlice    `boil:"ContactCompanies" json:"ContactCompanies" toml:"ContactCompanies" yaml:"ContactCompanies"`
-	CreatedByOffers  OfferSlice      `boil:"CreatedByOffers" json:"CreatedByOffers" toml:"CreatedByOffers" yaml:"CreatedByOffers"`
-	CustomerOffers   OfferSlice      `boil:"CustomerOffers" json:"CustomerOffers" toml:"CustomerOffers" yaml:"CustomerOffers"`
-	Permissions      PermissionSlice `boil:"Permissions" json:"Permissions" toml:"Permissions" yaml:"Permissions"`
+	CreatedByAPIKeys            APIKeySlice                `boil:"CreatedByAPIKeys" json:"CreatedByAPIKeys" toml:"CreatedByAPIKeys" yaml:"CreatedByAPIKeys"`
+	APIKeys                     APIKeySlice                `boil:"APIKeys" json:"APIKeys" toml:"APIKeys" yaml:"APIKeys"`
+	ContactCompanies            CompanySlice               `boil:"ContactCompanies" json:"ContactCompanies" toml:"ContactCompanies" yaml:"ContactCompanies"`
+	PublishedByGalleryTemplates GalleryTemplateSlice       `boil:"PublishedByGalleryTemplates" json:"PublishedByGalleryTemplates" toml:"PublishedByGalleryTemplates" yaml:"PublishedByGalleryTemplates"`
+	AcceptedByInvitations       InvitationSlice            `boil:"AcceptedByInvitations" json:"AcceptedByInvitations" toml:"AcceptedByInvitations" yaml:"AcceptedByInvitations"`
+	InvitedByInvitations        InvitationSlice            `boil:"InvitedByInvitations" json:"InvitedByInvitations" toml:"InvitedByInvitations" yaml:"InvitedByInvitations"`
+	LoginAttempts               LoginAttemptSlice          `boil:"LoginAttempts" json:"LoginAttempts" toml:"LoginAttempts" yaml:"LoginAttempts"`
+	LoginChallenges             LoginChallengeSlice        `boil:"LoginChallenges" json:"LoginChallenges" toml:"LoginChallenges" yaml:"LoginChallenges"`
+	CreatedByOffers             OfferSlice                 `boil:"CreatedByOffers" json:"CreatedByOffers" toml:"CreatedByOffers" yaml:"CreatedByOffers"`
+	CustomerOffers              OfferSlice                 `boil:"CustomerOffers" json:"CustomerOffers" toml:"CustomerOffers" yaml:"CustomerOffers"`
+	FromUserOwnershipTransfers  OwnershipTransferSlice     `boil:"FromUserOwnershipTransfers" json:"FromUserOwnershipTransfers" toml:"FromUserOwnershipTransfers" yaml:"FromUserOwnershipTransfers"`
+	ToUserOwnershipTransfers    OwnershipTransferSlice     `boil:"ToUserOwnershipTransfers" json:"ToUserOwnershipTransfers" toml:"ToUserOwnershipTransfers" yaml:"ToUserOwnershipTransfers"`
+	PasswordResetTokens         PasswordResetTokenSlice    `boil:"PasswordResetTokens" json:"PasswordResetTokens" toml:"PasswordResetTokens" yaml:"PasswordResetTokens"`
+	Permissions                 PermissionSlice            `boil:"Permissions" json:"Permissions" toml:"Permissions" yaml:"Permissions"`
+	TwoFactorRecoveryCodes      TwoFactorRecoveryCodeSlice `boil:"TwoFactorRecoveryCodes" json:"TwoFactorRecoveryCodes" toml:"TwoFactorRecoveryCodes" yaml:"TwoFactorRecoveryCodes"`
+	UserIdentities              UserIdentitySlice          `boil:"UserIdentities" json:"UserIdentities" toml:"UserIdentities" yaml:"UserIdentities"`
 }
 
 // NewStruct creates a new relationship struct
@@ -146,6 +297,20 @@ func (*userR) NewStruct() *userR {
 	return &userR{}
 }
 
+func (r *userR) GetCreatedByAPIKeys() APIKeySlice {
+	if r == nil {
+		return nil
+	}
+	return r.CreatedByAPIKeys
+}
+
+func (r *userR) GetAPIKeys() APIKeySlice {
+	if r == nil {
+		return nil
+	}
+	return r.APIKeys
+}
+
 func (r *userR) GetContactCompanies() CompanySlice {
 	if r == nil {
 		return nil
@@ -153,6 +318,41 @@ func (r *userR) GetContactCompanies() CompanySlice {
 	return r.ContactCompanies
 }
 
+func (r *userR) GetPublishedByGalleryTemplates() GalleryTemplateSlice {
+	if r == nil {
+		return nil
+	}
+	return r.PublishedByGalleryTemplates
+}
+
+func (r *userR) GetAcceptedByInvitations() InvitationSlice {
+	if r == nil {
+		return nil
+	}
+	return r.AcceptedByInvitations
+}
+
+func (r *userR) GetInvitedByInvitations() InvitationSlice {
+	if r == nil {
+		return nil
+	}
+	return r.InvitedByInvitations
+}
+
+func (r *userR) GetLoginAttempts() LoginAttemptSlice {
+	if r == nil {
+		return nil
+	}
+	return r.LoginAttempts
+}
+
+func (r *userR) GetLoginChallenges() LoginChallengeSlice {
+	if r == nil {
+		return nil
+	}
+	return r.LoginChallenges
+}
+
 func (r *userR) GetCreatedByOffers() OfferSlice {
 	if r == nil {
 		return nil
@@ -167,6 +367,27 @@ func (r *userR) GetCustomerOffers() OfferSlice {
 	return r.CustomerOffers
 }
 
+func (r *userR) GetFromUserOwnershipTransfers() OwnershipTransferSlice {
+	if r == nil {
+		return nil
+	}
+	return r.FromUserOwnershipTransfers
+}
+
+func (r *userR) GetToUserOwnershipTransfers() OwnershipTransferSlice {
+	if r == nil {
+		return nil
+	}
+	return r.ToUserOwnershipTransfers
+}
+
+func (r *userR) GetPasswordResetTokens() PasswordResetTokenSlice {
+	if r == nil {
+		return nil
+	}
+	return r.PasswordResetTokens
+}
+
 func (r *userR) GetPermissions() PermissionSlice {
 	if r == nil {
 		return nil
@@ -174,13 +395,27 @@ func (r *userR) GetPermissions() PermissionSlice {
 	return r.Permissions
 }
 
+func (r *userR) GetTwoFactorRecoveryCodes() TwoFactorRecoveryCodeSlice {
+	if r == nil {
+		return nil
+	}
+	return r.TwoFactorRecoveryCodes
+}
+
+func (r *userR) GetUserIdentities() UserIdentitySlice {
+	if r == nil {
+		return nil
+	}
+	return r.UserIdentities
+}
+
 // userL is where Load methods for each relationship are stored.
 type userL struct{}
 
 var (
-	userAllColumns            = []string{"id", "first_name", "last_name", "phone", "email", "email_hash", "password_hash", "invited_by", "created_at", "updated_at", "deleted_at"}
+	userAllColumns            = []string{"id", "first_name", "last_name", "phone", "email", "email_hash", "password_hash", "invited_by", "created_at", "updated_at", "deleted_at", "deleted_by", "deletion_id", "email_verified_at", "verification_sent_at", "totp_secret", "totp_enabled_at", "totp_last_used_step", "failed_login_count", "last_failed_login_at", "locked_until", "is_service_account"}
 	userColumnsWithoutDefault = []string{"id", "first_name", "last_name", "phone", "email", "email_hash", "password_hash", "created_at", "updated_at"}
-	userColumnsWithDefault    = []string{"invited_by", "deleted_at"}
+	userColumnsWithDefault    = []string{"invited_by", "deleted_at", "deleted_by", "deletion_id", "email_verified_at", "verification_sent_at", "totp_secret", "totp_enabled_at", "totp_last_used_step", "failed_login_count", "last_failed_login_at", "locked_until", "is_service_account"}
 	userPrimaryKeyColumns     = []string{"id"}
 	userGeneratedColumns      = []string{}
 )
@@ -276,6 +511,34 @@ func (q userQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool,
 	return count > 0, nil
 }
 
+// CreatedByAPIKeys retrieves all the api_key's APIKeys with an executor via created_by column.
+func (o *User) CreatedByAPIKeys(mods ...qm.QueryMod) apiKeyQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"api_keys\".\"created_by\"=?", o.ID),
+	)
+
+	return APIKeys(queryMods...)
+}
+
+// APIKeys retrieves all the api_key's APIKeys with an executor.
+func (o *User) APIKeys(mods ...qm.QueryMod) apiKeyQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"api_keys\".\"user_id\"=?", o.ID),
+	)
+
+	return APIKeys(queryMods...)
+}
+
 // ContactCompanies retrieves all the company's Companies with an executor via contact_id column.
 func (o *User) ContactCompanies(mods ...qm.QueryMod) companyQuery {
 	var queryMods []qm.QueryMod
@@ -290,6 +553,76 @@ func (o *User) ContactCompanies(mods ...qm.QueryMod) companyQuery {
 	return Companies(queryMods...)
 }
 
+// PublishedByGalleryTemplates retrieves all the gallery_template's GalleryTemplates with an executor via published_by column.
+func (o *User) PublishedByGalleryTemplates(mods ...qm.QueryMod) galleryTemplateQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"gallery_templates\".\"published_by\"=?", o.ID),
+	)
+
+	return GalleryTemplates(queryMods...)
+}
+
+// AcceptedByInvitations retrieves all the invitation's Invitations with an executor via accepted_by column.
+func (o *User) AcceptedByInvitations(mods ...qm.QueryMod) invitationQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"invitations\".\"accepted_by\"=?", o.ID),
+	)
+
+	return Invitations(queryMods...)
+}
+
+// InvitedByInvitations retrieves all the invitation's Invitations with an executor via invited_by column.
+func (o *User) InvitedByInvitations(mods ...qm.QueryMod) invitationQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"invitations\".\"invited_by\"=?", o.ID),
+	)
+
+	return Invitations(queryMods...)
+}
+
+// LoginAttempts retrieves all the login_attempt's LoginAttempts with an executor.
+func (o *User) LoginAttempts(mods ...qm.QueryMod) loginAttemptQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"login_attempts\".\"user_id\"=?", o.ID),
+	)
+
+	return LoginAttempts(queryMods...)
+}
+
+// LoginChallenges retrieves all the login_challenge's LoginChallenges with an executor.
+func (o *User) LoginChallenges(mods ...qm.QueryMod) loginChallengeQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"login_challenges\".\"user_id\"=?", o.ID),
+	)
+
+	return LoginChallenges(queryMods...)
+}
+
 // CreatedByOffers retrieves all the offer's Offers with an executor via created_by column.
 func (o *User) CreatedByOffers(mods ...qm.QueryMod) offerQuery {
 	var queryMods []qm.QueryMod
@@ -318,6 +651,48 @@ func (o *User) CustomerOffers(mods ...qm.QueryMod) offerQuery {
 	return Offers(queryMods...)
 }
 
+// FromUserOwnershipTransfers retrieves all the ownership_transfer's OwnershipTransfers with an executor via from_user_id column.
+func (o *User) FromUserOwnershipTransfers(mods ...qm.QueryMod) ownershipTransferQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"ownership_transfers\".\"from_user_id\"=?", o.ID),
+	)
+
+	return OwnershipTransfers(queryMods...)
+}
+
+// ToUserOwnershipTransfers retrieves all the ownership_transfer's OwnershipTransfers with an executor via to_user_id column.
+func (o *User) ToUserOwnershipTransfers(mods ...qm.QueryMod) ownershipTransferQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"ownership_transfers\".\"to_user_id\"=?", o.ID),
+	)
+
+	return OwnershipTransfers(queryMods...)
+}
+
+// PasswordResetTokens retrieves all the password_reset_token's PasswordResetTokens with an executor.
+func (o *User) PasswordResetTokens(mods ...qm.QueryMod) passwordResetTokenQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"password_reset_tokens\".\"user_id\"=?", o.ID),
+	)
+
+	return PasswordResetTokens(queryMods...)
+}
+
 // Permissions retrieves all the permission's Permissions with an executor.
 func (o *User) Permissions(mods ...qm.QueryMod) permissionQuery {
 	var queryMods []qm.QueryMod
@@ -332,9 +707,37 @@ func (o *User) Permissions(mods ...qm.QueryMod) permissionQuery {
 	return Permissions(queryMods...)
 }
 
-// LoadContactCompanies allows an eager lookup of values, cached into the
+// TwoFactorRecoveryCodes retrieves all the two_factor_recovery_code's TwoFactorRecoveryCodes with an executor.
+func (o *User) TwoFactorRecoveryCodes(mods ...qm.QueryMod) twoFactorRecoveryCodeQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"two_factor_recovery_codes\".\"user_id\"=?", o.ID),
+	)
+
+	return TwoFactorRecoveryCodes(queryMods...)
+}
+
+// UserIdentities retrieves all the user_identity's UserIdentities with an executor.
+func (o *User) UserIdentities(mods ...qm.QueryMod) userIdentityQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"user_identities\".\"user_id\"=?", o.ID),
+	)
+
+	return UserIdentities(queryMods...)
+}
+
+// LoadCreatedByAPIKeys allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (userL) LoadContactCompanies(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+func (userL) LoadCreatedByAPIKeys(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
 	var slice []*User
 	var object *User
 
@@ -387,8 +790,8 @@ func (userL) LoadContactCompanies(ctx context.Context, e boil.ContextExecutor, s
 	}
 
 	query := NewQuery(
-		qm.From(`companies`),
-		qm.WhereIn(`companies.contact_id in ?`, argsSlice...),
+		qm.From(`api_keys`),
+		qm.WhereIn(`api_keys.created_by in ?`, argsSlice...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -396,40 +799,40 @@ func (userL) LoadContactCompanies(ctx context.Context, e boil.ContextExecutor, s
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load companies")
+		return errors.Wrap(err, "failed to eager load api_keys")
 	}
 
-	var resultSlice []*Company
+	var resultSlice []*APIKey
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice companies")
+		return errors.Wrap(err, "failed to bind eager loaded slice api_keys")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on companies")
+		return errors.Wrap(err, "failed to close results in eager load on api_keys")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for companies")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for api_keys")
 	}
 
 	if singular {
-		object.R.ContactCompanies = resultSlice
+		object.R.CreatedByAPIKeys = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &companyR{}
+				foreign.R = &apiKeyR{}
 			}
-			foreign.R.Contact = object
+			foreign.R.CreatedByUser = object
 		}
 		return nil
 	}
 
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
-			if local.ID == foreign.ContactID {
-				local.R.ContactCompanies = append(local.R.ContactCompanies, foreign)
+			if local.ID == foreign.CreatedBy {
+				local.R.CreatedByAPIKeys = append(local.R.CreatedByAPIKeys, foreign)
 				if foreign.R == nil {
-					foreign.R = &companyR{}
+					foreign.R = &apiKeyR{}
 				}
-				foreign.R.Contact = local
+				foreign.R.CreatedByUser = local
 				break
 			}
 		}
@@ -438,9 +841,9 @@ func (userL) LoadContactCompanies(ctx context.Context, e boil.ContextExecutor, s
 	return nil
 }
 
-// LoadCreatedByOffers allows an eager lookup of values, cached into the
+// LoadAPIKeys allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (userL) LoadCreatedByOffers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+func (userL) LoadAPIKeys(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
 	var slice []*User
 	var object *User
 
@@ -493,8 +896,8 @@ func (userL) LoadCreatedByOffers(ctx context.Context, e boil.ContextExecutor, si
 	}
 
 	query := NewQuery(
-		qm.From(`offers`),
-		qm.WhereIn(`offers.created_by in ?`, argsSlice...),
+		qm.From(`api_keys`),
+		qm.WhereIn(`api_keys.user_id in ?`, argsSlice...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -502,40 +905,40 @@ func (userL) LoadCreatedByOffers(ctx context.Context, e boil.ContextExecutor, si
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load offers")
+		return errors.Wrap(err, "failed to eager load api_keys")
 	}
 
-	var resultSlice []*Offer
+	var resultSlice []*APIKey
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice offers")
+		return errors.Wrap(err, "failed to bind eager loaded slice api_keys")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on offers")
+		return errors.Wrap(err, "failed to close results in eager load on api_keys")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for offers")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for api_keys")
 	}
 
 	if singular {
-		object.R.CreatedByOffers = resultSlice
+		object.R.APIKeys = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &offerR{}
+				foreign.R = &apiKeyR{}
 			}
-			foreign.R.CreatedByUser = object
+			foreign.R.User = object
 		}
 		return nil
 	}
 
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
-			if local.ID == foreign.CreatedBy {
-				local.R.CreatedByOffers = append(local.R.CreatedByOffers, foreign)
+			if local.ID == foreign.UserID {
+				local.R.APIKeys = append(local.R.APIKeys, foreign)
 				if foreign.R == nil {
-					foreign.R = &offerR{}
+					foreign.R = &apiKeyR{}
 				}
-				foreign.R.CreatedByUser = local
+				foreign.R.User = local
 				break
 			}
 		}
@@ -544,9 +947,9 @@ func (userL) LoadCreatedByOffers(ctx context.Context, e boil.ContextExecutor, si
 	return nil
 }
 
-// LoadCustomerOffers allows an eager lookup of values, cached into the
+// LoadContactCompanies allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (userL) LoadCustomerOffers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+func (userL) LoadContactCompanies(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
 	var slice []*User
 	var object *User
 
@@ -599,8 +1002,8 @@ func (userL) LoadCustomerOffers(ctx context.Context, e boil.ContextExecutor, sin
 	}
 
 	query := NewQuery(
-		qm.From(`offers`),
-		qm.WhereIn(`offers.customer_id in ?`, argsSlice...),
+		qm.From(`companies`),
+		qm.WhereIn(`companies.contact_id in ?`, argsSlice...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -608,40 +1011,40 @@ func (userL) LoadCustomerOffers(ctx context.Context, e boil.ContextExecutor, sin
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load offers")
+		return errors.Wrap(err, "failed to eager load companies")
 	}
 
-	var resultSlice []*Offer
+	var resultSlice []*Company
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice offers")
+		return errors.Wrap(err, "failed to bind eager loaded slice companies")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on offers")
+		return errors.Wrap(err, "failed to close results in eager load on companies")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for offers")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for companies")
 	}
 
 	if singular {
-		object.R.CustomerOffers = resultSlice
+		object.R.ContactCompanies = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &offerR{}
+				foreign.R = &companyR{}
 			}
-			foreign.R.Customer = object
+			foreign.R.Contact = object
 		}
 		return nil
 	}
 
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
-			if local.ID == foreign.CustomerID {
-				local.R.CustomerOffers = append(local.R.CustomerOffers, foreign)
+			if local.ID == foreign.ContactID {
+				local.R.ContactCompanies = append(local.R.ContactCompanies, foreign)
 				if foreign.R == nil {
-					foreign.R = &offerR{}
+					foreign.R = &companyR{}
 				}
-				foreign.R.Customer = local
+				foreign.R.Contact = local
 				break
 			}
 		}
@@ -650,9 +1053,9 @@ func (userL) LoadCustomerOffers(ctx context.Context, e boil.ContextExecutor, sin
 	return nil
 }
 
-// LoadPermissions allows an eager lookup of values, cached into the
+// LoadPublishedByGalleryTemplates allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (userL) LoadPermissions(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+func (userL) LoadPublishedByGalleryTemplates(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
 	var slice []*User
 	var object *User
 
@@ -705,8 +1108,8 @@ func (userL) LoadPermissions(ctx context.Context, e boil.ContextExecutor, singul
 	}
 
 	query := NewQuery(
-		qm.From(`permissions`),
-		qm.WhereIn(`permissions.user_id in ?`, argsSlice...),
+		qm.From(`gallery_templates`),
+		qm.WhereIn(`gallery_templates.published_by in ?`, argsSlice...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -714,65 +1117,1856 @@ func (userL) LoadPermissions(ctx context.Context, e boil.ContextExecutor, singul
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load permissions")
+		return errors.Wrap(err, "failed to eager load gallery_templates")
 	}
 
-	var resultSlice []*Permission
+	var resultSlice []*GalleryTemplate
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice permissions")
+		return errors.Wrap(err, "failed to bind eager loaded slice gallery_templates")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on permissions")
+		return errors.Wrap(err, "failed to close results in eager load on gallery_templates")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for gallery_templates")
+	}
+
+	if singular {
+		object.R.PublishedByGalleryTemplates = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &galleryTemplateR{}
+			}
+			foreign.R.PublishedByUser = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.PublishedBy {
+				local.R.PublishedByGalleryTemplates = append(local.R.PublishedByGalleryTemplates, foreign)
+				if foreign.R == nil {
+					foreign.R = &galleryTemplateR{}
+				}
+				foreign.R.PublishedByUser = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadAcceptedByInvitations allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadAcceptedByInvitations(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`invitations`),
+		qm.WhereIn(`invitations.accepted_by in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load invitations")
+	}
+
+	var resultSlice []*Invitation
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice invitations")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on invitations")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for invitations")
+	}
+
+	if singular {
+		object.R.AcceptedByInvitations = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &invitationR{}
+			}
+			foreign.R.AcceptedByUser = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.AcceptedBy) {
+				local.R.AcceptedByInvitations = append(local.R.AcceptedByInvitations, foreign)
+				if foreign.R == nil {
+					foreign.R = &invitationR{}
+				}
+				foreign.R.AcceptedByUser = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadInvitedByInvitations allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadInvitedByInvitations(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`invitations`),
+		qm.WhereIn(`invitations.invited_by in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load invitations")
+	}
+
+	var resultSlice []*Invitation
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice invitations")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on invitations")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for invitations")
+	}
+
+	if singular {
+		object.R.InvitedByInvitations = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &invitationR{}
+			}
+			foreign.R.InvitedByUser = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.InvitedBy {
+				local.R.InvitedByInvitations = append(local.R.InvitedByInvitations, foreign)
+				if foreign.R == nil {
+					foreign.R = &invitationR{}
+				}
+				foreign.R.InvitedByUser = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadLoginAttempts allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadLoginAttempts(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`login_attempts`),
+		qm.WhereIn(`login_attempts.user_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load login_attempts")
+	}
+
+	var resultSlice []*LoginAttempt
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice login_attempts")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on login_attempts")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for login_attempts")
+	}
+
+	if singular {
+		object.R.LoginAttempts = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &loginAttemptR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.UserID) {
+				local.R.LoginAttempts = append(local.R.LoginAttempts, foreign)
+				if foreign.R == nil {
+					foreign.R = &loginAttemptR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadLoginChallenges allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadLoginChallenges(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`login_challenges`),
+		qm.WhereIn(`login_challenges.user_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load login_challenges")
+	}
+
+	var resultSlice []*LoginChallenge
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice login_challenges")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on login_challenges")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for login_challenges")
+	}
+
+	if singular {
+		object.R.LoginChallenges = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &loginChallengeR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.LoginChallenges = append(local.R.LoginChallenges, foreign)
+				if foreign.R == nil {
+					foreign.R = &loginChallengeR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadCreatedByOffers allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadCreatedByOffers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`offers`),
+		qm.WhereIn(`offers.created_by in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load offers")
+	}
+
+	var resultSlice []*Offer
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice offers")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on offers")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for offers")
+	}
+
+	if singular {
+		object.R.CreatedByOffers = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &offerR{}
+			}
+			foreign.R.CreatedByUser = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.CreatedBy {
+				local.R.CreatedByOffers = append(local.R.CreatedByOffers, foreign)
+				if foreign.R == nil {
+					foreign.R = &offerR{}
+				}
+				foreign.R.CreatedByUser = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadCustomerOffers allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadCustomerOffers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`offers`),
+		qm.WhereIn(`offers.customer_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load offers")
+	}
+
+	var resultSlice []*Offer
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice offers")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on offers")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for offers")
+	}
+
+	if singular {
+		object.R.CustomerOffers = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &offerR{}
+			}
+			foreign.R.Customer = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.CustomerID {
+				local.R.CustomerOffers = append(local.R.CustomerOffers, foreign)
+				if foreign.R == nil {
+					foreign.R = &offerR{}
+				}
+				foreign.R.Customer = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadFromUserOwnershipTransfers allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadFromUserOwnershipTransfers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`ownership_transfers`),
+		qm.WhereIn(`ownership_transfers.from_user_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load ownership_transfers")
+	}
+
+	var resultSlice []*OwnershipTransfer
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice ownership_transfers")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on ownership_transfers")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for ownership_transfers")
+	}
+
+	if singular {
+		object.R.FromUserOwnershipTransfers = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &ownershipTransferR{}
+			}
+			foreign.R.FromUser = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.FromUserID {
+				local.R.FromUserOwnershipTransfers = append(local.R.FromUserOwnershipTransfers, foreign)
+				if foreign.R == nil {
+					foreign.R = &ownershipTransferR{}
+				}
+				foreign.R.FromUser = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadToUserOwnershipTransfers allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadToUserOwnershipTransfers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`ownership_transfers`),
+		qm.WhereIn(`ownership_transfers.to_user_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load ownership_transfers")
+	}
+
+	var resultSlice []*OwnershipTransfer
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice ownership_transfers")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on ownership_transfers")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for ownership_transfers")
+	}
+
+	if singular {
+		object.R.ToUserOwnershipTransfers = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &ownershipTransferR{}
+			}
+			foreign.R.ToUser = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ToUserID {
+				local.R.ToUserOwnershipTransfers = append(local.R.ToUserOwnershipTransfers, foreign)
+				if foreign.R == nil {
+					foreign.R = &ownershipTransferR{}
+				}
+				foreign.R.ToUser = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadPasswordResetTokens allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadPasswordResetTokens(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`password_reset_tokens`),
+		qm.WhereIn(`password_reset_tokens.user_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load password_reset_tokens")
+	}
+
+	var resultSlice []*PasswordResetToken
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice password_reset_tokens")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on password_reset_tokens")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for password_reset_tokens")
+	}
+
+	if singular {
+		object.R.PasswordResetTokens = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &passwordResetTokenR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.PasswordResetTokens = append(local.R.PasswordResetTokens, foreign)
+				if foreign.R == nil {
+					foreign.R = &passwordResetTokenR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadPermissions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadPermissions(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`permissions`),
+		qm.WhereIn(`permissions.user_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load permissions")
+	}
+
+	var resultSlice []*Permission
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice permissions")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on permissions")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for permissions")
+	}
+
+	if singular {
+		object.R.Permissions = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &permissionR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.Permissions = append(local.R.Permissions, foreign)
+				if foreign.R == nil {
+					foreign.R = &permissionR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadTwoFactorRecoveryCodes allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadTwoFactorRecoveryCodes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`two_factor_recovery_codes`),
+		qm.WhereIn(`two_factor_recovery_codes.user_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load two_factor_recovery_codes")
+	}
+
+	var resultSlice []*TwoFactorRecoveryCode
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice two_factor_recovery_codes")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on two_factor_recovery_codes")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for two_factor_recovery_codes")
+	}
+
+	if singular {
+		object.R.TwoFactorRecoveryCodes = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &twoFactorRecoveryCodeR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.TwoFactorRecoveryCodes = append(local.R.TwoFactorRecoveryCodes, foreign)
+				if foreign.R == nil {
+					foreign.R = &twoFactorRecoveryCodeR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadUserIdentities allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadUserIdentities(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`user_identities`),
+		qm.WhereIn(`user_identities.user_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load user_identities")
+	}
+
+	var resultSlice []*UserIdentity
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice user_identities")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on user_identities")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_identities")
+	}
+
+	if singular {
+		object.R.UserIdentities = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &userIdentityR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.UserIdentities = append(local.R.UserIdentities, foreign)
+				if foreign.R == nil {
+					foreign.R = &userIdentityR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddCreatedByAPIKeys adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.CreatedByAPIKeys.
+// Sets related.R.CreatedByUser appropriately.
+func (o *User) AddCreatedByAPIKeys(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*APIKey) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.CreatedBy = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"api_keys\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"created_by"}),
+				strmangle.WhereClause("\"", "\"", 2, apiKeyPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.CreatedBy = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			CreatedByAPIKeys: related,
+		}
+	} else {
+		o.R.CreatedByAPIKeys = append(o.R.CreatedByAPIKeys, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &apiKeyR{
+				CreatedByUser: o,
+			}
+		} else {
+			rel.R.CreatedByUser = o
+		}
+	}
+	return nil
+}
+
+// AddAPIKeys adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.APIKeys.
+// Sets related.R.User appropriately.
+func (o *User) AddAPIKeys(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*APIKey) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"api_keys\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, apiKeyPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			APIKeys: related,
+		}
+	} else {
+		o.R.APIKeys = append(o.R.APIKeys, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &apiKeyR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddContactCompanies adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.ContactCompanies.
+// Sets related.R.Contact appropriately.
+func (o *User) AddContactCompanies(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Company) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ContactID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"companies\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"contact_id"}),
+				strmangle.WhereClause("\"", "\"", 2, companyPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ContactID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			ContactCompanies: related,
+		}
+	} else {
+		o.R.ContactCompanies = append(o.R.ContactCompanies, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &companyR{
+				Contact: o,
+			}
+		} else {
+			rel.R.Contact = o
+		}
+	}
+	return nil
+}
+
+// AddPublishedByGalleryTemplates adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.PublishedByGalleryTemplates.
+// Sets related.R.PublishedByUser appropriately.
+func (o *User) AddPublishedByGalleryTemplates(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*GalleryTemplate) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.PublishedBy = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"gallery_templates\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"published_by"}),
+				strmangle.WhereClause("\"", "\"", 2, galleryTemplatePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.PublishedBy = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			PublishedByGalleryTemplates: related,
+		}
+	} else {
+		o.R.PublishedByGalleryTemplates = append(o.R.PublishedByGalleryTemplates, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &galleryTemplateR{
+				PublishedByUser: o,
+			}
+		} else {
+			rel.R.PublishedByUser = o
+		}
+	}
+	return nil
+}
+
+// AddAcceptedByInvitations adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.AcceptedByInvitations.
+// Sets related.R.AcceptedByUser appropriately.
+func (o *User) AddAcceptedByInvitations(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Invitation) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.AcceptedBy, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"invitations\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"accepted_by"}),
+				strmangle.WhereClause("\"", "\"", 2, invitationPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.AcceptedBy, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			AcceptedByInvitations: related,
+		}
+	} else {
+		o.R.AcceptedByInvitations = append(o.R.AcceptedByInvitations, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &invitationR{
+				AcceptedByUser: o,
+			}
+		} else {
+			rel.R.AcceptedByUser = o
+		}
+	}
+	return nil
+}
+
+// SetAcceptedByInvitations removes all previously related items of the
+// user replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.AcceptedByUser's AcceptedByInvitations accordingly.
+// Replaces o.R.AcceptedByInvitations with related.
+// Sets related.R.AcceptedByUser's AcceptedByInvitations accordingly.
+func (o *User) SetAcceptedByInvitations(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Invitation) error {
+	query := "update \"invitations\" set \"accepted_by\" = null where \"accepted_by\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.AcceptedByInvitations {
+			queries.SetScanner(&rel.AcceptedBy, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.AcceptedByUser = nil
+		}
+		o.R.AcceptedByInvitations = nil
+	}
+
+	return o.AddAcceptedByInvitations(ctx, exec, insert, related...)
+}
+
+// RemoveAcceptedByInvitations relationships from objects passed in.
+// Removes related items from R.AcceptedByInvitations (uses pointer comparison, removal does not keep order)
+// Sets related.R.AcceptedByUser.
+func (o *User) RemoveAcceptedByInvitations(ctx context.Context, exec boil.ContextExecutor, related ...*Invitation) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.AcceptedBy, nil)
+		if rel.R != nil {
+			rel.R.AcceptedByUser = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("accepted_by")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.AcceptedByInvitations {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.AcceptedByInvitations)
+			if ln > 1 && i < ln-1 {
+				o.R.AcceptedByInvitations[i] = o.R.AcceptedByInvitations[ln-1]
+			}
+			o.R.AcceptedByInvitations = o.R.AcceptedByInvitations[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// AddInvitedByInvitations adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.InvitedByInvitations.
+// Sets related.R.InvitedByUser appropriately.
+func (o *User) AddInvitedByInvitations(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Invitation) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.InvitedBy = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"invitations\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"invited_by"}),
+				strmangle.WhereClause("\"", "\"", 2, invitationPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.InvitedBy = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			InvitedByInvitations: related,
+		}
+	} else {
+		o.R.InvitedByInvitations = append(o.R.InvitedByInvitations, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &invitationR{
+				InvitedByUser: o,
+			}
+		} else {
+			rel.R.InvitedByUser = o
+		}
+	}
+	return nil
+}
+
+// AddLoginAttempts adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.LoginAttempts.
+// Sets related.R.User appropriately.
+func (o *User) AddLoginAttempts(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*LoginAttempt) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.UserID, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"login_attempts\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, loginAttemptPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.UserID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			LoginAttempts: related,
+		}
+	} else {
+		o.R.LoginAttempts = append(o.R.LoginAttempts, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &loginAttemptR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// SetLoginAttempts removes all previously related items of the
+// user replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.User's LoginAttempts accordingly.
+// Replaces o.R.LoginAttempts with related.
+// Sets related.R.User's LoginAttempts accordingly.
+func (o *User) SetLoginAttempts(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*LoginAttempt) error {
+	query := "update \"login_attempts\" set \"user_id\" = null where \"user_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.LoginAttempts {
+			queries.SetScanner(&rel.UserID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.User = nil
+		}
+		o.R.LoginAttempts = nil
+	}
+
+	return o.AddLoginAttempts(ctx, exec, insert, related...)
+}
+
+// RemoveLoginAttempts relationships from objects passed in.
+// Removes related items from R.LoginAttempts (uses pointer comparison, removal does not keep order)
+// Sets related.R.User.
+func (o *User) RemoveLoginAttempts(ctx context.Context, exec boil.ContextExecutor, related ...*LoginAttempt) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.UserID, nil)
+		if rel.R != nil {
+			rel.R.User = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("user_id")); err != nil {
+			return err
+		}
 	}
-	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for permissions")
+	if o.R == nil {
+		return nil
 	}
 
-	if singular {
-		object.R.Permissions = resultSlice
-		for _, foreign := range resultSlice {
-			if foreign.R == nil {
-				foreign.R = &permissionR{}
+	for _, rel := range related {
+		for i, ri := range o.R.LoginAttempts {
+			if rel != ri {
+				continue
 			}
-			foreign.R.User = object
-		}
-		return nil
-	}
 
-	for _, foreign := range resultSlice {
-		for _, local := range slice {
-			if local.ID == foreign.UserID {
-				local.R.Permissions = append(local.R.Permissions, foreign)
-				if foreign.R == nil {
-					foreign.R = &permissionR{}
-				}
-				foreign.R.User = local
-				break
+			ln := len(o.R.LoginAttempts)
+			if ln > 1 && i < ln-1 {
+				o.R.LoginAttempts[i] = o.R.LoginAttempts[ln-1]
 			}
+			o.R.LoginAttempts = o.R.LoginAttempts[:ln-1]
+			break
 		}
 	}
 
 	return nil
 }
 
-// AddContactCompanies adds the given related objects to the existing relationships
+// AddLoginChallenges adds the given related objects to the existing relationships
 // of the user, optionally inserting them as new records.
-// Appends related to o.R.ContactCompanies.
-// Sets related.R.Contact appropriately.
-func (o *User) AddContactCompanies(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Company) error {
+// Appends related to o.R.LoginChallenges.
+// Sets related.R.User appropriately.
+func (o *User) AddLoginChallenges(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*LoginChallenge) error {
 	var err error
 	for _, rel := range related {
 		if insert {
-			rel.ContactID = o.ID
+			rel.UserID = o.ID
 			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
 				return errors.Wrap(err, "failed to insert into foreign table")
 			}
 		} else {
 			updateQuery := fmt.Sprintf(
-				"UPDATE \"companies\" SET %s WHERE %s",
-				strmangle.SetParamNames("\"", "\"", 1, []string{"contact_id"}),
-				strmangle.WhereClause("\"", "\"", 2, companyPrimaryKeyColumns),
+				"UPDATE \"login_challenges\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, loginChallengePrimaryKeyColumns),
 			)
 			values := []interface{}{o.ID, rel.ID}
 
@@ -785,25 +2979,25 @@ func (o *User) AddContactCompanies(ctx context.Context, exec boil.ContextExecuto
 				return errors.Wrap(err, "failed to update foreign table")
 			}
 
-			rel.ContactID = o.ID
+			rel.UserID = o.ID
 		}
 	}
 
 	if o.R == nil {
 		o.R = &userR{
-			ContactCompanies: related,
+			LoginChallenges: related,
 		}
 	} else {
-		o.R.ContactCompanies = append(o.R.ContactCompanies, related...)
+		o.R.LoginChallenges = append(o.R.LoginChallenges, related...)
 	}
 
 	for _, rel := range related {
 		if rel.R == nil {
-			rel.R = &companyR{
-				Contact: o,
+			rel.R = &loginChallengeR{
+				User: o,
 			}
 		} else {
-			rel.R.Contact = o
+			rel.R.User = o
 		}
 	}
 	return nil
@@ -915,6 +3109,165 @@ func (o *User) AddCustomerOffers(ctx context.Context, exec boil.ContextExecutor,
 	return nil
 }
 
+// AddFromUserOwnershipTransfers adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.FromUserOwnershipTransfers.
+// Sets related.R.FromUser appropriately.
+func (o *User) AddFromUserOwnershipTransfers(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*OwnershipTransfer) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.FromUserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"ownership_transfers\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"from_user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, ownershipTransferPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.FromUserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			FromUserOwnershipTransfers: related,
+		}
+	} else {
+		o.R.FromUserOwnershipTransfers = append(o.R.FromUserOwnershipTransfers, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &ownershipTransferR{
+				FromUser: o,
+			}
+		} else {
+			rel.R.FromUser = o
+		}
+	}
+	return nil
+}
+
+// AddToUserOwnershipTransfers adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.ToUserOwnershipTransfers.
+// Sets related.R.ToUser appropriately.
+func (o *User) AddToUserOwnershipTransfers(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*OwnershipTransfer) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ToUserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"ownership_transfers\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"to_user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, ownershipTransferPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ToUserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			ToUserOwnershipTransfers: related,
+		}
+	} else {
+		o.R.ToUserOwnershipTransfers = append(o.R.ToUserOwnershipTransfers, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &ownershipTransferR{
+				ToUser: o,
+			}
+		} else {
+			rel.R.ToUser = o
+		}
+	}
+	return nil
+}
+
+// AddPasswordResetTokens adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.PasswordResetTokens.
+// Sets related.R.User appropriately.
+func (o *User) AddPasswordResetTokens(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*PasswordResetToken) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"password_reset_tokens\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, passwordResetTokenPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			PasswordResetTokens: related,
+		}
+	} else {
+		o.R.PasswordResetTokens = append(o.R.PasswordResetTokens, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &passwordResetTokenR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
 // AddPermissions adds the given related objects to the existing relationships
 // of the user, optionally inserting them as new records.
 // Appends related to o.R.Permissions.
@@ -968,6 +3321,112 @@ func (o *User) AddPermissions(ctx context.Context, exec boil.ContextExecutor, in
 	return nil
 }
 
+// AddTwoFactorRecoveryCodes adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.TwoFactorRecoveryCodes.
+// Sets related.R.User appropriately.
+func (o *User) AddTwoFactorRecoveryCodes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*TwoFactorRecoveryCode) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"two_factor_recovery_codes\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, twoFactorRecoveryCodePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			TwoFactorRecoveryCodes: related,
+		}
+	} else {
+		o.R.TwoFactorRecoveryCodes = append(o.R.TwoFactorRecoveryCodes, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &twoFactorRecoveryCodeR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddUserIdentities adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.UserIdentities.
+// Sets related.R.User appropriately.
+func (o *User) AddUserIdentities(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*UserIdentity) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"user_identities\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, userIdentityPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			UserIdentities: related,
+		}
+	} else {
+		o.R.UserIdentities = append(o.R.UserIdentities, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &userIdentityR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
 // Users retrieves all the records using an executor.
 func Users(mods ...qm.QueryMod) userQuery {
 	mods = append(mods, qm.From("\"users\""))
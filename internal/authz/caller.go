@@ -0,0 +1,63 @@
+package authz
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/pro-posal/webserver/dao"
+	"github.com/pro-posal/webserver/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// TwoFactorPolicyCondition drops admin grants in companies that require two-factor
+// authentication while the user hasn't enabled it; it is written against the permissions table.
+const TwoFactorPolicyCondition = `NOT (
+	permissions.role IN ('admin', 'company_admin')
+	AND EXISTS (SELECT 1 FROM companies c WHERE c.id = permissions.company_id AND c.require_two_factor)
+	AND NOT EXISTS (SELECT 1 FROM users u WHERE u.id = permissions.user_id AND u.totp_enabled_at IS NOT NULL)
+)`
+
+// LoadCaller loads the user's permissions in effect now, with the capabilities of the company roles
+// they hold. Permissions that haven't started yet or have expired are left out, and so are admin
+// roles the user may not use before enabling two-factor authentication.
+func LoadCaller(ctx context.Context, exec boil.ContextExecutor, userID string) (*Caller, error) {
+	permissionsDao, err := dao.Permissions(
+		qm.Where("user_id = ?", userID),
+		qm.Where(TwoFactorPolicyCondition),
+		qm.Load(dao.PermissionRels.CompanyRole),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching permissions: %w", err)
+	}
+
+	now := time.Now().UTC()
+	permissions := make([]*models.Permission, 0, len(permissionsDao))
+	for _, permissionDao := range permissionsDao {
+		permission := &models.Permission{
+			ID:         permissionDao.ID,
+			UserID:     permissionDao.UserID,
+			CompanyID:  permissionDao.CompanyID,
+			Role:       models.Role(permissionDao.Role),
+			ContractID: permissionDao.ContractID,
+			StartsAt:   permissionDao.StartsAt.Ptr(),
+			ExpiresAt:  permissionDao.ExpiresAt.Ptr(),
+			CreatedAt:  permissionDao.CreatedAt,
+			UpdatedAt:  permissionDao.UpdatedAt,
+		}
+		if !permission.ActiveAt(now) {
+			continue
+		}
+		if companyRole := permissionDao.R.GetCompanyRole(); companyRole != nil {
+			permission.CompanyRoleID = companyRole.ID
+			if err := companyRole.Capabilities.Unmarshal(&permission.Capabilities); err != nil {
+				log.Printf("Failed unmarshaling capabilities of role %v: %v", companyRole.ID, err)
+			}
+		}
+		permissions = append(permissions, permission)
+	}
+
+	return &Caller{UserID: userID, Permissions: permissions}, nil
+}
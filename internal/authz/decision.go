@@ -0,0 +1,84 @@
+package authz
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/pro-posal/webserver/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// Decision is the outcome of evaluating a policy. Rule is the rule that decided, the route's policy
+// unless a platform admin passed it, and Permissions are the caller's permissions it looked at.
+type Decision struct {
+	Allowed     bool                 `json:"allowed"`
+	Rule        string               `json:"rule"`
+	Reason      string               `json:"reason,omitempty"`
+	CompanyID   string               `json:"company_id,omitempty"`
+	Permissions []*models.Permission `json:"permissions"`
+}
+
+func (d *Decision) deny(reason string) *Decision {
+	d.Allowed = false
+	d.Reason = reason
+	return d
+}
+
+// Explain evaluates the policy for the caller like Authorize, but reports how the decision was
+// reached instead of only whether the caller is let through.
+func (p Policy) Explain(r *http.Request, exec boil.ContextExecutor, caller *Caller) (*Decision, error) {
+	decision := &Decision{Allowed: true, Rule: p.String(), Permissions: caller.Permissions}
+	if p.public {
+		return decision, nil
+	}
+	if caller.IsAdmin() {
+		decision.Rule = "platform admin"
+		decision.Permissions = caller.permissionsWithRole(models.AdminRole)
+		return decision, nil
+	}
+	if p.admin {
+		return decision.deny("requires a platform admin"), nil
+	}
+
+	if p.self != nil && p.self(r) != caller.UserID {
+		return decision.deny("only the user themselves may make this request"), nil
+	}
+
+	if p.company != nil {
+		companyID, err := p.company(r, exec)
+		if err != nil {
+			return nil, err
+		}
+		if companyID == "" {
+			return decision.deny("the requested resource doesn't belong to a company"), nil
+		}
+		decision.CompanyID = companyID
+		decision.Permissions = caller.PermissionsInCompany(companyID)
+		if !caller.Can(companyID, p.capabilities...) {
+			return decision.deny("no role in the company grants one of the capabilities " + capabilityList(p.capabilities)), nil
+		}
+	}
+
+	if p.check != nil {
+		err := p.check(r, caller)
+		var denied *DeniedError
+		if errors.As(err, &denied) {
+			return decision.deny(denied.Reason), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return decision, nil
+}
+
+func capabilityList(capabilities []models.Capability) string {
+	list := ""
+	for i, capability := range capabilities {
+		if i > 0 {
+			list += ", "
+		}
+		list += string(capability)
+	}
+	return list
+}
@@ -0,0 +1,170 @@
+// Package authz declares who may call each route. Every route registered on the router carries a
+// Policy naming the capability it requires and how to find the company owning the requested resource,
+// and the router refuses to start when a route doesn't.
+package authz
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pro-posal/webserver/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// Caller is the signed in user a policy is evaluated for.
+type Caller struct {
+	UserID      string
+	Permissions []*models.Permission
+}
+
+// IsAdmin reports whether the caller is a platform admin, who may call every route.
+func (c *Caller) IsAdmin() bool {
+	for _, permission := range c.Permissions {
+		if permission.Role == models.AdminRole {
+			return true
+		}
+	}
+	return false
+}
+
+// Can reports whether one of the caller's roles in the company grants one of the capabilities.
+func (c *Caller) Can(companyID string, capabilities ...models.Capability) bool {
+	for _, permission := range c.Permissions {
+		if permission.CompanyID != companyID {
+			continue
+		}
+		for _, capability := range capabilities {
+			if permission.Can(capability) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PermissionsInCompany returns the caller's permissions in the company.
+func (c *Caller) PermissionsInCompany(companyID string) []*models.Permission {
+	permissions := []*models.Permission{}
+	for _, permission := range c.Permissions {
+		if permission.CompanyID == companyID {
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions
+}
+
+func (c *Caller) permissionsWithRole(role models.Role) []*models.Permission {
+	permissions := []*models.Permission{}
+	for _, permission := range c.Permissions {
+		if permission.Role == role {
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions
+}
+
+// DeniedError explains why a policy turned the caller away.
+type DeniedError struct {
+	Reason string
+}
+
+func (e *DeniedError) Error() string {
+	return "access denied: " + e.Reason
+}
+
+// Deny returns a DeniedError with a formatted reason.
+func Deny(format string, args ...any) error {
+	return &DeniedError{Reason: fmt.Sprintf(format, args...)}
+}
+
+// Check is a policy's own rule, for routes the declarative fields can't express.
+type Check func(r *http.Request, caller *Caller) error
+
+// Policy is the permission a route requires. Build one with the constructors below.
+type Policy struct {
+	public       bool
+	admin        bool
+	self         ID
+	company      CompanyResolver
+	capabilities []models.Capability
+	check        Check
+	unverified   bool
+}
+
+// Public routes are called without signing in.
+func Public() Policy {
+	return Policy{public: true}
+}
+
+// SignedIn routes are open to every signed in user, the handler scopes the work to the caller.
+func SignedIn() Policy {
+	return Policy{}
+}
+
+// PlatformAdmin routes are only open to platform admins.
+func PlatformAdmin() Policy {
+	return Policy{admin: true}
+}
+
+// Self routes act on the user whose ID is in the request, and only that user may call them.
+func Self(userID ID) Policy {
+	return Policy{self: userID}
+}
+
+// InCompany routes require a role granting one of the capabilities in the company that owns the
+// requested resource.
+func InCompany(company CompanyResolver, capabilities ...models.Capability) Policy {
+	return Policy{company: company, capabilities: capabilities}
+}
+
+// Custom routes are decided by check alone.
+func Custom(check Check) Policy {
+	return Policy{check: check}
+}
+
+// IsPublic reports whether the route is called without signing in.
+func (p Policy) IsPublic() bool {
+	return p.public
+}
+
+// AllowUnverified opens the route to users who haven't verified their email address yet, even in
+// the sensitive verification mode, so they can still fix their address, secure or sign out.
+func (p Policy) AllowUnverified() Policy {
+	p.unverified = true
+	return p
+}
+
+// AllowsUnverified reports whether users who haven't verified their email address may call the route.
+func (p Policy) AllowsUnverified() bool {
+	return p.unverified
+}
+
+// Authorize evaluates the policy for the caller, returning a *DeniedError when they may not
+// make the request. Platform admins pass every policy.
+func (p Policy) Authorize(r *http.Request, exec boil.ContextExecutor, caller *Caller) error {
+	decision, err := p.Explain(r, exec, caller)
+	if err != nil {
+		return err
+	}
+	if !decision.Allowed {
+		return &DeniedError{Reason: decision.Reason}
+	}
+	return nil
+}
+
+// String describes the rule the policy enforces.
+func (p Policy) String() string {
+	switch {
+	case p.public:
+		return "public"
+	case p.admin:
+		return "platform admin"
+	case p.self != nil:
+		return "the user themselves"
+	case p.company != nil:
+		return fmt.Sprintf("one of %v in the owning company", p.capabilities)
+	case p.check != nil:
+		return "custom check"
+	}
+	return "signed in"
+}
@@ -0,0 +1,151 @@
+package authz
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/pro-posal/webserver/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func noop(http.ResponseWriter, *http.Request) {}
+
+func requestFor(t *testing.T, template string, method string, path string, body string) *http.Request {
+	t.Helper()
+	router := mux.NewRouter()
+	router.Handle(template, Protect(SignedIn(), noop)).Methods(method)
+
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	var match mux.RouteMatch
+	require.True(t, router.Match(r, &match))
+	return mux.SetURLVars(r, match.Vars)
+}
+
+func caller(role models.Role, companyID string) *Caller {
+	return &Caller{
+		UserID:      "user",
+		Permissions: []*models.Permission{{UserID: "user", CompanyID: companyID, Role: role}},
+	}
+}
+
+func TestPolicy_InCompany(t *testing.T) {
+	policy := InCompany(Company(Var("companyId")), models.CapabilityCompanyManage)
+	r := requestFor(t, "/companies/{companyId}", "PUT", "/companies/acme", "")
+
+	assert.NoError(t, policy.Authorize(r, nil, caller(models.CompanyAdminRole, "acme")))
+	assert.NoError(t, policy.Authorize(r, nil, caller(models.AdminRole, "")))
+
+	var denied *DeniedError
+	assert.ErrorAs(t, policy.Authorize(r, nil, caller(models.CompanyContributorRole, "acme")), &denied)
+	assert.ErrorAs(t, policy.Authorize(r, nil, caller(models.CompanyAdminRole, "other")), &denied)
+}
+
+func TestPolicy_CompanyRoleCapabilities(t *testing.T) {
+	policy := InCompany(Company(Var("companyId")), models.CapabilityCatalogEdit)
+	r := requestFor(t, "/companies/{companyId}/categories/order", "PUT", "/companies/acme/categories/order", "")
+
+	pricingManager := &Caller{
+		UserID: "user",
+		Permissions: []*models.Permission{{
+			UserID:       "user",
+			CompanyID:    "acme",
+			Role:         models.CompanyCustomRole,
+			Capabilities: []models.Capability{models.CapabilityCatalogView, models.CapabilityCatalogEdit},
+		}},
+	}
+	assert.NoError(t, policy.Authorize(r, nil, pricingManager))
+
+	templates := InCompany(Company(Var("companyId")), models.CapabilityTemplatesEdit)
+	var denied *DeniedError
+	assert.ErrorAs(t, templates.Authorize(r, nil, pricingManager), &denied)
+	assert.ErrorAs(t, policy.Authorize(r, nil, caller(models.ProspectRole, "acme")), &denied)
+}
+
+func TestPolicy_BodyFieldIsRestored(t *testing.T) {
+	policy := InCompany(Company(BodyField("company_id")), models.CapabilityCatalogEdit)
+	body := `{"company_id":"acme","name":"Acme"}`
+	r := requestFor(t, "/categories", "POST", "/categories", body)
+
+	assert.NoError(t, policy.Authorize(r, nil, caller(models.CompanyAdminRole, "acme")))
+
+	restored, err := io.ReadAll(r.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(restored))
+}
+
+func TestPolicy_BodyFieldSpelledTwiceIsRefused(t *testing.T) {
+	policy := InCompany(Company(BodyField("company_id")), models.CapabilityCatalogEdit)
+	// encoding/json would hand the handler the second spelling
+	r := requestFor(t, "/categories", "POST", "/categories", `{"company_id":"acme","COMPANY_ID":"other"}`)
+
+	var denied *DeniedError
+	assert.ErrorAs(t, policy.Authorize(r, nil, caller(models.CompanyAdminRole, "acme")), &denied)
+
+	r = requestFor(t, "/categories", "POST", "/categories", `{"Company_Id":"acme"}`)
+	assert.NoError(t, policy.Authorize(r, nil, caller(models.CompanyAdminRole, "acme")))
+}
+
+func TestPolicy_SelfAndPlatformAdmin(t *testing.T) {
+	r := requestFor(t, "/users/{id}", "GET", "/users/user", "")
+
+	assert.NoError(t, Self(Var("id")).Authorize(r, nil, &Caller{UserID: "user"}))
+	assert.Error(t, Self(Var("id")).Authorize(r, nil, &Caller{UserID: "someone else"}))
+	assert.Error(t, PlatformAdmin().Authorize(r, nil, &Caller{UserID: "user"}))
+	assert.NoError(t, SignedIn().Authorize(r, nil, &Caller{UserID: "user"}))
+}
+
+func TestVerify(t *testing.T) {
+	router := mux.NewRouter()
+	router.Handle("/status", Protect(Public(), noop)).Methods("GET")
+	require.NoError(t, Verify(router))
+
+	router.HandleFunc("/users", noop).Methods("GET")
+	err := Verify(router)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GET /users")
+}
+
+func TestPolicy_Explain(t *testing.T) {
+	policy := InCompany(Company(Var("companyId")), models.CapabilityTemplatesEdit)
+	r := requestFor(t, "/companies/{companyId}/contracts", "POST", "/companies/acme/contracts", "")
+
+	prospect := &Caller{
+		UserID: "user",
+		Permissions: []*models.Permission{
+			{UserID: "user", CompanyID: "acme", Role: models.ProspectRole},
+			{UserID: "user", CompanyID: "other", Role: models.CompanyAdminRole},
+		},
+	}
+	decision, err := policy.Explain(r, nil, prospect)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, "one of [templates.edit] in the owning company", decision.Rule)
+	assert.Equal(t, "acme", decision.CompanyID)
+	assert.Contains(t, decision.Reason, "templates.edit")
+	assert.Equal(t, prospect.Permissions[:1], decision.Permissions)
+
+	decision, err = policy.Explain(r, nil, caller(models.AdminRole, "other"))
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, "platform admin", decision.Rule)
+
+	denied := Custom(func(*http.Request, *Caller) error { return Deny("not the customer") })
+	decision, err = denied.Explain(r, nil, prospect)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, "custom check", decision.Rule)
+	assert.Equal(t, "not the customer", decision.Reason)
+}
+
+func TestPolicy_AllowUnverified(t *testing.T) {
+	assert.False(t, SignedIn().AllowsUnverified())
+
+	policy := SignedIn().AllowUnverified()
+	assert.True(t, policy.AllowsUnverified())
+	assert.Equal(t, "signed in", policy.String())
+}
@@ -0,0 +1,97 @@
+package authz
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// ID reads the ID of a resource from a request.
+type ID func(r *http.Request) string
+
+// Var reads an ID from a route variable.
+func Var(name string) ID {
+	return func(r *http.Request) string {
+		return mux.Vars(r)[name]
+	}
+}
+
+// BodyField reads an ID from a field of the JSON request body. The body is restored so the
+// handler can still read it. encoding/json matches field names case-insensitively, so a body
+// spelling the field more than one way is refused rather than letting the handler decode another
+// company than the one authorized. Prefer IDs from the path where a route has them.
+func BodyField(name string) ID {
+	return func(r *http.Request) string {
+		if r.Body == nil {
+			return ""
+		}
+		body, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return ""
+		}
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return ""
+		}
+		var value json.RawMessage
+		for field, raw := range fields {
+			if !strings.EqualFold(field, name) {
+				continue
+			}
+			if value != nil {
+				return ""
+			}
+			value = raw
+		}
+
+		var id string
+		if err := json.Unmarshal(value, &id); err != nil {
+			return ""
+		}
+		return id
+	}
+}
+
+// CompanyResolver finds the company owning the resource a request is about. It returns an empty
+// ID when there is no such resource.
+type CompanyResolver func(r *http.Request, exec boil.ContextExecutor) (string, error)
+
+// Company resolves to the company with the ID, for routes on the company itself.
+func Company(id ID) CompanyResolver {
+	return func(r *http.Request, _ boil.ContextExecutor) (string, error) {
+		return id(r), nil
+	}
+}
+
+// CompanyOf resolves to the company_id of the row with the ID in the table, for routes on
+// resources that don't carry their company in the path.
+func CompanyOf(table string, id ID) CompanyResolver {
+	query := fmt.Sprintf(`SELECT company_id FROM "%s" WHERE id = $1`, table)
+	return func(r *http.Request, exec boil.ContextExecutor) (string, error) {
+		resourceID := id(r)
+		if resourceID == "" {
+			return "", nil
+		}
+
+		var companyID string
+		err := exec.QueryRowContext(r.Context(), query, resourceID).Scan(&companyID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return "", nil
+			}
+			return "", fmt.Errorf("failed resolving the company of %s %v: %w", table, resourceID, err)
+		}
+		return companyID, nil
+	}
+}
@@ -0,0 +1,67 @@
+package authz
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// protected is a route's handler together with the policy guarding it.
+type protected struct {
+	policy  Policy
+	handler http.HandlerFunc
+}
+
+func (p *protected) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.handler(w, r)
+}
+
+// Protect attaches the policy to a handler, register the result with router.Handle.
+func Protect(policy Policy, handler http.HandlerFunc) http.Handler {
+	return &protected{policy: policy, handler: handler}
+}
+
+// PolicyOf returns the policy of the route that matched the request.
+func PolicyOf(r *http.Request) (Policy, bool) {
+	return RoutePolicy(mux.CurrentRoute(r))
+}
+
+// RoutePolicy returns the policy a route was registered with.
+func RoutePolicy(route *mux.Route) (Policy, bool) {
+	if route == nil {
+		return Policy{}, false
+	}
+	handler, ok := route.GetHandler().(*protected)
+	if !ok {
+		return Policy{}, false
+	}
+	return handler.policy, true
+}
+
+// Verify fails when a route of the router was registered without a policy.
+func Verify(router *mux.Router) error {
+	var missing []string
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		if _, ok := route.GetHandler().(*protected); ok {
+			return nil
+		}
+
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		methods, _ := route.GetMethods()
+		missing = append(missing, fmt.Sprintf("%s %s", strings.Join(methods, ","), path))
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed walking routes: %w", err)
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("routes without an authorization policy: %s", strings.Join(missing, "; "))
+	}
+	return nil
+}
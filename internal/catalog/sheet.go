@@ -0,0 +1,199 @@
+package catalog
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// Columns is the header written on export and expected on import.
+var Columns = []string{"category", "sub_category", "description", "unit", "price"}
+
+// columnAliases maps the header spellings we accept to the canonical column names.
+var columnAliases = map[string]string{
+	"category":     "category",
+	"sub_category": "sub_category",
+	"sub-category": "sub_category",
+	"subcategory":  "sub_category",
+	"sub category": "sub_category",
+	"description":  "description",
+	"unit":         "unit",
+	"price":        "price",
+}
+
+// Row is a single catalog line; Line is the 1-based row number in the sheet, header included.
+type Row struct {
+	Line        int
+	Category    string
+	SubCategory string
+	Description string
+	Unit        string
+	Price       string
+}
+
+// ParseFormat resolves the sheet format from an explicit name, a file name or a content type.
+func ParseFormat(values ...string) (Format, error) {
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		switch {
+		case value == "":
+			continue
+		case value == "csv", value == ".csv", strings.HasPrefix(value, "text/csv"):
+			return FormatCSV, nil
+		case value == "xlsx", value == ".xlsx", strings.HasPrefix(value, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"):
+			return FormatXLSX, nil
+		case filepath.Ext(value) != "" && filepath.Ext(value) != value:
+			if format, err := ParseFormat(filepath.Ext(value)); err == nil {
+				return format, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("unsupported catalog format, expected csv or xlsx")
+}
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// ReadRows reads catalog rows from a CSV file or the first sheet of an XLSX workbook.
+// Blank lines are skipped.
+func ReadRows(r io.Reader, format Format) ([]Row, error) {
+	var records [][]string
+	switch format {
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		var err error
+		records, err = reader.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("failed reading csv: %w", err)
+		}
+	case FormatXLSX:
+		workbook, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed opening xlsx: %w", err)
+		}
+		defer workbook.Close()
+
+		sheets := workbook.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, errors.New("xlsx workbook has no sheets")
+		}
+		records, err = workbook.GetRows(sheets[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed reading xlsx sheet: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported catalog format %q", format)
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("catalog sheet is empty")
+	}
+
+	indexes, err := headerIndexes(records[0])
+	if err != nil {
+		return nil, err
+	}
+
+	cell := func(record []string, column string) string {
+		index, ok := indexes[column]
+		if !ok || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	var rows []Row
+	for i, record := range records[1:] {
+		row := Row{
+			Line:        i + 2,
+			Category:    cell(record, "category"),
+			SubCategory: cell(record, "sub_category"),
+			Description: cell(record, "description"),
+			Unit:        cell(record, "unit"),
+			Price:       cell(record, "price"),
+		}
+		if row == (Row{Line: row.Line}) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// WriteRows writes the rows with a header line in the given format.
+func WriteRows(w io.Writer, format Format, rows []Row) error {
+	records := [][]string{Columns}
+	for _, row := range rows {
+		records = append(records, []string{row.Category, row.SubCategory, row.Description, row.Unit, row.Price})
+	}
+
+	switch format {
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.WriteAll(records); err != nil {
+			return fmt.Errorf("failed writing csv: %w", err)
+		}
+		return nil
+	case FormatXLSX:
+		workbook := excelize.NewFile()
+		defer workbook.Close()
+
+		sheet := workbook.GetSheetName(0)
+		for i, record := range records {
+			cell, err := excelize.CoordinatesToCellName(1, i+1)
+			if err != nil {
+				return err
+			}
+			values := make([]interface{}, len(record))
+			for j, value := range record {
+				values[j] = value
+			}
+			if err := workbook.SetSheetRow(sheet, cell, &values); err != nil {
+				return fmt.Errorf("failed writing xlsx row: %w", err)
+			}
+		}
+		if _, err := workbook.WriteTo(w); err != nil {
+			return fmt.Errorf("failed writing xlsx: %w", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported catalog format %q", format)
+}
+
+func headerIndexes(header []string) (map[string]int, error) {
+	indexes := map[string]int{}
+	for i, name := range header {
+		column, ok := columnAliases[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))]
+		if !ok {
+			continue
+		}
+		if _, seen := indexes[column]; seen {
+			return nil, fmt.Errorf("column %q appears more than once in the header", column)
+		}
+		indexes[column] = i
+	}
+
+	if _, ok := indexes["category"]; !ok {
+		return nil, errors.New(`catalog sheet header must contain a "category" column`)
+	}
+	return indexes, nil
+}
@@ -0,0 +1,66 @@
+package catalog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sampleRows = []Row{
+	{Category: "Electrical", SubCategory: "Wiring", Description: "Install outlet", Unit: "unit", Price: "45.00"},
+	{Category: "Electrical", SubCategory: "Lighting", Description: "Hang fixture", Unit: "hour", Price: "60.50"},
+	{Category: "Plumbing"},
+}
+
+func TestReadRows_CSVHeaderAliasesAndBlankLines(t *testing.T) {
+	input := "Category,Sub-Category,Description,Price,Unit\n" +
+		"Electrical, Wiring ,Install outlet,45.00,unit\n" +
+		",,,,\n" +
+		"Plumbing\n"
+
+	rows, err := ReadRows(strings.NewReader(input), FormatCSV)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, Row{Line: 2, Category: "Electrical", SubCategory: "Wiring", Description: "Install outlet", Unit: "unit", Price: "45.00"}, rows[0])
+	assert.Equal(t, Row{Line: 4, Category: "Plumbing"}, rows[1])
+}
+
+func TestReadRows_MissingCategoryColumn(t *testing.T) {
+	_, err := ReadRows(strings.NewReader("name,price\nx,1\n"), FormatCSV)
+	assert.Error(t, err)
+}
+
+func TestWriteRows_RoundTrip(t *testing.T) {
+	for _, format := range []Format{FormatCSV, FormatXLSX} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, WriteRows(&buf, format, sampleRows))
+
+			rows, err := ReadRows(&buf, format)
+			require.NoError(t, err)
+			require.Len(t, rows, len(sampleRows))
+			for i, row := range rows {
+				expected := sampleRows[i]
+				expected.Line = i + 2
+				assert.Equal(t, expected, row)
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("", "catalog.XLSX")
+	require.NoError(t, err)
+	assert.Equal(t, FormatXLSX, format)
+
+	format, err = ParseFormat("text/csv; charset=utf-8")
+	require.NoError(t, err)
+	assert.Equal(t, FormatCSV, format)
+
+	_, err = ParseFormat("application/json")
+	assert.Error(t, err)
+}
@@ -0,0 +1,47 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStart_RunsImmediatelyAndOnInterval(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var runs atomic.Int32
+	Start(ctx, Job{
+		Name:     "counter",
+		Interval: 10 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			runs.Add(1)
+			return nil
+		},
+	})
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, 5*time.Millisecond)
+}
+
+func TestStart_SurvivesFailuresAndPanics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var runs atomic.Int32
+	Start(ctx, Job{
+		Name:     "flaky",
+		Interval: 10 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			if runs.Add(1)%2 == 0 {
+				panic("boom")
+			}
+			return errors.New("failed")
+		},
+	})
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 4 }, time.Second, 5*time.Millisecond)
+}
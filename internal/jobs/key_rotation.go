@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pro-posal/webserver/internal/keys"
+)
+
+// NewKeyRotationJob generates a new token signing key every interval, published publishAhead before
+// it starts signing, and removes keys that were replaced more than retention ago. It checks hourly,
+// which also picks up keys rotated by other instances.
+func NewKeyRotationJob(keyStore *keys.KeyStore, interval time.Duration, publishAhead time.Duration, retention time.Duration) Job {
+	return Job{
+		Name:     "key-rotation",
+		Interval: time.Hour,
+		Run: func(ctx context.Context) error {
+			result, err := keyStore.Rotate(time.Now(), interval, publishAhead, retention)
+			if err != nil {
+				return err
+			}
+			if result.Created != "" {
+				log.Printf("Generated signing key %s", result.Created)
+			}
+			for _, kid := range result.Retired {
+				log.Printf("Retired signing key %s", kid)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pro-posal/webserver/services"
+)
+
+// NewLoginAttemptsCleanupJob deletes recorded login attempts once they are older than the retention period.
+func NewLoginAttemptsCleanupJob(auth services.AuthService, retention time.Duration) Job {
+	return Job{
+		Name:     "login-attempts-cleanup",
+		Interval: time.Hour,
+		Run: func(ctx context.Context) error {
+			deleted, err := auth.PruneLoginAttempts(ctx, time.Now().Add(-retention))
+			if err != nil {
+				return err
+			}
+			log.Printf("Deleted %d old login attempts", deleted)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pro-posal/webserver/services"
+)
+
+// NewPermissionExpiryJob emails company admins about permissions expiring within the notice period
+// and deletes the permissions that have expired. A zero notice sends no emails.
+func NewPermissionExpiryJob(permissions services.PermissionManagementService, notice time.Duration, interval time.Duration) Job {
+	return Job{
+		Name:     "permission-expiry",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			if notice > 0 {
+				notified, err := permissions.NotifyExpiringPermissions(ctx, notice)
+				if err != nil {
+					return err
+				}
+				if notified > 0 {
+					log.Printf("Notified company admins about %d expiring permissions", notified)
+				}
+			}
+
+			deleted, err := permissions.DeleteExpiredPermissions(ctx)
+			if err != nil {
+				return err
+			}
+			if deleted > 0 {
+				log.Printf("Deleted %d expired permissions", deleted)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,45 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pro-posal/webserver/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePermissionService struct {
+	services.PermissionManagementService
+	notices []time.Duration
+	deletes int
+}
+
+func (f *fakePermissionService) NotifyExpiringPermissions(ctx context.Context, notice time.Duration) (int, error) {
+	f.notices = append(f.notices, notice)
+	return 0, nil
+}
+
+func (f *fakePermissionService) DeleteExpiredPermissions(ctx context.Context) (int, error) {
+	f.deletes++
+	return 0, nil
+}
+
+func TestPermissionExpiryJob_NotifiesThenDeletes(t *testing.T) {
+	permissions := &fakePermissionService{}
+	job := NewPermissionExpiryJob(permissions, 72*time.Hour, time.Minute)
+
+	require.NoError(t, job.Run(context.Background()))
+	assert.Equal(t, []time.Duration{72 * time.Hour}, permissions.notices)
+	assert.Equal(t, 1, permissions.deletes)
+}
+
+func TestPermissionExpiryJob_ZeroNoticeOnlyDeletes(t *testing.T) {
+	permissions := &fakePermissionService{}
+	job := NewPermissionExpiryJob(permissions, 0, time.Minute)
+
+	require.NoError(t, job.Run(context.Background()))
+	assert.Empty(t, permissions.notices)
+	assert.Equal(t, 1, permissions.deletes)
+}
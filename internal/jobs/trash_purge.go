@@ -0,0 +1,26 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pro-posal/webserver/services"
+)
+
+// NewTrashPurgeJob hard deletes soft-deleted records once they are older than the retention period.
+func NewTrashPurgeJob(trash services.TrashManagementService, retention time.Duration, interval time.Duration) Job {
+	return Job{
+		Name:     "trash-purge",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			result, err := trash.PurgeExpired(ctx, time.Now().Add(-retention))
+			if err != nil {
+				return err
+			}
+			log.Printf("Purged %d categories, %d contract templates, %d companies and %d users from the trash",
+				result.Categories, result.ContractTemplates, result.Companies, result.Users)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,407 @@
+// Package keys manages the keys auth tokens are signed with. Keys are PEM files in a directory
+// shared by every instance of the server, named after their key id. The newest active key signs new
+// tokens, older keys keep verifying the tokens they signed until they are retired by Rotate. A key
+// rotated in is published ahead of time and only starts signing once verifiers had time to see it.
+package keys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// Supported algorithms for generated keys
+const (
+	RS256 = "RS256"
+	EdDSA = "EdDSA"
+)
+
+const (
+	rsaKeyBits = 2048
+	keyFileExt = ".pem"
+	// reloadCooldown limits how often an unknown kid makes the store re-read the key directory
+	reloadCooldown = 10 * time.Second
+)
+
+// Key is a private signing key and what is needed to verify its signatures.
+type Key struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Private crypto.PrivateKey
+	Public  crypto.PublicKey
+	// ActiveFrom is when the key starts signing, until then it is only published
+	ActiveFrom time.Time
+}
+
+// KeyStore signs and verifies tokens. Without a key directory it falls back to HS256 with the
+// shared secret. With one, HS256 tokens are still accepted as long as a secret is configured,
+// so sessions survive switching to asymmetric keys. Unset the secret to stop accepting them.
+type KeyStore struct {
+	dir       string
+	algorithm string
+	secret    []byte
+
+	// now is swapped out by tests to move past activation times
+	now func() time.Time
+
+	mu         sync.RWMutex
+	keys       map[string]*Key
+	lastReload time.Time
+}
+
+// NewKeyStore loads the keys in dir, generating a first key when there is none.
+func NewKeyStore(dir string, algorithm string, hmacSecret string) (*KeyStore, error) {
+	s := &KeyStore{
+		dir:       dir,
+		algorithm: algorithm,
+		secret:    []byte(hmacSecret),
+		now:       time.Now,
+		keys:      map[string]*Key{},
+	}
+
+	if dir == "" {
+		if len(s.secret) == 0 {
+			return nil, errors.New("either a key directory or a signing secret is required")
+		}
+		return s, nil
+	}
+
+	if algorithm != RS256 && algorithm != EdDSA {
+		return nil, fmt.Errorf("unsupported key algorithm %q", algorithm)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed creating key directory: %w", err)
+	}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	if s.signingKey(s.now()) == nil {
+		// There are no tokens to verify yet, so the first key signs right away
+		if _, err := s.generate(s.now()); err != nil {
+			return nil, err
+		}
+		if err := s.Reload(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Sign signs the claims with the current key, or with the shared secret when there are no keys.
+func (s *KeyStore) Sign(claims jwt.Claims) (string, error) {
+	key := s.signingKey(s.now())
+	if key == nil && s.dir != "" {
+		return "", errors.New("no signing key is active yet")
+	}
+	if key == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+	}
+
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.Private)
+}
+
+// Keyfunc looks up the key a token was signed with, for use with jwt.Parse.
+func (s *KeyStore) Keyfunc(token *jwt.Token) (any, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if token.Method != jwt.SigningMethodHS256 || len(s.secret) == 0 {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return s.secret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, errors.New("token has no key id")
+	}
+
+	key := s.lookup(kid)
+	if key == nil {
+		// Another instance may have rotated in a key we have not loaded yet
+		if err := s.reloadIfStale(); err != nil {
+			return nil, err
+		}
+		key = s.lookup(kid)
+	}
+	if key == nil {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	if key.Method.Alg() != token.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.Public, nil
+}
+
+// Reload reads the key directory again, picking up keys added or removed by other instances.
+func (s *KeyStore) Reload() error {
+	if s.dir == "" {
+		return nil
+	}
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("failed reading key directory: %w", err)
+	}
+
+	keys := map[string]*Key{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != keyFileExt {
+			continue
+		}
+		key, err := readKey(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		keys[key.ID] = key
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+	s.lastReload = time.Now()
+	return nil
+}
+
+// RotationResult tells what a call to Rotate changed.
+type RotationResult struct {
+	Created string
+	Retired []string
+}
+
+// Rotate generates the next signing key publishAhead before the current one is interval old, so it
+// is in the JWKS well before its first token, and deletes keys that stopped signing more than
+// retention ago. Retention has to cover the lifetime of the longest-lived token, or those tokens stop
+// validating early.
+func (s *KeyStore) Rotate(now time.Time, interval time.Duration, publishAhead time.Duration, retention time.Duration) (*RotationResult, error) {
+	if s.dir == "" {
+		return &RotationResult{}, nil
+	}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+
+	result := &RotationResult{}
+	keys := s.sortedKeys()
+	if len(keys) == 0 || now.Add(publishAhead).Sub(keys[len(keys)-1].ActiveFrom) >= interval {
+		created, err := s.generate(now.Add(publishAhead))
+		if err != nil {
+			return nil, err
+		}
+		result.Created = created
+		if err := s.Reload(); err != nil {
+			return nil, err
+		}
+	}
+
+	// A key retires the moment a newer one takes over signing
+	keys = s.sortedKeys()
+	for i := 0; i < len(keys)-1; i++ {
+		retiredAt := keys[i+1].ActiveFrom
+		if now.Sub(retiredAt) < retention {
+			continue
+		}
+		err := os.Remove(filepath.Join(s.dir, keys[i].ID+keyFileExt))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed removing key %s: %w", keys[i].ID, err)
+		}
+		result.Retired = append(result.Retired, keys[i].ID)
+	}
+
+	if len(result.Retired) > 0 {
+		if err := s.Reload(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// JSONWebKey is the public part of a key as published in a JWKS document (RFC 7517).
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// RSA keys
+	Modulus  string `json:"n,omitempty"`
+	Exponent string `json:"e,omitempty"`
+	// Ed25519 keys
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JWKS returns the public keys that tokens may currently be signed with, and the key about to take
+// over. The HS256 secret is never published, so tokens signed with it can only be verified by this
+// server.
+func (s *KeyStore) JWKS() *JWKS {
+	jwks := &JWKS{Keys: []JSONWebKey{}}
+	for _, key := range s.sortedKeys() {
+		jwk := JSONWebKey{KeyID: key.ID, Use: "sig", Algorithm: key.Method.Alg()}
+		switch public := key.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.Modulus = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.Exponent = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks
+}
+
+// signingKey returns the newest key active at now.
+func (s *KeyStore) signingKey(now time.Time) *Key {
+	keys := s.sortedKeys()
+	for i := len(keys) - 1; i >= 0; i-- {
+		if !keys[i].ActiveFrom.After(now) {
+			return keys[i]
+		}
+	}
+	return nil
+}
+
+func (s *KeyStore) lookup(kid string) *Key {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.keys[kid]
+}
+
+func (s *KeyStore) reloadIfStale() error {
+	s.mu.RLock()
+	stale := time.Since(s.lastReload) > reloadCooldown
+	s.mu.RUnlock()
+	if !stale {
+		return nil
+	}
+	return s.Reload()
+}
+
+// sortedKeys returns the loaded keys from the oldest to the newest.
+func (s *KeyStore) sortedKeys() []*Key {
+	s.mu.RLock()
+	keys := make([]*Key, 0, len(s.keys))
+	for _, key := range s.keys {
+		keys = append(keys, key)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].ActiveFrom.Equal(keys[j].ActiveFrom) {
+			return keys[i].ID < keys[j].ID
+		}
+		return keys[i].ActiveFrom.Before(keys[j].ActiveFrom)
+	})
+	return keys
+}
+
+// generate writes a new key that signs from activeFrom on to the directory and returns its id. The
+// file is written under a temporary name first so other instances never read a partial key.
+func (s *KeyStore) generate(activeFrom time.Time) (string, error) {
+	var private crypto.PrivateKey
+	var err error
+	switch s.algorithm {
+	case RS256:
+		private, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case EdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed generating %s key: %w", s.algorithm, err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return "", fmt.Errorf("failed encoding key: %w", err)
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("failed generating key id: %w", err)
+	}
+	kid := activeFrom.UTC().Format("20060102T150405Z") + "-" + hex.EncodeToString(suffix)
+
+	path := filepath.Join(s.dir, kid+keyFileExt)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		return "", fmt.Errorf("failed writing key: %w", err)
+	}
+	if err := os.Chtimes(tmp, activeFrom, activeFrom); err != nil {
+		return "", fmt.Errorf("failed writing key: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return "", fmt.Errorf("failed writing key: %w", err)
+	}
+	return kid, nil
+}
+
+// readKey parses a PEM private key. Its id is the file name and it signs from the file's
+// modification time on, so keys made with openssl can be dropped into the directory as well, and
+// published ahead by setting a modification time in the future.
+func readKey(path string) (*Key, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading key %s: %w", path, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading key %s: %w", path, err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key %s is not PEM encoded", path)
+	}
+
+	var private any
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		private, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		private, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		err = fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing key %s: %w", path, err)
+	}
+
+	key := &Key{
+		ID:         strings.TrimSuffix(filepath.Base(path), keyFileExt),
+		Private:    private,
+		ActiveFrom: info.ModTime(),
+	}
+	switch private := private.(type) {
+	case *rsa.PrivateKey:
+		key.Method = jwt.SigningMethodRS256
+		key.Public = &private.PublicKey
+	case ed25519.PrivateKey:
+		key.Method = jwt.SigningMethodEdDSA
+		key.Public = private.Public()
+	default:
+		return nil, fmt.Errorf("key %s has an unsupported type %T", path, private)
+	}
+	return key, nil
+}
@@ -0,0 +1,93 @@
+package keys
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signAndParse(t *testing.T, signer *KeyStore, verifier *KeyStore) error {
+	t.Helper()
+	token, err := signer.Sign(jwt.MapClaims{"sub": "user"})
+	require.NoError(t, err)
+	_, err = jwt.Parse(token, verifier.Keyfunc)
+	return err
+}
+
+func TestKeyStore_HS256Fallback(t *testing.T) {
+	store, err := NewKeyStore("", "", "secret")
+	require.NoError(t, err)
+
+	assert.NoError(t, signAndParse(t, store, store))
+	assert.Empty(t, store.JWKS().Keys)
+
+	other, err := NewKeyStore("", "", "other secret")
+	require.NoError(t, err)
+	assert.Error(t, signAndParse(t, other, store))
+}
+
+func TestKeyStore_RotationKeepsOldKeysUntilRetired(t *testing.T) {
+	for _, algorithm := range []string{RS256, EdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			dir := t.TempDir()
+			store, err := NewKeyStore(dir, algorithm, "")
+			require.NoError(t, err)
+			require.Len(t, store.JWKS().Keys, 1)
+			start := time.Now()
+
+			oldToken, err := store.Sign(jwt.MapClaims{"sub": "user"})
+			require.NoError(t, err)
+			oldKid := store.JWKS().Keys[0].KeyID
+
+			// A second instance sharing the directory picks the new key up on demand
+			peer, err := NewKeyStore(dir, algorithm, "")
+			require.NoError(t, err)
+			peer.lastReload = time.Time{}
+
+			result, err := store.Rotate(start.Add(time.Hour), time.Hour, 2*time.Hour, 24*time.Hour)
+			require.NoError(t, err)
+			assert.NotEmpty(t, result.Created)
+			assert.Empty(t, result.Retired)
+			assert.Len(t, store.JWKS().Keys, 2)
+
+			// The new key is published, but the old one keeps signing until the new one activates
+			pendingToken, err := store.Sign(jwt.MapClaims{"sub": "user"})
+			require.NoError(t, err)
+			parsed, err := jwt.Parse(pendingToken, store.Keyfunc)
+			require.NoError(t, err)
+			assert.Equal(t, oldKid, parsed.Header["kid"])
+
+			store.now = func() time.Time { return start.Add(3 * time.Hour) }
+			newToken, err := store.Sign(jwt.MapClaims{"sub": "user"})
+			require.NoError(t, err)
+			parsed, err = jwt.Parse(newToken, peer.Keyfunc)
+			require.NoError(t, err)
+			assert.Equal(t, result.Created, parsed.Header["kid"])
+
+			_, err = jwt.Parse(oldToken, store.Keyfunc)
+			assert.NoError(t, err)
+
+			result, err = store.Rotate(start.Add(29*time.Hour), 48*time.Hour, 2*time.Hour, 24*time.Hour)
+			require.NoError(t, err)
+			assert.Empty(t, result.Created)
+			assert.Len(t, result.Retired, 1)
+
+			_, err = jwt.Parse(oldToken, store.Keyfunc)
+			assert.Error(t, err)
+			_, err = jwt.Parse(newToken, store.Keyfunc)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestKeyStore_RejectsHS256WithoutSecret(t *testing.T) {
+	store, err := NewKeyStore(t.TempDir(), EdDSA, "")
+	require.NoError(t, err)
+	hmac, err := NewKeyStore("", "", "secret")
+	require.NoError(t, err)
+
+	assert.Error(t, signAndParse(t, hmac, store))
+}
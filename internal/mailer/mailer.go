@@ -0,0 +1,78 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// NewMailer returns an SMTP mailer, or a mailer that only logs the messages when no SMTP host
+// is configured, which is what local development and the tests use.
+func NewMailer(config SMTPConfig) Mailer {
+	if config.Host == "" {
+		return NewLogMailer()
+	}
+	return &smtpMailer{config: config}
+}
+
+type smtpMailer struct {
+	config SMTPConfig
+}
+
+func (m *smtpMailer) Send(_ context.Context, message Message) error {
+	var auth smtp.Auth
+	if m.config.Username != "" {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+
+	err := smtp.SendMail(net.JoinHostPort(m.config.Host, m.config.Port), auth, m.config.From, []string{message.To}, formatMessage(m.config.From, message))
+	if err != nil {
+		return fmt.Errorf("failed sending email to %s: %w", message.To, err)
+	}
+	return nil
+}
+
+func formatMessage(from string, message Message) []byte {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "From: %s\r\n", from)
+	fmt.Fprintf(&builder, "To: %s\r\n", message.To)
+	fmt.Fprintf(&builder, "Subject: %s\r\n", message.Subject)
+	fmt.Fprintf(&builder, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	builder.WriteString("MIME-Version: 1.0\r\n")
+	builder.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	builder.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+	return []byte(builder.String())
+}
+
+type logMailer struct{}
+
+func NewLogMailer() Mailer {
+	return &logMailer{}
+}
+
+func (m *logMailer) Send(_ context.Context, message Message) error {
+	log.Printf("[Mail] To: %s, Subject: %s\n%s", message.To, message.Subject, message.Body)
+	return nil
+}
@@ -6,23 +6,41 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/gorilla/mux"
+	"github.com/pro-posal/webserver/internal/authz"
+	"github.com/pro-posal/webserver/models"
 	"github.com/pro-posal/webserver/services"
 )
 
-var AuthBypassRoutes = map[string]string{
-	"/status":      "GET",
-	"/users/login": "POST",
-	"/users":       "POST",
-}
+// APIKeyHeader carries the API key of requests made by integrations
+const APIKeyHeader = "X-API-Key"
 
 func AuthenticationMiddleware(authService services.AuthService, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Bypass authentication if needed
-		if _, ok := AuthBypassRoutes[r.URL.Path]; ok {
-			if r.Method == AuthBypassRoutes[r.URL.Path] {
-				next.ServeHTTP(w, r)
+		// Bypass authentication for routes registered with a public policy
+		if policy, ok := authz.PolicyOf(r); ok && policy.IsPublic() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Integrations authenticate with an API key instead of a bearer token
+		if apiKey := r.Header.Get(APIKeyHeader); apiKey != "" {
+			session, err := authService.ValidateAPIKey(r.Context(), apiKey)
+			if err != nil {
+				log.Printf("Failed validating api key: %v", err)
+				http.Error(w, "Invalid API Key", http.StatusForbidden)
 				return
 			}
+			if !apiKeyAllows(r, session.APIKey) {
+				log.Printf("Api key %v is not allowed to call %v %v", session.APIKey.ID, r.Method, r.URL.Path)
+				http.Error(w, "API Key is not allowed to make this request", http.StatusForbidden)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), "session", session)
+			log.Printf("Authenticated user %v with api key: %v", session.UserID, session.APIKey.ID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
 		}
 
 		// Perform authentication
@@ -47,6 +65,28 @@ func AuthenticationMiddleware(authService services.AuthService, next http.Handle
 	})
 }
 
+// apiKeyAllows checks a request against the key's scopes and company. Keys can't manage the
+// account they belong to, and routes of another company are off limits.
+func apiKeyAllows(r *http.Request, grant *models.APIKeyGrant) bool {
+	if strings.HasPrefix(r.URL.Path, "/auth/") || (strings.HasPrefix(r.URL.Path, "/users/") && r.Method != http.MethodGet) {
+		return false
+	}
+	if companyID, ok := mux.Vars(r)["companyId"]; ok && companyID != grant.CompanyID {
+		return false
+	}
+
+	required := models.APIKeyScopeWrite
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		required = models.APIKeyScopeRead
+	}
+	for _, scope := range grant.Scopes {
+		if scope == required || scope == models.APIKeyScopeWrite {
+			return true
+		}
+	}
+	return false
+}
+
 func extractBearerToken(w http.ResponseWriter, r *http.Request) string {
 	authHeaderValue := r.Header.Get("Authorization")
 	if authHeaderValue == "" {
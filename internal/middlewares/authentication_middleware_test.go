@@ -0,0 +1,47 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/pro-posal/webserver/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyAllows(t *testing.T) {
+	readOnly := &models.APIKeyGrant{ID: "key", CompanyID: "acme", Scopes: []models.APIKeyScope{models.APIKeyScopeRead}}
+	readWrite := &models.APIKeyGrant{ID: "key", CompanyID: "acme", Scopes: []models.APIKeyScope{models.APIKeyScopeWrite}}
+	unscoped := &models.APIKeyGrant{ID: "key", CompanyID: "acme"}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		vars   map[string]string
+		grant  *models.APIKeyGrant
+		want   bool
+	}{
+		{"read in its company", http.MethodGet, "/companies/acme/contracts", map[string]string{"companyId": "acme"}, readOnly, true},
+		{"head is a read", http.MethodHead, "/companies/acme/contracts", map[string]string{"companyId": "acme"}, readOnly, true},
+		{"write without the scope", http.MethodPost, "/companies/acme/contracts", map[string]string{"companyId": "acme"}, readOnly, false},
+		{"write with the scope", http.MethodPost, "/companies/acme/contracts", map[string]string{"companyId": "acme"}, readWrite, true},
+		{"write scope includes reading", http.MethodGet, "/companies/acme/contracts", map[string]string{"companyId": "acme"}, readWrite, true},
+		{"no scopes", http.MethodGet, "/companies/acme/contracts", map[string]string{"companyId": "acme"}, unscoped, false},
+		{"another company", http.MethodGet, "/companies/other/contracts", map[string]string{"companyId": "other"}, readWrite, false},
+		{"auth routes", http.MethodPost, "/auth/apiKeys", nil, readWrite, false},
+		{"reading the account", http.MethodGet, "/users/me", nil, readOnly, true},
+		{"changing the account", http.MethodPut, "/users/me", nil, readWrite, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.vars != nil {
+				r = mux.SetURLVars(r, tt.vars)
+			}
+			assert.Equal(t, tt.want, apiKeyAllows(r, tt.grant))
+		})
+	}
+}
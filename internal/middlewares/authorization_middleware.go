@@ -3,98 +3,59 @@ package middlewares
 import (
 	"log"
 	"net/http"
-	"strings"
 
-	"github.com/gorilla/mux"
-	"github.com/pro-posal/webserver/dao"
+	"github.com/pro-posal/webserver/internal/authz"
 	"github.com/pro-posal/webserver/internal/database"
 	"github.com/pro-posal/webserver/internal/utils"
-	"github.com/pro-posal/webserver/models"
-	"github.com/pro-posal/webserver/services"
-	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
-func AuthorizationMiddleware(authService services.AuthService, next http.Handler, db *database.DBConnector) http.Handler {
+// AuthorizationMiddleware evaluates the policy the matched route was registered with. Denials are
+// logged with the rule and reason, POST /authz/explain gives the same answer for any user.
+func AuthorizationMiddleware(next http.Handler, db *database.DBConnector) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		policy, ok := authz.PolicyOf(r)
+		if !ok {
+			log.Printf("No authorization policy found for %v %v", r.Method, r.URL.Path)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		if policy.IsPublic() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
 		session := utils.GetSessionFromContext(r.Context())
 		if session == nil {
-			log.Println("No session found in the context, skipping authorization")
-			next.ServeHTTP(w, r)
+			log.Printf("No session found in the context of %v %v", r.Method, r.URL.Path)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
-		permissionsDao, err := dao.Permissions(qm.Where("user_id = ?", session.UserID)).All(r.Context(), db.Conn)
+		caller, err := authz.LoadCaller(r.Context(), db.Conn, session.UserID.String())
 		if err != nil {
-			log.Print("Error fetching permissions from the database: ", err)
-			next.ServeHTTP(w, r)
+			log.Printf("Error loading permissions of user %v: %v", session.UserID, err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
 			return
 		}
-
-		permissions := make([]*models.Permission, len(permissionsDao))
-		for i, permissionDao := range permissionsDao {
-			permissions[i] = &models.Permission{
-				ID:         permissionDao.ID,
-				UserID:     permissionDao.UserID,
-				CompanyID:  permissionDao.CompanyID,
-				Role:       models.Role(permissionDao.Role),
-				ContractID: permissionDao.ContractID,
-				CreatedAt:  permissionDao.CreatedAt,
-				UpdatedAt:  permissionDao.UpdatedAt,
-			}
+		// An API key only carries the roles of its own company
+		if session.APIKey != nil {
+			caller.Permissions = caller.PermissionsInCompany(session.APIKey.CompanyID)
 		}
 
-		if hasAdminRole(permissions) {
-			log.Printf("Authorized admin %v to call %v", session.UserID, r.URL.Path)
-			next.ServeHTTP(w, r)
+		decision, err := policy.Explain(r, db.Conn, caller)
+		if err != nil {
+			log.Printf("Error authorizing user %v: %v", session.UserID, err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
 			return
 		}
-
-		pathParts := strings.Split(r.URL.Path, "/")
-		resourceIndex := 0
-
-		for resourceIndex < len(pathParts) {
-			hasMoreParts := resourceIndex+2 < len(pathParts)
-
-			switch pathParts[resourceIndex] {
-			case "companies":
-				companyID := mux.Vars(r)["companyId"]
-				err = authService.AuthorizeCompany(r.Method, session.UserID, permissions, hasMoreParts, companyID)
-
-			// case "users":
-			// 	userID := mux.Vars(r)["userId"]
-			// 	err = authService.AuthorizeUser(r.Method, session.UserID, userID, hasMoreParts)
-
-			case "contracts":
-				companyID := mux.Vars(r)["companyId"]
-				contractID := mux.Vars(r)["contractId"]
-				err = authService.AuthorizeContract(r.Method, session.UserID, permissions, hasMoreParts, companyID, contractID)
-			}
-
-			if err != nil {
-				if _, ok := err.(*services.UnauthorizedError); ok {
-					log.Printf("Unauthorized user %v tried to call %v", session.UserID, r.URL.Path)
-					http.Error(w, "Unauthorized", http.StatusUnauthorized)
-					return
-				}
-
-				log.Printf("Error authorizing user %v: %v", session.UserID, err)
-				http.Error(w, "Internal server error", http.StatusInternalServerError)
-				return
-			}
-
-			resourceIndex += 2
+		if !decision.Allowed {
+			log.Printf("Authorization denied: user=%v method=%v path=%q rule=%q company=%q reason=%q",
+				session.UserID, r.Method, r.URL.Path, decision.Rule, decision.CompanyID, decision.Reason)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
 		}
 
 		log.Printf("Authorized user %v to call %v", session.UserID, r.URL.Path)
 		next.ServeHTTP(w, r)
 	})
 }
-
-func hasAdminRole(userPermissions []*models.Permission) bool {
-	for _, permission := range userPermissions {
-		if permission.Role == models.AdminRole {
-			return true
-		}
-	}
-	return false
-}
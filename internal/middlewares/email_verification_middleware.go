@@ -0,0 +1,33 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/pro-posal/webserver/config"
+	"github.com/pro-posal/webserver/internal/authz"
+	"github.com/pro-posal/webserver/internal/utils"
+)
+
+// EmailVerificationMiddleware blocks unverified users from changing anything when
+// EMAIL_VERIFICATION_MODE is "sensitive"; reading stays allowed, and so do the routes whose policy
+// allows unverified users.
+func EmailVerificationMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if config.AppConfig.Auth.EmailVerificationMode != config.EmailVerificationSensitive {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		session := utils.GetSessionFromContext(r.Context())
+		if session == nil || session.EmailVerified || r.Method == http.MethodGet {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if policy, ok := authz.PolicyOf(r); ok && policy.AllowsUnverified() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		http.Error(w, "Email address is not verified", http.StatusForbidden)
+	})
+}
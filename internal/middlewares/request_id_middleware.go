@@ -0,0 +1,35 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/pro-posal/webserver/internal/utils"
+	"github.com/pro-posal/webserver/models"
+)
+
+// RequestIDHeader carries the ID of a request. An ID set by a proxy is kept, otherwise one is
+// generated, and either way it is sent back with the response.
+const RequestIDHeader = "X-Request-ID"
+
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// RequestIDMiddleware adds the request's ID and the caller's address to the context, the audit
+// log records both with every change.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = uuid.NewString()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+
+		ctx := context.WithValue(r.Context(), "request", &models.RequestInfo{
+			ID:        requestID,
+			IPAddress: utils.GetClientIP(r),
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
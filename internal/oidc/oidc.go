@@ -0,0 +1,506 @@
+// Package oidc is a small OpenID Connect relying party: provider discovery, the authorization
+// code flow with PKCE (RFC 7636) and ID token verification against the provider's JWKS.
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	// discoveryTTL is how long provider metadata is reused before it is fetched again
+	discoveryTTL = time.Hour
+	// keysRefreshCooldown limits how often an unknown kid makes the client fetch the JWKS again
+	keysRefreshCooldown = time.Minute
+	// maxResponseSize guards against providers returning huge documents
+	maxResponseSize = 1 << 20
+)
+
+// Provider is the metadata published at the issuer's /.well-known/openid-configuration.
+type Provider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Config is the client registration at the provider.
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// Claims are the verified claims of an ID token.
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	GivenName     string
+	FamilyName    string
+	Raw           jwt.MapClaims
+}
+
+// Client talks to OpenID providers, caching their metadata and signing keys.
+type Client struct {
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	providers map[string]cachedProvider
+	keySets   map[string]*keySet
+}
+
+type cachedProvider struct {
+	provider  *Provider
+	fetchedAt time.Time
+}
+
+type keySet struct {
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// ErrPrivateAddress is returned for providers that resolve to an address that isn't publicly routable.
+var ErrPrivateAddress = errors.New("the identity provider is not on a public address")
+
+// nonPublicPrefixes are the special purpose ranges netip doesn't already tell apart.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// PublicTransport only connects to publicly routable addresses. Issuers are entered by company
+// admins and the endpoints they publish are fetched too, so without it they could make the server
+// call its own or its network's internal services. The address is checked when dialing, after
+// name resolution, and proxies are skipped since they would be the ones checked instead.
+func PublicTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network string, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip, err := netip.ParseAddr(host)
+			if err != nil {
+				return err
+			}
+			if !IsPublicAddress(ip) {
+				return fmt.Errorf("%w: %s", ErrPrivateAddress, ip)
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport
+}
+
+// IsPublicAddress reports whether the address is publicly routable: not loopback, private, link
+// local, multicast or reserved for some other special purpose.
+func IsPublicAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func NewClient(httpClient *http.Client) *Client {
+	return &Client{
+		httpClient: httpClient,
+		providers:  map[string]cachedProvider{},
+		keySets:    map[string]*keySet{},
+	}
+}
+
+// Discover fetches the provider's metadata and checks it really belongs to the issuer.
+func (c *Client) Discover(ctx context.Context, issuer string) (*Provider, error) {
+	issuer = strings.TrimSuffix(issuer, "/")
+
+	c.mu.Lock()
+	cached, ok := c.providers[issuer]
+	c.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < discoveryTTL {
+		return cached.provider, nil
+	}
+
+	var provider Provider
+	if err := c.getJSON(ctx, issuer+"/.well-known/openid-configuration", &provider); err != nil {
+		return nil, fmt.Errorf("failed discovering provider %s: %w", issuer, err)
+	}
+	if strings.TrimSuffix(provider.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("provider %s reported issuer %s", issuer, provider.Issuer)
+	}
+	if provider.AuthorizationEndpoint == "" || provider.TokenEndpoint == "" || provider.JWKSURI == "" {
+		return nil, fmt.Errorf("provider %s metadata is incomplete", issuer)
+	}
+
+	c.mu.Lock()
+	c.providers[issuer] = cachedProvider{provider: &provider, fetchedAt: time.Now()}
+	c.mu.Unlock()
+	return &provider, nil
+}
+
+// AuthCodeURL is where the user is sent to sign in. The verifier stays on our side and is only
+// sent with the code exchange, its S256 challenge goes to the provider now.
+func (p *Provider) AuthCodeURL(config Config, state string, nonce string, verifier string) string {
+	scopes := append([]string{"openid"}, config.Scopes...)
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {config.ClientID},
+		"redirect_uri":          {config.RedirectURL},
+		"scope":                 {strings.Join(uniqueStrings(scopes), " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {ChallengeS256(verifier)},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(p.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return p.AuthorizationEndpoint + separator + query.Encode()
+}
+
+// Exchange trades the authorization code for tokens and returns the raw ID token.
+func (c *Client) Exchange(ctx context.Context, provider *Provider, config Config, code string, verifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {config.RedirectURL},
+		"client_id":     {config.ClientID},
+		"code_verifier": {verifier},
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, provider.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed creating token request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	if config.ClientSecret != "" {
+		request.SetBasicAuth(url.QueryEscape(config.ClientID), url.QueryEscape(config.ClientSecret))
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("failed exchanging code: %w", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxResponseSize))
+	if err != nil {
+		return "", fmt.Errorf("failed reading token response: %w", err)
+	}
+
+	var token struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("failed parsing token response (status %d): %w", response.StatusCode, err)
+	}
+	if response.StatusCode != http.StatusOK || token.Error != "" {
+		return "", fmt.Errorf("token endpoint returned %d: %s %s", response.StatusCode, token.Error, token.ErrorDescription)
+	}
+	if token.IDToken == "" {
+		return "", errors.New("token response has no id_token")
+	}
+	return token.IDToken, nil
+}
+
+// VerifyIDToken checks the token's signature, issuer, audience, expiry and nonce.
+func (c *Client) VerifyIDToken(ctx context.Context, provider *Provider, clientID string, rawIDToken string, nonce string) (*Claims, error) {
+	parsed, err := jwt.Parse(rawIDToken, func(token *jwt.Token) (any, error) {
+		return c.verificationKey(ctx, provider, token)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid id token: %w", err)
+	}
+
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok || !parsed.Valid {
+		return nil, errors.New("invalid id token")
+	}
+	now := time.Now().Unix()
+	if !claims.VerifyIssuer(provider.Issuer, true) {
+		return nil, errors.New("id token was issued by another provider")
+	}
+	if !claims.VerifyAudience(clientID, true) {
+		return nil, errors.New("id token was issued to another client")
+	}
+	if !claims.VerifyExpiresAt(now, true) {
+		return nil, errors.New("id token has expired")
+	}
+	tokenNonce, _ := claims["nonce"].(string)
+	if subtle.ConstantTimeCompare([]byte(tokenNonce), []byte(nonce)) != 1 {
+		return nil, errors.New("id token nonce does not match")
+	}
+
+	result := &Claims{Raw: claims}
+	result.Subject, _ = claims["sub"].(string)
+	result.Email, _ = claims["email"].(string)
+	result.Name, _ = claims["name"].(string)
+	result.GivenName, _ = claims["given_name"].(string)
+	result.FamilyName, _ = claims["family_name"].(string)
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		result.EmailVerified = verified
+	case string:
+		// Some providers send the flag as a string
+		result.EmailVerified = verified == "true"
+	}
+	if result.Subject == "" {
+		return nil, errors.New("id token has no subject")
+	}
+	return result, nil
+}
+
+// Groups returns the values of a group claim, which providers send as a list or a single string.
+func (c *Claims) Groups(claim string) []string {
+	switch groups := c.Raw[claim].(type) {
+	case string:
+		return []string{groups}
+	case []any:
+		result := make([]string, 0, len(groups))
+		for _, group := range groups {
+			if name, ok := group.(string); ok {
+				result = append(result, name)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// GenerateVerifier returns a random PKCE code verifier, which is also fine as a state or nonce.
+func GenerateVerifier() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed generating random value: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// ChallengeS256 derives the PKCE code challenge of a verifier.
+func ChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (c *Client) verificationKey(ctx context.Context, provider *Provider, token *jwt.Token) (crypto.PublicKey, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, err := c.lookupKey(ctx, provider, kid, false)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		// The provider may have rotated its keys since we last fetched them
+		if key, err = c.lookupKey(ctx, provider, kid, true); err != nil {
+			return nil, err
+		}
+	}
+	if key == nil {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	// Only accept the algorithm family matching the key, so a public key is never used as an HMAC secret
+	switch key.(type) {
+	case *rsa.PublicKey:
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+	case *ecdsa.PublicKey:
+		if _, ok := token.Method.(*jwt.SigningMethodECDSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+	case ed25519.PublicKey:
+		if token.Method != jwt.SigningMethodEdDSA {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+	}
+	return key, nil
+}
+
+func (c *Client) lookupKey(ctx context.Context, provider *Provider, kid string, refresh bool) (crypto.PublicKey, error) {
+	c.mu.Lock()
+	set := c.keySets[provider.JWKSURI]
+	c.mu.Unlock()
+
+	if set == nil || (refresh && time.Since(set.fetchedAt) > keysRefreshCooldown) {
+		keys, err := c.fetchKeys(ctx, provider.JWKSURI)
+		if err != nil {
+			return nil, err
+		}
+		set = &keySet{keys: keys, fetchedAt: time.Now()}
+		c.mu.Lock()
+		c.keySets[provider.JWKSURI] = set
+		c.mu.Unlock()
+	}
+
+	if kid == "" && len(set.keys) == 1 {
+		// Tokens may leave out the kid when the provider only has one key
+		for _, key := range set.keys {
+			return key, nil
+		}
+	}
+	return set.keys[kid], nil
+}
+
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+func (c *Client) fetchKeys(ctx context.Context, jwksURI string) (map[string]crypto.PublicKey, error) {
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := c.getJSON(ctx, jwksURI, &jwks); err != nil {
+		return nil, fmt.Errorf("failed fetching provider keys: %w", err)
+	}
+
+	keys := map[string]crypto.PublicKey{}
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// Skip keys we can't use rather than failing on a provider publishing new key types
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+	return keys, nil
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	decode := base64.RawURLEncoding.DecodeString
+
+	switch k.KeyType {
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Curve)
+		}
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, errors.New("invalid ec key")
+		}
+		return key, nil
+	case "OKP":
+		if k.Curve != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Curve)
+		}
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.KeyType)
+}
+
+func (c *Client) getJSON(ctx context.Context, url string, target any) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %d", url, response.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(response.Body, maxResponseSize)).Decode(target)
+}
+
+func uniqueStrings(values []string) []string {
+	seen := map[string]bool{}
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value != "" && !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,112 @@
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"testing"
+
+	"github.com/pro-posal/webserver/internal/oidc"
+	"github.com/pro-posal/webserver/internal/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const redirectURL = "https://app.example.com/sso/callback"
+
+func startLogin(t *testing.T, idp *oidctest.Provider) (*oidc.Client, *oidc.Provider, oidc.Config, string) {
+	t.Helper()
+	client := oidc.NewClient(http.DefaultClient)
+	provider, err := client.Discover(context.Background(), idp.Issuer)
+	require.NoError(t, err)
+
+	config := oidc.Config{ClientID: idp.ClientID, ClientSecret: idp.ClientSecret, RedirectURL: redirectURL, Scopes: []string{"email", "profile"}}
+	verifier, err := oidc.GenerateVerifier()
+	require.NoError(t, err)
+	return client, provider, config, verifier
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	idp, err := oidctest.NewProvider("pro-posal", "secret")
+	require.NoError(t, err)
+	defer idp.Close()
+	idp.SetClaims(map[string]any{"sub": "123", "email": "jane@example.com", "email_verified": true, "groups": []string{"sales", "admins"}})
+
+	client, provider, config, verifier := startLogin(t, idp)
+	code, state, err := idp.Authorize(provider.AuthCodeURL(config, "the-state", "the-nonce", verifier))
+	require.NoError(t, err)
+	assert.Equal(t, "the-state", state)
+
+	idToken, err := client.Exchange(context.Background(), provider, config, code, verifier)
+	require.NoError(t, err)
+
+	claims, err := client.VerifyIDToken(context.Background(), provider, config.ClientID, idToken, "the-nonce")
+	require.NoError(t, err)
+	assert.Equal(t, "123", claims.Subject)
+	assert.Equal(t, "jane@example.com", claims.Email)
+	assert.True(t, claims.EmailVerified)
+	assert.Equal(t, []string{"sales", "admins"}, claims.Groups("groups"))
+}
+
+func TestExchange_RejectsWrongVerifier(t *testing.T) {
+	idp, err := oidctest.NewProvider("pro-posal", "secret")
+	require.NoError(t, err)
+	defer idp.Close()
+	idp.SetClaims(map[string]any{"sub": "123"})
+
+	client, provider, config, verifier := startLogin(t, idp)
+	code, _, err := idp.Authorize(provider.AuthCodeURL(config, "state", "nonce", verifier))
+	require.NoError(t, err)
+
+	_, err = client.Exchange(context.Background(), provider, config, code, verifier+"x")
+	assert.Error(t, err)
+}
+
+func TestVerifyIDToken_RejectsWrongNonceAndAudience(t *testing.T) {
+	idp, err := oidctest.NewProvider("pro-posal", "secret")
+	require.NoError(t, err)
+	defer idp.Close()
+	idp.SetClaims(map[string]any{"sub": "123"})
+
+	client, provider, config, verifier := startLogin(t, idp)
+	code, _, err := idp.Authorize(provider.AuthCodeURL(config, "state", "nonce", verifier))
+	require.NoError(t, err)
+	idToken, err := client.Exchange(context.Background(), provider, config, code, verifier)
+	require.NoError(t, err)
+
+	_, err = client.VerifyIDToken(context.Background(), provider, config.ClientID, idToken, "another nonce")
+	assert.Error(t, err)
+	_, err = client.VerifyIDToken(context.Background(), provider, "another client", idToken, "nonce")
+	assert.Error(t, err)
+}
+
+func TestPublicTransport_RefusesPrivateIssuers(t *testing.T) {
+	idp, err := oidctest.NewProvider("pro-posal", "secret")
+	require.NoError(t, err)
+	defer idp.Close()
+
+	client := oidc.NewClient(&http.Client{Transport: oidc.PublicTransport()})
+	_, err = client.Discover(context.Background(), idp.Issuer)
+	assert.ErrorIs(t, err, oidc.ErrPrivateAddress)
+}
+
+func TestIsPublicAddress(t *testing.T) {
+	for address, public := range map[string]bool{
+		"8.8.8.8":                true,
+		"2606:4700::1111":        true,
+		"127.0.0.1":              false,
+		"10.1.2.3":               false,
+		"172.16.0.1":             false,
+		"192.168.1.1":            false,
+		"169.254.169.254":        false,
+		"100.64.0.1":             false,
+		"0.0.0.0":                false,
+		"::1":                    false,
+		"fe80::1":                false,
+		"fd00::1":                false,
+		"::ffff:127.0.0.1":       false,
+		"::ffff:169.254.169.254": false,
+	} {
+		assert.Equal(t, public, oidc.IsPublicAddress(netip.MustParseAddr(address)), address)
+	}
+}
@@ -0,0 +1,295 @@
+package rendering
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"text/template/parse"
+	"unicode/utf8"
+
+	"github.com/pro-posal/webserver/models"
+)
+
+const (
+	LintRuleSyntax             = "syntax"
+	LintRuleUndeclaredArgument = "undeclared-argument"
+	LintRuleUnreachableBranch  = "unreachable-branch"
+	LintRuleMissingSignature   = "missing-signature"
+	LintRuleMissingClause      = "missing-clause"
+	LintRuleUnknownClause      = "unknown-clause"
+
+	// SignatureBlock is the template every contract must define and invoke,
+	// e.g. {{block "signature" .}}...{{end}}.
+	SignatureBlock = "signature"
+)
+
+// LintOptions carries the context a template is checked against.
+type LintOptions struct {
+	// Schema lists the declared arguments; references are only checked when it declares any.
+	Schema models.TemplateSchema
+	// Clauses are the company's legal clauses; mandatory ones must be included through
+	// {{clause "name"}}, copying their text doesn't count.
+	Clauses []models.LegalClause
+}
+
+var parseErrorPattern = regexp.MustCompile(`^template: [^:]*:(\d+):(?:(\d+):)? ?(.*)$`)
+
+// Lint statically analyzes a contract template and returns its findings ordered by position.
+// A template is acceptable when none of the findings has error severity.
+func Lint(templateText string, opts LintOptions) []models.LintFinding {
+	tmpl, err := texttemplate.New("contract").Funcs(TemplateFuncs()).Parse(templateText)
+	if err != nil {
+		return []models.LintFinding{syntaxFinding(err)}
+	}
+
+	l := &templateLinter{
+		text:     templateText,
+		declared: map[string]bool{},
+		invoked:  map[string]bool{},
+		clauses:  map[string]bool{},
+	}
+	for _, argument := range opts.Schema.Arguments {
+		l.declared[argument.Name] = true
+	}
+	l.checkArguments = len(l.declared) > 0
+
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil && t.Tree.Root != nil {
+			l.walk(t.Tree.Root, true)
+		}
+	}
+
+	if !l.invoked[SignatureBlock] {
+		l.report(models.LintSeverityError, LintRuleMissingSignature, -1,
+			fmt.Sprintf(`template has no signature block, add {{block "%s" .}}...{{end}}`, SignatureBlock))
+	} else if tmpl.Lookup(SignatureBlock) == nil {
+		l.report(models.LintSeverityError, LintRuleMissingSignature, -1,
+			fmt.Sprintf("signature block %q is invoked but never defined", SignatureBlock))
+	}
+
+	configured := map[string]bool{}
+	for _, clause := range opts.Clauses {
+		configured[clause.Name] = true
+		if clause.Mandatory && !l.clauses[clause.Name] {
+			l.report(models.LintSeverityError, LintRuleMissingClause, -1,
+				fmt.Sprintf("mandatory legal clause %q is missing", clause.Name))
+		}
+	}
+	for _, ref := range l.clauseRefs {
+		if !configured[ref.name] {
+			l.report(models.LintSeverityError, LintRuleUnknownClause, ref.pos,
+				fmt.Sprintf("legal clause %q is not configured for the company", ref.name))
+		}
+	}
+
+	sort.SliceStable(l.findings, func(i, j int) bool {
+		if l.findings[i].Line != l.findings[j].Line {
+			return l.findings[i].Line < l.findings[j].Line
+		}
+		return l.findings[i].Column < l.findings[j].Column
+	})
+	return l.findings
+}
+
+// HasLintErrors reports whether any of the findings should block saving the template.
+func HasLintErrors(findings []models.LintFinding) bool {
+	for _, finding := range findings {
+		if finding.Severity == models.LintSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+type clauseRef struct {
+	name string
+	pos  parse.Pos
+}
+
+type templateLinter struct {
+	text           string
+	declared       map[string]bool
+	checkArguments bool
+	invoked        map[string]bool
+	clauses        map[string]bool
+	clauseRefs     []clauseRef
+	findings       []models.LintFinding
+}
+
+// walk visits the parse tree; dotIsRoot tells whether "." still refers to the
+// template arguments, which stops being true inside range and with blocks.
+func (l *templateLinter) walk(node parse.Node, dotIsRoot bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			l.walk(child, dotIsRoot)
+		}
+	case *parse.ActionNode:
+		l.walkPipe(n.Pipe, dotIsRoot)
+	case *parse.TemplateNode:
+		l.invoked[n.Name] = true
+		l.walkPipe(n.Pipe, dotIsRoot)
+	case *parse.IfNode:
+		l.walkIf(n, dotIsRoot, map[string]bool{})
+	case *parse.RangeNode:
+		l.walkPipe(n.Pipe, dotIsRoot)
+		l.walk(n.List, false)
+		l.walk(n.ElseList, dotIsRoot)
+	case *parse.WithNode:
+		l.walkPipe(n.Pipe, dotIsRoot)
+		l.walk(n.List, false)
+		l.walk(n.ElseList, dotIsRoot)
+	}
+}
+
+// walkIf checks an if / else if chain, flagging constant conditions and conditions
+// that repeat an earlier branch of the same chain.
+func (l *templateLinter) walkIf(n *parse.IfNode, dotIsRoot bool, seen map[string]bool) {
+	l.walkPipe(n.Pipe, dotIsRoot)
+
+	condition := n.Pipe.String()
+	if seen[condition] {
+		l.report(models.LintSeverityWarning, LintRuleUnreachableBranch, n.Position(),
+			fmt.Sprintf("condition %q is already handled by an earlier branch", condition))
+	}
+	seen[condition] = true
+
+	value, constant := constantCondition(n.Pipe)
+	if constant && !value {
+		l.report(models.LintSeverityWarning, LintRuleUnreachableBranch, n.Position(),
+			fmt.Sprintf("condition %q is always false, its block never renders", condition))
+	}
+	if constant && value && n.ElseList != nil {
+		l.report(models.LintSeverityWarning, LintRuleUnreachableBranch, n.ElseList.Position(),
+			fmt.Sprintf("condition %q is always true, its else block never renders", condition))
+	}
+
+	l.walk(n.List, dotIsRoot)
+
+	if n.ElseList != nil && len(n.ElseList.Nodes) == 1 {
+		if elseIf, ok := n.ElseList.Nodes[0].(*parse.IfNode); ok {
+			l.walkIf(elseIf, dotIsRoot, seen)
+			return
+		}
+	}
+	l.walk(n.ElseList, dotIsRoot)
+}
+
+func (l *templateLinter) walkPipe(pipe *parse.PipeNode, dotIsRoot bool) {
+	if pipe == nil {
+		return
+	}
+	for _, cmd := range pipe.Cmds {
+		l.walkCommand(cmd, dotIsRoot)
+	}
+}
+
+func (l *templateLinter) walkCommand(cmd *parse.CommandNode, dotIsRoot bool) {
+	if len(cmd.Args) == 2 {
+		if ident, ok := cmd.Args[0].(*parse.IdentifierNode); ok && ident.Ident == "clause" {
+			if name, ok := cmd.Args[1].(*parse.StringNode); ok {
+				l.clauses[name.Text] = true
+				l.clauseRefs = append(l.clauseRefs, clauseRef{name: name.Text, pos: name.Position()})
+			}
+		}
+	}
+
+	for _, arg := range cmd.Args {
+		l.walkArg(arg, dotIsRoot)
+	}
+}
+
+func (l *templateLinter) walkArg(arg parse.Node, dotIsRoot bool) {
+	switch a := arg.(type) {
+	case *parse.FieldNode:
+		if dotIsRoot {
+			l.checkArgument(a.Ident[0], a.Position())
+		}
+	case *parse.VariableNode:
+		// $.name always refers to the template arguments
+		if a.Ident[0] == "$" && len(a.Ident) > 1 {
+			l.checkArgument(a.Ident[1], a.Position())
+		}
+	case *parse.ChainNode:
+		l.walkArg(a.Node, dotIsRoot)
+	case *parse.PipeNode:
+		l.walkPipe(a, dotIsRoot)
+	}
+}
+
+func (l *templateLinter) checkArgument(name string, pos parse.Pos) {
+	if !l.checkArguments || l.declared[name] {
+		return
+	}
+	l.report(models.LintSeverityError, LintRuleUndeclaredArgument, pos,
+		fmt.Sprintf("argument %q is not declared in the template schema", name))
+}
+
+// report records a finding; a negative position marks a finding about the whole template.
+func (l *templateLinter) report(severity models.LintSeverity, rule string, pos parse.Pos, message string) {
+	finding := models.LintFinding{
+		Severity: severity,
+		Rule:     rule,
+		Message:  message,
+	}
+	if pos >= 0 {
+		finding.Line, finding.Column = lineAndColumn(l.text, int(pos))
+	}
+	l.findings = append(l.findings, finding)
+}
+
+// constantCondition reports the truth value of a condition made of a single literal.
+func constantCondition(pipe *parse.PipeNode) (value bool, constant bool) {
+	if pipe == nil || len(pipe.Decl) > 0 || len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return false, false
+	}
+
+	switch a := pipe.Cmds[0].Args[0].(type) {
+	case *parse.BoolNode:
+		return a.True, true
+	case *parse.NilNode:
+		return false, true
+	case *parse.StringNode:
+		return a.Text != "", true
+	case *parse.NumberNode:
+		if a.IsFloat {
+			return a.Float64 != 0, true
+		}
+		return a.Text != "0", true
+	}
+	return false, false
+}
+
+func syntaxFinding(err error) models.LintFinding {
+	finding := models.LintFinding{
+		Severity: models.LintSeverityError,
+		Rule:     LintRuleSyntax,
+		Message:  err.Error(),
+	}
+
+	if match := parseErrorPattern.FindStringSubmatch(err.Error()); match != nil {
+		finding.Line, _ = strconv.Atoi(match[1])
+		if match[2] != "" {
+			finding.Column, _ = strconv.Atoi(match[2])
+		}
+		finding.Message = match[3]
+	}
+	return finding
+}
+
+// lineAndColumn converts a byte offset into a 1-based line and character column.
+func lineAndColumn(text string, offset int) (int, int) {
+	if offset > len(text) {
+		offset = len(text)
+	}
+	before := text[:offset]
+	line := strings.Count(before, "\n") + 1
+	lineStart := strings.LastIndex(before, "\n") + 1
+	return line, utf8.RuneCountInString(before[lineStart:]) + 1
+}
@@ -0,0 +1,74 @@
+package rendering
+
+import (
+	"testing"
+
+	"github.com/pro-posal/webserver/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const signature = `{{block "signature" .}}Signed: {{.client}}{{end}}`
+
+func rules(findings []models.LintFinding) []string {
+	var result []string
+	for _, finding := range findings {
+		result = append(result, finding.Rule)
+	}
+	return result
+}
+
+func TestLint_CleanTemplate(t *testing.T) {
+	findings := Lint("Dear {{.client}},\n{{clause \"privacy\"}}\n"+signature, LintOptions{
+		Schema:  models.TemplateSchema{Arguments: []models.TemplateArgument{{Name: "client"}}},
+		Clauses: []models.LegalClause{{Name: "privacy", Content: "We keep your data safe.", Mandatory: true}},
+	})
+	assert.Empty(t, findings)
+	assert.False(t, HasLintErrors(findings))
+}
+
+func TestLint_SyntaxErrorReportsLine(t *testing.T) {
+	findings := Lint("Hello\n{{if .client}}\nno end", LintOptions{})
+	require.Len(t, findings, 1)
+	assert.Equal(t, LintRuleSyntax, findings[0].Rule)
+	assert.Equal(t, models.LintSeverityError, findings[0].Severity)
+	assert.Equal(t, 3, findings[0].Line)
+}
+
+func TestLint_UndeclaredArgument(t *testing.T) {
+	findings := Lint("Hi {{.client}}\n  {{.total}} {{range .items}}{{.name}}{{end}}"+signature, LintOptions{
+		Schema: models.TemplateSchema{Arguments: []models.TemplateArgument{{Name: "client"}, {Name: "items"}}},
+	})
+	require.Len(t, findings, 1)
+	assert.Equal(t, LintRuleUndeclaredArgument, findings[0].Rule)
+	assert.Contains(t, findings[0].Message, `"total"`)
+	assert.Equal(t, 2, findings[0].Line)
+	assert.Equal(t, 5, findings[0].Column)
+}
+
+func TestLint_UnreachableBranches(t *testing.T) {
+	findings := Lint(`{{if false}}never{{end}}{{if true}}a{{else}}b{{end}}{{if .vip}}x{{else if .vip}}y{{end}}`+signature, LintOptions{})
+	assert.Equal(t, []string{LintRuleUnreachableBranch, LintRuleUnreachableBranch, LintRuleUnreachableBranch}, rules(findings))
+	assert.False(t, HasLintErrors(findings))
+}
+
+func TestLint_MissingSignatureAndClauses(t *testing.T) {
+	findings := Lint(`Terms apply. {{clause "refunds"}}`, LintOptions{
+		Clauses: []models.LegalClause{
+			{Name: "privacy", Content: "We keep your data safe.", Mandatory: true},
+			{Name: "terms", Content: "Terms apply.", Mandatory: true},
+		},
+	})
+	// Copying a clause's text doesn't include it, only referencing it does
+	assert.ElementsMatch(t, []string{LintRuleMissingSignature, LintRuleMissingClause, LintRuleMissingClause, LintRuleUnknownClause}, rules(findings))
+	assert.True(t, HasLintErrors(findings))
+}
+
+func TestRender_Clause(t *testing.T) {
+	out, err := Render(`{{clause "privacy"}}`, nil, Options{
+		Format:  FormatText,
+		Clauses: map[string]string{"privacy": "We keep your data safe."},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "We keep your data safe.", out)
+}
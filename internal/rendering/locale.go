@@ -0,0 +1,222 @@
+package rendering
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pro-posal/webserver/models"
+	"golang.org/x/text/language"
+)
+
+const (
+	DefaultLanguage = "en"
+
+	// Unicode directional isolates, used to keep numbers intact inside RTL text
+	// when the output is not HTML (e.g. plain text laid out as a PDF).
+	leftToRightIsolate    = "\u2066"
+	rightToLeftIsolate    = "\u2067"
+	firstStrongIsolate    = "\u2068"
+	popDirectionalIsolate = "\u2069"
+)
+
+// Locale holds the formatting rules used when rendering values into a contract.
+type Locale struct {
+	Language    string
+	Direction   models.TextDirection
+	DecimalSep  string
+	GroupSep    string
+	Digits      []rune
+	ShortDate   string
+	LongDate    func(t time.Time) string
+	SymbolFirst bool
+}
+
+var rtlLanguages = map[string]bool{
+	"ar": true,
+	"he": true,
+	"fa": true,
+	"ur": true,
+	"yi": true,
+	"ps": true,
+	"dv": true,
+}
+
+var hebrewMonths = []string{"ינואר", "פברואר", "מרץ", "אפריל", "מאי", "יוני", "יולי", "אוגוסט", "ספטמבר", "אוקטובר", "נובמבר", "דצמבר"}
+var arabicMonths = []string{"يناير", "فبراير", "مارس", "أبريل", "مايو", "يونيو", "يوليو", "أغسطس", "سبتمبر", "أكتوبر", "نوفمبر", "ديسمبر"}
+var arabicIndicDigits = []rune("٠١٢٣٤٥٦٧٨٩")
+
+var locales = map[string]Locale{
+	"en": {
+		Language:    "en",
+		Direction:   models.TextDirectionLTR,
+		DecimalSep:  ".",
+		GroupSep:    ",",
+		ShortDate:   "01/02/2006",
+		LongDate:    func(t time.Time) string { return t.Format("January 2, 2006") },
+		SymbolFirst: true,
+	},
+	"he": {
+		Language:   "he",
+		Direction:  models.TextDirectionRTL,
+		DecimalSep: ".",
+		GroupSep:   ",",
+		ShortDate:  "2.1.2006",
+		LongDate: func(t time.Time) string {
+			return fmt.Sprintf("%d ב%s %d", t.Day(), hebrewMonths[t.Month()-1], t.Year())
+		},
+	},
+	"ar": {
+		Language:   "ar",
+		Direction:  models.TextDirectionRTL,
+		DecimalSep: "٫",
+		GroupSep:   "٬",
+		Digits:     arabicIndicDigits,
+		ShortDate:  "2/1/2006",
+		LongDate: func(t time.Time) string {
+			return fmt.Sprintf("%d %s %d", t.Day(), arabicMonths[t.Month()-1], t.Year())
+		},
+	},
+}
+
+var currencySymbols = map[string]string{
+	"ILS": "₪",
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JOD": "د.أ",
+	"AED": "د.إ",
+}
+
+// NormalizeLanguage validates a BCP 47 language tag and returns it in canonical form.
+func NormalizeLanguage(lang string) (string, error) {
+	if lang == "" {
+		return DefaultLanguage, nil
+	}
+
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return "", fmt.Errorf("invalid language %q: %w", lang, err)
+	}
+	return tag.String(), nil
+}
+
+// DirectionForLanguage returns the natural text direction of the given language tag.
+func DirectionForLanguage(lang string) models.TextDirection {
+	if rtlLanguages[baseLanguage(lang)] {
+		return models.TextDirectionRTL
+	}
+	return models.TextDirectionLTR
+}
+
+// LocaleFor returns the formatting rules for a language tag, falling back to English
+// number and date formats for languages we do not have explicit rules for.
+func LocaleFor(lang string, direction models.TextDirection) Locale {
+	locale, ok := locales[baseLanguage(lang)]
+	if !ok {
+		locale = locales[DefaultLanguage]
+		locale.Language = lang
+	}
+
+	// Arabic speaking regions in Israel and the Maghreb use western digits
+	if locale.Language == "ar" && hasRegion(lang, "IL", "MA", "DZ", "TN") {
+		locale.Digits = nil
+		locale.DecimalSep = "."
+		locale.GroupSep = ","
+	}
+
+	if direction != "" {
+		locale.Direction = direction
+	}
+	return locale
+}
+
+// FormatNumber formats a number with the locale's separators and digits.
+func (l Locale) FormatNumber(value float64, decimals int) string {
+	negative := value < 0
+	formatted := strconv.FormatFloat(math.Abs(value), 'f', decimals, 64)
+
+	integerPart, fractionPart, _ := strings.Cut(formatted, ".")
+
+	var grouped strings.Builder
+	for i, digit := range integerPart {
+		if i > 0 && (len(integerPart)-i)%3 == 0 {
+			grouped.WriteString(l.GroupSep)
+		}
+		grouped.WriteRune(digit)
+	}
+
+	result := grouped.String()
+	if fractionPart != "" {
+		result += l.DecimalSep + fractionPart
+	}
+	if negative {
+		result = "-" + result
+	}
+
+	return l.localizeDigits(result)
+}
+
+// FormatCurrency formats an amount with the currency symbol placed according to the locale.
+func (l Locale) FormatCurrency(amount float64, currencyCode string) string {
+	currencyCode = strings.ToUpper(currencyCode)
+	symbol, ok := currencySymbols[currencyCode]
+	if !ok {
+		symbol = currencyCode
+	}
+
+	number := l.FormatNumber(amount, 2)
+	if l.SymbolFirst {
+		return symbol + number
+	}
+	return number + " " + symbol
+}
+
+// FormatDate formats a date using the locale's "short" or "long" style.
+func (l Locale) FormatDate(t time.Time, style string) string {
+	if style == "long" {
+		return l.localizeDigits(l.LongDate(t))
+	}
+	return l.localizeDigits(t.Format(l.ShortDate))
+}
+
+func (l Locale) localizeDigits(s string) string {
+	if l.Digits == nil {
+		return s
+	}
+
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return l.Digits[r-'0']
+		}
+		return r
+	}, s)
+}
+
+func baseLanguage(lang string) string {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return strings.ToLower(strings.SplitN(lang, "-", 2)[0])
+	}
+	base, _ := tag.Base()
+	return base.String()
+}
+
+func hasRegion(lang string, regions ...string) bool {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return false
+	}
+	region, confidence := tag.Region()
+	if confidence != language.Exact {
+		return false
+	}
+	for _, r := range regions {
+		if region.String() == r {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package rendering
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/pro-posal/webserver/models"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/text/unicode/bidi"
+)
+
+const (
+	pdfFont       = "goregular"
+	pdfMargin     = 20.0 // mm
+	pdfTopMargin  = 30.0 // mm, the first page leaves room for a letterhead
+	pdfTitleSize  = 16.0 // pt
+	pdfBodySize   = 11.0 // pt
+	pdfLineHeight = 5.5  // mm
+)
+
+// renderPDF lays the text rendering of a contract out on A4 pages. The embedded Go font covers the
+// Latin, Greek and Cyrillic scripts.
+func renderPDF(text string, locale Locale, opts Options) (string, error) {
+	rtl := locale.Direction == models.TextDirectionRTL
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin)
+	pdf.AddUTF8FontFromBytes(pdfFont, "", goregular.TTF)
+	pdf.SetTitle(opts.Title, true)
+	pdf.SetLang(opts.Language)
+	pdf.SetCreator("Proposal", true)
+	if rtl {
+		pdf.RTL()
+	}
+
+	align := "L"
+	if rtl {
+		align = "R"
+	}
+
+	pdf.AddPage()
+	pdf.SetY(pdfTopMargin)
+	if opts.Title != "" {
+		pdf.SetFont(pdfFont, "", pdfTitleSize)
+		pdf.MultiCell(0, pdfLineHeight*1.5, visualOrder(opts.Title, rtl), "", align, false)
+		pdf.Ln(pdfLineHeight)
+	}
+	pdf.SetFont(pdfFont, "", pdfBodySize)
+	pdf.MultiCell(0, pdfLineHeight, visualOrder(strings.TrimSpace(text), rtl), "", align, false)
+
+	var document bytes.Buffer
+	if err := pdf.Output(&document); err != nil {
+		return "", fmt.Errorf("failed writing pdf: %w", err)
+	}
+	return document.String(), nil
+}
+
+// visualOrder drops the bidi isolates of the text rendering, which the PDF can't interpret. In
+// right-to-left documents every line is printed reversed, so the left-to-right runs the isolates
+// delimited are reversed beforehand to keep reading left to right.
+func visualOrder(text string, rtl bool) string {
+	var out, run strings.Builder
+	depth := 0
+	runIsLTR := false
+
+	for i, r := range text {
+		switch string(r) {
+		case leftToRightIsolate, rightToLeftIsolate, firstStrongIsolate:
+			if depth == 0 {
+				runIsLTR = string(r) == leftToRightIsolate ||
+					string(r) == firstStrongIsolate && firstStrongIsLTR(text[i+utf8.RuneLen(r):])
+			}
+			depth++
+		case popDirectionalIsolate:
+			if depth == 0 {
+				continue
+			}
+			depth--
+			if depth == 0 {
+				out.WriteString(isolatedRun(run.String(), rtl && runIsLTR))
+				run.Reset()
+			}
+		default:
+			if depth > 0 {
+				run.WriteRune(r)
+			} else {
+				out.WriteRune(r)
+			}
+		}
+	}
+	out.WriteString(isolatedRun(run.String(), rtl && runIsLTR))
+	return out.String()
+}
+
+func isolatedRun(s string, reverse bool) string {
+	if !reverse {
+		return s
+	}
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+// firstStrongIsLTR reports whether the first strongly directional character of the text is
+// left-to-right.
+func firstStrongIsLTR(text string) bool {
+	for _, r := range text {
+		switch props, _ := bidi.LookupRune(r); props.Class() {
+		case bidi.L:
+			return true
+		case bidi.R, bidi.AL:
+			return false
+		}
+	}
+	return true
+}
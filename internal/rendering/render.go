@@ -0,0 +1,285 @@
+package rendering
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/pro-posal/webserver/models"
+)
+
+type Format string
+
+const (
+	FormatHTML Format = "html"
+	// FormatPDF lays the text rendering out on A4 pages.
+	FormatPDF Format = "pdf"
+	// FormatText produces plain text with Unicode bidi isolates, suitable as input for PDF generation.
+	FormatText Format = "text"
+)
+
+type Options struct {
+	Language  string
+	Direction models.TextDirection
+	Format    Format
+	Title     string
+	// Clauses maps the company's legal clause names to their text for the clause helper.
+	Clauses map[string]string
+}
+
+const htmlDocument = `<!DOCTYPE html>
+<html lang="{{.Language}}" dir="{{.Direction}}">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { direction: {{.Direction}}; text-align: {{.Align}}; unicode-bidi: isolate; }
+bdi { unicode-bidi: isolate; }
+</style>
+</head>
+<body>
+{{.Body}}
+</body>
+</html>
+`
+
+var documentTemplate = htmltemplate.Must(htmltemplate.New("document").Parse(htmlDocument))
+
+// IsValid reports whether the format is one the renderer produces.
+func (f Format) IsValid() bool {
+	return f == FormatHTML || f == FormatPDF || f == FormatText
+}
+
+// ContentType returns the MIME type produced by the given format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatText:
+		return "text/plain; charset=utf-8"
+	case FormatPDF:
+		return "application/pdf"
+	}
+	return "text/html; charset=utf-8"
+}
+
+// Render executes a contract template against the given arguments, formatting numbers,
+// currencies and dates according to the template's language and direction.
+func Render(templateText string, arguments map[string]any, opts Options) (string, error) {
+	locale := LocaleFor(opts.Language, opts.Direction)
+
+	switch opts.Format {
+	case FormatText:
+		return renderText(templateText, arguments, locale, opts.Clauses)
+	case FormatPDF:
+		text, err := renderText(templateText, arguments, locale, opts.Clauses)
+		if err != nil {
+			return "", err
+		}
+		return renderPDF(text, locale, opts)
+	}
+	return renderHTML(templateText, arguments, locale, opts)
+}
+
+// TemplateFuncs lists the helper functions available inside contract templates.
+func TemplateFuncs() map[string]any {
+	funcs := map[string]any{}
+	for name, fn := range textFuncs(LocaleFor(DefaultLanguage, ""), nil) {
+		funcs[name] = fn
+	}
+	return funcs
+}
+
+func renderHTML(templateText string, arguments map[string]any, locale Locale, opts Options) (string, error) {
+	isolate := func(s string) htmltemplate.HTML {
+		escaped := htmltemplate.HTMLEscapeString(s)
+		if locale.Direction != models.TextDirectionRTL {
+			return htmltemplate.HTML(escaped)
+		}
+		return htmltemplate.HTML(`<bdi dir="ltr">` + escaped + `</bdi>`)
+	}
+
+	funcs := htmltemplate.FuncMap{}
+	for name, fn := range valueFuncs(locale) {
+		fn := fn
+		funcs[name] = func(args ...any) (htmltemplate.HTML, error) {
+			s, err := fn(args...)
+			if err != nil {
+				return "", err
+			}
+			return isolate(s), nil
+		}
+	}
+	funcs["bdi"] = func(v any) htmltemplate.HTML {
+		return htmltemplate.HTML(`<bdi>` + htmltemplate.HTMLEscapeString(fmt.Sprint(v)) + `</bdi>`)
+	}
+	funcs["clause"] = clauseFunc(opts.Clauses)
+
+	tmpl, err := htmltemplate.New("contract").Funcs(funcs).Parse(templateText)
+	if err != nil {
+		return "", fmt.Errorf("failed parsing template: %w", err)
+	}
+
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, arguments); err != nil {
+		return "", fmt.Errorf("failed executing template: %w", err)
+	}
+
+	align := "left"
+	if locale.Direction == models.TextDirectionRTL {
+		align = "right"
+	}
+
+	var document bytes.Buffer
+	err = documentTemplate.Execute(&document, map[string]any{
+		"Language":  opts.Language,
+		"Direction": string(locale.Direction),
+		"Align":     align,
+		"Title":     opts.Title,
+		"Body":      htmltemplate.HTML(body.String()),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed executing document template: %w", err)
+	}
+
+	return document.String(), nil
+}
+
+func renderText(templateText string, arguments map[string]any, locale Locale, clauses map[string]string) (string, error) {
+	tmpl, err := texttemplate.New("contract").Funcs(textFuncs(locale, clauses)).Parse(templateText)
+	if err != nil {
+		return "", fmt.Errorf("failed parsing template: %w", err)
+	}
+
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, arguments); err != nil {
+		return "", fmt.Errorf("failed executing template: %w", err)
+	}
+
+	return body.String(), nil
+}
+
+func textFuncs(locale Locale, clauses map[string]string) texttemplate.FuncMap {
+	isolate := func(s string) string {
+		if locale.Direction != models.TextDirectionRTL {
+			return s
+		}
+		return leftToRightIsolate + s + popDirectionalIsolate
+	}
+
+	funcs := texttemplate.FuncMap{}
+	for name, fn := range valueFuncs(locale) {
+		fn := fn
+		funcs[name] = func(args ...any) (string, error) {
+			s, err := fn(args...)
+			if err != nil {
+				return "", err
+			}
+			return isolate(s), nil
+		}
+	}
+	funcs["bdi"] = func(v any) string {
+		return firstStrongIsolate + fmt.Sprint(v) + popDirectionalIsolate
+	}
+	funcs["clause"] = clauseFunc(clauses)
+	return funcs
+}
+
+// clauseFunc inserts the text of one of the company's legal clauses by name.
+func clauseFunc(clauses map[string]string) func(name string) (string, error) {
+	return func(name string) (string, error) {
+		content, ok := clauses[name]
+		if !ok {
+			return "", fmt.Errorf("legal clause %q is not configured", name)
+		}
+		return content, nil
+	}
+}
+
+// valueFuncs are the locale aware formatting helpers; their output is always
+// isolated as a left-to-right run so digits and signs survive RTL layout.
+func valueFuncs(locale Locale) map[string]func(args ...any) (string, error) {
+	return map[string]func(args ...any) (string, error){
+		"number": func(args ...any) (string, error) {
+			if len(args) == 0 || len(args) > 2 {
+				return "", fmt.Errorf("number expects a value and optional decimals")
+			}
+			value, err := toFloat(args[0])
+			if err != nil {
+				return "", err
+			}
+			decimals := 0
+			if len(args) == 2 {
+				d, err := toFloat(args[1])
+				if err != nil {
+					return "", err
+				}
+				decimals = int(d)
+			}
+			return locale.FormatNumber(value, decimals), nil
+		},
+		"currency": func(args ...any) (string, error) {
+			if len(args) != 2 {
+				return "", fmt.Errorf("currency expects an amount and a currency code")
+			}
+			amount, err := toFloat(args[0])
+			if err != nil {
+				return "", err
+			}
+			return locale.FormatCurrency(amount, fmt.Sprint(args[1])), nil
+		},
+		"date": func(args ...any) (string, error) {
+			if len(args) == 0 || len(args) > 2 {
+				return "", fmt.Errorf("date expects a value and optional style")
+			}
+			t, err := toTime(args[0])
+			if err != nil {
+				return "", err
+			}
+			style := "short"
+			if len(args) == 2 {
+				style = fmt.Sprint(args[1])
+			}
+			return locale.FormatDate(t, style), nil
+		},
+	}
+}
+
+func toFloat(v any) (float64, error) {
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case float32:
+		return float64(n), nil
+	case int:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		if err != nil {
+			return 0, fmt.Errorf("value %q is not a number", n)
+		}
+		return f, nil
+	case nil:
+		return 0, fmt.Errorf("missing numeric value")
+	}
+	return 0, fmt.Errorf("value %v is not a number", v)
+}
+
+func toTime(v any) (time.Time, error) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, nil
+	case string:
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if parsed, err := time.Parse(layout, t); err == nil {
+				return parsed, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("value %q is not a date", t)
+	}
+	return time.Time{}, fmt.Errorf("value %v is not a date", v)
+}
@@ -0,0 +1,78 @@
+package rendering
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pro-posal/webserver/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectionForLanguage(t *testing.T) {
+	assert.Equal(t, models.TextDirectionRTL, DirectionForLanguage("he"))
+	assert.Equal(t, models.TextDirectionRTL, DirectionForLanguage("ar-IL"))
+	assert.Equal(t, models.TextDirectionLTR, DirectionForLanguage("en-US"))
+}
+
+func TestLocaleFormatting(t *testing.T) {
+	date := time.Date(2024, time.May, 9, 0, 0, 0, 0, time.UTC)
+
+	en := LocaleFor("en", "")
+	assert.Equal(t, "$1,234.50", en.FormatCurrency(1234.5, "usd"))
+	assert.Equal(t, "May 9, 2024", en.FormatDate(date, "long"))
+
+	he := LocaleFor("he", "")
+	assert.Equal(t, "1,234.50 ₪", he.FormatCurrency(1234.5, "ILS"))
+	assert.Equal(t, "9.5.2024", he.FormatDate(date, "short"))
+	assert.Equal(t, "9 במאי 2024", he.FormatDate(date, "long"))
+
+	ar := LocaleFor("ar", "")
+	assert.Equal(t, "-١٬٢٣٤", ar.FormatNumber(-1234, 0))
+
+	arIL := LocaleFor("ar-IL", "")
+	assert.Equal(t, "-1,234", arIL.FormatNumber(-1234, 0))
+}
+
+func TestRenderHTML_IsolatesNumbersInRTL(t *testing.T) {
+	out, err := Render(`סה"כ: {{currency .total "ILS"}}`, map[string]any{"total": -50.0}, Options{
+		Language:  "he",
+		Direction: models.TextDirectionRTL,
+		Format:    FormatHTML,
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, out, `<html lang="he" dir="rtl">`)
+	assert.Contains(t, out, `<bdi dir="ltr">-50.00 ₪</bdi>`)
+}
+
+func TestRenderText_UsesUnicodeIsolates(t *testing.T) {
+	out, err := Render(`{{number .amount 1}}`, map[string]any{"amount": "12.34"}, Options{
+		Language:  "he",
+		Direction: models.TextDirectionRTL,
+		Format:    FormatText,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "\u206612.3\u2069", out)
+}
+
+func TestRenderPDF_LaysOutPages(t *testing.T) {
+	out, err := Render(`Signed on {{date .signed "long"}}`, map[string]any{"signed": "2024-05-09"}, Options{
+		Language: "en",
+		Format:   FormatPDF,
+		Title:    "Offer",
+	})
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(out, "%PDF-"))
+	assert.Contains(t, out, "/FontName /")
+	assert.Equal(t, "application/pdf", FormatPDF.ContentType())
+}
+
+func TestVisualOrder_KeepsIsolatedRunsLeftToRight(t *testing.T) {
+	text := "סה״כ \u206612.3\u2069 ש״ח"
+	assert.Equal(t, "סה״כ 3.21 ש״ח", visualOrder(text, true))
+	assert.Equal(t, "סה״כ 12.3 ש״ח", visualOrder(text, false))
+	assert.Equal(t, "total 12.3", visualOrder("total \u206812.3\u2069", false))
+}
@@ -6,9 +6,13 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
+	"net/netip"
+	"strings"
 
 	"github.com/gofrs/uuid"
+	"github.com/pro-posal/webserver/config"
 	"github.com/pro-posal/webserver/models"
 )
 
@@ -27,6 +31,10 @@ func UnmarshalRequest(r *http.Request, v any) error {
 }
 
 func MarshalAndWriteResponse(w http.ResponseWriter, data interface{}) {
+	MarshalAndWriteResponseWithStatus(w, http.StatusCreated, data)
+}
+
+func MarshalAndWriteResponseWithStatus(w http.ResponseWriter, status int, data interface{}) {
 	resp, err := json.Marshal(data)
 	if err != nil {
 		log.Printf("Failed marshaling response: %v", err)
@@ -34,7 +42,7 @@ func MarshalAndWriteResponse(w http.ResponseWriter, data interface{}) {
 		return
 	}
 
-	w.WriteHeader(http.StatusCreated)
+	w.WriteHeader(status)
 	w.Write(resp)
 }
 
@@ -58,3 +66,57 @@ func GetUserIDFromSession(r *http.Request) uuid.UUID {
 	log.Printf("Request is invoked by user %v", session.UserID)
 	return uuid.UUID(session.UserID)
 }
+
+// GetRequestInfoFromContext returns the ID and address of the request being served, nil outside
+// of a request.
+func GetRequestInfoFromContext(ctx context.Context) *models.RequestInfo {
+	info, _ := ctx.Value("request").(*models.RequestInfo)
+	return info
+}
+
+// GetClientIP returns the address of the caller. Forwarding headers are only believed when the
+// request comes from one of the configured trusted proxies, the client is then the right-most
+// address in X-Forwarded-For that isn't a trusted proxy, anything left of it could be made up.
+func GetClientIP(r *http.Request) string {
+	return clientIP(r, config.AppConfig.Server.TrustedProxies)
+}
+
+func clientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	remote, err := netip.ParseAddr(host)
+	if err != nil || !isTrustedProxy(remote, trustedProxies) {
+		return host
+	}
+
+	client := remote
+	if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+		hops := strings.Split(strings.Join(forwarded, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+			if err != nil {
+				// The proxy that appended it passed on garbage, it is the last address we can trust
+				break
+			}
+			client = hop
+			if !isTrustedProxy(hop, trustedProxies) {
+				break
+			}
+		}
+	} else if realIP, err := netip.ParseAddr(strings.TrimSpace(r.Header.Get("X-Real-IP"))); err == nil {
+		client = realIP
+	}
+	return client.Unmap().String()
+}
+
+func isTrustedProxy(addr netip.Addr, trustedProxies []netip.Prefix) bool {
+	addr = addr.Unmap()
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIP(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	for name, test := range map[string]struct {
+		remoteAddr string
+		headers    map[string]string
+		want       string
+	}{
+		"direct":                        {"203.0.113.7:4000", nil, "203.0.113.7"},
+		"untrusted peer is not relayed": {"203.0.113.7:4000", map[string]string{"X-Forwarded-For": "198.51.100.1", "X-Real-IP": "198.51.100.2"}, "203.0.113.7"},
+		"trusted proxy":                 {"10.0.0.2:4000", map[string]string{"X-Forwarded-For": "198.51.100.1"}, "198.51.100.1"},
+		"spoofed hops are skipped":      {"10.0.0.2:4000", map[string]string{"X-Forwarded-For": "1.2.3.4, 198.51.100.1, 10.0.0.3"}, "198.51.100.1"},
+		"garbage from the client":       {"10.0.0.2:4000", map[string]string{"X-Forwarded-For": "not an address"}, "10.0.0.2"},
+		"real ip from trusted proxy":    {"10.0.0.2:4000", map[string]string{"X-Real-IP": "198.51.100.2"}, "198.51.100.2"},
+		"only trusted hops":             {"10.0.0.2:4000", map[string]string{"X-Forwarded-For": "10.0.0.4, 10.0.0.3"}, "10.0.0.4"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = test.remoteAddr
+			for header, value := range test.headers {
+				r.Header.Set(header, value)
+			}
+			assert.Equal(t, test.want, clientIP(r, trusted))
+		})
+	}
+}
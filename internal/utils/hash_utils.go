@@ -1,8 +1,11 @@
 package utils
 
 import (
+	"crypto/rand"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
+	"fmt"
 	"strings"
 
 	"golang.org/x/crypto/bcrypt"
@@ -24,3 +27,19 @@ func HashEmail(email string) string {
 	hasher.Write([]byte(lowerEmail))
 	return hex.EncodeToString(hasher.Sum(nil))
 }
+
+// GenerateRandomToken returns a URL safe token made of size random bytes.
+func GenerateRandomToken(size int) (string, error) {
+	bytes := make([]byte, size)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed generating random token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// HashToken hashes an opaque token, such as a refresh token, for storage and lookup.
+func HashToken(token string) string {
+	hasher := sha256.New()
+	hasher.Write([]byte(token))
+	return hex.EncodeToString(hasher.Sum(nil))
+}
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE contract_templates ADD COLUMN language TEXT NOT NULL DEFAULT 'en';
+ALTER TABLE contract_templates ADD COLUMN direction TEXT NOT NULL DEFAULT 'ltr';
+ALTER TABLE contract_templates ADD COLUMN translation_group_id UUID NULL;
+CREATE INDEX "contract_templates_translation_group_id_index" ON
+    "contract_templates"("translation_group_id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX "contract_templates_translation_group_id_index";
+ALTER TABLE contract_templates DROP COLUMN translation_group_id;
+ALTER TABLE contract_templates DROP COLUMN direction;
+ALTER TABLE contract_templates DROP COLUMN language;
+-- +goose StatementEnd
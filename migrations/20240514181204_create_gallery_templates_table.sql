@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "gallery_templates"(
+    "id" UUID NOT NULL PRIMARY KEY,
+    "name" TEXT NOT NULL,
+    "description" TEXT NOT NULL,
+    "template" TEXT NOT NULL,
+    "language" TEXT NOT NULL DEFAULT 'en',
+    "direction" TEXT NOT NULL DEFAULT 'ltr',
+    "arguments_schema" jsonb NOT NULL DEFAULT '{}',
+    "categories" jsonb NOT NULL DEFAULT '[]',
+    "version" INTEGER NOT NULL DEFAULT 1,
+    "published_by" UUID NOT NULL,
+    "created_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL,
+    "updated_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL,
+    "deleted_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL
+);
+ALTER TABLE
+    "gallery_templates" ADD CONSTRAINT "gallery_templates_published_by_foreign" FOREIGN KEY("published_by") REFERENCES "users"("id");
+
+ALTER TABLE contract_templates ADD COLUMN arguments_schema jsonb NOT NULL DEFAULT '{}';
+ALTER TABLE contract_templates ADD COLUMN source_gallery_template_id UUID NULL;
+ALTER TABLE contract_templates ADD COLUMN source_version INTEGER NULL;
+ALTER TABLE
+    "contract_templates" ADD CONSTRAINT "contract_templates_source_gallery_template_id_foreign" FOREIGN KEY("source_gallery_template_id") REFERENCES "gallery_templates"("id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE contract_templates DROP CONSTRAINT "contract_templates_source_gallery_template_id_foreign";
+ALTER TABLE contract_templates DROP COLUMN source_version;
+ALTER TABLE contract_templates DROP COLUMN source_gallery_template_id;
+ALTER TABLE contract_templates DROP COLUMN arguments_schema;
+DROP TABLE "gallery_templates";
+-- +goose StatementEnd
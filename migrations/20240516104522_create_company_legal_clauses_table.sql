@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "company_legal_clauses"(
+    "id" UUID NOT NULL PRIMARY KEY,
+    "company_id" UUID NOT NULL,
+    "name" TEXT NOT NULL,
+    "content" TEXT NOT NULL,
+    "mandatory" BOOLEAN NOT NULL DEFAULT TRUE,
+    "created_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL,
+    "updated_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL,
+    "deleted_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL
+);
+ALTER TABLE
+    "company_legal_clauses" ADD CONSTRAINT "company_legal_clauses_company_id_foreign" FOREIGN KEY("company_id") REFERENCES "companies"("id");
+CREATE INDEX "company_legal_clauses_company_id_index" ON "company_legal_clauses"("company_id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "company_legal_clauses";
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE categories ADD COLUMN position INTEGER NOT NULL DEFAULT 0;
+UPDATE categories SET position = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY company_id, category_id ORDER BY created_at, id) - 1 AS position
+    FROM categories
+) AS ordered
+WHERE categories.id = ordered.id;
+CREATE INDEX "categories_company_id_category_id_position_index" ON "categories"("company_id", "category_id", "position");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX "categories_company_id_category_id_position_index";
+ALTER TABLE categories DROP COLUMN position;
+-- +goose StatementEnd
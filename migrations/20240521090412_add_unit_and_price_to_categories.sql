@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE categories ADD COLUMN unit TEXT NULL;
+ALTER TABLE categories ADD COLUMN price NUMERIC(14, 2) NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE categories DROP COLUMN price;
+ALTER TABLE categories DROP COLUMN unit;
+-- +goose StatementEnd
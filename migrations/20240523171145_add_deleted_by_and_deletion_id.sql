@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE categories ADD COLUMN deleted_by UUID NULL;
+ALTER TABLE categories ADD COLUMN deletion_id UUID NULL;
+ALTER TABLE contract_templates ADD COLUMN deleted_by UUID NULL;
+ALTER TABLE contract_templates ADD COLUMN deletion_id UUID NULL;
+ALTER TABLE companies ADD COLUMN deleted_by UUID NULL;
+ALTER TABLE companies ADD COLUMN deletion_id UUID NULL;
+ALTER TABLE users ADD COLUMN deleted_by UUID NULL;
+ALTER TABLE users ADD COLUMN deletion_id UUID NULL;
+
+-- Records deleted before this migration get a deletion so they can still be restored; cascaded
+-- category deletes shared one timestamp, so those are grouped back together by it
+UPDATE categories SET deletion_id = md5(company_id::text || deleted_at::text)::uuid WHERE deleted_at IS NOT NULL;
+UPDATE contract_templates SET deletion_id = id WHERE deleted_at IS NOT NULL;
+UPDATE companies SET deletion_id = id WHERE deleted_at IS NOT NULL;
+UPDATE users SET deletion_id = id WHERE deleted_at IS NOT NULL;
+
+CREATE INDEX "categories_deletion_id_index" ON "categories"("deletion_id");
+CREATE INDEX "contract_templates_deletion_id_index" ON "contract_templates"("deletion_id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX "contract_templates_deletion_id_index";
+DROP INDEX "categories_deletion_id_index";
+ALTER TABLE users DROP COLUMN deletion_id;
+ALTER TABLE users DROP COLUMN deleted_by;
+ALTER TABLE companies DROP COLUMN deletion_id;
+ALTER TABLE companies DROP COLUMN deleted_by;
+ALTER TABLE contract_templates DROP COLUMN deletion_id;
+ALTER TABLE contract_templates DROP COLUMN deleted_by;
+ALTER TABLE categories DROP COLUMN deletion_id;
+ALTER TABLE categories DROP COLUMN deleted_by;
+-- +goose StatementEnd
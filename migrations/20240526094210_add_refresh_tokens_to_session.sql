@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "session" ADD COLUMN "refresh_token_hash" TEXT NULL;
+ALTER TABLE "session" ADD COLUMN "refresh_expires_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL;
+ALTER TABLE "session" ADD COLUMN "family_id" UUID NULL;
+ALTER TABLE "session" ADD COLUMN "replaced_by" UUID NULL;
+ALTER TABLE "session" ADD COLUMN "revoked_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL;
+
+-- Every existing session starts its own family
+UPDATE "session" SET "family_id" = "id";
+ALTER TABLE "session" ALTER COLUMN "family_id" SET NOT NULL;
+
+CREATE UNIQUE INDEX "session_refresh_token_hash_unique" ON "session"("refresh_token_hash");
+CREATE INDEX "session_family_id_index" ON "session"("family_id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX "session_family_id_index";
+DROP INDEX "session_refresh_token_hash_unique";
+ALTER TABLE "session" DROP COLUMN "revoked_at";
+ALTER TABLE "session" DROP COLUMN "replaced_by";
+ALTER TABLE "session" DROP COLUMN "family_id";
+ALTER TABLE "session" DROP COLUMN "refresh_expires_at";
+ALTER TABLE "session" DROP COLUMN "refresh_token_hash";
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "session" ADD COLUMN "user_agent" TEXT NULL;
+ALTER TABLE "session" ADD COLUMN "ip_address" TEXT NULL;
+ALTER TABLE "session" ADD COLUMN "last_seen_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL;
+
+CREATE INDEX "session_user_id_index" ON "session"("user_id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX "session_user_id_index";
+ALTER TABLE "session" DROP COLUMN "last_seen_at";
+ALTER TABLE "session" DROP COLUMN "ip_address";
+ALTER TABLE "session" DROP COLUMN "user_agent";
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "password_reset_tokens"(
+    "id" UUID NOT NULL PRIMARY KEY,
+    "user_id" UUID NOT NULL,
+    "token_hash" TEXT NOT NULL,
+    "requested_ip" TEXT NULL,
+    "expires_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL,
+    "used_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL,
+    "created_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL
+);
+ALTER TABLE
+    "password_reset_tokens" ADD CONSTRAINT "password_reset_tokens_user_id_foreign" FOREIGN KEY("user_id") REFERENCES "users"("id");
+ALTER TABLE
+    "password_reset_tokens" ADD CONSTRAINT "password_reset_tokens_token_hash_unique" UNIQUE("token_hash");
+CREATE INDEX "password_reset_tokens_user_id_index" ON "password_reset_tokens"("user_id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "password_reset_tokens";
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "users" ADD COLUMN "email_verified_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL;
+ALTER TABLE "users" ADD COLUMN "verification_sent_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL;
+
+-- Accounts created before verification existed keep working as they did
+UPDATE "users" SET "email_verified_at" = "created_at";
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "users" DROP COLUMN "verification_sent_at";
+ALTER TABLE "users" DROP COLUMN "email_verified_at";
+-- +goose StatementEnd
@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "users" ADD COLUMN "totp_secret" TEXT NULL;
+ALTER TABLE "users" ADD COLUMN "totp_enabled_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL;
+ALTER TABLE "users" ADD COLUMN "totp_last_used_step" BIGINT NULL;
+ALTER TABLE "companies" ADD COLUMN "require_two_factor" BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE "two_factor_recovery_codes"(
+    "id" UUID NOT NULL PRIMARY KEY,
+    "user_id" UUID NOT NULL,
+    "code_hash" TEXT NOT NULL,
+    "used_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL,
+    "created_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL
+);
+ALTER TABLE
+    "two_factor_recovery_codes" ADD CONSTRAINT "two_factor_recovery_codes_user_id_foreign" FOREIGN KEY("user_id") REFERENCES "users"("id");
+CREATE INDEX "two_factor_recovery_codes_user_id_index" ON "two_factor_recovery_codes"("user_id");
+
+CREATE TABLE "login_challenges"(
+    "id" UUID NOT NULL PRIMARY KEY,
+    "user_id" UUID NOT NULL,
+    "token_hash" TEXT NOT NULL,
+    "user_agent" TEXT NULL,
+    "ip_address" TEXT NULL,
+    "attempts" INTEGER NOT NULL DEFAULT 0,
+    "expires_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL,
+    "used_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL,
+    "created_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL
+);
+ALTER TABLE
+    "login_challenges" ADD CONSTRAINT "login_challenges_user_id_foreign" FOREIGN KEY("user_id") REFERENCES "users"("id");
+ALTER TABLE
+    "login_challenges" ADD CONSTRAINT "login_challenges_token_hash_unique" UNIQUE("token_hash");
+CREATE INDEX "login_challenges_user_id_index" ON "login_challenges"("user_id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "login_challenges";
+DROP TABLE "two_factor_recovery_codes";
+ALTER TABLE "companies" DROP COLUMN "require_two_factor";
+ALTER TABLE "users" DROP COLUMN "totp_last_used_step";
+ALTER TABLE "users" DROP COLUMN "totp_enabled_at";
+ALTER TABLE "users" DROP COLUMN "totp_secret";
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "users" ADD COLUMN "failed_login_count" INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE "users" ADD COLUMN "last_failed_login_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL;
+ALTER TABLE "users" ADD COLUMN "locked_until" TIMESTAMP(0) WITHOUT TIME ZONE NULL;
+
+CREATE TABLE "login_attempts"(
+    "id" UUID NOT NULL PRIMARY KEY,
+    "email_hash" TEXT NOT NULL,
+    "user_id" UUID NULL,
+    "ip_address" TEXT NULL,
+    "succeeded" BOOLEAN NOT NULL,
+    "created_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL
+);
+ALTER TABLE
+    "login_attempts" ADD CONSTRAINT "login_attempts_user_id_foreign" FOREIGN KEY("user_id") REFERENCES "users"("id");
+CREATE INDEX "login_attempts_ip_address_created_at_index" ON "login_attempts"("ip_address", "created_at");
+CREATE INDEX "login_attempts_email_hash_created_at_index" ON "login_attempts"("email_hash", "created_at");
+CREATE INDEX "login_attempts_user_id_index" ON "login_attempts"("user_id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "login_attempts";
+ALTER TABLE "users" DROP COLUMN "locked_until";
+ALTER TABLE "users" DROP COLUMN "last_failed_login_at";
+ALTER TABLE "users" DROP COLUMN "failed_login_count";
+-- +goose StatementEnd
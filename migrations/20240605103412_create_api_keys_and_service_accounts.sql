@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "users" ADD COLUMN "is_service_account" BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE "api_keys"(
+    "id" UUID NOT NULL PRIMARY KEY,
+    "company_id" UUID NOT NULL,
+    "user_id" UUID NOT NULL,
+    "name" TEXT NOT NULL,
+    "prefix" TEXT NOT NULL,
+    "key_hash" TEXT NOT NULL,
+    "scopes" TEXT NOT NULL,
+    "expires_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL,
+    "last_used_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL,
+    "created_by" UUID NOT NULL,
+    "created_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL,
+    "revoked_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL
+);
+ALTER TABLE
+    "api_keys" ADD CONSTRAINT "api_keys_key_hash_unique" UNIQUE("key_hash");
+ALTER TABLE
+    "api_keys" ADD CONSTRAINT "api_keys_company_id_foreign" FOREIGN KEY("company_id") REFERENCES "companies"("id");
+ALTER TABLE
+    "api_keys" ADD CONSTRAINT "api_keys_user_id_foreign" FOREIGN KEY("user_id") REFERENCES "users"("id");
+ALTER TABLE
+    "api_keys" ADD CONSTRAINT "api_keys_created_by_foreign" FOREIGN KEY("created_by") REFERENCES "users"("id");
+CREATE INDEX "api_keys_company_id_index" ON "api_keys"("company_id");
+CREATE INDEX "api_keys_user_id_index" ON "api_keys"("user_id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "api_keys";
+ALTER TABLE "users" DROP COLUMN "is_service_account";
+-- +goose StatementEnd
@@ -0,0 +1,61 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "identity_providers"(
+    "id" UUID NOT NULL PRIMARY KEY,
+    "company_id" UUID NOT NULL,
+    "name" TEXT NOT NULL,
+    "issuer" TEXT NOT NULL,
+    "client_id" TEXT NOT NULL,
+    "client_secret" TEXT NOT NULL,
+    "scopes" TEXT NOT NULL,
+    "groups_claim" TEXT NOT NULL,
+    "group_roles" TEXT NOT NULL,
+    "default_role" TEXT NULL,
+    "enabled" BOOLEAN NOT NULL DEFAULT TRUE,
+    "created_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL,
+    "updated_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL
+);
+ALTER TABLE
+    "identity_providers" ADD CONSTRAINT "identity_providers_company_id_foreign" FOREIGN KEY("company_id") REFERENCES "companies"("id");
+CREATE INDEX "identity_providers_company_id_index" ON "identity_providers"("company_id");
+
+CREATE TABLE "oidc_login_states"(
+    "id" UUID NOT NULL PRIMARY KEY,
+    "provider_id" UUID NOT NULL,
+    "state_hash" TEXT NOT NULL,
+    "nonce" TEXT NOT NULL,
+    "code_verifier" TEXT NOT NULL,
+    "user_agent" TEXT NULL,
+    "ip_address" TEXT NULL,
+    "expires_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL,
+    "used_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL,
+    "created_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL
+);
+ALTER TABLE
+    "oidc_login_states" ADD CONSTRAINT "oidc_login_states_state_hash_unique" UNIQUE("state_hash");
+ALTER TABLE
+    "oidc_login_states" ADD CONSTRAINT "oidc_login_states_provider_id_foreign" FOREIGN KEY("provider_id") REFERENCES "identity_providers"("id");
+
+CREATE TABLE "user_identities"(
+    "id" UUID NOT NULL PRIMARY KEY,
+    "provider_id" UUID NOT NULL,
+    "user_id" UUID NOT NULL,
+    "subject" TEXT NOT NULL,
+    "created_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL,
+    "last_login_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL
+);
+ALTER TABLE
+    "user_identities" ADD CONSTRAINT "user_identities_provider_id_subject_unique" UNIQUE("provider_id", "subject");
+ALTER TABLE
+    "user_identities" ADD CONSTRAINT "user_identities_provider_id_foreign" FOREIGN KEY("provider_id") REFERENCES "identity_providers"("id");
+ALTER TABLE
+    "user_identities" ADD CONSTRAINT "user_identities_user_id_foreign" FOREIGN KEY("user_id") REFERENCES "users"("id");
+CREATE INDEX "user_identities_user_id_index" ON "user_identities"("user_id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "user_identities";
+DROP TABLE "oidc_login_states";
+DROP TABLE "identity_providers";
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "invitations"(
+    "id" UUID NOT NULL PRIMARY KEY,
+    "company_id" UUID NOT NULL,
+    "email" TEXT NOT NULL,
+    "email_hash" TEXT NOT NULL,
+    "role" TEXT NOT NULL,
+    "token_hash" TEXT NOT NULL,
+    "invited_by" UUID NOT NULL,
+    "expires_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL,
+    "sent_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL,
+    "accepted_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL,
+    "accepted_by" UUID NULL,
+    "revoked_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL,
+    "created_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL,
+    "updated_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL
+);
+ALTER TABLE
+    "invitations" ADD CONSTRAINT "invitations_token_hash_unique" UNIQUE("token_hash");
+ALTER TABLE
+    "invitations" ADD CONSTRAINT "invitations_company_id_foreign" FOREIGN KEY("company_id") REFERENCES "companies"("id");
+ALTER TABLE
+    "invitations" ADD CONSTRAINT "invitations_invited_by_foreign" FOREIGN KEY("invited_by") REFERENCES "users"("id");
+ALTER TABLE
+    "invitations" ADD CONSTRAINT "invitations_accepted_by_foreign" FOREIGN KEY("accepted_by") REFERENCES "users"("id");
+CREATE INDEX "invitations_company_id_index" ON "invitations"("company_id");
+-- Only one pending invitation per address and company
+CREATE UNIQUE INDEX "invitations_pending_unique" ON "invitations"("company_id", "email_hash")
+    WHERE "accepted_at" IS NULL AND "revoked_at" IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "invitations";
+-- +goose StatementEnd
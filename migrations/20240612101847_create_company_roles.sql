@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "company_roles"(
+    "id" UUID NOT NULL PRIMARY KEY,
+    "company_id" UUID NOT NULL,
+    "name" TEXT NOT NULL,
+    "description" TEXT NULL,
+    "capabilities" JSONB NOT NULL,
+    "created_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL,
+    "updated_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL
+);
+ALTER TABLE
+    "company_roles" ADD CONSTRAINT "company_roles_company_id_foreign" FOREIGN KEY("company_id") REFERENCES "companies"("id");
+CREATE UNIQUE INDEX "company_roles_company_id_name_unique" ON "company_roles"("company_id", "name");
+ALTER TABLE
+    "permissions" ADD COLUMN "company_role_id" UUID NULL;
+ALTER TABLE
+    "permissions" ADD CONSTRAINT "permissions_company_role_id_foreign" FOREIGN KEY("company_role_id") REFERENCES "company_roles"("id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE
+    "permissions" DROP COLUMN "company_role_id";
+DROP TABLE "company_roles";
+-- +goose StatementEnd
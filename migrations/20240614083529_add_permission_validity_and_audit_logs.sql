@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE
+    "permissions" ADD COLUMN "starts_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL;
+ALTER TABLE
+    "permissions" ADD COLUMN "expires_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL;
+ALTER TABLE
+    "permissions" ADD COLUMN "expiry_notified_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL;
+CREATE INDEX "permissions_expires_at_index" ON "permissions"("expires_at");
+CREATE TABLE "audit_logs"(
+    "id" UUID NOT NULL PRIMARY KEY,
+    "company_id" UUID NOT NULL,
+    "actor_id" UUID NULL,
+    "action" TEXT NOT NULL,
+    "entity_type" TEXT NOT NULL,
+    "entity_id" TEXT NOT NULL,
+    "before" JSONB NULL,
+    "created_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL
+);
+ALTER TABLE
+    "audit_logs" ADD CONSTRAINT "audit_logs_company_id_foreign" FOREIGN KEY("company_id") REFERENCES "companies"("id");
+CREATE INDEX "audit_logs_company_id_created_at_index" ON "audit_logs"("company_id", "created_at");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "audit_logs";
+ALTER TABLE
+    "permissions" DROP COLUMN "expiry_notified_at";
+ALTER TABLE
+    "permissions" DROP COLUMN "expires_at";
+ALTER TABLE
+    "permissions" DROP COLUMN "starts_at";
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "ownership_transfers"(
+    "id" UUID NOT NULL PRIMARY KEY,
+    "company_id" UUID NOT NULL,
+    "from_user_id" UUID NOT NULL,
+    "to_user_id" UUID NOT NULL,
+    "previous_owner_role" TEXT NOT NULL,
+    "expires_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL,
+    "accepted_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL,
+    "declined_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL,
+    "cancelled_at" TIMESTAMP(0) WITHOUT TIME ZONE NULL,
+    "created_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL,
+    "updated_at" TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL
+);
+ALTER TABLE
+    "ownership_transfers" ADD CONSTRAINT "ownership_transfers_company_id_foreign" FOREIGN KEY("company_id") REFERENCES "companies"("id");
+ALTER TABLE
+    "ownership_transfers" ADD CONSTRAINT "ownership_transfers_from_user_id_foreign" FOREIGN KEY("from_user_id") REFERENCES "users"("id");
+ALTER TABLE
+    "ownership_transfers" ADD CONSTRAINT "ownership_transfers_to_user_id_foreign" FOREIGN KEY("to_user_id") REFERENCES "users"("id");
+CREATE INDEX "ownership_transfers_company_id_index" ON "ownership_transfers"("company_id");
+CREATE INDEX "ownership_transfers_to_user_id_index" ON "ownership_transfers"("to_user_id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "ownership_transfers";
+-- +goose StatementEnd
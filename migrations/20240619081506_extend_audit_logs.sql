@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE
+    "audit_logs" ADD COLUMN "session_id" UUID NULL;
+ALTER TABLE
+    "audit_logs" ADD COLUMN "after" JSONB NULL;
+ALTER TABLE
+    "audit_logs" ADD COLUMN "changes" JSONB NULL;
+ALTER TABLE
+    "audit_logs" ADD COLUMN "ip_address" TEXT NULL;
+ALTER TABLE
+    "audit_logs" ADD COLUMN "request_id" TEXT NULL;
+-- The audit log outlives the companies it is about
+ALTER TABLE
+    "audit_logs" DROP CONSTRAINT "audit_logs_company_id_foreign";
+CREATE INDEX "audit_logs_company_id_entity_index" ON "audit_logs"("company_id", "entity_type", "entity_id");
+CREATE FUNCTION "audit_logs_append_only"() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+CREATE TRIGGER "audit_logs_append_only" BEFORE UPDATE OR DELETE ON "audit_logs"
+    FOR EACH ROW EXECUTE FUNCTION "audit_logs_append_only"();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER "audit_logs_append_only" ON "audit_logs";
+DROP FUNCTION "audit_logs_append_only"();
+DROP INDEX "audit_logs_company_id_entity_index";
+ALTER TABLE
+    "audit_logs" ADD CONSTRAINT "audit_logs_company_id_foreign" FOREIGN KEY("company_id") REFERENCES "companies"("id") NOT VALID;
+ALTER TABLE
+    "audit_logs" DROP COLUMN "request_id";
+ALTER TABLE
+    "audit_logs" DROP COLUMN "ip_address";
+ALTER TABLE
+    "audit_logs" DROP COLUMN "changes";
+ALTER TABLE
+    "audit_logs" DROP COLUMN "after";
+ALTER TABLE
+    "audit_logs" DROP COLUMN "session_id";
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Set when a signed in user starts the sign in to link the identity to their own account
+ALTER TABLE
+    "oidc_login_states" ADD COLUMN "link_user_id" UUID NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE
+    "oidc_login_states" DROP COLUMN "link_user_id";
+-- +goose StatementEnd
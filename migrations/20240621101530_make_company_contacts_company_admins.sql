@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Companies used to make their contact a platform admin, they only administer the company itself
+UPDATE
+    "permissions" SET "role" = 'company_admin', "updated_at" = NOW()
+FROM
+    "companies"
+WHERE
+    "permissions"."company_id" = "companies"."id"
+    AND "permissions"."user_id" = "companies"."contact_id"
+    AND "permissions"."role" = 'admin';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- The contacts who were meant to be platform admins can't be told apart, they are granted again by hand
+SELECT 1;
+-- +goose StatementEnd
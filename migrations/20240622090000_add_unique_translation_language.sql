@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Translations created concurrently may have slipped in twice, the later ones leave the group
+UPDATE "contract_templates" SET "translation_group_id" = NULL
+WHERE "id" IN (
+    SELECT "id" FROM (
+        SELECT "id", ROW_NUMBER() OVER (PARTITION BY "translation_group_id", "language" ORDER BY "created_at", "id") AS "position"
+        FROM "contract_templates"
+        WHERE "translation_group_id" IS NOT NULL AND "deleted_at" IS NULL
+    ) "translations"
+    WHERE "position" > 1
+);
+CREATE UNIQUE INDEX "contract_templates_translation_group_id_language_unique" ON
+    "contract_templates"("translation_group_id", "language") WHERE "deleted_at" IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX "contract_templates_translation_group_id_language_unique";
+-- +goose StatementEnd
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+type APIKeyScope string
+
+const (
+	// APIKeyScopeRead only allows GET requests
+	APIKeyScopeRead APIKeyScope = "read"
+	// APIKeyScopeWrite allows every request, reading included
+	APIKeyScopeWrite APIKeyScope = "write"
+)
+
+// APIKey lets an integration call the API as a user within a single company. The Key itself is
+// only returned when the key is created; afterwards it is identified by its Prefix.
+type APIKey struct {
+	ID         string        `json:"id"`
+	CompanyID  string        `json:"company_id"`
+	UserID     string        `json:"user_id"`
+	Name       string        `json:"name"`
+	Prefix     string        `json:"prefix"`
+	Key        string        `json:"key,omitempty"`
+	Scopes     []APIKeyScope `json:"scopes"`
+	ExpiresAt  *time.Time    `json:"expires_at"`
+	LastUsedAt *time.Time    `json:"last_used_at"`
+	CreatedBy  string        `json:"created_by"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// APIKeyGrant is what a request authenticated with an API key is limited to.
+type APIKeyGrant struct {
+	ID        string        `json:"id"`
+	CompanyID string        `json:"company_id"`
+	Scopes    []APIKeyScope `json:"scopes"`
+}
+
+// ServiceAccount is a user that can't sign in and only acts through API keys, with a single role in its company.
+type ServiceAccount struct {
+	ID        string    `json:"id"`
+	CompanyID string    `json:"company_id"`
+	Name      string    `json:"name"`
+	Role      Role      `json:"role"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
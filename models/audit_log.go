@@ -0,0 +1,36 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditLog records a change to a company's data: who made it, from which request, and the
+// entity before and after. Changes lists the fields an update changed.
+type AuditLog struct {
+	ID         string                 `json:"id"`
+	CompanyID  string                 `json:"company_id"`
+	ActorID    string                 `json:"actor_id"`
+	SessionID  string                 `json:"session_id"`
+	Action     string                 `json:"action"`
+	EntityType string                 `json:"entity_type"`
+	EntityID   string                 `json:"entity_id"`
+	Before     json.RawMessage        `json:"before,omitempty"`
+	After      json.RawMessage        `json:"after,omitempty"`
+	Changes    map[string]AuditChange `json:"changes,omitempty"`
+	IPAddress  string                 `json:"ip_address"`
+	RequestID  string                 `json:"request_id"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// AuditChange is the value of a field before and after an update.
+type AuditChange struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+// RequestInfo identifies the HTTP request a change was made in, for the audit log.
+type RequestInfo struct {
+	ID        string
+	IPAddress string
+}
@@ -16,7 +16,56 @@ type Category struct {
 	CategoryID  string       `json:"category_id"`
 	Description string       `json:"description"`
 	Type        CategoryType `json:"type"`
+	Position    int          `json:"position"`
+	Unit        string       `json:"unit,omitempty"`
+	Price       string       `json:"price,omitempty"`
 	CreatedAt   time.Time    `json:"created_at"`
 	UpdatedAt   time.Time    `json:"updated_at"`
 	DeleteAt    time.Time    `json:"deleted_at"`
 }
+
+// CategoryNode is a category together with its nested sub categories and descriptions.
+type CategoryNode struct {
+	Category
+	Depth    int             `json:"depth"`
+	Children []*CategoryNode `json:"children"`
+}
+
+type CatalogImportIssue struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// CatalogImportReport describes what importing a catalog sheet did, or would do on a dry run.
+type CatalogImportReport struct {
+	DryRun     bool                 `json:"dry_run"`
+	Applied    bool                 `json:"applied"`
+	TotalRows  int                  `json:"total_rows"`
+	Created    int                  `json:"created"`
+	Updated    int                  `json:"updated"`
+	Unchanged  int                  `json:"unchanged"`
+	Duplicates []CatalogImportIssue `json:"duplicates"`
+	Conflicts  []CatalogImportIssue `json:"conflicts"`
+	Errors     []CatalogImportIssue `json:"errors"`
+}
+
+type CatalogConflictPolicy string
+
+const (
+	// CatalogConflictSkip leaves descriptions that already exist in the target untouched
+	CatalogConflictSkip CatalogConflictPolicy = "skip"
+	// CatalogConflictMerge overwrites the unit and price of existing descriptions with the copied ones
+	CatalogConflictMerge CatalogConflictPolicy = "merge"
+)
+
+// CatalogCopyReport describes what copying a catalog into another company did.
+type CatalogCopyReport struct {
+	ConflictPolicy CatalogConflictPolicy `json:"conflict_policy"`
+	Created        int                   `json:"created"`
+	Merged         int                   `json:"merged"`
+	Updated        int                   `json:"updated"`
+	Skipped        int                   `json:"skipped"`
+	Unchanged      int                   `json:"unchanged"`
+	// Categories maps every copied source category ID to its ID in the target company
+	Categories map[string]string `json:"categories"`
+}
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// Capability is a single thing a role allows within a company.
+type Capability string
+
+const (
+	// CapabilityCompanyView allows reading the company and managing one's own API keys
+	CapabilityCompanyView Capability = "company.view"
+	// CapabilityCompanyManage allows changing or deleting the company, its trash and its identity providers
+	CapabilityCompanyManage Capability = "company.manage"
+	// CapabilityMembersView allows listing the company's members and roles
+	CapabilityMembersView Capability = "members.view"
+	// CapabilityMembersManage allows granting permissions, inviting users and defining roles
+	CapabilityMembersManage Capability = "members.manage"
+	// CapabilityIntegrationsManage allows managing service accounts and everyone's API keys
+	CapabilityIntegrationsManage Capability = "integrations.manage"
+	// CapabilityAuditView allows reading and exporting the company's audit log
+	CapabilityAuditView Capability = "audit.view"
+	// CapabilityTemplatesView allows reading and rendering contract templates and legal clauses
+	CapabilityTemplatesView Capability = "templates.view"
+	// CapabilityTemplatesEdit allows writing contract templates and legal clauses
+	CapabilityTemplatesEdit Capability = "templates.edit"
+	// CapabilityTemplatesPublish allows adopting gallery templates into the company
+	CapabilityTemplatesPublish Capability = "templates.publish"
+	// CapabilityCatalogView allows reading and exporting the catalog
+	CapabilityCatalogView Capability = "catalog.view"
+	// CapabilityCatalogEdit allows changing the catalog, prices included
+	CapabilityCatalogEdit Capability = "catalog.edit"
+	// CapabilityOffersView allows reading all of the company's offers
+	CapabilityOffersView Capability = "offers.view"
+	// CapabilityOffersCreate allows sending offers
+	CapabilityOffersCreate Capability = "offers.create"
+	// CapabilityOffersRespond allows reading and answering the offers sent to oneself
+	CapabilityOffersRespond Capability = "offers.respond"
+)
+
+// Capabilities lists every capability a company role can be composed of.
+var Capabilities = []Capability{
+	CapabilityCompanyView,
+	CapabilityCompanyManage,
+	CapabilityMembersView,
+	CapabilityMembersManage,
+	CapabilityIntegrationsManage,
+	CapabilityAuditView,
+	CapabilityTemplatesView,
+	CapabilityTemplatesEdit,
+	CapabilityTemplatesPublish,
+	CapabilityCatalogView,
+	CapabilityCatalogEdit,
+	CapabilityOffersView,
+	CapabilityOffersCreate,
+	CapabilityOffersRespond,
+}
+
+var staffCapabilities = []Capability{
+	CapabilityCompanyView,
+	CapabilityMembersView,
+	CapabilityTemplatesView,
+	CapabilityTemplatesEdit,
+	CapabilityCatalogView,
+	CapabilityCatalogEdit,
+	CapabilityOffersView,
+	CapabilityOffersCreate,
+}
+
+// RoleCapabilities are the capabilities of the built-in roles. Platform admins aren't listed,
+// they may do everything.
+var RoleCapabilities = map[Role][]Capability{
+	CompanyAdminRole: {
+		CapabilityCompanyView,
+		CapabilityCompanyManage,
+		CapabilityMembersView,
+		CapabilityMembersManage,
+		CapabilityIntegrationsManage,
+		CapabilityAuditView,
+		CapabilityTemplatesView,
+		CapabilityTemplatesEdit,
+		CapabilityTemplatesPublish,
+		CapabilityCatalogView,
+		CapabilityCatalogEdit,
+		CapabilityOffersView,
+		CapabilityOffersCreate,
+	},
+	CompanyProjectManagerRole: staffCapabilities,
+	CompanyContributorRole:    staffCapabilities,
+	ProspectRole:              {CapabilityOffersRespond},
+}
+
+// IsCapability reports whether the capability exists.
+func IsCapability(capability Capability) bool {
+	return slices.Contains(Capabilities, capability)
+}
+
+// CompanyRole is a role a company defines for itself out of capabilities, for example a pricing
+// manager who may edit the catalog but not the templates. It is assigned with CompanyCustomRole permissions.
+type CompanyRole struct {
+	ID           string       `json:"id"`
+	CompanyID    string       `json:"company_id"`
+	Name         string       `json:"name"`
+	Description  string       `json:"description"`
+	Capabilities []Capability `json:"capabilities"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
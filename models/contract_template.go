@@ -2,11 +2,49 @@ package models
 
 import "time"
 
+type TextDirection string
+
+const (
+	TextDirectionLTR TextDirection = "ltr"
+	TextDirectionRTL TextDirection = "rtl"
+)
+
 type ContractTemplate struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	CompanyID string    `json:"company_id"`
-	Template  string    `json:"template"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                      string         `json:"id"`
+	Name                    string         `json:"name"`
+	CompanyID               string         `json:"company_id"`
+	Template                string         `json:"template"`
+	Language                string         `json:"language"`
+	Direction               TextDirection  `json:"direction"`
+	TranslationGroupID      string         `json:"translation_group_id,omitempty"`
+	Schema                  TemplateSchema `json:"schema"`
+	SourceGalleryTemplateID string         `json:"source_gallery_template_id,omitempty"`
+	SourceVersion           int            `json:"source_version,omitempty"`
+	UpdateAvailable         bool           `json:"update_available,omitempty"`
+	Findings                []LintFinding  `json:"findings,omitempty"`
+	CreatedAt               time.Time      `json:"created_at"`
+	UpdatedAt               time.Time      `json:"updated_at"`
+}
+
+type RenderedContract struct {
+	ContractTemplateID string        `json:"contract_template_id"`
+	Language           string        `json:"language"`
+	Direction          TextDirection `json:"direction"`
+	ContentType        string        `json:"content_type"`
+	Content            string        `json:"content"`
+}
+
+type LintSeverity string
+
+const (
+	LintSeverityError   LintSeverity = "error"
+	LintSeverityWarning LintSeverity = "warning"
+)
+
+type LintFinding struct {
+	Severity LintSeverity `json:"severity"`
+	Rule     string       `json:"rule"`
+	Message  string       `json:"message"`
+	Line     int          `json:"line"`
+	Column   int          `json:"column"`
 }
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+type TemplateArgument struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Required    bool   `json:"required"`
+	Description string `json:"description,omitempty"`
+}
+
+// TemplateSchema declares the arguments a contract template expects when it is rendered.
+type TemplateSchema struct {
+	Arguments []TemplateArgument `json:"arguments"`
+}
+
+// GalleryCategory is a catalog node shipped with a gallery template, copied into the
+// adopting company's category tree.
+type GalleryCategory struct {
+	Description string            `json:"description"`
+	Type        CategoryType      `json:"type"`
+	Children    []GalleryCategory `json:"children,omitempty"`
+}
+
+type GalleryTemplate struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Template    string            `json:"template"`
+	Language    string            `json:"language"`
+	Direction   TextDirection     `json:"direction"`
+	Schema      TemplateSchema    `json:"schema"`
+	Categories  []GalleryCategory `json:"categories"`
+	Version     int               `json:"version"`
+	PublishedBy string            `json:"published_by"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
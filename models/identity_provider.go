@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// IdentityProvider is a company's OpenID Connect provider its staff can sign in with. The client
+// secret is never returned.
+type IdentityProvider struct {
+	ID        string   `json:"id"`
+	CompanyID string   `json:"company_id"`
+	Name      string   `json:"name"`
+	Issuer    string   `json:"issuer"`
+	ClientID  string   `json:"client_id"`
+	Scopes    []string `json:"scopes"`
+	// GroupsClaim is the ID token claim listing the user's groups, GroupRoles maps them to roles
+	GroupsClaim string          `json:"groups_claim"`
+	GroupRoles  map[string]Role `json:"group_roles"`
+	// DefaultRole is given to users in none of the mapped groups, who can't sign in when it is empty
+	DefaultRole Role      `json:"default_role"`
+	Enabled     bool      `json:"enabled"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// OIDCLogin is where to send the user to sign in at their identity provider.
+type OIDCLogin struct {
+	AuthorizationURL string    `json:"authorization_url"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Invitation offers a role in a company to an email address. The user accepts it from the
+// emailed link, signing up first if they don't have an account yet.
+type Invitation struct {
+	ID        string    `json:"id"`
+	CompanyID string    `json:"company_id"`
+	Email     string    `json:"email"`
+	Role      Role      `json:"role"`
+	InvitedBy string    `json:"invited_by"`
+	ExpiresAt time.Time `json:"expires_at"`
+	SentAt    time.Time `json:"sent_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
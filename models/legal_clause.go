@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+type LegalClause struct {
+	ID        string    `json:"id"`
+	CompanyID string    `json:"company_id"`
+	Name      string    `json:"name"`
+	Content   string    `json:"content"`
+	Mandatory bool      `json:"mandatory"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
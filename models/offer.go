@@ -1 +1,23 @@
 package models
+
+import "time"
+
+// Offer is a contract template rendered with a customer's arguments and sent to them. The
+// customer holds a prospect permission for the template and may only open and respond to it.
+type Offer struct {
+	ID                 string         `json:"id"`
+	CompanyID          string         `json:"company_id"`
+	ContractTemplateID string         `json:"contract_template_id"`
+	CustomerID         string         `json:"customer_id"`
+	CreatedBy          string         `json:"created_by"`
+	Arguments          map[string]any `json:"arguments"`
+	Content            string         `json:"content"`
+	FinalizedAt        time.Time      `json:"finalized_at"`
+	SentAt             *time.Time     `json:"sent_at"`
+	OpenedAt           *time.Time     `json:"opened_at"`
+	AcceptedAt         *time.Time     `json:"accepted_at"`
+	RejectedAt         *time.Time     `json:"rejected_at"`
+	RejectionReason    string         `json:"rejection_reason,omitempty"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+}
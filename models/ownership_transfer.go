@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// OwnershipTransfer hands a company from its owner to another member. Once the recipient accepts,
+// they become the company's contact with its admin permission, and the previous owner keeps
+// PreviousOwnerRole.
+type OwnershipTransfer struct {
+	ID                string     `json:"id"`
+	CompanyID         string     `json:"company_id"`
+	FromUserID        string     `json:"from_user_id"`
+	ToUserID          string     `json:"to_user_id"`
+	PreviousOwnerRole Role       `json:"previous_owner_role"`
+	ExpiresAt         time.Time  `json:"expires_at"`
+	AcceptedAt        *time.Time `json:"accepted_at"`
+	DeclinedAt        *time.Time `json:"declined_at"`
+	CancelledAt       *time.Time `json:"cancelled_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
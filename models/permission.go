@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 type Role string
 
@@ -10,16 +13,40 @@ const (
 	CompanyContributorRole    Role = "company_contributor"
 	CompanyProjectManagerRole Role = "company_project_manager"
 	ProspectRole              Role = "prospect"
+	CompanyCustomRole         Role = "company_custom" // grants the capabilities of one of the company's own roles
 )
 
+// Permission grants a user a role in a company. CompanyRoleID and Capabilities are only set for
+// CompanyCustomRole permissions. A permission with StartsAt or ExpiresAt only grants its role
+// between the two.
 type Permission struct {
-	ID         string    `json:"id"`
-	UserID     string    `json:"user_id"`
-	CompanyID  string    `json:"company_id"`
-	Role       Role      `json:"role"`
-	ContractID string    `json:"contract_id"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID            string       `json:"id"`
+	UserID        string       `json:"user_id"`
+	CompanyID     string       `json:"company_id"`
+	Role          Role         `json:"role"`
+	ContractID    string       `json:"contract_id"`
+	CompanyRoleID string       `json:"company_role_id,omitempty"`
+	Capabilities  []Capability `json:"capabilities,omitempty"`
+	StartsAt      *time.Time   `json:"starts_at"`
+	ExpiresAt     *time.Time   `json:"expires_at"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
+
+// ActiveAt reports whether the permission grants its role at the given time.
+func (p *Permission) ActiveAt(t time.Time) bool {
+	if p.StartsAt != nil && t.Before(*p.StartsAt) {
+		return false
+	}
+	return p.ExpiresAt == nil || t.Before(*p.ExpiresAt)
+}
+
+// Can reports whether the permission grants the capability in its company.
+func (p *Permission) Can(capability Capability) bool {
+	if p.Role == CompanyCustomRole {
+		return slices.Contains(p.Capabilities, capability)
+	}
+	return slices.Contains(RoleCapabilities[p.Role], capability)
 }
 
 type UserPermission struct {
@@ -20,12 +20,35 @@ import (
 type Session struct {
 	ID        uuid.UUID `json:"id"`
 	UserID    uuid.UUID `json:"user_id"`
+	FamilyID  uuid.UUID `json:"family_id"` // shared by every session rotated from the same login
 	CreatedAt time.Time `json:"created_at"`
 	ExpiresAt time.Time `json:"expires_at"`
+	// EmailVerified tells whether the session's user has verified their email address
+	EmailVerified bool `json:"email_verified"`
+	// APIKey is set when the request authenticated with an API key instead of a bearer token
+	APIKey *APIKeyGrant `json:"api_key,omitempty"`
 }
 
 type AuthToken struct {
-	BearerToken  string    `json:"bearer_token"`
-	// RefreshToken string    `json:"refresh_token"`
-	ExpiresAt    time.Time `json:"expires_at"`
+	BearerToken      string    `json:"bearer_token"`
+	RefreshToken     string    `json:"refresh_token"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+	// Challenge is set instead of the tokens when the user still has to enter their second factor
+	Challenge *LoginChallenge `json:"challenge,omitempty"`
+	// TwoFactorSetupRequired is set when a company the user administers requires two-factor
+	// authentication and the user hasn't enrolled yet; their admin roles don't apply until they do
+	TwoFactorSetupRequired bool `json:"two_factor_setup_required"`
+}
+
+// ActiveSession describes a signed in device; rotating its refresh token keeps the same FamilyID,
+// which is also the ID used to revoke it.
+type ActiveSession struct {
+	ID         uuid.UUID `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	SignedInAt time.Time `json:"signed_in_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
 }
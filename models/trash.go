@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+type TrashItemType string
+
+const (
+	TrashItemCategory         TrashItemType = "category"
+	TrashItemContractTemplate TrashItemType = "contract_template"
+	TrashItemCompany          TrashItemType = "company"
+	TrashItemUser             TrashItemType = "user"
+)
+
+// TrashItem is a soft-deleted record. Records removed by the same delete share a DeletionID
+// and are restored together; RestoresWith counts the other records in that deletion.
+type TrashItem struct {
+	ID            string        `json:"id"`
+	Type          TrashItemType `json:"type"`
+	Name          string        `json:"name"`
+	DeletionID    string        `json:"deletion_id"`
+	DeletedAt     time.Time     `json:"deleted_at"`
+	DeletedBy     string        `json:"deleted_by,omitempty"`
+	DeletedByName string        `json:"deleted_by_name,omitempty"`
+	RestoresWith  int           `json:"restores_with"`
+}
+
+type TrashPurgeResult struct {
+	Categories        int64 `json:"categories"`
+	ContractTemplates int64 `json:"contract_templates"`
+	Companies         int64 `json:"companies"`
+	Users             int64 `json:"users"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// LoginChallenge is handed out after a correct password when the user has two-factor
+// authentication enabled; it is exchanged for an auth token together with a code.
+type LoginChallenge struct {
+	Token     string    `json:"challenge_token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// TwoFactorEnrollment holds a new TOTP secret until it is confirmed with a first code.
+type TwoFactorEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// RecoveryCodes are shown once; each can replace a TOTP code a single time.
+type RecoveryCodes struct {
+	Codes []string `json:"recovery_codes"`
+}
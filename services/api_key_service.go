@@ -0,0 +1,586 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pro-posal/webserver/dao"
+	"github.com/pro-posal/webserver/internal/utils"
+	"github.com/pro-posal/webserver/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+type CreateAPIKeyRequest struct {
+	CompanyID string
+	Name      string
+	Scopes    []models.APIKeyScope
+	ExpiresAt *time.Time
+	// ServiceAccountID creates the key for a service account of the company instead of the requester
+	ServiceAccountID string
+	RequestedBy      string
+}
+
+type CreateServiceAccountRequest struct {
+	CompanyID   string
+	Name        string
+	Role        models.Role
+	RequestedBy string
+}
+
+var (
+	ErrInvalidAPIKey             = errors.New("invalid or expired api key")
+	ErrInvalidAPIKeyScopes       = errors.New("api key scopes must be read or write")
+	ErrInvalidAPIKeyExpiry       = errors.New("api key expiry must be in the future")
+	ErrInvalidServiceAccount     = errors.New("service account not found in the company")
+	ErrInvalidServiceAccountRole = errors.New("service accounts can only be company admins, contributors or project managers")
+)
+
+const (
+	// apiKeyPrefix makes keys easy to recognise, for people and for secret scanners
+	apiKeyPrefix = "pp_"
+	// apiKeyDisplayLength is how much of a key is kept in clear to tell keys apart
+	apiKeyDisplayLength = len(apiKeyPrefix) + 8
+	// serviceAccountEmailDomain is a reserved domain, so service account addresses never reach anyone
+	serviceAccountEmailDomain = "service-accounts.invalid"
+)
+
+// apiKeyGrantFromContext returns the API key the request authenticated with, if any.
+func apiKeyGrantFromContext(ctx context.Context) *models.APIKeyGrant {
+	session, ok := ctx.Value("session").(*models.Session)
+	if !ok || session == nil {
+		return nil
+	}
+	return session.APIKey
+}
+
+// CreateAPIKey creates a key acting as the requester, or as one of the company's service accounts
+// when the requester administers the company. The key is only ever returned here.
+func (s *authServiceImpl) CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (*models.APIKey, error) {
+	// Keys can't mint other keys, a leaked key would otherwise never go away
+	if apiKeyGrantFromContext(ctx) != nil {
+		return nil, &UnauthorizedError{}
+	}
+
+	scopes, err := normalizeAPIKeyScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, ErrInvalidAPIKeyExpiry
+	}
+
+	userID := req.RequestedBy
+	if req.ServiceAccountID != "" {
+		allowed, err := userHasCompanyCapability(ctx, s.db.Conn, req.RequestedBy, req.CompanyID, models.CapabilityIntegrationsManage)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, &UnauthorizedError{}
+		}
+		if _, err := s.findServiceAccount(ctx, req.CompanyID, req.ServiceAccountID); err != nil {
+			return nil, err
+		}
+		userID = req.ServiceAccountID
+	} else {
+		member, err := dao.Permissions(
+			dao.PermissionWhere.UserID.EQ(req.RequestedBy),
+			dao.PermissionWhere.CompanyID.EQ(req.CompanyID),
+		).Exists(ctx, s.db.Conn)
+		if err != nil {
+			return nil, fmt.Errorf("error checking user permissions: %w", err)
+		}
+		if !member {
+			return nil, &UnauthorizedError{}
+		}
+	}
+
+	secret, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	key := apiKeyPrefix + secret
+
+	keyDao := dao.APIKey{
+		ID:        uuid.NewString(),
+		CompanyID: req.CompanyID,
+		UserID:    userID,
+		Name:      req.Name,
+		Prefix:    key[:apiKeyDisplayLength],
+		KeyHash:   utils.HashToken(key),
+		Scopes:    joinAPIKeyScopes(scopes),
+		CreatedBy: req.RequestedBy,
+		CreatedAt: time.Now().UTC(),
+	}
+	if req.ExpiresAt != nil {
+		keyDao.ExpiresAt = null.TimeFrom(req.ExpiresAt.UTC())
+	}
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := keyDao.Insert(ctx, tx, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("failed creating api key: %w", err)
+	}
+
+	// Audited before the key is set, it is only ever shown to its creator
+	apiKey := apiKeyDaoToModel(&keyDao)
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  req.CompanyID,
+		ActorID:    req.RequestedBy,
+		Action:     AuditActionCreate,
+		EntityType: AuditEntityAPIKey,
+		EntityID:   apiKey.ID,
+		After:      apiKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	log.Printf("User %v created api key %v for user %v in company %v", req.RequestedBy, keyDao.ID, userID, req.CompanyID)
+	apiKey.Key = key
+	return apiKey, nil
+}
+
+// GetAPIKeys lists the company's active keys for its admins, and only their own keys for other members.
+func (s *authServiceImpl) GetAPIKeys(ctx context.Context, companyID string, requestedBy string) ([]*models.APIKey, error) {
+	query := []qm.QueryMod{
+		dao.APIKeyWhere.CompanyID.EQ(companyID),
+		dao.APIKeyWhere.RevokedAt.IsNull(),
+		qm.OrderBy(dao.APIKeyColumns.CreatedAt + " DESC"),
+	}
+
+	isAdmin, err := userHasCompanyCapability(ctx, s.db.Conn, requestedBy, companyID, models.CapabilityIntegrationsManage)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		query = append(query, qm.Where("(user_id = ? OR created_by = ?)", requestedBy, requestedBy))
+	}
+
+	keysDao, err := dao.APIKeys(query...).All(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching api keys from database: %w", err)
+	}
+
+	apiKeys := make([]*models.APIKey, len(keysDao))
+	for i, keyDao := range keysDao {
+		apiKeys[i] = apiKeyDaoToModel(keyDao)
+	}
+	return apiKeys, nil
+}
+
+// RevokeAPIKey stops a key from working. Keys can be revoked by whoever they belong to or were
+// created by, and by the company's admins.
+func (s *authServiceImpl) RevokeAPIKey(ctx context.Context, companyID string, keyID string, requestedBy string) error {
+	keyDao, err := dao.APIKeys(
+		dao.APIKeyWhere.ID.EQ(keyID),
+		dao.APIKeyWhere.CompanyID.EQ(companyID),
+		dao.APIKeyWhere.RevokedAt.IsNull(),
+	).One(ctx, s.db.Conn)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidAPIKey
+		}
+		return fmt.Errorf("failed fetching api key from database: %w", err)
+	}
+
+	if keyDao.UserID != requestedBy && keyDao.CreatedBy != requestedBy {
+		allowed, err := userHasCompanyCapability(ctx, s.db.Conn, requestedBy, companyID, models.CapabilityIntegrationsManage)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return &UnauthorizedError{}
+		}
+	}
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	keyDao.RevokedAt = null.TimeFrom(time.Now().UTC())
+	if _, err := keyDao.Update(ctx, tx, boil.Whitelist(dao.APIKeyColumns.RevokedAt)); err != nil {
+		return fmt.Errorf("failed revoking api key: %w", err)
+	}
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  companyID,
+		ActorID:    requestedBy,
+		Action:     AuditActionRevoke,
+		EntityType: AuditEntityAPIKey,
+		EntityID:   keyDao.ID,
+		Before:     apiKeyDaoToModel(keyDao),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	log.Printf("User %v revoked api key %v", requestedBy, keyID)
+	return nil
+}
+
+// revokeUserAPIKeys revokes the keys that act as the user, in every company. Keys of the company's
+// service accounts are left alone.
+func revokeUserAPIKeys(ctx context.Context, exec boil.ContextExecutor, userID string, revokedBy string) error {
+	keyDaos, err := dao.APIKeys(
+		dao.APIKeyWhere.UserID.EQ(userID),
+		dao.APIKeyWhere.RevokedAt.IsNull(),
+	).All(ctx, exec)
+	if err != nil {
+		return fmt.Errorf("failed fetching api keys from database: %w", err)
+	}
+
+	now := time.Now().UTC()
+	for _, keyDao := range keyDaos {
+		before := apiKeyDaoToModel(keyDao)
+		keyDao.RevokedAt = null.TimeFrom(now)
+		if _, err := keyDao.Update(ctx, exec, boil.Whitelist(dao.APIKeyColumns.RevokedAt)); err != nil {
+			return fmt.Errorf("failed revoking api key: %w", err)
+		}
+		err = recordAudit(ctx, exec, auditEntry{
+			CompanyID:  keyDao.CompanyID,
+			ActorID:    revokedBy,
+			Action:     AuditActionRevoke,
+			EntityType: AuditEntityAPIKey,
+			EntityID:   keyDao.ID,
+			Before:     before,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateAPIKey returns a session for the key's user, limited to the key's company and scopes.
+func (s *authServiceImpl) ValidateAPIKey(ctx context.Context, key string) (*models.Session, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	keyDao, err := dao.APIKeys(dao.APIKeyWhere.KeyHash.EQ(utils.HashToken(key))).One(ctx, s.db.Conn)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("failed fetching api key from database: %w", err)
+	}
+	if keyDao.RevokedAt.Valid || (keyDao.ExpiresAt.Valid && time.Now().After(keyDao.ExpiresAt.Time)) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	userDao, err := dao.Users(
+		dao.UserWhere.ID.EQ(keyDao.UserID),
+		dao.UserWhere.DeletedAt.IsNull(),
+	).One(ctx, s.db.Conn)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("failed fetching user from database: %w", err)
+	}
+
+	if !keyDao.LastUsedAt.Valid || time.Since(keyDao.LastUsedAt.Time) > lastSeenResolution {
+		keyDao.LastUsedAt = null.TimeFrom(time.Now().UTC())
+		_, err = keyDao.Update(ctx, s.db.Conn, boil.Whitelist(dao.APIKeyColumns.LastUsedAt))
+		if err != nil {
+			log.Printf("Failed updating last used time of api key %v: %v", keyDao.ID, err)
+		}
+	}
+
+	return &models.Session{
+		ID:            uuid.MustParse(keyDao.ID),
+		UserID:        uuid.MustParse(keyDao.UserID),
+		FamilyID:      uuid.MustParse(keyDao.ID),
+		CreatedAt:     keyDao.CreatedAt,
+		ExpiresAt:     keyDao.ExpiresAt.Time,
+		EmailVerified: userDao.EmailVerifiedAt.Valid,
+		APIKey: &models.APIKeyGrant{
+			ID:        keyDao.ID,
+			CompanyID: keyDao.CompanyID,
+			Scopes:    splitAPIKeyScopes(keyDao.Scopes),
+		},
+	}, nil
+}
+
+// CreateServiceAccount adds a user that can't sign in and holds a single role in the company.
+func (s *authServiceImpl) CreateServiceAccount(ctx context.Context, req CreateServiceAccountRequest) (*models.ServiceAccount, error) {
+	if apiKeyGrantFromContext(ctx) != nil {
+		return nil, &UnauthorizedError{}
+	}
+	switch req.Role {
+	case models.CompanyAdminRole, models.CompanyContributorRole, models.CompanyProjectManagerRole:
+	default:
+		return nil, ErrInvalidServiceAccountRole
+	}
+
+	allowed, err := userHasCompanyCapability(ctx, s.db.Conn, req.RequestedBy, req.CompanyID, models.CapabilityIntegrationsManage)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, &UnauthorizedError{}
+	}
+	if err := checkCanGrantRole(ctx, s.db.Conn, req.RequestedBy, req.CompanyID, req.Role); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	userID := uuid.NewString()
+	email := fmt.Sprintf("%s@%s", userID, serviceAccountEmailDomain)
+	userDao := dao.User{
+		ID:        userID,
+		FirstName: req.Name,
+		Email:     email,
+		EmailHash: utils.HashEmail(email),
+		// No password hash matches any password, so the account can't sign in
+		PasswordHash:     "",
+		InvitedBy:        null.StringFrom(req.RequestedBy),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+		EmailVerifiedAt:  null.TimeFrom(now),
+		IsServiceAccount: true,
+	}
+	if err := userDao.Insert(ctx, tx, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("failed creating service account: %w", err)
+	}
+
+	permissionDao := dao.Permission{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		CompanyID: req.CompanyID,
+		Role:      string(req.Role),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := permissionDao.Insert(ctx, tx, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("failed granting service account role: %w", err)
+	}
+
+	account := serviceAccountDaoToModel(&userDao, &permissionDao)
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  req.CompanyID,
+		ActorID:    req.RequestedBy,
+		Action:     AuditActionCreate,
+		EntityType: AuditEntityServiceAccount,
+		EntityID:   account.ID,
+		After:      account,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	log.Printf("User %v created service account %v in company %v", req.RequestedBy, userID, req.CompanyID)
+	return account, nil
+}
+
+func (s *authServiceImpl) GetServiceAccounts(ctx context.Context, companyID string, requestedBy string) ([]*models.ServiceAccount, error) {
+	allowed, err := userHasCompanyCapability(ctx, s.db.Conn, requestedBy, companyID, models.CapabilityIntegrationsManage)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, &UnauthorizedError{}
+	}
+
+	permissionsDao, err := dao.Permissions(
+		qm.Load(dao.PermissionRels.User),
+		qm.InnerJoin("users u ON u.id = permissions.user_id"),
+		qm.Where("permissions.company_id = ? AND u.is_service_account AND u.deleted_at IS NULL", companyID),
+		qm.OrderBy("u.created_at"),
+	).All(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching service accounts from database: %w", err)
+	}
+
+	accounts := make([]*models.ServiceAccount, len(permissionsDao))
+	for i, permissionDao := range permissionsDao {
+		accounts[i] = serviceAccountDaoToModel(permissionDao.R.User, permissionDao)
+	}
+	return accounts, nil
+}
+
+// DeleteServiceAccount moves the service account to the trash and revokes its keys.
+func (s *authServiceImpl) DeleteServiceAccount(ctx context.Context, companyID string, userID string, requestedBy string) error {
+	if apiKeyGrantFromContext(ctx) != nil {
+		return &UnauthorizedError{}
+	}
+	allowed, err := userHasCompanyCapability(ctx, s.db.Conn, requestedBy, companyID, models.CapabilityIntegrationsManage)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return &UnauthorizedError{}
+	}
+
+	userDao, err := s.findServiceAccount(ctx, companyID, userID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	permissionDao, err := dao.Permissions(
+		dao.PermissionWhere.UserID.EQ(userID),
+		dao.PermissionWhere.CompanyID.EQ(companyID),
+	).One(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("failed fetching service account role: %w", err)
+	}
+	before := serviceAccountDaoToModel(userDao, permissionDao)
+
+	now := time.Now().UTC()
+	userDao.DeletedAt = null.TimeFrom(now)
+	userDao.DeletedBy = deletedByUser(requestedBy)
+	userDao.DeletionID = null.StringFrom(uuid.NewString())
+	_, err = userDao.Update(ctx, tx, boil.Whitelist(dao.UserColumns.DeletedAt, dao.UserColumns.DeletedBy, dao.UserColumns.DeletionID))
+	if err != nil {
+		return fmt.Errorf("failed deleting service account: %w", err)
+	}
+
+	_, err = dao.APIKeys(
+		dao.APIKeyWhere.UserID.EQ(userID),
+		dao.APIKeyWhere.RevokedAt.IsNull(),
+	).UpdateAll(ctx, tx, dao.M{dao.APIKeyColumns.RevokedAt: now})
+	if err != nil {
+		return fmt.Errorf("failed revoking service account keys: %w", err)
+	}
+
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  companyID,
+		ActorID:    requestedBy,
+		Action:     AuditActionDelete,
+		EntityType: AuditEntityServiceAccount,
+		EntityID:   userID,
+		Before:     before,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	log.Printf("User %v deleted service account %v", requestedBy, userID)
+	return nil
+}
+
+func (s *authServiceImpl) findServiceAccount(ctx context.Context, companyID string, userID string) (*dao.User, error) {
+	userDao, err := dao.Users(
+		dao.UserWhere.ID.EQ(userID),
+		dao.UserWhere.IsServiceAccount.EQ(true),
+		dao.UserWhere.DeletedAt.IsNull(),
+		qm.Where("EXISTS (SELECT 1 FROM permissions p WHERE p.user_id = users.id AND p.company_id = ?)", companyID),
+	).One(ctx, s.db.Conn)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidServiceAccount
+		}
+		return nil, fmt.Errorf("failed fetching service account from database: %w", err)
+	}
+	return userDao, nil
+}
+
+// normalizeAPIKeyScopes validates the scopes and removes duplicates, defaulting to read only.
+func normalizeAPIKeyScopes(scopes []models.APIKeyScope) ([]models.APIKeyScope, error) {
+	if len(scopes) == 0 {
+		return []models.APIKeyScope{models.APIKeyScopeRead}, nil
+	}
+
+	seen := map[models.APIKeyScope]bool{}
+	normalized := make([]models.APIKeyScope, 0, len(scopes))
+	for _, scope := range scopes {
+		if scope != models.APIKeyScopeRead && scope != models.APIKeyScopeWrite {
+			return nil, ErrInvalidAPIKeyScopes
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized, nil
+}
+
+// API key scopes are stored space separated, like OAuth scopes
+func joinAPIKeyScopes(scopes []models.APIKeyScope) string {
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = string(scope)
+	}
+	return strings.Join(names, " ")
+}
+
+func splitAPIKeyScopes(scopes string) []models.APIKeyScope {
+	names := strings.Fields(scopes)
+	result := make([]models.APIKeyScope, len(names))
+	for i, name := range names {
+		result[i] = models.APIKeyScope(name)
+	}
+	return result
+}
+
+func apiKeyDaoToModel(keyDao *dao.APIKey) *models.APIKey {
+	apiKey := &models.APIKey{
+		ID:        keyDao.ID,
+		CompanyID: keyDao.CompanyID,
+		UserID:    keyDao.UserID,
+		Name:      keyDao.Name,
+		Prefix:    keyDao.Prefix,
+		Scopes:    splitAPIKeyScopes(keyDao.Scopes),
+		CreatedBy: keyDao.CreatedBy,
+		CreatedAt: keyDao.CreatedAt,
+	}
+	if keyDao.ExpiresAt.Valid {
+		apiKey.ExpiresAt = &keyDao.ExpiresAt.Time
+	}
+	if keyDao.LastUsedAt.Valid {
+		apiKey.LastUsedAt = &keyDao.LastUsedAt.Time
+	}
+	return apiKey
+}
+
+func serviceAccountDaoToModel(userDao *dao.User, permissionDao *dao.Permission) *models.ServiceAccount {
+	return &models.ServiceAccount{
+		ID:        userDao.ID,
+		CompanyID: permissionDao.CompanyID,
+		Name:      userDao.FirstName,
+		Role:      models.Role(permissionDao.Role),
+		CreatedBy: userDao.InvitedBy.String,
+		CreatedAt: userDao.CreatedAt,
+	}
+}
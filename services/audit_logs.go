@@ -0,0 +1,328 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pro-posal/webserver/dao"
+	"github.com/pro-posal/webserver/internal/database"
+	"github.com/pro-posal/webserver/internal/utils"
+	"github.com/pro-posal/webserver/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// Audit log actions
+const (
+	AuditActionCreate   = "create"
+	AuditActionUpdate   = "update"
+	AuditActionDelete   = "delete"
+	AuditActionRestore  = "restore"
+	AuditActionMove     = "move"
+	AuditActionImport   = "import"
+	AuditActionCopy     = "copy"
+	AuditActionAccept   = "accept"
+	AuditActionDecline  = "decline"
+	AuditActionRevoke   = "revoke"
+	AuditActionRespond  = "respond"
+	AuditActionExpire   = "expire"
+	AuditActionTransfer = "transfer"
+	AuditActionUnlock   = "unlock"
+	AuditActionPurge    = "purge"
+	// Password and two-factor changes don't show up in the user's fields, so they get actions of their own
+	AuditActionPasswordChange          = "password_change"
+	AuditActionPasswordReset           = "password_reset"
+	AuditActionTwoFactorEnable         = "two_factor_enable"
+	AuditActionTwoFactorDisable        = "two_factor_disable"
+	AuditActionRecoveryCodesRegenerate = "recovery_codes_regenerate"
+)
+
+// Audit log entity types
+const (
+	AuditEntityCompany           = "company"
+	AuditEntityCategory          = "category"
+	AuditEntityCatalog           = "catalog"
+	AuditEntityContractTemplate  = "contract_template"
+	AuditEntityLegalClause       = "legal_clause"
+	AuditEntityOffer             = "offer"
+	AuditEntityPermission        = "permission"
+	AuditEntityCompanyRole       = "company_role"
+	AuditEntityInvitation        = "invitation"
+	AuditEntityAPIKey            = "api_key"
+	AuditEntityServiceAccount    = "service_account"
+	AuditEntityIdentityProvider  = "identity_provider"
+	AuditEntityOwnershipTransfer = "ownership_transfer"
+	AuditEntityTrash             = "trash"
+	AuditEntityUser              = "user"
+	AuditEntityGalleryTemplate   = "gallery_template"
+)
+
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 500
+)
+
+// AuditLogFilter narrows down a company's audit log, empty fields match everything. Page starts at 1.
+type AuditLogFilter struct {
+	CompanyID  string
+	ActorID    string
+	EntityType string
+	EntityID   string
+	Action     string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+	Page       int
+	PageSize   int
+}
+
+// Paged returns the filter with its page and page size defaulted and capped.
+func (f AuditLogFilter) Paged() AuditLogFilter {
+	if f.Page < 1 {
+		f.Page = 1
+	}
+	if f.PageSize < 1 {
+		f.PageSize = defaultAuditPageSize
+	}
+	if f.PageSize > maxAuditPageSize {
+		f.PageSize = maxAuditPageSize
+	}
+	return f
+}
+
+var ErrInvalidAuditLogFilter = errors.New("invalid audit log filter")
+
+type AuditLogService interface {
+	// GetAuditLogs returns a page of the matching entries, newest first, and how many match in total.
+	GetAuditLogs(context.Context, AuditLogFilter) ([]*models.AuditLog, int64, error)
+	// ExportAuditLogs returns every matching entry, newest first, ignoring the filter's page.
+	ExportAuditLogs(context.Context, AuditLogFilter) ([]*models.AuditLog, error)
+}
+
+type AuditLogServiceImpl struct {
+	db *database.DBConnector
+}
+
+func NewAuditLogService(db *database.DBConnector) AuditLogService {
+	return &AuditLogServiceImpl{
+		db: db,
+	}
+}
+
+func (s *AuditLogServiceImpl) GetAuditLogs(ctx context.Context, filter AuditLogFilter) ([]*models.AuditLog, int64, error) {
+	query, err := auditLogQuery(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := dao.AuditLogs(query...).Count(ctx, s.db.Conn)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed counting audit logs: %w", err)
+	}
+
+	filter = filter.Paged()
+	query = append(query,
+		qm.OrderBy(dao.AuditLogColumns.CreatedAt+" DESC, "+dao.AuditLogColumns.ID),
+		qm.Limit(filter.PageSize),
+		qm.Offset((filter.Page-1)*filter.PageSize),
+	)
+
+	auditLogsDao, err := dao.AuditLogs(query...).All(ctx, s.db.Conn)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed fetching audit logs from database: %w", err)
+	}
+	return auditLogDaosToModels(auditLogsDao), total, nil
+}
+
+func (s *AuditLogServiceImpl) ExportAuditLogs(ctx context.Context, filter AuditLogFilter) ([]*models.AuditLog, error) {
+	query, err := auditLogQuery(filter)
+	if err != nil {
+		return nil, err
+	}
+	query = append(query, qm.OrderBy(dao.AuditLogColumns.CreatedAt+" DESC, "+dao.AuditLogColumns.ID))
+
+	auditLogsDao, err := dao.AuditLogs(query...).All(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching audit logs from database: %w", err)
+	}
+	return auditLogDaosToModels(auditLogsDao), nil
+}
+
+func auditLogQuery(filter AuditLogFilter) ([]qm.QueryMod, error) {
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return nil, fmt.Errorf("%w: to is before from", ErrInvalidAuditLogFilter)
+	}
+
+	query := []qm.QueryMod{dao.AuditLogWhere.CompanyID.EQ(filter.CompanyID)}
+	if filter.ActorID != "" {
+		if _, err := uuid.Parse(filter.ActorID); err != nil {
+			return nil, fmt.Errorf("%w: actor_id is not an ID", ErrInvalidAuditLogFilter)
+		}
+		query = append(query, dao.AuditLogWhere.ActorID.EQ(null.StringFrom(filter.ActorID)))
+	}
+	if filter.EntityType != "" {
+		query = append(query, dao.AuditLogWhere.EntityType.EQ(filter.EntityType))
+	}
+	if filter.EntityID != "" {
+		query = append(query, dao.AuditLogWhere.EntityID.EQ(filter.EntityID))
+	}
+	if filter.Action != "" {
+		query = append(query, dao.AuditLogWhere.Action.EQ(filter.Action))
+	}
+	if filter.RequestID != "" {
+		query = append(query, dao.AuditLogWhere.RequestID.EQ(null.StringFrom(filter.RequestID)))
+	}
+	if filter.From != nil {
+		query = append(query, dao.AuditLogWhere.CreatedAt.GTE(filter.From.UTC()))
+	}
+	if filter.To != nil {
+		query = append(query, dao.AuditLogWhere.CreatedAt.LT(filter.To.UTC()))
+	}
+	return query, nil
+}
+
+// auditEntry is a change to record in a company's audit log. Before is the entity as it was and
+// After as it is now, nil for creations and deletions respectively. ActorID is taken from the
+// session when empty, changes made by the server itself have no actor.
+type auditEntry struct {
+	CompanyID  string
+	ActorID    string
+	Action     string
+	EntityType string
+	EntityID   string
+	Before     any
+	After      any
+}
+
+// recordAudit appends the entry to the audit log together with the session and request it was
+// made in. Pass the transaction making the change when there is one, so both are kept or neither.
+func recordAudit(ctx context.Context, exec boil.ContextExecutor, entry auditEntry) error {
+	auditLogDao := dao.AuditLog{
+		ID:         uuid.NewString(),
+		CompanyID:  entry.CompanyID,
+		Action:     entry.Action,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	// Requests made with an API key carry the key's ID as their session
+	if session, ok := ctx.Value("session").(*models.Session); ok && session != nil {
+		if entry.ActorID == "" {
+			entry.ActorID = session.UserID.String()
+		}
+		auditLogDao.SessionID = null.StringFrom(session.ID.String())
+	}
+	auditLogDao.ActorID = null.NewString(entry.ActorID, entry.ActorID != "")
+	if request := utils.GetRequestInfoFromContext(ctx); request != nil {
+		auditLogDao.IPAddress = null.StringFrom(request.IPAddress)
+		auditLogDao.RequestID = null.StringFrom(request.ID)
+	}
+
+	before, err := marshalAudited(entry.Before)
+	if err != nil {
+		return fmt.Errorf("failed marshaling audited %s: %w", entry.EntityType, err)
+	}
+	after, err := marshalAudited(entry.After)
+	if err != nil {
+		return fmt.Errorf("failed marshaling audited %s: %w", entry.EntityType, err)
+	}
+	auditLogDao.Before = null.NewJSON(before, before != nil)
+	auditLogDao.After = null.NewJSON(after, after != nil)
+	if before != nil && after != nil {
+		changes, err := auditChanges(before, after)
+		if err != nil {
+			return err
+		}
+		auditLogDao.Changes = null.JSONFrom(changes)
+	}
+
+	if err := auditLogDao.Insert(ctx, exec, boil.Infer()); err != nil {
+		return fmt.Errorf("failed inserting audit log to database: %w", err)
+	}
+	return nil
+}
+
+func marshalAudited(entity any) ([]byte, error) {
+	if entity == nil {
+		return nil, nil
+	}
+	return json.Marshal(entity)
+}
+
+// auditChanges lists the top level fields whose values differ between the two JSON objects.
+func auditChanges(before []byte, after []byte) ([]byte, error) {
+	var beforeFields, afterFields map[string]json.RawMessage
+	if err := json.Unmarshal(before, &beforeFields); err != nil {
+		return nil, fmt.Errorf("failed diffing audited entity: %w", err)
+	}
+	if err := json.Unmarshal(after, &afterFields); err != nil {
+		return nil, fmt.Errorf("failed diffing audited entity: %w", err)
+	}
+
+	changes := map[string]models.AuditChange{}
+	for field, value := range beforeFields {
+		if !bytes.Equal(value, afterFields[field]) {
+			changes[field] = models.AuditChange{Before: value, After: afterFields[field]}
+		}
+	}
+	for field, value := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			changes[field] = models.AuditChange{After: value}
+		}
+	}
+	return json.Marshal(changes)
+}
+
+func auditLogDaosToModels(auditLogsDao dao.AuditLogSlice) []*models.AuditLog {
+	auditLogs := make([]*models.AuditLog, len(auditLogsDao))
+	for i, auditLogDao := range auditLogsDao {
+		auditLogs[i] = &models.AuditLog{
+			ID:         auditLogDao.ID,
+			CompanyID:  auditLogDao.CompanyID,
+			ActorID:    auditLogDao.ActorID.String,
+			SessionID:  auditLogDao.SessionID.String,
+			Action:     auditLogDao.Action,
+			EntityType: auditLogDao.EntityType,
+			EntityID:   auditLogDao.EntityID,
+			Before:     json.RawMessage(auditLogDao.Before.JSON),
+			After:      json.RawMessage(auditLogDao.After.JSON),
+			IPAddress:  auditLogDao.IPAddress.String,
+			RequestID:  auditLogDao.RequestID.String,
+			CreatedAt:  auditLogDao.CreatedAt,
+		}
+		if auditLogDao.Changes.Valid {
+			if err := json.Unmarshal(auditLogDao.Changes.JSON, &auditLogs[i].Changes); err != nil {
+				log.Printf("Failed unmarshaling changes of audit log %v: %v", auditLogDao.ID, err)
+			}
+		}
+	}
+	return auditLogs
+}
+
+// recordUserAudit records a change to a user account in the audit log of every company the user
+// is a member of; the entry's CompanyID is ignored. Accounts outside any company have no audit log.
+func recordUserAudit(ctx context.Context, exec boil.ContextExecutor, userID string, entry auditEntry) error {
+	permissionsDao, err := dao.Permissions(
+		qm.Select("DISTINCT "+dao.PermissionColumns.CompanyID),
+		dao.PermissionWhere.UserID.EQ(userID),
+	).All(ctx, exec)
+	if err != nil {
+		return fmt.Errorf("failed fetching companies of user %v: %w", userID, err)
+	}
+
+	for _, permissionDao := range permissionsDao {
+		entry.CompanyID = permissionDao.CompanyID
+		if err := recordAudit(ctx, exec, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"log"
+	"net/http"
 	"time"
 
 	"github.com/golang-jwt/jwt"
@@ -12,9 +14,15 @@ import (
 	"github.com/pro-posal/webserver/config"
 	"github.com/pro-posal/webserver/dao"
 	"github.com/pro-posal/webserver/internal/database"
+	"github.com/pro-posal/webserver/internal/keys"
+	"github.com/pro-posal/webserver/internal/mailer"
+	"github.com/pro-posal/webserver/internal/oidc"
 	"github.com/pro-posal/webserver/internal/utils"
 	"github.com/pro-posal/webserver/models"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
 type UnauthorizedError struct{}
@@ -23,77 +31,429 @@ func (u *UnauthorizedError) Error() string {
 	return "Unauthorized"
 }
 
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrEmailNotVerified    = errors.New("email address is not verified")
+)
+
+// purposeClaim marks single-purpose tokens, such as email verification links, which are signed
+// like auth tokens but must never be accepted as one. They also carry a type and an audience, so
+// verifiers using the published keys tell them apart from auth tokens without knowing the claim.
+const (
+	purposeClaim          = "purpose"
+	purposeTokenType      = "purpose"
+	purposeAudiencePrefix = "proposal:"
+)
+
 type AuthService interface {
-	CreateAuthToken(ctx context.Context, email string, password string) (*models.AuthToken, error)
+	CreateAuthToken(ctx context.Context, req CreateAuthTokenRequest) (*models.AuthToken, error)
+	RefreshAuthToken(ctx context.Context, req RefreshAuthTokenRequest) (*models.AuthToken, error)
+	Logout(ctx context.Context, session *models.Session) error
+	GetActiveSessions(ctx context.Context, session *models.Session) ([]*models.ActiveSession, error)
+	RevokeSession(ctx context.Context, session *models.Session, activeSessionID string) error
+	RevokeOtherSessions(ctx context.Context, session *models.Session) (int64, error)
+
+	CompleteTwoFactorLogin(ctx context.Context, req CompleteTwoFactorLoginRequest) (*models.AuthToken, error)
+	EnrollTwoFactor(ctx context.Context, req EnrollTwoFactorRequest) (*models.TwoFactorEnrollment, error)
+	ConfirmTwoFactor(ctx context.Context, req ConfirmTwoFactorRequest) (*models.RecoveryCodes, error)
+	DisableTwoFactor(ctx context.Context, req DisableTwoFactorRequest) error
+	RegenerateRecoveryCodes(ctx context.Context, userID string, code string) (*models.RecoveryCodes, error)
+
+	UnlockUser(ctx context.Context, userID string, requestedBy string) (*models.User, error)
+
+	// PublicKeys returns the keys other services can verify our tokens with
+	PublicKeys() *keys.JWKS
+
+	ValidateAPIKey(ctx context.Context, key string) (*models.Session, error)
+	CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (*models.APIKey, error)
+	GetAPIKeys(ctx context.Context, companyID string, requestedBy string) ([]*models.APIKey, error)
+	RevokeAPIKey(ctx context.Context, companyID string, keyID string, requestedBy string) error
+	CreateServiceAccount(ctx context.Context, req CreateServiceAccountRequest) (*models.ServiceAccount, error)
+	GetServiceAccounts(ctx context.Context, companyID string, requestedBy string) ([]*models.ServiceAccount, error)
+	DeleteServiceAccount(ctx context.Context, companyID string, userID string, requestedBy string) error
+	CreateIdentityProvider(ctx context.Context, req IdentityProviderRequest) (*models.IdentityProvider, error)
+	UpdateIdentityProvider(ctx context.Context, providerID string, req IdentityProviderRequest) (*models.IdentityProvider, error)
+	GetIdentityProviders(ctx context.Context, companyID string, requestedBy string) ([]*models.IdentityProvider, error)
+	DeleteIdentityProvider(ctx context.Context, companyID string, providerID string, requestedBy string) error
+	StartOIDCLogin(ctx context.Context, req StartOIDCLoginRequest) (*models.OIDCLogin, error)
+	CompleteOIDCLogin(ctx context.Context, req CompleteOIDCLoginRequest) (*models.AuthToken, error)
+	PruneLoginAttempts(ctx context.Context, before time.Time) (int64, error)
 	ValidateAuthToken(context.Context, string) (*models.Session, error)
 
-	AuthorizeCompany(method string, callerUserID uuid.UUID, permissions []*models.Permission, hasMoreParts bool, requestedCompanyId string) error
-	AuthorizeContract(method string, callerUserID uuid.UUID, permissions []*models.Permission, hasMoreParts bool, requestedCompanyId string, requestedContractId string) error
-	// AuthorizeUser(method string, callerUserID uuid.UUID, requestedUserId string) error
+	AuthorizeOffer(ctx context.Context, callerUserID string, permissions []*models.Permission, requestedCompanyId string, requestedOfferId string) error
+}
+
+// SessionClient identifies the device a session was started from.
+type SessionClient struct {
+	UserAgent string
+	IPAddress string
+}
+
+type CreateAuthTokenRequest struct {
+	Email    string
+	Password string
+	Client   SessionClient
+}
+
+type RefreshAuthTokenRequest struct {
+	RefreshToken string
+	Client       SessionClient
 }
 
+// lastSeenResolution limits how often validating a token writes the session's last seen time.
+const lastSeenResolution = time.Minute
+
 type authServiceImpl struct {
-	db *database.DBConnector
+	db     *database.DBConnector
+	mailer mailer.Mailer
+	keys   *keys.KeyStore
+	oidc   *oidc.Client
 }
 
-func NewAuthService(db *database.DBConnector) AuthService {
+func NewAuthService(db *database.DBConnector, mailer mailer.Mailer, keyStore *keys.KeyStore) AuthService {
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	if !config.AppConfig.Auth.OIDCAllowPrivateIssuers {
+		httpClient.Transport = oidc.PublicTransport()
+	}
+
 	return &authServiceImpl{
-		db: db,
+		db:     db,
+		mailer: mailer,
+		keys:   keyStore,
+		oidc:   oidc.NewClient(httpClient),
 	}
 }
 
-func (s *authServiceImpl) CreateAuthToken(ctx context.Context, email string, password string) (*models.AuthToken, error) {
+func (s *authServiceImpl) CreateAuthToken(ctx context.Context, req CreateAuthTokenRequest) (*models.AuthToken, error) {
+	// Throttling is checked before bcrypt so that hammering the endpoint stays cheap
+	if err := s.checkAddressThrottle(ctx, req.Client.IPAddress); err != nil {
+		return nil, err
+	}
+
+	// The user row stays locked until the attempt is counted, or concurrent guesses would all pass
+	// the throttle before any of them registers its failure
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
 
+	emailHash := utils.HashEmail(req.Email)
 	userDao, err := dao.Users(
-		dao.UserWhere.EmailHash.EQ(utils.HashEmail(email)),
+		dao.UserWhere.EmailHash.EQ(emailHash),
 		dao.UserWhere.DeletedAt.IsNull(),
-	).One(ctx, s.db.Conn)
+		dao.UserWhere.IsServiceAccount.EQ(false),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
+			s.recordLoginAttempt(ctx, s.db.Conn, emailHash, null.String{}, req.Client.IPAddress, false)
 			return nil, errors.New("invalid email or password")
 		}
 		return nil, fmt.Errorf("failed fetching user from database: %w", err)
 	}
 
-	if !utils.ComparePasswords(userDao.PasswordHash, password) {
+	if err := checkAccountThrottle(userDao); err != nil {
+		return nil, err
+	}
+
+	if !utils.ComparePasswords(userDao.PasswordHash, req.Password) {
+		s.recordLoginAttempt(ctx, tx, emailHash, null.StringFrom(userDao.ID), req.Client.IPAddress, false)
+		if err := registerFailedLogin(ctx, tx, s.mailer, userDao); err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, fmt.Errorf("failed committing transaction: %w", err)
+		}
 		return nil, errors.New("invalid email or password")
 	}
 
+	s.recordLoginAttempt(ctx, tx, emailHash, null.StringFrom(userDao.ID), req.Client.IPAddress, true)
+	// Failed second factors keep counting until one is entered, or guessing codes would only
+	// take knowing the password
+	if !userDao.TotpEnabledAt.Valid {
+		if err := s.resetFailedLogins(ctx, tx, userDao); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	return s.completeLogin(ctx, userDao, req.Client)
+}
+
+// completeLogin signs in a user whose identity was confirmed, by password or single sign-on,
+// handing out a two-factor challenge instead of a session when the user has it enabled.
+func (s *authServiceImpl) completeLogin(ctx context.Context, userDao *dao.User, client SessionClient) (*models.AuthToken, error) {
+	if config.AppConfig.Auth.EmailVerificationMode == config.EmailVerificationLogin && !userDao.EmailVerifiedAt.Valid {
+		return nil, ErrEmailNotVerified
+	}
+
 	userID, err := uuid.Parse(userDao.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed parsing user ID %v: %w", userDao.ID, err) // Should never happen
 	}
 
+	if userDao.TotpEnabledAt.Valid {
+		challenge, err := createLoginChallenge(ctx, s.db.Conn, userDao.ID, client)
+		if err != nil {
+			return nil, err
+		}
+		return &models.AuthToken{Challenge: challenge}, nil
+	}
+
+	// A login starts a new session family, refreshing it later keeps the family ID
+	sessionID := uuid.New()
+	token, err := s.startSession(ctx, s.db.Conn, sessionID, userID, sessionID, client)
+	if err != nil {
+		return nil, err
+	}
+
+	token.TwoFactorSetupRequired, err = companyRequiresTwoFactor(ctx, s.db.Conn, userDao.ID)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// RefreshAuthToken rotates a refresh token: the session it belongs to is replaced by a new one
+// in the same family. Presenting a refresh token that was already rotated means it leaked, so
+// the whole family is revoked.
+func (s *authServiceImpl) RefreshAuthToken(ctx context.Context, req RefreshAuthTokenRequest) (*models.AuthToken, error) {
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	sessionDao, err := dao.Sessions(
+		dao.SessionWhere.RefreshTokenHash.EQ(null.StringFrom(utils.HashToken(req.RefreshToken))),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed fetching session from database: %w", err)
+	}
+
+	if sessionDao.ReplacedBy.Valid {
+		log.Printf("Refresh token of session %v was reused, revoking session family %v", sessionDao.ID, sessionDao.FamilyID)
+		if err := revokeSessionFamily(ctx, tx, sessionDao.FamilyID); err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, fmt.Errorf("failed committing transaction: %w", err)
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if sessionDao.RevokedAt.Valid || !sessionDao.RefreshExpiresAt.Valid || time.Now().After(sessionDao.RefreshExpiresAt.Time) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	userID, err := uuid.Parse(sessionDao.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing user ID %v: %w", sessionDao.UserID, err) // Should never happen
+	}
+	familyID, err := uuid.Parse(sessionDao.FamilyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing session family ID %v: %w", sessionDao.FamilyID, err) // Should never happen
+	}
+
+	newSessionID := uuid.New()
+	token, err := s.startSession(ctx, tx, newSessionID, userID, familyID, req.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionDao.ReplacedBy = null.StringFrom(newSessionID.String())
+	sessionDao.RevokedAt = null.TimeFrom(time.Now().UTC())
+	_, err = sessionDao.Update(ctx, tx, boil.Whitelist(dao.SessionColumns.ReplacedBy, dao.SessionColumns.RevokedAt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update session in database: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	return token, nil
+}
+
+// startSession stores a new session with a fresh refresh token and signs its bearer token.
+func (s *authServiceImpl) startSession(ctx context.Context, exec boil.ContextExecutor, sessionID uuid.UUID, userID uuid.UUID, familyID uuid.UUID, client SessionClient) (*models.AuthToken, error) {
+	refreshToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	// The session table stores whole seconds, so truncate to keep the token claims comparable
+	now := time.Now().UTC().Truncate(time.Second)
 	session := models.Session{
-		ID:        uuid.New(),
+		ID:        sessionID,
 		UserID:    userID,
-		CreatedAt: time.Now(),
-		ExpiresAt: time.Now().Add(time.Duration(config.AppConfig.Auth.ExpirationTimeMinutes) * time.Minute),
+		FamilyID:  familyID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Duration(config.AppConfig.Auth.ExpirationTimeMinutes) * time.Minute),
 	}
+	refreshExpiresAt := now.Add(time.Duration(config.AppConfig.Auth.RefreshExpirationTimeMinutes) * time.Minute)
 
 	sessionDao := dao.Session{
-		ID:        session.ID.String(),
-		UserID:    session.UserID.String(),
-		CreatedAt: time.Now().UTC(),
-		ExpiresAt: time.Now().Add(time.Duration(config.AppConfig.Auth.ExpirationTimeMinutes) * time.Minute).UTC(),
+		ID:               session.ID.String(),
+		UserID:           session.UserID.String(),
+		FamilyID:         session.FamilyID.String(),
+		CreatedAt:        session.CreatedAt,
+		ExpiresAt:        session.ExpiresAt,
+		RefreshTokenHash: null.StringFrom(utils.HashToken(refreshToken)),
+		RefreshExpiresAt: null.TimeFrom(refreshExpiresAt),
+		UserAgent:        null.NewString(client.UserAgent, client.UserAgent != ""),
+		IPAddress:        null.NewString(client.IPAddress, client.IPAddress != ""),
+		LastSeenAt:       null.TimeFrom(now),
 	}
 
-	err = sessionDao.Insert(ctx, s.db.Conn, boil.Infer())
+	err = sessionDao.Insert(ctx, exec, boil.Infer())
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert session into database: %w", err)
 	}
 
-	return s.createTokenFromSession(ctx, &session)
+	token, err := s.createTokenFromSession(ctx, &session)
+	if err != nil {
+		return nil, err
+	}
+	token.RefreshToken = refreshToken
+	token.RefreshExpiresAt = refreshExpiresAt
+	return token, nil
 }
 
-func (s *authServiceImpl) ValidateAuthToken(ctx context.Context, token string) (*models.Session, error) {
-	at, err := jwt.Parse(token, func(token *jwt.Token) (any, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+// Logout revokes the caller's session, including the refresh token rotated into it.
+func (s *authServiceImpl) Logout(ctx context.Context, session *models.Session) error {
+	return revokeSessionFamily(ctx, s.db.Conn, session.FamilyID.String())
+}
+
+const activeSessionsQuery = `SELECT s.*, f.created_at AS signed_in_at
+FROM session s
+LEFT JOIN session f ON f.id = s.family_id
+WHERE s.user_id = $1 AND s.revoked_at IS NULL AND (s.expires_at > $2 OR s.refresh_expires_at > $2)
+ORDER BY s.last_seen_at DESC NULLS LAST, s.created_at DESC`
+
+type activeSessionRow struct {
+	dao.Session `boil:",bind"`
+	SignedInAt  null.Time `boil:"signed_in_at"`
+}
+
+// GetActiveSessions lists the devices the caller is signed in on; only the latest session of
+// each family is active, older ones were revoked when their refresh token was rotated.
+func (s *authServiceImpl) GetActiveSessions(ctx context.Context, session *models.Session) ([]*models.ActiveSession, error) {
+	var rows []*activeSessionRow
+	err := queries.Raw(activeSessionsQuery, session.UserID.String(), time.Now().UTC()).Bind(ctx, s.db.Conn, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions from database: %w", err)
+	}
+
+	activeSessions := []*models.ActiveSession{}
+	for _, row := range rows {
+		familyID, err := uuid.Parse(row.FamilyID)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing session family ID %v: %w", row.FamilyID, err) // Should never happen
 		}
-		return []byte(config.AppConfig.Auth.JWTSigningSecret), nil
-	})
+
+		activeSession := &models.ActiveSession{
+			ID:         familyID,
+			UserAgent:  row.UserAgent.String,
+			IPAddress:  row.IPAddress.String,
+			SignedInAt: row.CreatedAt,
+			LastSeenAt: row.LastSeenAt.Time,
+			ExpiresAt:  row.ExpiresAt,
+			Current:    familyID == session.FamilyID,
+		}
+		if row.SignedInAt.Valid {
+			activeSession.SignedInAt = row.SignedInAt.Time
+		}
+		if row.RefreshExpiresAt.Valid {
+			activeSession.ExpiresAt = row.RefreshExpiresAt.Time
+		}
+		activeSessions = append(activeSessions, activeSession)
+	}
+
+	return activeSessions, nil
+}
+
+// RevokeSession signs the caller out of one of their devices, identified by the ID listed by GetActiveSessions.
+func (s *authServiceImpl) RevokeSession(ctx context.Context, session *models.Session, activeSessionID string) error {
+	exists, err := dao.Sessions(
+		dao.SessionWhere.FamilyID.EQ(activeSessionID),
+		dao.SessionWhere.UserID.EQ(session.UserID.String()),
+		dao.SessionWhere.RevokedAt.IsNull(),
+	).Exists(ctx, s.db.Conn)
+	if err != nil {
+		return fmt.Errorf("failed fetching session from database: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("no active session found with ID %s", activeSessionID)
+	}
+
+	return revokeSessionFamily(ctx, s.db.Conn, activeSessionID)
+}
+
+// RevokeOtherSessions signs the caller out everywhere except the device making the request.
+func (s *authServiceImpl) RevokeOtherSessions(ctx context.Context, session *models.Session) (int64, error) {
+	revoked, err := dao.Sessions(
+		dao.SessionWhere.UserID.EQ(session.UserID.String()),
+		dao.SessionWhere.FamilyID.NEQ(session.FamilyID.String()),
+		dao.SessionWhere.RevokedAt.IsNull(),
+	).UpdateAll(ctx, s.db.Conn, dao.M{dao.SessionColumns.RevokedAt: time.Now().UTC()})
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return revoked, nil
+}
+
+// signPurposeToken signs a short-lived token that can only be used for the given purpose.
+func signPurposeToken(keyStore *keys.KeyStore, purpose string, claims jwt.MapClaims, ttl time.Duration) (string, error) {
+	claims[purposeClaim] = purpose
+	claims["typ"] = purposeTokenType
+	claims["aud"] = purposeAudiencePrefix + purpose
+	claims["exp"] = time.Now().Add(ttl).Unix()
+
+	token, err := keyStore.Sign(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed signing %s token: %w", purpose, err)
+	}
+	return token, nil
+}
+
+// parsePurposeToken validates a token made by signPurposeToken for the given purpose and returns its claims.
+func parsePurposeToken(keyStore *keys.KeyStore, token string, purpose string) (jwt.MapClaims, error) {
+	parsed, err := jwt.Parse(token, keyStore.Keyfunc)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing token: %w", err)
+	}
+
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok || !parsed.Valid || claims[purposeClaim] != purpose || claims["typ"] != purposeTokenType ||
+		!claims.VerifyAudience(purposeAudiencePrefix+purpose, true) {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
+
+// revokeSessionFamily revokes every session that is still active in the family.
+func revokeSessionFamily(ctx context.Context, exec boil.ContextExecutor, familyID string) error {
+	_, err := dao.Sessions(
+		dao.SessionWhere.FamilyID.EQ(familyID),
+		dao.SessionWhere.RevokedAt.IsNull(),
+	).UpdateAll(ctx, exec, dao.M{dao.SessionColumns.RevokedAt: time.Now().UTC()})
+	if err != nil {
+		return fmt.Errorf("failed to revoke session family: %w", err)
+	}
+	return nil
+}
+
+func (s *authServiceImpl) ValidateAuthToken(ctx context.Context, token string) (*models.Session, error) {
+	at, err := jwt.Parse(token, s.keys.Keyfunc)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed parsing token: %w", err)
@@ -107,6 +467,11 @@ func (s *authServiceImpl) ValidateAuthToken(ctx context.Context, token string) (
 	if !ok {
 		return nil, errors.New("invalid claims within token")
 	}
+	_, hasPurpose := claims[purposeClaim]
+	_, hasAudience := claims["aud"]
+	if hasPurpose || hasAudience {
+		return nil, errors.New("token can not be used for authentication")
+	}
 
 	// Convert the Unix timestamps to time.Time
 	createdAt := time.Unix(int64(claims["cre"].(float64)), 0).UTC()
@@ -123,6 +488,13 @@ func (s *authServiceImpl) ValidateAuthToken(ctx context.Context, token string) (
 	if err != nil {
 		return nil, fmt.Errorf("failed to find user: %w", err)
 	}
+	session.FamilyID = uuid.MustParse(sessionDao.FamilyID)
+
+	userDao, err := dao.FindUser(ctx, s.db.Conn, sessionDao.UserID, dao.UserColumns.ID, dao.UserColumns.EmailVerifiedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	session.EmailVerified = userDao.EmailVerifiedAt.Valid
 
 	// Validate the fetched session's details
 	if sessionDao.UserID != session.UserID.String() ||
@@ -132,11 +504,23 @@ func (s *authServiceImpl) ValidateAuthToken(ctx context.Context, token string) (
 		return nil, errors.New("session details do not match token details")
 	}
 
+	if sessionDao.RevokedAt.Valid {
+		return nil, errors.New("session has been revoked")
+	}
+
 	// Additional optional checks
 	if time.Now().After(session.ExpiresAt) {
 		return nil, errors.New("session has expired")
 	}
 
+	if !sessionDao.LastSeenAt.Valid || time.Since(sessionDao.LastSeenAt.Time) > lastSeenResolution {
+		sessionDao.LastSeenAt = null.TimeFrom(time.Now().UTC())
+		_, err = sessionDao.Update(ctx, s.db.Conn, boil.Whitelist(dao.SessionColumns.LastSeenAt))
+		if err != nil {
+			log.Printf("Failed updating last seen time of session %v: %v", sessionDao.ID, err)
+		}
+	}
+
 	// Sanity Check
 	if session.UserID.String() != claims["sub"].(string) {
 		return nil, errors.New("malformed session in token")
@@ -146,14 +530,12 @@ func (s *authServiceImpl) ValidateAuthToken(ctx context.Context, token string) (
 }
 
 func (s *authServiceImpl) createTokenFromSession(_ context.Context, session *models.Session) (*models.AuthToken, error) {
-	at := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	bearerToken, err := s.keys.Sign(jwt.MapClaims{
 		"sub": session.UserID,
 		"id":  session.ID,
 		"exp": session.ExpiresAt.Unix(),
 		"cre": session.CreatedAt.Unix(),
 	})
-
-	bearerToken, err := at.SignedString([]byte(config.AppConfig.Auth.JWTSigningSecret))
 	if err != nil {
 		return nil, fmt.Errorf("failed signing token: %w", err)
 	}
@@ -164,81 +546,46 @@ func (s *authServiceImpl) createTokenFromSession(_ context.Context, session *mod
 	}, nil
 }
 
-func (s *authServiceImpl) AuthorizeCompany(method string, callerUserID uuid.UUID, permissions []*models.Permission, hasMoreParts bool, requestedCompanyId string) error {
-	// Example for DB table entries
-	// | user_id | role | company_id | contract_id
-	// | blah1   | admin | - | -
-	// | blah2   | company_admin | company1 | -
-	// | blah2   | company_project_manager | company5 | -
-	// | blah3   | company_contributor | company1 | -
-	// | blah4   | prospect | company1 | contract1
-	// | blah4   | prospect | company1 | contract2
-
-	if method == "POST" {
-		// POST /companies/{companyId}/contracts
-		// POST /companies/{companyId}/categories
-		if hasMoreParts {
-			return callerHasRolesForCompanyById(requestedCompanyId, permissions, models.CompanyAdminRole, models.CompanyProjectManagerRole, models.CompanyContributorRole)
-		}
+func (s *authServiceImpl) PublicKeys() *keys.JWKS {
+	return s.keys.JWKS()
+}
 
-		// POST /companies
-		return &UnauthorizedError{}
+// AuthorizeOffer lets whoever may view the company's offers work with all of them, and the customer
+// of an offer open it and respond to it, as long as they may respond to the offers of its contract template.
+func (s *authServiceImpl) AuthorizeOffer(ctx context.Context, callerUserID string, permissions []*models.Permission, requestedCompanyId string, requestedOfferId string) error {
+	if callerHasCapabilityForContractById(requestedCompanyId, "", permissions, models.CapabilityOffersView) == nil {
+		return nil
 	}
 
-	if method == "PUT" {
-		// PUT /companies/{companyId}/contracts/{contractId}
-		if hasMoreParts {
-			return callerHasRolesForCompanyById(requestedCompanyId, permissions, models.CompanyAdminRole, models.CompanyProjectManagerRole, models.CompanyContributorRole)
+	offerDao, err := dao.Offers(
+		dao.OfferWhere.ID.EQ(requestedOfferId),
+		dao.OfferWhere.CompanyID.EQ(requestedCompanyId),
+		dao.OfferWhere.DeletedAt.IsNull(),
+	).One(ctx, s.db.Conn)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &UnauthorizedError{}
 		}
-
-		// PUT /companies/{companyId}
-		return callerHasRolesForCompanyById(requestedCompanyId, permissions, models.CompanyAdminRole)
-	}
-
-	if method == "GET" {
-		// GET /companies/{companyId}/...
-		return callerHasRolesForCompanyById(requestedCompanyId, permissions, models.CompanyAdminRole, models.CompanyProjectManagerRole, models.CompanyContributorRole)
-	}
-
-	// Unsupported method
-	return &UnauthorizedError{}
-}
-
-func (s *authServiceImpl) AuthorizeContract(method string, callerUserID uuid.UUID, permissions []*models.Permission, hasMoreParts bool, requestedCompanyId string, requestedContractId string) error {
-	if method == "POST" {
-		// POST /companies/{companyId}/contracts
-		return callerHasRolesForCompanyById(requestedCompanyId, permissions, models.CompanyProjectManagerRole)
+		return fmt.Errorf("error fetching offer: %w", err)
 	}
-
-	if method == "GET" {
-		// TODO: Implement this
+	if offerDao.CustomerID != callerUserID {
+		return &UnauthorizedError{}
 	}
-
-	// Unsupported method
-	return &UnauthorizedError{}
+	return callerHasCapabilityForContractById(requestedCompanyId, offerDao.ContractTemplateID, permissions, models.CapabilityOffersRespond)
 }
 
-func callerHasRolesForCompanyById(requestedCompanyId string, permissions []*models.Permission, allowedRoles ...models.Role) error {
+// callerHasCapabilityForContractById checks the caller's permissions in the company, only those
+// for the contract template when requestedContractId is set.
+func callerHasCapabilityForContractById(requestedCompanyId string, requestedContractId string, permissions []*models.Permission, capability models.Capability) error {
 	for _, permission := range permissions {
-		if permission.CompanyID == requestedCompanyId {
-			for _, role := range allowedRoles {
-				if permission.Role == role {
-					return nil
-				}
-			}
+		if permission.CompanyID != requestedCompanyId {
+			continue
 		}
-	}
-	return &UnauthorizedError{}
-}
-
-func callerHasRolesForContractById(requestedCompanyId string, requestedContractId string, permissions []*models.Permission, allowedRoles ...models.Role) error {
-	for _, permission := range permissions {
-		if permission.CompanyID == requestedCompanyId && permission.ContractID == requestedContractId {
-			for _, role := range allowedRoles {
-				if permission.Role == role {
-					return nil
-				}
-			}
+		if requestedContractId != "" && permission.ContractID != requestedContractId {
+			continue
+		}
+		if permission.Can(capability) {
+			return nil
 		}
 	}
 	return &UnauthorizedError{}
@@ -5,15 +5,20 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/ericlagergren/decimal"
 	"github.com/google/uuid"
 	"github.com/pro-posal/webserver/dao"
+	"github.com/pro-posal/webserver/internal/catalog"
 	"github.com/pro-posal/webserver/internal/database"
 	"github.com/pro-posal/webserver/models"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
 )
 
 type CreateCategoryRequest struct {
@@ -28,13 +33,60 @@ type UpdateCategoryRequest struct {
 	Description string
 }
 
+type ReorderCategoriesRequest struct {
+	CompanyID string
+	// ParentID is empty when reordering the top level categories
+	ParentID    string
+	CategoryIDs []string
+}
+
+type MoveCategoryRequest struct {
+	CompanyID  string
+	CategoryID string
+	ParentID   string
+	// Position within the new parent, nil appends the category at the end
+	Position *int
+}
+
+type ImportCatalogRequest struct {
+	CompanyID string
+	Rows      []catalog.Row
+	// DryRun reports what the import would do without writing anything
+	DryRun bool
+}
+
+type CopyCatalogRequest struct {
+	SourceCompanyID string
+	TargetCompanyID string
+	// CategoryID is the root of the subtree to copy, empty copies the whole catalog
+	CategoryID string
+	// TargetParentID is the category the copied subtree is placed under in the target company
+	TargetParentID string
+	ConflictPolicy models.CatalogConflictPolicy
+	RequestedBy    string
+}
+
+type GetCategoryTreeRequest struct {
+	CompanyID string
+	// Depth limits how many levels are returned, zero returns the whole tree
+	Depth          int
+	IncludeDeleted bool
+	RequestedBy    string
+}
+
 type CategoryManagementService interface {
 	CreateCategory(ctx context.Context, req CreateCategoryRequest) (*models.Category, error)
 	CreateSub(ctx context.Context, req CreateCategoryRequest) (*models.Category, error)
-	DeleteCategory(ctx context.Context, id string) (*models.Category, error)
+	DeleteCategory(ctx context.Context, id string, deletedBy string) (*models.Category, error)
 	UpdateCategory(ctx context.Context, id string, req UpdateCategoryRequest) (*models.Category, error)
 	GetCategory(ctx context.Context, companyID string) ([]*models.Category, error)
 	GetSub(ctx context.Context, id string) ([]*models.Category, error)
+	GetCategoryTree(ctx context.Context, req GetCategoryTreeRequest) ([]*models.CategoryNode, error)
+	ReorderCategories(ctx context.Context, req ReorderCategoriesRequest) ([]*models.Category, error)
+	MoveCategory(ctx context.Context, req MoveCategoryRequest) (*models.Category, error)
+	ImportCatalog(ctx context.Context, req ImportCatalogRequest) (*models.CatalogImportReport, error)
+	ExportCatalog(ctx context.Context, companyID string) ([]catalog.Row, error)
+	CopyCatalog(ctx context.Context, req CopyCatalogRequest) (*models.CatalogCopyReport, error)
 }
 
 type CategoryManagementServiceImpl struct {
@@ -61,55 +113,118 @@ func (s *CategoryManagementServiceImpl) CreateCategory(ctx context.Context, req
 
 	categoryDao := dao.Category{
 		ID:          uuid.NewString(),
-		CategoryID:  null.String{String: req.CategoryID, Valid: false},
+		CategoryID:  null.String{},
 		Description: req.Description,
 		CompanyID:   req.CompanyID,
 		Type:        req.Type,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
+	// Only top level categories can be created without a parent
+	if err := validateCategoryParent(ctx, s.db.Conn, &categoryDao, categoryDao.CategoryID); err != nil {
+		return nil, err
+	}
 
-	err = categoryDao.Insert(ctx, s.db.Conn, boil.Infer())
+	categoryDao.Position, err = nextCategoryPosition(ctx, s.db.Conn, req.CompanyID, null.String{}, "")
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = categoryDao.Insert(ctx, tx, boil.Infer())
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert category into database: %w", err)
 	}
-	return categoryDaoToCategoryModel(categoryDao), nil
+
+	category := categoryDaoToCategoryModel(categoryDao)
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  category.CompanyID,
+		Action:     AuditActionCreate,
+		EntityType: AuditEntityCategory,
+		EntityID:   category.ID,
+		After:      category,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+	return category, nil
 }
 
+// CreateSub adds a category under req.CategoryID. It always goes into the parent's company,
+// req.CompanyID is ignored.
 func (s *CategoryManagementServiceImpl) CreateSub(ctx context.Context, req CreateCategoryRequest) (*models.Category, error) {
-	existingCategory, err := dao.Categories(qm.Where("id = ?", req.CategoryID)).One(ctx, s.db.Conn)
+	parent, err := dao.Categories(qm.Where("id = ? AND deleted_at IS NULL", req.CategoryID)).One(ctx, s.db.Conn)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("no existing category found: %w", err)
+			return nil, fmt.Errorf("no parent category found with ID %s", req.CategoryID)
+		}
+		return nil, fmt.Errorf("failed to get parent category from database: %w", err)
+	}
+
+	categoryDao := dao.Category{
+		ID:          uuid.NewString(),
+		CategoryID:  null.StringFrom(parent.ID),
+		Description: req.Description,
+		CompanyID:   parent.CompanyID,
+		Type:        req.Type,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	if err := validateCategoryParent(ctx, s.db.Conn, &categoryDao, categoryDao.CategoryID); err != nil {
+		return nil, err
+	}
+
+	existingsub, err := dao.Categories(qm.Where("description= ? AND category_id = ? AND type = ?", req.Description, parent.ID, req.Type)).One(ctx, s.db.Conn)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// No existing category found; continue to create a new one.
 		} else {
 			return nil, fmt.Errorf("error checking if category exists: %w", err)
 		}
-	} else {
-		existingsub, err := dao.Categories(qm.Where("description= ? AND category_id = ? AND type = ?", req.Description, existingCategory.ID, req.Type)).One(ctx, s.db.Conn)
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				// No existing category found; continue to create a new one.
-			} else {
-				return nil, fmt.Errorf("error checking if category exists: %w", err)
-			}
-		} else if existingsub != nil {
-			return nil, fmt.Errorf("category already exists")
-		}
-		categoryDao := dao.Category{
-			ID:          uuid.NewString(),
-			CategoryID:  null.String{String: req.CategoryID, Valid: true},
-			Description: req.Description,
-			CompanyID:   req.CompanyID,
-			Type:        req.Type,
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		}
-		err = categoryDao.Insert(ctx, s.db.Conn, boil.Infer())
-		if err != nil {
-			return nil, fmt.Errorf("failed to insert category into database: %w", err)
-		}
-		return categoryDaoToCategoryModel(categoryDao), nil
+	} else if existingsub != nil {
+		return nil, fmt.Errorf("category already exists")
+	}
+
+	categoryDao.Position, err = nextCategoryPosition(ctx, s.db.Conn, parent.CompanyID, categoryDao.CategoryID, "")
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
 	}
+	defer tx.Rollback()
+
+	err = categoryDao.Insert(ctx, tx, boil.Infer())
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert category into database: %w", err)
+	}
+
+	category := categoryDaoToCategoryModel(categoryDao)
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  category.CompanyID,
+		Action:     AuditActionCreate,
+		EntityType: AuditEntityCategory,
+		EntityID:   category.ID,
+		After:      category,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+	return category, nil
 }
 
 func (s *CategoryManagementServiceImpl) UpdateCategory(ctx context.Context, id string, req UpdateCategoryRequest) (*models.Category, error) {
@@ -118,36 +233,66 @@ func (s *CategoryManagementServiceImpl) UpdateCategory(ctx context.Context, id s
 		return nil, fmt.Errorf("failed to get category from database: %w", err)
 	}
 
+	before := categoryDaoToCategoryModel(*categoryDao)
 	categoryDao.Description = req.Description
 	categoryDao.UpdatedAt = time.Now()
 
-	_, err = categoryDao.Update(ctx, s.db.Conn, boil.Infer())
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = categoryDao.Update(ctx, tx, boil.Infer())
 	if err != nil {
 		return nil, fmt.Errorf("failed to update category in database: %w", err)
 	}
 
-	return categoryDaoToCategoryModel(*categoryDao), nil
+	category := categoryDaoToCategoryModel(*categoryDao)
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  category.CompanyID,
+		Action:     AuditActionUpdate,
+		EntityType: AuditEntityCategory,
+		EntityID:   category.ID,
+		Before:     before,
+		After:      category,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	return category, nil
 }
 
-func (s *CategoryManagementServiceImpl) DeleteCategory(ctx context.Context, id string) (*models.Category, error) {
+// DeleteCategory soft deletes a category with its sub categories and descriptions; they all
+// share one deletion ID so the trash can restore them together.
+func (s *CategoryManagementServiceImpl) DeleteCategory(ctx context.Context, id string, deletedBy string) (*models.Category, error) {
 	categoriesDao, err := dao.Categories(
-		qm.Where("id = ? OR category_id = ?", id, id),
+		qm.Where("(id = ? OR category_id = ?) AND deleted_at IS NULL", id, id),
 	).All(ctx, s.db.Conn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get category from database: %w", err)
 	}
 
 	var parentCategory *models.Category
-	categoryIDsToDelete := make([]string, 0)
+	categoryIDsToDelete := make([]interface{}, 0)
 	for _, category := range categoriesDao {
 		if category.ID == id {
 			parentCategory = categoryDaoToCategoryModel(*category)
 		}
 		categoryIDsToDelete = append(categoryIDsToDelete, category.ID)
 	}
+	if parentCategory == nil {
+		return nil, fmt.Errorf("no category found with ID %s", id)
+	}
 
 	categoriesDao, err = dao.Categories(
-		qm.Where("category_id IN ?", categoryIDsToDelete),
+		qm.WhereIn("category_id IN ?", categoryIDsToDelete...),
+		qm.Where("deleted_at IS NULL"),
 	).All(ctx, s.db.Conn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get sub categories and descriptions from database: %w", err)
@@ -159,22 +304,47 @@ func (s *CategoryManagementServiceImpl) DeleteCategory(ctx context.Context, id s
 
 	deletedAt := null.TimeFrom(time.Now())
 
-	for _, categoryID := range categoryIDsToDelete {
-		_, err := dao.Categories(
-			qm.Where("id = ?", categoryID),
-		).UpdateAll(ctx, s.db.Conn, map[string]interface{}{
-			"deleted_at": deletedAt,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to mark category as deleted: %w", err)
-		}
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = dao.Categories(
+		qm.WhereIn("id IN ?", categoryIDsToDelete...),
+		qm.Where("deleted_at IS NULL"),
+	).UpdateAll(ctx, tx, map[string]interface{}{
+		"deleted_at":  deletedAt,
+		"deleted_by":  deletedByUser(deletedBy),
+		"deletion_id": uuid.NewString(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark category as deleted: %w", err)
 	}
 
+	// One entry for the whole subtree, the trash lists what was deleted with it
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  parentCategory.CompanyID,
+		ActorID:    deletedBy,
+		Action:     AuditActionDelete,
+		EntityType: AuditEntityCategory,
+		EntityID:   parentCategory.ID,
+		Before:     parentCategory,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+	parentCategory.DeleteAt = deletedAt.Time
+
 	return parentCategory, nil
 }
 
 func (s *CategoryManagementServiceImpl) GetCategory(ctx context.Context, companyID string) ([]*models.Category, error) {
-	categoryDao, err := dao.Categories(qm.Where("company_id = ? AND type = ?", companyID, "category")).All(ctx, s.db.Conn)
+	categoryDao, err := dao.Categories(qm.Where("company_id = ? AND type = ?", companyID, "category"), qm.OrderBy("position, created_at")).All(ctx, s.db.Conn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get categories from database: %w", err)
 	}
@@ -186,7 +356,7 @@ func (s *CategoryManagementServiceImpl) GetCategory(ctx context.Context, company
 }
 
 func (s *CategoryManagementServiceImpl) GetSub(ctx context.Context, id string) ([]*models.Category, error) {
-	categoryDao, err := dao.Categories(qm.Where("category_id = ?", id)).All(ctx, s.db.Conn)
+	categoryDao, err := dao.Categories(qm.Where("category_id = ?", id), qm.OrderBy("position, created_at")).All(ctx, s.db.Conn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get sub categories from database: %w", err)
 	}
@@ -197,6 +367,709 @@ func (s *CategoryManagementServiceImpl) GetSub(ctx context.Context, id string) (
 	return subcategories, nil
 }
 
+// categoryTreeQuery walks the company's categories from the top level down in a single
+// round trip; $2 is the depth limit (0 for unlimited) and $3 includes soft-deleted nodes.
+const categoryTreeQuery = `
+WITH RECURSIVE tree AS (
+	SELECT c.*, 1 AS depth
+	FROM categories c
+	WHERE c.company_id = $1 AND c.category_id IS NULL AND ($3 OR c.deleted_at IS NULL)
+	UNION ALL
+	SELECT c.*, tree.depth + 1
+	FROM categories c
+	JOIN tree ON c.category_id = tree.id
+	WHERE ($2 = 0 OR tree.depth < $2) AND ($3 OR c.deleted_at IS NULL)
+)
+SELECT * FROM tree ORDER BY depth, position, created_at`
+
+type categoryTreeRow struct {
+	dao.Category `boil:",bind"`
+	Depth        int `boil:"depth"`
+}
+
+func (s *CategoryManagementServiceImpl) GetCategoryTree(ctx context.Context, req GetCategoryTreeRequest) ([]*models.CategoryNode, error) {
+	if req.Depth < 0 {
+		return nil, fmt.Errorf("depth must not be negative")
+	}
+
+	if req.IncludeDeleted {
+		isAdmin, err := userHasCompanyCapability(ctx, s.db.Conn, req.RequestedBy, req.CompanyID, models.CapabilityCompanyManage)
+		if err != nil {
+			return nil, err
+		}
+		if !isAdmin {
+			return nil, &UnauthorizedError{}
+		}
+	}
+
+	var rows []*categoryTreeRow
+	err := queries.Raw(categoryTreeQuery, req.CompanyID, req.Depth, req.IncludeDeleted).Bind(ctx, s.db.Conn, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category tree from database: %w", err)
+	}
+
+	return buildCategoryTree(rows), nil
+}
+
+// buildCategoryTree nests the rows under their parents; rows must be ordered by depth
+// so every parent is seen before its children.
+func buildCategoryTree(rows []*categoryTreeRow) []*models.CategoryNode {
+	roots := []*models.CategoryNode{}
+	nodes := make(map[string]*models.CategoryNode, len(rows))
+
+	for _, row := range rows {
+		node := &models.CategoryNode{
+			Category: *categoryDaoToCategoryModel(row.Category),
+			Depth:    row.Depth,
+			Children: []*models.CategoryNode{},
+		}
+		nodes[node.ID] = node
+
+		parent, ok := nodes[row.CategoryID.String]
+		if !row.CategoryID.Valid || !ok {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+
+	return roots
+}
+
+func (s *CategoryManagementServiceImpl) ReorderCategories(ctx context.Context, req ReorderCategoriesRequest) ([]*models.Category, error) {
+	parentID := null.NewString(req.ParentID, req.ParentID != "")
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	siblings, err := dao.Categories(categorySiblingsQuery(req.CompanyID, parentID)...).All(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories from database: %w", err)
+	}
+
+	// The new order must list every sibling exactly once
+	byID := make(map[string]*dao.Category, len(siblings))
+	for _, sibling := range siblings {
+		byID[sibling.ID] = sibling
+	}
+	if len(req.CategoryIDs) != len(siblings) {
+		return nil, fmt.Errorf("expected %d category IDs, got %d", len(siblings), len(req.CategoryIDs))
+	}
+
+	var categories []*models.Category
+	for position, id := range req.CategoryIDs {
+		categoryDao, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("category %s is not a child of the given parent or is listed twice", id)
+		}
+		delete(byID, id)
+		if categoryDao.Position == position {
+			categories = append(categories, categoryDaoToCategoryModel(*categoryDao))
+			continue
+		}
+
+		before := categoryDaoToCategoryModel(*categoryDao)
+		categoryDao.Position = position
+		categoryDao.UpdatedAt = time.Now()
+		_, err = categoryDao.Update(ctx, tx, boil.Whitelist("position", "updated_at"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to update category position: %w", err)
+		}
+
+		category := categoryDaoToCategoryModel(*categoryDao)
+		err = recordAudit(ctx, tx, auditEntry{
+			CompanyID:  category.CompanyID,
+			Action:     AuditActionMove,
+			EntityType: AuditEntityCategory,
+			EntityID:   category.ID,
+			Before:     before,
+			After:      category,
+		})
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	return categories, nil
+}
+
+func (s *CategoryManagementServiceImpl) MoveCategory(ctx context.Context, req MoveCategoryRequest) (*models.Category, error) {
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	categoryDao, err := dao.Categories(
+		qm.Where("id = ? AND company_id = ? AND deleted_at IS NULL", req.CategoryID, req.CompanyID),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("no category found with ID %s", req.CategoryID)
+		}
+		return nil, fmt.Errorf("failed to get category from database: %w", err)
+	}
+
+	newParentID := null.NewString(req.ParentID, req.ParentID != "")
+	if err := validateCategoryParent(ctx, tx, categoryDao, newParentID); err != nil {
+		return nil, err
+	}
+	before := categoryDaoToCategoryModel(*categoryDao)
+
+	// Close the gap left in the old parent, then open one at the requested position
+	err = shiftCategoryPositions(ctx, tx, categoryDao.CompanyID, categoryDao.CategoryID, categoryDao.ID, categoryDao.Position+1, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	position, err := nextCategoryPosition(ctx, tx, categoryDao.CompanyID, newParentID, categoryDao.ID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Position != nil {
+		if *req.Position < 0 || *req.Position > position {
+			return nil, fmt.Errorf("position %d is out of range", *req.Position)
+		}
+		position = *req.Position
+		err = shiftCategoryPositions(ctx, tx, categoryDao.CompanyID, newParentID, categoryDao.ID, position, 1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	categoryDao.CategoryID = newParentID
+	categoryDao.Position = position
+	categoryDao.UpdatedAt = time.Now()
+	_, err = categoryDao.Update(ctx, tx, boil.Whitelist("category_id", "position", "updated_at"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to move category: %w", err)
+	}
+
+	category := categoryDaoToCategoryModel(*categoryDao)
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  category.CompanyID,
+		Action:     AuditActionMove,
+		EntityType: AuditEntityCategory,
+		EntityID:   category.ID,
+		Before:     before,
+		After:      category,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	return category, nil
+}
+
+// categoryParentTypes lists the type a category's parent must have; top level categories have no parent.
+var categoryParentTypes = map[models.CategoryType]models.CategoryType{
+	models.CategoryTypeSubCategory: models.CategoryTypeCategory,
+	models.CategoryTypeDescription: models.CategoryTypeSubCategory,
+}
+
+// validateCategoryParent enforces the category -> sub_category -> description hierarchy
+// and rejects moving a category underneath itself.
+func validateCategoryParent(ctx context.Context, exec boil.ContextExecutor, categoryDao *dao.Category, parentID null.String) error {
+	parentType, needsParent := categoryParentTypes[models.CategoryType(categoryDao.Type)]
+	if !parentID.Valid {
+		if needsParent {
+			return fmt.Errorf("a %s must be placed under a %s", categoryDao.Type, parentType)
+		}
+		return nil
+	}
+	if !needsParent {
+		return fmt.Errorf("a %s can not be placed under another category", categoryDao.Type)
+	}
+
+	parent, err := dao.Categories(
+		qm.Where("id = ? AND company_id = ? AND deleted_at IS NULL", parentID.String, categoryDao.CompanyID),
+	).One(ctx, exec)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("no parent category found with ID %s", parentID.String)
+		}
+		return fmt.Errorf("failed to get parent category from database: %w", err)
+	}
+	if models.CategoryType(parent.Type) != parentType {
+		return fmt.Errorf("a %s must be placed under a %s, not a %s", categoryDao.Type, parentType, parent.Type)
+	}
+
+	// Walk up from the new parent; reaching the moved category means it would become its own ancestor
+	for ancestor := parent; ; {
+		if ancestor.ID == categoryDao.ID {
+			return fmt.Errorf("can not move a category underneath itself")
+		}
+		if !ancestor.CategoryID.Valid {
+			return nil
+		}
+		ancestor, err = dao.FindCategory(ctx, exec, ancestor.CategoryID.String)
+		if err != nil {
+			return fmt.Errorf("failed to get parent category from database: %w", err)
+		}
+	}
+}
+
+func categorySiblingsQuery(companyID string, parentID null.String) []qm.QueryMod {
+	query := []qm.QueryMod{
+		qm.Where("company_id = ? AND deleted_at IS NULL", companyID),
+	}
+	if parentID.Valid {
+		return append(query, qm.Where("category_id = ?", parentID.String))
+	}
+	return append(query, qm.Where("category_id IS NULL"))
+}
+
+// nextCategoryPosition returns the position that appends a category at the end of its parent,
+// ignoring the category being moved, if any.
+func nextCategoryPosition(ctx context.Context, exec boil.ContextExecutor, companyID string, parentID null.String, excludeID string) (int, error) {
+	var position int
+	err := queries.Raw(`SELECT COALESCE(MAX(position) + 1, 0) FROM categories
+		WHERE company_id = $1 AND category_id IS NOT DISTINCT FROM $2 AND deleted_at IS NULL AND id::text <> $3`,
+		companyID, parentID, excludeID,
+	).QueryRowContext(ctx, exec).Scan(&position)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get next category position: %w", err)
+	}
+	return position, nil
+}
+
+// shiftCategoryPositions moves every sibling at or after the given position by delta.
+func shiftCategoryPositions(ctx context.Context, exec boil.ContextExecutor, companyID string, parentID null.String, excludeID string, from int, delta int) error {
+	_, err := queries.Raw(`UPDATE categories SET position = position + $1
+		WHERE company_id = $2 AND category_id IS NOT DISTINCT FROM $3 AND deleted_at IS NULL AND id::text <> $4 AND position >= $5`,
+		delta, companyID, parentID, excludeID, from,
+	).ExecContext(ctx, exec)
+	if err != nil {
+		return fmt.Errorf("failed to shift category positions: %w", err)
+	}
+	return nil
+}
+
+// catalogOrder numbers the company's categories in the order they appear when the
+// tree is read top to bottom.
+func catalogOrder(ctx context.Context, exec boil.ContextExecutor, companyID string) (map[string]int, error) {
+	var rows []*categoryTreeRow
+	err := queries.Raw(categoryTreeQuery, companyID, 0, false).Bind(ctx, exec, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category tree from database: %w", err)
+	}
+
+	order := make(map[string]int, len(rows))
+	var visit func(nodes []*models.CategoryNode)
+	visit = func(nodes []*models.CategoryNode) {
+		for _, node := range nodes {
+			order[node.ID] = len(order)
+			visit(node.Children)
+		}
+	}
+	visit(buildCategoryTree(rows))
+
+	return order, nil
+}
+
+// ImportCatalog merges the rows of a catalog sheet into the company's category tree in a
+// single transaction. Nothing is written when the sheet has errors or on a dry run.
+func (s *CategoryManagementServiceImpl) ImportCatalog(ctx context.Context, req ImportCatalogRequest) (*models.CatalogImportReport, error) {
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	existing, err := dao.Categories(
+		qm.Where("company_id = ? AND deleted_at IS NULL", req.CompanyID),
+	).All(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories from database: %w", err)
+	}
+
+	importer := newCatalogImporter(req.CompanyID, existing)
+	for _, row := range req.Rows {
+		importer.importRow(row)
+	}
+
+	report := importer.report
+	report.DryRun = req.DryRun
+	report.TotalRows = len(req.Rows)
+	if req.DryRun || len(report.Errors) > 0 {
+		return report, nil
+	}
+
+	for _, categoryDao := range importer.inserts {
+		if err := categoryDao.Insert(ctx, tx, boil.Infer()); err != nil {
+			return nil, fmt.Errorf("failed to insert category into database: %w", err)
+		}
+	}
+	for _, categoryDao := range importer.updates {
+		categoryDao.UpdatedAt = time.Now()
+		if _, err := categoryDao.Update(ctx, tx, boil.Whitelist("unit", "price", "updated_at")); err != nil {
+			return nil, fmt.Errorf("failed to update category in database: %w", err)
+		}
+	}
+
+	// The import is recorded as a whole, its report says how many categories it touched
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  req.CompanyID,
+		Action:     AuditActionImport,
+		EntityType: AuditEntityCatalog,
+		EntityID:   req.CompanyID,
+		After:      report,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	report.Applied = true
+	return report, nil
+}
+
+// ExportCatalog returns the company's catalog as sheet rows in catalog order, one row per
+// leaf and per inner node that carries a unit or price.
+func (s *CategoryManagementServiceImpl) ExportCatalog(ctx context.Context, companyID string) ([]catalog.Row, error) {
+	var treeRows []*categoryTreeRow
+	err := queries.Raw(categoryTreeQuery, companyID, 0, false).Bind(ctx, s.db.Conn, &treeRows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category tree from database: %w", err)
+	}
+
+	rows := []catalog.Row{}
+	var visit func(nodes []*models.CategoryNode, path []string)
+	visit = func(nodes []*models.CategoryNode, path []string) {
+		for _, node := range nodes {
+			nodePath := append(path[:len(path):len(path)], node.Description)
+			if len(node.Children) == 0 || node.Unit != "" || node.Price != "" {
+				row := catalog.Row{Unit: node.Unit, Price: node.Price}
+				fields := []*string{&row.Category, &row.SubCategory, &row.Description}
+				for i := 0; i < len(nodePath) && i < len(fields); i++ {
+					*fields[i] = nodePath[i]
+				}
+				rows = append(rows, row)
+			}
+			visit(node.Children, nodePath)
+		}
+	}
+	visit(buildCategoryTree(treeRows), nil)
+
+	return rows, nil
+}
+
+// CopyCatalog deep-copies a category subtree, or the whole catalog, into another company in a
+// single transaction. Categories and sub categories that already exist under the same parent are
+// reused; existing descriptions are skipped or get the source unit and price depending on the policy.
+func (s *CategoryManagementServiceImpl) CopyCatalog(ctx context.Context, req CopyCatalogRequest) (*models.CatalogCopyReport, error) {
+	switch req.ConflictPolicy {
+	case "":
+		req.ConflictPolicy = models.CatalogConflictSkip
+	case models.CatalogConflictSkip, models.CatalogConflictMerge:
+	default:
+		return nil, fmt.Errorf("unknown conflict policy %q", req.ConflictPolicy)
+	}
+	if req.SourceCompanyID == req.TargetCompanyID {
+		return nil, fmt.Errorf("source and target company must be different")
+	}
+	if req.CategoryID == "" && req.TargetParentID != "" {
+		return nil, fmt.Errorf("a whole catalog can only be copied to the top level")
+	}
+
+	for _, companyID := range []string{req.SourceCompanyID, req.TargetCompanyID} {
+		isAdmin, err := userHasCompanyCapability(ctx, s.db.Conn, req.RequestedBy, companyID, models.CapabilityCompanyManage)
+		if err != nil {
+			return nil, err
+		}
+		if !isAdmin {
+			return nil, &UnauthorizedError{}
+		}
+	}
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var treeRows []*categoryTreeRow
+	err = queries.Raw(categoryTreeQuery, req.SourceCompanyID, 0, false).Bind(ctx, tx, &treeRows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category tree from database: %w", err)
+	}
+
+	roots := buildCategoryTree(treeRows)
+	if req.CategoryID != "" {
+		root := findCategoryNode(roots, req.CategoryID)
+		if root == nil {
+			return nil, fmt.Errorf("no category found with ID %s", req.CategoryID)
+		}
+		roots = []*models.CategoryNode{root}
+	}
+
+	targetParentID := null.NewString(req.TargetParentID, req.TargetParentID != "")
+	if len(roots) == 1 {
+		// Check the copied root fits under the target parent the same way a move would
+		root := &dao.Category{ID: roots[0].ID, CompanyID: req.TargetCompanyID, Type: string(roots[0].Type)}
+		if err := validateCategoryParent(ctx, tx, root, targetParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	existing, err := dao.Categories(
+		qm.Where("company_id = ? AND deleted_at IS NULL", req.TargetCompanyID),
+	).All(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories from database: %w", err)
+	}
+
+	importer := newCatalogImporter(req.TargetCompanyID, existing)
+	report := &models.CatalogCopyReport{
+		ConflictPolicy: req.ConflictPolicy,
+		Categories:     map[string]string{},
+	}
+
+	var visit func(nodes []*models.CategoryNode, parentID null.String) error
+	visit = func(nodes []*models.CategoryNode, parentID null.String) error {
+		for _, node := range nodes {
+			unit := null.NewString(node.Unit, node.Unit != "")
+			price := types.NullDecimal{}
+			if node.Price != "" {
+				value, ok := new(decimal.Big).SetString(node.Price)
+				if !ok {
+					return fmt.Errorf("category %s has an invalid price %q", node.ID, node.Price)
+				}
+				price = types.NewNullDecimal(value)
+			}
+
+			copyDao := importer.ensure(parentID, node.Type, node.Description)
+			report.Categories[node.ID] = copyDao.ID
+			switch {
+			case importer.created[copyDao.ID]:
+				copyDao.Unit = unit
+				copyDao.Price = price
+				report.Created++
+			case node.Type != models.CategoryTypeDescription:
+				report.Merged++
+			case req.ConflictPolicy == models.CatalogConflictSkip:
+				report.Skipped++
+			case copyDao.Unit == unit && formatPrice(copyDao.Price) == formatPrice(price):
+				report.Unchanged++
+			default:
+				copyDao.Unit = unit
+				copyDao.Price = price
+				importer.updates[copyDao.ID] = copyDao
+				report.Updated++
+			}
+
+			if err := visit(node.Children, null.StringFrom(copyDao.ID)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := visit(roots, targetParentID); err != nil {
+		return nil, err
+	}
+
+	for _, categoryDao := range importer.inserts {
+		if err := categoryDao.Insert(ctx, tx, boil.Infer()); err != nil {
+			return nil, fmt.Errorf("failed to insert category into database: %w", err)
+		}
+	}
+	for _, categoryDao := range importer.updates {
+		categoryDao.UpdatedAt = time.Now()
+		if _, err := categoryDao.Update(ctx, tx, boil.Whitelist("unit", "price", "updated_at")); err != nil {
+			return nil, fmt.Errorf("failed to update category in database: %w", err)
+		}
+	}
+
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  req.TargetCompanyID,
+		ActorID:    req.RequestedBy,
+		Action:     AuditActionCopy,
+		EntityType: AuditEntityCatalog,
+		EntityID:   req.TargetCompanyID,
+		After:      report,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	return report, nil
+}
+
+func findCategoryNode(nodes []*models.CategoryNode, id string) *models.CategoryNode {
+	for _, node := range nodes {
+		if node.ID == id {
+			return node
+		}
+		if found := findCategoryNode(node.Children, id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// catalogImporter plans the inserts and updates for a catalog import, matching sheet rows
+// to existing categories by parent, type and case-insensitive description.
+type catalogImporter struct {
+	companyID     string
+	nodes         map[string]*dao.Category
+	nextPositions map[string]int
+	seenRows      map[string]int
+	created       map[string]bool
+	inserts       []*dao.Category
+	updates       map[string]*dao.Category
+	report        *models.CatalogImportReport
+}
+
+func newCatalogImporter(companyID string, existing dao.CategorySlice) *catalogImporter {
+	importer := &catalogImporter{
+		companyID:     companyID,
+		nodes:         map[string]*dao.Category{},
+		nextPositions: map[string]int{},
+		seenRows:      map[string]int{},
+		created:       map[string]bool{},
+		updates:       map[string]*dao.Category{},
+		report: &models.CatalogImportReport{
+			Duplicates: []models.CatalogImportIssue{},
+			Conflicts:  []models.CatalogImportIssue{},
+			Errors:     []models.CatalogImportIssue{},
+		},
+	}
+
+	for _, categoryDao := range existing {
+		importer.nodes[catalogNodeKey(categoryDao.CategoryID.String, categoryDao.Type, categoryDao.Description)] = categoryDao
+		if categoryDao.Position >= importer.nextPositions[categoryDao.CategoryID.String] {
+			importer.nextPositions[categoryDao.CategoryID.String] = categoryDao.Position + 1
+		}
+	}
+	return importer
+}
+
+func (i *catalogImporter) importRow(row catalog.Row) {
+	issue := func(issues *[]models.CatalogImportIssue, format string, args ...any) {
+		*issues = append(*issues, models.CatalogImportIssue{Line: row.Line, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch {
+	case row.Category == "":
+		issue(&i.report.Errors, "category is required")
+		return
+	case row.Description != "" && row.SubCategory == "":
+		issue(&i.report.Errors, "description %q needs a sub category", row.Description)
+		return
+	}
+
+	price := types.NullDecimal{}
+	if row.Price != "" {
+		value, ok := new(decimal.Big).SetString(row.Price)
+		if !ok || value.IsNaN(0) || value.IsInf(0) {
+			issue(&i.report.Errors, "price %q is not a number", row.Price)
+			return
+		}
+		price = types.NewNullDecimal(value)
+	}
+
+	pathKey := strings.ToLower(strings.Join([]string{row.Category, row.SubCategory, row.Description}, "\x00"))
+	if line, ok := i.seenRows[pathKey]; ok {
+		issue(&i.report.Duplicates, "duplicates line %d", line)
+		return
+	}
+	i.seenRows[pathKey] = row.Line
+
+	leaf := i.ensure(null.String{}, models.CategoryTypeCategory, row.Category)
+	if row.SubCategory != "" {
+		leaf = i.ensure(null.StringFrom(leaf.ID), models.CategoryTypeSubCategory, row.SubCategory)
+	}
+	if row.Description != "" {
+		leaf = i.ensure(null.StringFrom(leaf.ID), models.CategoryTypeDescription, row.Description)
+	}
+
+	unit := null.NewString(row.Unit, row.Unit != "")
+	if i.created[leaf.ID] {
+		leaf.Unit = unit
+		leaf.Price = price
+		return
+	}
+
+	var changes []string
+	if row.Unit != "" && leaf.Unit != unit {
+		changes = append(changes, fmt.Sprintf("unit changes from %q to %q", leaf.Unit.String, row.Unit))
+	}
+	if row.Price != "" && (leaf.Price.Big == nil || leaf.Price.Big.Cmp(price.Big) != 0) {
+		changes = append(changes, fmt.Sprintf("price changes from %q to %q", formatPrice(leaf.Price), row.Price))
+	}
+	if len(changes) == 0 {
+		i.report.Unchanged++
+		return
+	}
+
+	issue(&i.report.Conflicts, "%s already exists: %s", leaf.Description, strings.Join(changes, ", "))
+	if row.Unit != "" {
+		leaf.Unit = unit
+	}
+	if row.Price != "" {
+		leaf.Price = price
+	}
+	if _, ok := i.updates[leaf.ID]; !ok {
+		i.updates[leaf.ID] = leaf
+		i.report.Updated++
+	}
+}
+
+// ensure returns the matching category, planning its creation when it does not exist yet.
+func (i *catalogImporter) ensure(parentID null.String, categoryType models.CategoryType, description string) *dao.Category {
+	key := catalogNodeKey(parentID.String, string(categoryType), description)
+	if categoryDao, ok := i.nodes[key]; ok {
+		return categoryDao
+	}
+
+	categoryDao := &dao.Category{
+		ID:          uuid.NewString(),
+		CompanyID:   i.companyID,
+		CategoryID:  parentID,
+		Description: description,
+		Type:        string(categoryType),
+		Position:    i.nextPositions[parentID.String],
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	i.nextPositions[parentID.String]++
+	i.nodes[key] = categoryDao
+	i.created[categoryDao.ID] = true
+	i.inserts = append(i.inserts, categoryDao)
+	i.report.Created++
+	return categoryDao
+}
+
+func catalogNodeKey(parentID string, categoryType string, description string) string {
+	return parentID + "\x00" + categoryType + "\x00" + strings.ToLower(strings.TrimSpace(description))
+}
+
+func formatPrice(price types.NullDecimal) string {
+	if price.Big == nil {
+		return ""
+	}
+	return price.Big.String()
+}
+
 func categoryDaoToCategoryModel(categoryDao dao.Category) *models.Category {
 	return &models.Category{
 		ID:          categoryDao.ID,
@@ -204,6 +1077,9 @@ func categoryDaoToCategoryModel(categoryDao dao.Category) *models.Category {
 		CategoryID:  categoryDao.CategoryID.String,
 		Description: categoryDao.Description,
 		Type:        models.CategoryType(categoryDao.Type),
+		Position:    categoryDao.Position,
+		Unit:        categoryDao.Unit.String,
+		Price:       formatPrice(categoryDao.Price),
 		CreatedAt:   categoryDao.CreatedAt,
 		UpdatedAt:   categoryDao.UpdatedAt,
 		DeleteAt:    categoryDao.DeletedAt.Time,
@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/pro-posal/webserver/dao"
 	"github.com/pro-posal/webserver/internal/database"
+	"github.com/pro-posal/webserver/internal/mailer"
 	"github.com/pro-posal/webserver/models"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
@@ -26,23 +27,33 @@ type UpdateCompanyRequest struct {
 	Name       string
 	Address    string
 	LogoBase64 string
+	// RequireTwoFactor is left nil to keep the current policy
+	RequireTwoFactor *bool
 }
 
 //go:generate go run github.com/golang/mock/mockgen -package $GOPACKAGE -source=$GOFILE -destination=mock_$GOFILE
 type CompanyManagementService interface {
 	CreateCompany(context.Context, CreateCompanyRequest) (*models.Company, error)
-	DeleteCompany(context.Context, string) (*models.Company, error)
+	DeleteCompany(context.Context, string, string) (*models.Company, error)
 	UpdateCompany(context.Context, string, UpdateCompanyRequest) (*models.Company, error)
+	GetCompany(context.Context, string) (*models.Company, error)
 	GetCompanies(context.Context, string) ([]*models.Company, error)
+	TransferOwnership(context.Context, TransferOwnershipRequest) (*models.OwnershipTransfer, error)
+	CancelOwnershipTransfer(ctx context.Context, companyID string, transferID string, requestedBy string) error
+	GetOwnershipTransfers(ctx context.Context, userID string) ([]*models.OwnershipTransfer, error)
+	AcceptOwnershipTransfer(ctx context.Context, transferID string, requestedBy string) (*models.Company, error)
+	DeclineOwnershipTransfer(ctx context.Context, transferID string, requestedBy string) error
 }
 
 type CompanyManagementServiceImpl struct {
-	db *database.DBConnector
+	db     *database.DBConnector
+	mailer mailer.Mailer
 }
 
-func NewCompanyManagementService(db *database.DBConnector) CompanyManagementService {
+func NewCompanyManagementService(db *database.DBConnector, mailer mailer.Mailer) CompanyManagementService {
 	return &CompanyManagementServiceImpl{
-		db: db,
+		db:     db,
+		mailer: mailer,
 	}
 }
 
@@ -69,7 +80,13 @@ func (s *CompanyManagementServiceImpl) CreateCompany(ctx context.Context, req Cr
 		UpdatedAt:  time.Now(),
 	}
 
-	err = companyDao.Insert(ctx, s.db.Conn, boil.Infer())
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = companyDao.Insert(ctx, tx, boil.Infer())
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert company into database: %w", err)
 	}
@@ -78,21 +95,38 @@ func (s *CompanyManagementServiceImpl) CreateCompany(ctx context.Context, req Cr
 		ID:         uuid.NewString(),
 		UserID:     companyDao.ContactID,
 		CompanyID:  companyDao.ID,
-		Role:       string(models.AdminRole),
+		Role:       string(models.CompanyAdminRole),
 		ContractID: "",
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
 	}
 
-	err = permissionDao.Insert(ctx, s.db.Conn, boil.Infer())
+	err = permissionDao.Insert(ctx, tx, boil.Infer())
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert user permission, company added: %w", err)
 	}
 
-	return companyDaoToCompanyModel(companyDao), nil
+	company := companyDaoToCompanyModel(companyDao)
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  company.ID,
+		ActorID:    req.ContactID,
+		Action:     AuditActionCreate,
+		EntityType: AuditEntityCompany,
+		EntityID:   company.ID,
+		After:      company,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	return company, nil
 }
 
-func (s *CompanyManagementServiceImpl) DeleteCompany(ctx context.Context, id string) (*models.Company, error) {
+func (s *CompanyManagementServiceImpl) DeleteCompany(ctx context.Context, id string, deletedBy string) (*models.Company, error) {
 	companyDao, err := dao.FindCompany(ctx, s.db.Conn, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -102,13 +136,36 @@ func (s *CompanyManagementServiceImpl) DeleteCompany(ctx context.Context, id str
 	}
 	deletedAt := null.TimeFrom(time.Now())
 	companyDao.DeletedAt = deletedAt
+	companyDao.DeletedBy = deletedByUser(deletedBy)
+	companyDao.DeletionID = null.StringFrom(uuid.NewString())
 
 	company := companyDaoToCompanyModel(*companyDao)
 
-	_, err = companyDao.Update(ctx, s.db.Conn, boil.Infer())
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = companyDao.Update(ctx, tx, boil.Infer())
 	if err != nil {
 		return nil, fmt.Errorf("error deleteing company: %w", err)
 	}
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  company.ID,
+		ActorID:    deletedBy,
+		Action:     AuditActionDelete,
+		EntityType: AuditEntityCompany,
+		EntityID:   company.ID,
+		Before:     company,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
 
 	return company, nil
 }
@@ -122,6 +179,7 @@ func (s *CompanyManagementServiceImpl) UpdateCompany(ctx context.Context, id str
 		return nil, fmt.Errorf("error retrieving company: %w", err)
 	}
 
+	before := companyDaoToCompanyModel(*companyDao)
 	if req.Name != "" {
 		companyDao.Name = req.Name
 	}
@@ -131,21 +189,63 @@ func (s *CompanyManagementServiceImpl) UpdateCompany(ctx context.Context, id str
 	if req.LogoBase64 != "" {
 		companyDao.LogoBase64 = req.LogoBase64
 	}
+	if req.RequireTwoFactor != nil {
+		companyDao.RequireTwoFactor = *req.RequireTwoFactor
+	}
 	companyDao.UpdatedAt = time.Now()
 
-	_, err = companyDao.Update(ctx, s.db.Conn, boil.Infer())
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = companyDao.Update(ctx, tx, boil.Infer())
 	if err != nil {
 		return nil, fmt.Errorf("error updating company: %w", err)
 	}
 
+	company := companyDaoToCompanyModel(*companyDao)
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  company.ID,
+		Action:     AuditActionUpdate,
+		EntityType: AuditEntityCompany,
+		EntityID:   company.ID,
+		Before:     before,
+		After:      company,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	return company, nil
+}
+
+func (s *CompanyManagementServiceImpl) GetCompany(ctx context.Context, id string) (*models.Company, error) {
+	companyDao, err := dao.Companies(
+		dao.CompanyWhere.ID.EQ(id),
+		dao.CompanyWhere.DeletedAt.IsNull(),
+	).One(ctx, s.db.Conn)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("no company found with ID %s", id)
+		}
+		return nil, fmt.Errorf("error retrieving company: %w", err)
+	}
 	return companyDaoToCompanyModel(*companyDao), nil
 }
 
+// GetCompanies lists the companies the user holds a permission in effect for.
 func (s *CompanyManagementServiceImpl) GetCompanies(ctx context.Context, id string) ([]*models.Company, error) {
 	companiesDaos, err := dao.Companies(
 		qm.Where("companies.deleted_at IS NULL"),
 		qm.InnerJoin("permissions p on p.company_id = companies.id"),
 		qm.Where("p.user_id = ?", id),
+		permissionInEffect("p"),
 	).All(ctx, s.db.Conn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get Companies from database via join: %w", err)
@@ -161,13 +261,14 @@ func (s *CompanyManagementServiceImpl) GetCompanies(ctx context.Context, id stri
 
 func companyDaoToCompanyModel(companyDao dao.Company) *models.Company {
 	return &models.Company{
-		ID:         companyDao.ID,
-		Name:       companyDao.Name,
-		ContactID:  companyDao.ContactID,
-		Address:    companyDao.Address,
-		LogoBase64: companyDao.LogoBase64,
-		CreatedAt:  companyDao.CreatedAt,
-		UpdatedAt:  companyDao.UpdatedAt,
-		DeleteAt:   companyDao.DeletedAt.Time,
+		ID:               companyDao.ID,
+		Name:             companyDao.Name,
+		ContactID:        companyDao.ContactID,
+		Address:          companyDao.Address,
+		LogoBase64:       companyDao.LogoBase64,
+		RequireTwoFactor: companyDao.RequireTwoFactor,
+		CreatedAt:        companyDao.CreatedAt,
+		UpdatedAt:        companyDao.UpdatedAt,
+		DeleteAt:         companyDao.DeletedAt.Time,
 	}
 }
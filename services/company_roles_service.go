@@ -0,0 +1,329 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pro-posal/webserver/dao"
+	"github.com/pro-posal/webserver/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// CompanyRoleRequest creates a company role, or updates the one with RoleID.
+type CompanyRoleRequest struct {
+	CompanyID    string
+	RoleID       string
+	Name         string
+	Description  string
+	Capabilities []models.Capability
+	RequestedBy  string
+}
+
+var (
+	ErrCompanyRoleNotFound = errors.New("company role not found")
+	ErrCompanyRoleExists   = errors.New("the company already has a role with this name")
+	ErrCompanyRoleInUse    = errors.New("the role is still assigned, revoke its permissions first")
+	ErrInvalidCompanyRole  = errors.New("a company role needs a name and at least one known capability")
+)
+
+// CreateCompanyRole defines a role for the company. Callers can only put capabilities they hold
+// themselves into a role.
+func (s *PermissionManagementServiceImpl) CreateCompanyRole(ctx context.Context, req CompanyRoleRequest) (*models.CompanyRole, error) {
+	capabilities, err := s.checkCompanyRoleRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	roleDao := dao.CompanyRole{
+		ID:           uuid.NewString(),
+		CompanyID:    req.CompanyID,
+		Name:         strings.TrimSpace(req.Name),
+		Description:  null.NewString(req.Description, req.Description != ""),
+		Capabilities: capabilities,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := roleDao.Insert(ctx, tx, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("failed inserting company role to database: %w", err)
+	}
+
+	role := companyRoleDaoToModel(&roleDao)
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  req.CompanyID,
+		ActorID:    req.RequestedBy,
+		Action:     AuditActionCreate,
+		EntityType: AuditEntityCompanyRole,
+		EntityID:   role.ID,
+		After:      role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	log.Printf("User %v created role %v in company %v", req.RequestedBy, roleDao.ID, req.CompanyID)
+	return role, nil
+}
+
+func (s *PermissionManagementServiceImpl) GetCompanyRoles(ctx context.Context, companyID string) ([]*models.CompanyRole, error) {
+	rolesDao, err := dao.CompanyRoles(
+		dao.CompanyRoleWhere.CompanyID.EQ(companyID),
+		qm.OrderBy(dao.CompanyRoleColumns.Name),
+	).All(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching company roles: %w", err)
+	}
+
+	roles := make([]*models.CompanyRole, len(rolesDao))
+	for i, roleDao := range rolesDao {
+		roles[i] = companyRoleDaoToModel(roleDao)
+	}
+	return roles, nil
+}
+
+// UpdateCompanyRole replaces a role's name, description and capabilities. The change applies to
+// everyone holding the role right away.
+func (s *PermissionManagementServiceImpl) UpdateCompanyRole(ctx context.Context, req CompanyRoleRequest) (*models.CompanyRole, error) {
+	roleDao, err := findCompanyRole(ctx, s.db.Conn, req.CompanyID, req.RoleID)
+	if err != nil {
+		return nil, err
+	}
+	// Narrowing a role is as much a grant as widening it
+	if err := checkCanGrant(ctx, s.db.Conn, req.RequestedBy, req.CompanyID, companyRoleCapabilities(roleDao)); err != nil {
+		return nil, err
+	}
+	capabilities, err := s.checkCompanyRoleRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	before := companyRoleDaoToModel(roleDao)
+	roleDao.Name = strings.TrimSpace(req.Name)
+	roleDao.Description = null.NewString(req.Description, req.Description != "")
+	roleDao.Capabilities = capabilities
+	roleDao.UpdatedAt = time.Now().UTC()
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := roleDao.Update(ctx, tx, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("error updating company role: %w", err)
+	}
+
+	role := companyRoleDaoToModel(roleDao)
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  req.CompanyID,
+		ActorID:    req.RequestedBy,
+		Action:     AuditActionUpdate,
+		EntityType: AuditEntityCompanyRole,
+		EntityID:   role.ID,
+		Before:     before,
+		After:      role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	log.Printf("User %v updated role %v in company %v", req.RequestedBy, roleDao.ID, req.CompanyID)
+	return role, nil
+}
+
+// DeleteCompanyRole deletes a role nobody holds anymore.
+func (s *PermissionManagementServiceImpl) DeleteCompanyRole(ctx context.Context, companyID string, roleID string, requestedBy string) error {
+	roleDao, err := findCompanyRole(ctx, s.db.Conn, companyID, roleID)
+	if err != nil {
+		return err
+	}
+
+	inUse, err := dao.Permissions(dao.PermissionWhere.CompanyRoleID.EQ(null.StringFrom(roleDao.ID))).Exists(ctx, s.db.Conn)
+	if err != nil {
+		return fmt.Errorf("error checking role permissions: %w", err)
+	}
+	if inUse {
+		return ErrCompanyRoleInUse
+	}
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := roleDao.Delete(ctx, tx); err != nil {
+		return fmt.Errorf("error deleting company role: %w", err)
+	}
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  companyID,
+		ActorID:    requestedBy,
+		Action:     AuditActionDelete,
+		EntityType: AuditEntityCompanyRole,
+		EntityID:   roleDao.ID,
+		Before:     companyRoleDaoToModel(roleDao),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	log.Printf("User %v deleted role %v in company %v", requestedBy, roleDao.ID, companyID)
+	return nil
+}
+
+func (s *PermissionManagementServiceImpl) checkCompanyRoleRequest(ctx context.Context, req CompanyRoleRequest) (types.JSON, error) {
+	if strings.TrimSpace(req.Name) == "" || len(req.Capabilities) == 0 {
+		return nil, ErrInvalidCompanyRole
+	}
+	for _, capability := range req.Capabilities {
+		if !models.IsCapability(capability) {
+			return nil, fmt.Errorf("%w: unknown capability %q", ErrInvalidCompanyRole, capability)
+		}
+	}
+	if err := checkCanGrant(ctx, s.db.Conn, req.RequestedBy, req.CompanyID, req.Capabilities); err != nil {
+		return nil, err
+	}
+
+	query := []qm.QueryMod{
+		dao.CompanyRoleWhere.CompanyID.EQ(req.CompanyID),
+		dao.CompanyRoleWhere.Name.EQ(strings.TrimSpace(req.Name)),
+	}
+	if req.RoleID != "" {
+		query = append(query, dao.CompanyRoleWhere.ID.NEQ(req.RoleID))
+	}
+	exists, err := dao.CompanyRoles(query...).Exists(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("error checking company roles: %w", err)
+	}
+	if exists {
+		return nil, ErrCompanyRoleExists
+	}
+
+	capabilities := slices.Clone(req.Capabilities)
+	slices.Sort(capabilities)
+	encoded, err := json.Marshal(slices.Compact(capabilities))
+	if err != nil {
+		return nil, fmt.Errorf("failed marshaling capabilities: %w", err)
+	}
+	return types.JSON(encoded), nil
+}
+
+func findCompanyRole(ctx context.Context, exec boil.ContextExecutor, companyID string, roleID string) (*dao.CompanyRole, error) {
+	roleDao, err := dao.CompanyRoles(
+		dao.CompanyRoleWhere.ID.EQ(roleID),
+		dao.CompanyRoleWhere.CompanyID.EQ(companyID),
+	).One(ctx, exec)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCompanyRoleNotFound
+		}
+		return nil, fmt.Errorf("error fetching company role: %w", err)
+	}
+	return roleDao, nil
+}
+
+// userHasCompanyCapability reports whether one of the user's roles in the company grants the
+// capability, built-in or defined by the company. Platform admins have every capability, and the
+// two-factor, validity and API key restrictions of userHasCompanyRole apply.
+func userHasCompanyCapability(ctx context.Context, exec boil.ContextExecutor, userID string, companyID string, capability models.Capability) (bool, error) {
+	// Platform admins hold every capability, in every company
+	roles := []models.Role{models.AdminRole}
+	for role, capabilities := range models.RoleCapabilities {
+		if slices.Contains(capabilities, capability) {
+			roles = append(roles, role)
+		}
+	}
+	hasRole, err := userHasCompanyRole(ctx, exec, userID, companyID, roles...)
+	if err != nil || hasRole {
+		return hasRole, err
+	}
+
+	if grant := apiKeyGrantFromContext(ctx); grant != nil && grant.CompanyID != companyID {
+		return false, nil
+	}
+	exists, err := dao.Permissions(
+		qm.InnerJoin("company_roles cr ON cr.id = permissions.company_role_id"),
+		qm.Where("permissions.user_id = ? AND permissions.company_id = ? AND permissions.role = ?", userID, companyID, string(models.CompanyCustomRole)),
+		qm.Where("cr.capabilities @> jsonb_build_array(?::text)", string(capability)),
+		permissionInEffect("permissions"),
+	).Exists(ctx, exec)
+	if err != nil {
+		return false, fmt.Errorf("error checking user permissions: %w", err)
+	}
+	return exists, nil
+}
+
+// checkCanGrant keeps users from handing out more than they have: granting a role or putting
+// capabilities into one requires holding all of them. Answering offers is the exception, whoever
+// sends offers may let others answer them.
+func checkCanGrant(ctx context.Context, exec boil.ContextExecutor, userID string, companyID string, capabilities []models.Capability) error {
+	for _, capability := range capabilities {
+		if capability == models.CapabilityOffersRespond {
+			capability = models.CapabilityOffersCreate
+		}
+		allowed, err := userHasCompanyCapability(ctx, exec, userID, companyID, capability)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return &UnauthorizedError{}
+		}
+	}
+	return nil
+}
+
+// checkCanGrantRole is checkCanGrant for a built-in role.
+func checkCanGrantRole(ctx context.Context, exec boil.ContextExecutor, userID string, companyID string, role models.Role) error {
+	if role == models.AdminRole {
+		return &UnauthorizedError{}
+	}
+	return checkCanGrant(ctx, exec, userID, companyID, models.RoleCapabilities[role])
+}
+
+func companyRoleCapabilities(roleDao *dao.CompanyRole) []models.Capability {
+	var capabilities []models.Capability
+	if err := roleDao.Capabilities.Unmarshal(&capabilities); err != nil {
+		log.Printf("Failed unmarshaling capabilities of role %v: %v", roleDao.ID, err)
+	}
+	return capabilities
+}
+
+func companyRoleDaoToModel(roleDao *dao.CompanyRole) *models.CompanyRole {
+	return &models.CompanyRole{
+		ID:           roleDao.ID,
+		CompanyID:    roleDao.CompanyID,
+		Name:         roleDao.Name,
+		Description:  roleDao.Description.String,
+		Capabilities: companyRoleCapabilities(roleDao),
+		CreatedAt:    roleDao.CreatedAt,
+		UpdatedAt:    roleDao.UpdatedAt,
+	}
+}
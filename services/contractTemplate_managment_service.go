@@ -3,28 +3,70 @@ package services
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/pro-posal/webserver/dao"
 	"github.com/pro-posal/webserver/internal/database"
+	"github.com/pro-posal/webserver/internal/rendering"
 	"github.com/pro-posal/webserver/models"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
 )
 
 type CreateContractTemplateRequest struct {
+	Name          string
+	CompanyID     string
+	Template      string
+	Language      string
+	Direction     string
+	TranslationOf string
+	Schema        models.TemplateSchema
+}
+
+type UpdateContractsTemplatesRequest struct {
 	Name      string
-	CompanyID string
 	Template  string
+	Language  string
+	Direction string
+	Schema    *models.TemplateSchema
 }
 
-type UpdateContractsTemplatesRequest struct {
-	Name     string
-	Template string
+type RenderContractTemplateRequest struct {
+	Arguments map[string]any
+	Format    string
+}
+
+type CreateLegalClauseRequest struct {
+	CompanyID string
+	Name      string
+	Content   string
+	Mandatory bool
+}
+
+type UpdateLegalClauseRequest struct {
+	Name      string
+	Content   string
+	Mandatory bool
+}
+
+var ErrEmptyLegalClause = errors.New("legal clause content can't be empty")
+
+// TemplateLintError is returned when a contract template is rejected by the linter.
+type TemplateLintError struct {
+	Findings []models.LintFinding
+}
+
+func (e *TemplateLintError) Error() string {
+	return fmt.Sprintf("contract template failed linting with %d findings", len(e.Findings))
 }
 
 type ContractTemplateManagementService interface {
@@ -32,7 +74,13 @@ type ContractTemplateManagementService interface {
 	GetContractsTemplate(context.Context, string) (*models.ContractTemplate, error)
 	GetContractsTemplates(context.Context, string) ([]*models.ContractTemplate, error)
 	UpdateContractsTemplate(context.Context, string, UpdateContractsTemplatesRequest) (*models.ContractTemplate, error)
-	DeleteContractsTemplate(context.Context, string) (*models.ContractTemplate, error)
+	DeleteContractsTemplate(context.Context, string, string) (*models.ContractTemplate, error)
+	GetContractsTemplateTranslations(context.Context, string) ([]*models.ContractTemplate, error)
+	RenderContractsTemplate(context.Context, string, RenderContractTemplateRequest) (*models.RenderedContract, error)
+	CreateLegalClause(context.Context, CreateLegalClauseRequest) (*models.LegalClause, error)
+	GetLegalClauses(context.Context, string) ([]*models.LegalClause, error)
+	UpdateLegalClause(context.Context, string, string, UpdateLegalClauseRequest) (*models.LegalClause, error)
+	DeleteLegalClause(context.Context, string, string) (*models.LegalClause, error)
 }
 
 type ContractTemplateManagementServiceImpl struct {
@@ -59,35 +107,183 @@ func (s *ContractTemplateManagementServiceImpl) PostContractsTemplate(ctx contex
 		return nil, fmt.Errorf("contract template already exists")
 	}
 
+	language, direction, err := resolveLanguageAndDirection(req.Language, req.Direction)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := schemaToJSON(req.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	findings, err := s.lintTemplate(ctx, req.CompanyID, req.Template, req.Schema)
+	if err != nil {
+		return nil, err
+	}
+
 	contractDao := dao.ContractTemplate{
-		ID:        uuid.NewString(),
-		Name:      req.Name,
-		CompanyID: req.CompanyID,
-		Template:  req.Template,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:              uuid.NewString(),
+		Name:            req.Name,
+		CompanyID:       req.CompanyID,
+		Template:        req.Template,
+		Language:        language,
+		Direction:       string(direction),
+		ArgumentsSchema: schema,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	err = contractDao.Insert(ctx, s.db.Conn, boil.Infer())
+	if req.TranslationOf != "" {
+		groupID, err := joinTranslationGroup(ctx, tx, req.TranslationOf, req.CompanyID, language)
+		if err != nil {
+			return nil, err
+		}
+		contractDao.TranslationGroupID = null.StringFrom(groupID)
+	}
+
+	err = contractDao.Insert(ctx, tx, boil.Infer())
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert contract template into database: %w", err)
 	}
 
-	return contractDaoToContractModel(contractDao), nil
+	contract := contractDaoToContractModel(contractDao)
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  contract.CompanyID,
+		Action:     AuditActionCreate,
+		EntityType: AuditEntityContractTemplate,
+		EntityID:   contract.ID,
+		After:      contract,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+	contract.Findings = findings
+	return contract, nil
+}
+
+// lintTemplate runs the template linter against the company's legal clauses, failing with a
+// TemplateLintError when it reports errors and returning the remaining warnings otherwise.
+func (s *ContractTemplateManagementServiceImpl) lintTemplate(ctx context.Context, companyID string, template string, schema models.TemplateSchema) ([]models.LintFinding, error) {
+	clauses, err := s.GetLegalClauses(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	lintClauses := make([]models.LegalClause, 0, len(clauses))
+	for _, clause := range clauses {
+		lintClauses = append(lintClauses, *clause)
+	}
+
+	findings := rendering.Lint(template, rendering.LintOptions{
+		Schema:  schema,
+		Clauses: lintClauses,
+	})
+	if rendering.HasLintErrors(findings) {
+		return nil, &TemplateLintError{Findings: findings}
+	}
+	return findings, nil
+}
+
+// joinTranslationGroup links a new translation to the group of the source template,
+// starting a new group keyed by the source template's ID if it has none yet. The source stays
+// locked for the rest of the transaction so concurrent translations of it queue up.
+func joinTranslationGroup(ctx context.Context, exec boil.ContextExecutor, sourceID string, companyID string, language string) (string, error) {
+	source, err := dao.ContractTemplates(
+		qm.Where("id = ? AND company_id = ? AND deleted_at IS NULL", sourceID, companyID),
+		qm.For("UPDATE"),
+	).One(ctx, exec)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("no contract template found with ID %s", sourceID)
+		}
+		return "", fmt.Errorf("error retrieving source contract template: %w", err)
+	}
+
+	if !source.TranslationGroupID.Valid {
+		source.TranslationGroupID = null.StringFrom(source.ID)
+		source.UpdatedAt = time.Now()
+		_, err = source.Update(ctx, exec, boil.Whitelist("translation_group_id", "updated_at"))
+		if err != nil {
+			return "", fmt.Errorf("error starting translation group: %w", err)
+		}
+	}
+
+	existing, err := dao.ContractTemplates(
+		qm.Where("translation_group_id = ? AND language = ? AND deleted_at IS NULL", source.TranslationGroupID.String, language),
+	).Exists(ctx, exec)
+	if err != nil {
+		return "", fmt.Errorf("error checking existing translations: %w", err)
+	}
+	if existing {
+		return "", fmt.Errorf("a %s translation of this contract template already exists", language)
+	}
+
+	return source.TranslationGroupID.String, nil
+}
+
+func resolveLanguageAndDirection(language string, direction string) (string, models.TextDirection, error) {
+	language, err := rendering.NormalizeLanguage(language)
+	if err != nil {
+		return "", "", err
+	}
+
+	switch models.TextDirection(direction) {
+	case "":
+		return language, rendering.DirectionForLanguage(language), nil
+	case models.TextDirectionLTR, models.TextDirectionRTL:
+		return language, models.TextDirection(direction), nil
+	}
+	return "", "", fmt.Errorf("invalid text direction %q", direction)
+}
+
+func schemaToJSON(schema models.TemplateSchema) (types.JSON, error) {
+	if schema.Arguments == nil {
+		schema.Arguments = []models.TemplateArgument{}
+	}
+	schemaJSON, err := json.Marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed marshaling template schema: %w", err)
+	}
+	return types.JSON(schemaJSON), nil
+}
+
+func schemaFromJSON(schemaJSON types.JSON) models.TemplateSchema {
+	var schema models.TemplateSchema
+	if err := schemaJSON.Unmarshal(&schema); err != nil {
+		log.Printf("Failed unmarshaling template schema: %v", err)
+	}
+	return schema
 }
 
 func contractDaoToContractModel(contractDao dao.ContractTemplate) *models.ContractTemplate {
 	return &models.ContractTemplate{
-		ID:        contractDao.ID,
-		Name:      contractDao.Name,
-		CompanyID: contractDao.CompanyID,
-		Template:  contractDao.Template,
-		CreatedAt: contractDao.CreatedAt,
-		UpdatedAt: contractDao.UpdatedAt,
+		ID:                      contractDao.ID,
+		Name:                    contractDao.Name,
+		CompanyID:               contractDao.CompanyID,
+		Template:                contractDao.Template,
+		Language:                contractDao.Language,
+		Direction:               models.TextDirection(contractDao.Direction),
+		TranslationGroupID:      contractDao.TranslationGroupID.String,
+		Schema:                  schemaFromJSON(contractDao.ArgumentsSchema),
+		SourceGalleryTemplateID: contractDao.SourceGalleryTemplateID.String,
+		SourceVersion:           contractDao.SourceVersion.Int,
+		CreatedAt:               contractDao.CreatedAt,
+		UpdatedAt:               contractDao.UpdatedAt,
 	}
 }
 
-func (s *ContractTemplateManagementServiceImpl) DeleteContractsTemplate(ctx context.Context, id string) (*models.ContractTemplate, error) {
+func (s *ContractTemplateManagementServiceImpl) DeleteContractsTemplate(ctx context.Context, id string, deletedBy string) (*models.ContractTemplate, error) {
 	contractTemplateDoa, err := dao.FindContractTemplate(ctx, s.db.Conn, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -95,15 +291,39 @@ func (s *ContractTemplateManagementServiceImpl) DeleteContractsTemplate(ctx cont
 		}
 		return nil, fmt.Errorf("error retrieving contract template: %w", err)
 	}
+	before := contractDaoToContractModel(*contractTemplateDoa)
 	deletedAt := null.TimeFrom(time.Now())
 	contractTemplateDoa.DeletedAt = deletedAt
+	contractTemplateDoa.DeletedBy = deletedByUser(deletedBy)
+	contractTemplateDoa.DeletionID = null.StringFrom(uuid.NewString())
 
 	contract := contractDaoToContractModel(*contractTemplateDoa)
 
-	_, err = contractTemplateDoa.Update(ctx, s.db.Conn, boil.Infer())
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = contractTemplateDoa.Update(ctx, tx, boil.Infer())
 	if err != nil {
 		return nil, fmt.Errorf("error deleteing contract template: %w", err)
 	}
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  contract.CompanyID,
+		ActorID:    deletedBy,
+		Action:     AuditActionDelete,
+		EntityType: AuditEntityContractTemplate,
+		EntityID:   contract.ID,
+		Before:     before,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
 
 	return contract, nil
 }
@@ -117,16 +337,66 @@ func (s *ContractTemplateManagementServiceImpl) UpdateContractsTemplate(ctx cont
 		return nil, fmt.Errorf("error retrieving contract template: %w", err)
 	}
 
+	before := contractDaoToContractModel(*contractTemplateDoa)
 	contractTemplateDoa.Name = req.Name
 	contractTemplateDoa.Template = req.Template
+	if req.Language != "" || req.Direction != "" {
+		language := req.Language
+		if language == "" {
+			language = contractTemplateDoa.Language
+		}
+		language, direction, err := resolveLanguageAndDirection(language, req.Direction)
+		if err != nil {
+			return nil, err
+		}
+		contractTemplateDoa.Language = language
+		contractTemplateDoa.Direction = string(direction)
+	}
+	schema := schemaFromJSON(contractTemplateDoa.ArgumentsSchema)
+	if req.Schema != nil {
+		schema = *req.Schema
+		schemaJSON, err := schemaToJSON(schema)
+		if err != nil {
+			return nil, err
+		}
+		contractTemplateDoa.ArgumentsSchema = schemaJSON
+	}
+
+	findings, err := s.lintTemplate(ctx, contractTemplateDoa.CompanyID, contractTemplateDoa.Template, schema)
+	if err != nil {
+		return nil, err
+	}
 	contractTemplateDoa.UpdatedAt = time.Now()
 
-	_, err = contractTemplateDoa.Update(ctx, s.db.Conn, boil.Infer())
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = contractTemplateDoa.Update(ctx, tx, boil.Infer())
 	if err != nil {
 		return nil, fmt.Errorf("error updating contract template: %w", err)
 	}
 
-	return contractDaoToContractModel(*contractTemplateDoa), nil
+	contract := contractDaoToContractModel(*contractTemplateDoa)
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  contract.CompanyID,
+		Action:     AuditActionUpdate,
+		EntityType: AuditEntityContractTemplate,
+		EntityID:   contract.ID,
+		Before:     before,
+		After:      contract,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+	contract.Findings = findings
+	return contract, nil
 }
 
 func (s *ContractTemplateManagementServiceImpl) GetContractsTemplate(ctx context.Context, id string) (*models.ContractTemplate, error) {
@@ -156,3 +426,294 @@ func (s *ContractTemplateManagementServiceImpl) GetContractsTemplates(ctx contex
 
 	return contractTemplateModels, nil
 }
+
+func (s *ContractTemplateManagementServiceImpl) GetContractsTemplateTranslations(ctx context.Context, id string) ([]*models.ContractTemplate, error) {
+	contractTemplateDoa, err := dao.FindContractTemplate(ctx, s.db.Conn, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("no contract template found with ID %s", id)
+		}
+		return nil, fmt.Errorf("error retrieving contract template: %w", err)
+	}
+
+	if !contractTemplateDoa.TranslationGroupID.Valid {
+		return []*models.ContractTemplate{contractDaoToContractModel(*contractTemplateDoa)}, nil
+	}
+
+	contractTemplates, err := dao.ContractTemplates(
+		qm.Where("translation_group_id = ? AND deleted_at IS NULL", contractTemplateDoa.TranslationGroupID.String),
+		qm.OrderBy("language"),
+	).All(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving contract template translations: %w", err)
+	}
+
+	var contractTemplateModels []*models.ContractTemplate
+	for _, contractTemplate := range contractTemplates {
+		contractTemplateModels = append(contractTemplateModels, contractDaoToContractModel(*contractTemplate))
+	}
+
+	return contractTemplateModels, nil
+}
+
+func (s *ContractTemplateManagementServiceImpl) RenderContractsTemplate(ctx context.Context, id string, req RenderContractTemplateRequest) (*models.RenderedContract, error) {
+	contractTemplateDoa, err := dao.FindContractTemplate(ctx, s.db.Conn, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("no contract template found with ID %s", id)
+		}
+		return nil, fmt.Errorf("error retrieving contract template: %w", err)
+	}
+
+	format := rendering.Format(req.Format)
+	if format == "" {
+		format = rendering.FormatHTML
+	}
+	if !format.IsValid() {
+		return nil, fmt.Errorf("unsupported render format %q", req.Format)
+	}
+
+	if _, ok := req.Arguments[lineItemsArgument]; ok {
+		order, err := catalogOrder(ctx, s.db.Conn, contractTemplateDoa.CompanyID)
+		if err != nil {
+			return nil, err
+		}
+		sortLineItemsByCatalog(req.Arguments, order)
+	}
+
+	clauses, err := s.GetLegalClauses(ctx, contractTemplateDoa.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	clauseContents := map[string]string{}
+	for _, clause := range clauses {
+		clauseContents[clause.Name] = clause.Content
+	}
+
+	content, err := rendering.Render(contractTemplateDoa.Template, req.Arguments, rendering.Options{
+		Language:  contractTemplateDoa.Language,
+		Direction: models.TextDirection(contractTemplateDoa.Direction),
+		Format:    format,
+		Title:     contractTemplateDoa.Name,
+		Clauses:   clauseContents,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed rendering contract template: %w", err)
+	}
+
+	return &models.RenderedContract{
+		ContractTemplateID: contractTemplateDoa.ID,
+		Language:           contractTemplateDoa.Language,
+		Direction:          models.TextDirection(contractTemplateDoa.Direction),
+		ContentType:        format.ContentType(),
+		Content:            content,
+	}, nil
+}
+
+// lineItemsArgument is the render argument holding the offer's line items, each an object
+// that references its catalog entry through "category_id".
+const lineItemsArgument = "line_items"
+
+// sortLineItemsByCatalog orders the line items the way they appear in the company's catalog;
+// items that are not in the catalog keep their relative order at the end.
+func sortLineItemsByCatalog(arguments map[string]any, order map[string]int) {
+	items, ok := arguments[lineItemsArgument].([]any)
+	if !ok {
+		return
+	}
+
+	rank := func(item any) int {
+		if fields, ok := item.(map[string]any); ok {
+			if id, ok := fields["category_id"].(string); ok {
+				if position, ok := order[id]; ok {
+					return position
+				}
+			}
+		}
+		return len(order)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return rank(items[i]) < rank(items[j])
+	})
+}
+
+func (s *ContractTemplateManagementServiceImpl) CreateLegalClause(ctx context.Context, req CreateLegalClauseRequest) (*models.LegalClause, error) {
+	if strings.TrimSpace(req.Content) == "" {
+		return nil, ErrEmptyLegalClause
+	}
+
+	exists, err := dao.CompanyLegalClauses(
+		qm.Where("name = ? AND company_id = ? AND deleted_at IS NULL", req.Name, req.CompanyID),
+	).Exists(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if legal clause exists: %w", err)
+	}
+	if exists {
+		return nil, fmt.Errorf("legal clause already exists")
+	}
+
+	clauseDao := dao.CompanyLegalClause{
+		ID:        uuid.NewString(),
+		CompanyID: req.CompanyID,
+		Name:      req.Name,
+		Content:   req.Content,
+		Mandatory: req.Mandatory,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = clauseDao.Insert(ctx, tx, boil.Infer())
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert legal clause into database: %w", err)
+	}
+
+	clause := legalClauseDaoToLegalClauseModel(clauseDao)
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  clause.CompanyID,
+		Action:     AuditActionCreate,
+		EntityType: AuditEntityLegalClause,
+		EntityID:   clause.ID,
+		After:      clause,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	return clause, nil
+}
+
+func (s *ContractTemplateManagementServiceImpl) GetLegalClauses(ctx context.Context, companyID string) ([]*models.LegalClause, error) {
+	clauseDaos, err := dao.CompanyLegalClauses(
+		qm.Where("company_id = ? AND deleted_at IS NULL", companyID),
+		qm.OrderBy("name"),
+	).All(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving legal clauses: %w", err)
+	}
+
+	var clauses []*models.LegalClause
+	for _, clauseDao := range clauseDaos {
+		clauses = append(clauses, legalClauseDaoToLegalClauseModel(*clauseDao))
+	}
+
+	return clauses, nil
+}
+
+func (s *ContractTemplateManagementServiceImpl) UpdateLegalClause(ctx context.Context, companyID string, id string, req UpdateLegalClauseRequest) (*models.LegalClause, error) {
+	if strings.TrimSpace(req.Content) == "" {
+		return nil, ErrEmptyLegalClause
+	}
+
+	clauseDao, err := s.findLegalClause(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := legalClauseDaoToLegalClauseModel(*clauseDao)
+	clauseDao.Name = req.Name
+	clauseDao.Content = req.Content
+	clauseDao.Mandatory = req.Mandatory
+	clauseDao.UpdatedAt = time.Now()
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = clauseDao.Update(ctx, tx, boil.Infer())
+	if err != nil {
+		return nil, fmt.Errorf("error updating legal clause: %w", err)
+	}
+
+	clause := legalClauseDaoToLegalClauseModel(*clauseDao)
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  companyID,
+		Action:     AuditActionUpdate,
+		EntityType: AuditEntityLegalClause,
+		EntityID:   clause.ID,
+		Before:     before,
+		After:      clause,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	return clause, nil
+}
+
+func (s *ContractTemplateManagementServiceImpl) DeleteLegalClause(ctx context.Context, companyID string, id string) (*models.LegalClause, error) {
+	clauseDao, err := s.findLegalClause(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := legalClauseDaoToLegalClauseModel(*clauseDao)
+	clauseDao.DeletedAt = null.TimeFrom(time.Now())
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = clauseDao.Update(ctx, tx, boil.Infer())
+	if err != nil {
+		return nil, fmt.Errorf("error deleting legal clause: %w", err)
+	}
+
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  companyID,
+		Action:     AuditActionDelete,
+		EntityType: AuditEntityLegalClause,
+		EntityID:   clauseDao.ID,
+		Before:     before,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	return legalClauseDaoToLegalClauseModel(*clauseDao), nil
+}
+
+func (s *ContractTemplateManagementServiceImpl) findLegalClause(ctx context.Context, companyID string, id string) (*dao.CompanyLegalClause, error) {
+	clauseDao, err := dao.CompanyLegalClauses(
+		qm.Where("id = ? AND company_id = ? AND deleted_at IS NULL", id, companyID),
+	).One(ctx, s.db.Conn)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("no legal clause found with ID %s", id)
+		}
+		return nil, fmt.Errorf("error retrieving legal clause: %w", err)
+	}
+	return clauseDao, nil
+}
+
+func legalClauseDaoToLegalClauseModel(clauseDao dao.CompanyLegalClause) *models.LegalClause {
+	return &models.LegalClause{
+		ID:        clauseDao.ID,
+		CompanyID: clauseDao.CompanyID,
+		Name:      clauseDao.Name,
+		Content:   clauseDao.Content,
+		Mandatory: clauseDao.Mandatory,
+		CreatedAt: clauseDao.CreatedAt,
+		UpdatedAt: clauseDao.UpdatedAt,
+	}
+}
@@ -0,0 +1,445 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pro-posal/webserver/dao"
+	"github.com/pro-posal/webserver/internal/database"
+	"github.com/pro-posal/webserver/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+type PublishGalleryTemplateRequest struct {
+	Name        string
+	Description string
+	Template    string
+	Language    string
+	Direction   string
+	Schema      models.TemplateSchema
+	Categories  []models.GalleryCategory
+	PublishedBy string
+}
+
+type AdoptGalleryTemplateRequest struct {
+	GalleryTemplateID string
+	CompanyID         string
+	Name              string
+	AdoptedBy         string
+}
+
+type GalleryManagementService interface {
+	PublishGalleryTemplate(context.Context, PublishGalleryTemplateRequest) (*models.GalleryTemplate, error)
+	UpdateGalleryTemplate(context.Context, string, PublishGalleryTemplateRequest) (*models.GalleryTemplate, error)
+	DeleteGalleryTemplate(context.Context, string, string) (*models.GalleryTemplate, error)
+	GetGalleryTemplate(context.Context, string) (*models.GalleryTemplate, error)
+	GetGalleryTemplates(context.Context) ([]*models.GalleryTemplate, error)
+	AdoptGalleryTemplate(context.Context, AdoptGalleryTemplateRequest) (*models.ContractTemplate, error)
+	GetOutdatedContractsTemplates(context.Context, string) ([]*models.ContractTemplate, error)
+}
+
+type GalleryManagementServiceImpl struct {
+	db *database.DBConnector
+}
+
+func NewGalleryManagementService(db *database.DBConnector) GalleryManagementService {
+	return &GalleryManagementServiceImpl{
+		db: db,
+	}
+}
+
+func (s *GalleryManagementServiceImpl) PublishGalleryTemplate(ctx context.Context, req PublishGalleryTemplateRequest) (*models.GalleryTemplate, error) {
+	isAdmin, err := userHasRole(ctx, s.db.Conn, req.PublishedBy, models.AdminRole)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, &UnauthorizedError{}
+	}
+
+	galleryDao := dao.GalleryTemplate{
+		ID:          uuid.NewString(),
+		Version:     1,
+		PublishedBy: req.PublishedBy,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	if err := applyGalleryTemplateRequest(&galleryDao, req); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = galleryDao.Insert(ctx, tx, boil.Infer())
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert gallery template into database: %w", err)
+	}
+
+	galleryTemplate := galleryDaoToGalleryModel(galleryDao)
+	// The gallery belongs to no company, its changes are audited in the companies of the admin
+	err = recordUserAudit(ctx, tx, req.PublishedBy, auditEntry{
+		ActorID:    req.PublishedBy,
+		Action:     AuditActionCreate,
+		EntityType: AuditEntityGalleryTemplate,
+		EntityID:   galleryTemplate.ID,
+		After:      galleryTemplate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+	return galleryTemplate, nil
+}
+
+func (s *GalleryManagementServiceImpl) UpdateGalleryTemplate(ctx context.Context, id string, req PublishGalleryTemplateRequest) (*models.GalleryTemplate, error) {
+	isAdmin, err := userHasRole(ctx, s.db.Conn, req.PublishedBy, models.AdminRole)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, &UnauthorizedError{}
+	}
+
+	galleryDao, err := s.findGalleryTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := galleryDaoToGalleryModel(*galleryDao)
+	if err := applyGalleryTemplateRequest(galleryDao, req); err != nil {
+		return nil, err
+	}
+	// Bumping the version is what lets adopting companies know the original changed
+	galleryDao.Version++
+	galleryDao.UpdatedAt = time.Now()
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = galleryDao.Update(ctx, tx, boil.Infer())
+	if err != nil {
+		return nil, fmt.Errorf("error updating gallery template: %w", err)
+	}
+
+	galleryTemplate := galleryDaoToGalleryModel(*galleryDao)
+	err = recordUserAudit(ctx, tx, req.PublishedBy, auditEntry{
+		ActorID:    req.PublishedBy,
+		Action:     AuditActionUpdate,
+		EntityType: AuditEntityGalleryTemplate,
+		EntityID:   galleryTemplate.ID,
+		Before:     before,
+		After:      galleryTemplate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+	return galleryTemplate, nil
+}
+
+func (s *GalleryManagementServiceImpl) DeleteGalleryTemplate(ctx context.Context, id string, deletedBy string) (*models.GalleryTemplate, error) {
+	isAdmin, err := userHasRole(ctx, s.db.Conn, deletedBy, models.AdminRole)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, &UnauthorizedError{}
+	}
+
+	galleryDao, err := s.findGalleryTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := galleryDaoToGalleryModel(*galleryDao)
+	galleryDao.DeletedAt = null.TimeFrom(time.Now())
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = galleryDao.Update(ctx, tx, boil.Infer())
+	if err != nil {
+		return nil, fmt.Errorf("error deleting gallery template: %w", err)
+	}
+	err = recordUserAudit(ctx, tx, deletedBy, auditEntry{
+		ActorID:    deletedBy,
+		Action:     AuditActionDelete,
+		EntityType: AuditEntityGalleryTemplate,
+		EntityID:   galleryDao.ID,
+		Before:     before,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+	return galleryDaoToGalleryModel(*galleryDao), nil
+}
+
+func (s *GalleryManagementServiceImpl) GetGalleryTemplate(ctx context.Context, id string) (*models.GalleryTemplate, error) {
+	galleryDao, err := s.findGalleryTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return galleryDaoToGalleryModel(*galleryDao), nil
+}
+
+func (s *GalleryManagementServiceImpl) GetGalleryTemplates(ctx context.Context) ([]*models.GalleryTemplate, error) {
+	galleryDaos, err := dao.GalleryTemplates(
+		qm.Where("deleted_at IS NULL"),
+		qm.OrderBy("name"),
+	).All(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving gallery templates: %w", err)
+	}
+
+	var galleryTemplates []*models.GalleryTemplate
+	for _, galleryDao := range galleryDaos {
+		galleryTemplates = append(galleryTemplates, galleryDaoToGalleryModel(*galleryDao))
+	}
+
+	return galleryTemplates, nil
+}
+
+func (s *GalleryManagementServiceImpl) AdoptGalleryTemplate(ctx context.Context, req AdoptGalleryTemplateRequest) (*models.ContractTemplate, error) {
+	isCompanyAdmin, err := userHasCompanyCapability(ctx, s.db.Conn, req.AdoptedBy, req.CompanyID, models.CapabilityTemplatesPublish)
+	if err != nil {
+		return nil, err
+	}
+	if !isCompanyAdmin {
+		return nil, &UnauthorizedError{}
+	}
+
+	galleryDao, err := s.findGalleryTemplate(ctx, req.GalleryTemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := req.Name
+	if name == "" {
+		name = galleryDao.Name
+	}
+
+	exists, err := dao.ContractTemplates(
+		qm.Where("name = ? AND company_id = ? AND deleted_at IS NULL", name, req.CompanyID),
+	).Exists(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if contract template exists: %w", err)
+	}
+	if exists {
+		return nil, fmt.Errorf("contract template already exists")
+	}
+
+	var categories []models.GalleryCategory
+	if err := galleryDao.Categories.Unmarshal(&categories); err != nil {
+		return nil, fmt.Errorf("failed unmarshaling gallery template categories: %w", err)
+	}
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	contractDao := dao.ContractTemplate{
+		ID:                      uuid.NewString(),
+		Name:                    name,
+		CompanyID:               req.CompanyID,
+		Template:                galleryDao.Template,
+		Language:                galleryDao.Language,
+		Direction:               galleryDao.Direction,
+		ArgumentsSchema:         galleryDao.ArgumentsSchema,
+		SourceGalleryTemplateID: null.StringFrom(galleryDao.ID),
+		SourceVersion:           null.IntFrom(galleryDao.Version),
+		CreatedAt:               time.Now(),
+		UpdatedAt:               time.Now(),
+	}
+
+	err = contractDao.Insert(ctx, tx, boil.Infer())
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert contract template into database: %w", err)
+	}
+
+	err = copyGalleryCategories(ctx, tx, req.CompanyID, null.String{}, categories)
+	if err != nil {
+		return nil, err
+	}
+
+	contract := contractDaoToContractModel(contractDao)
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  req.CompanyID,
+		ActorID:    req.AdoptedBy,
+		Action:     AuditActionCreate,
+		EntityType: AuditEntityContractTemplate,
+		EntityID:   contract.ID,
+		After:      contract,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	return contract, nil
+}
+
+func (s *GalleryManagementServiceImpl) GetOutdatedContractsTemplates(ctx context.Context, companyID string) ([]*models.ContractTemplate, error) {
+	contractTemplates, err := dao.ContractTemplates(
+		qm.InnerJoin("gallery_templates g on g.id = contract_templates.source_gallery_template_id"),
+		qm.Where("contract_templates.company_id = ?", companyID),
+		qm.Where("contract_templates.deleted_at IS NULL"),
+		qm.Where("g.deleted_at IS NULL AND g.version > contract_templates.source_version"),
+	).All(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving outdated contract templates: %w", err)
+	}
+
+	var contractTemplateModels []*models.ContractTemplate
+	for _, contractTemplate := range contractTemplates {
+		contract := contractDaoToContractModel(*contractTemplate)
+		contract.UpdateAvailable = true
+		contractTemplateModels = append(contractTemplateModels, contract)
+	}
+
+	return contractTemplateModels, nil
+}
+
+func (s *GalleryManagementServiceImpl) findGalleryTemplate(ctx context.Context, id string) (*dao.GalleryTemplate, error) {
+	galleryDao, err := dao.GalleryTemplates(
+		qm.Where("id = ? AND deleted_at IS NULL", id),
+	).One(ctx, s.db.Conn)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("no gallery template found with ID %s", id)
+		}
+		return nil, fmt.Errorf("error retrieving gallery template: %w", err)
+	}
+	return galleryDao, nil
+}
+
+// copyGalleryCategories inserts the gallery catalog under the given parent, reusing
+// categories the company already has with the same description and type.
+func copyGalleryCategories(ctx context.Context, exec boil.ContextExecutor, companyID string, parentID null.String, categories []models.GalleryCategory) error {
+	for _, category := range categories {
+		query := []qm.QueryMod{
+			qm.Where("company_id = ? AND description = ? AND type = ? AND deleted_at IS NULL", companyID, category.Description, string(category.Type)),
+		}
+		if parentID.Valid {
+			query = append(query, qm.Where("category_id = ?", parentID.String))
+		} else {
+			query = append(query, qm.Where("category_id IS NULL"))
+		}
+
+		categoryDao, err := dao.Categories(query...).One(ctx, exec)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("error checking if category exists: %w", err)
+		}
+
+		if categoryDao == nil {
+			position, err := nextCategoryPosition(ctx, exec, companyID, parentID, "")
+			if err != nil {
+				return err
+			}
+			categoryDao = &dao.Category{
+				ID:          uuid.NewString(),
+				CompanyID:   companyID,
+				CategoryID:  parentID,
+				Description: category.Description,
+				Type:        string(category.Type),
+				Position:    position,
+				CreatedAt:   time.Now(),
+				UpdatedAt:   time.Now(),
+			}
+			if err := categoryDao.Insert(ctx, exec, boil.Infer()); err != nil {
+				return fmt.Errorf("failed to insert category into database: %w", err)
+			}
+		}
+
+		err = copyGalleryCategories(ctx, exec, companyID, null.StringFrom(categoryDao.ID), category.Children)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyGalleryTemplateRequest(galleryDao *dao.GalleryTemplate, req PublishGalleryTemplateRequest) error {
+	language, direction, err := resolveLanguageAndDirection(req.Language, req.Direction)
+	if err != nil {
+		return err
+	}
+
+	schema, err := schemaToJSON(req.Schema)
+	if err != nil {
+		return err
+	}
+
+	if req.Categories == nil {
+		req.Categories = []models.GalleryCategory{}
+	}
+	categories, err := json.Marshal(req.Categories)
+	if err != nil {
+		return fmt.Errorf("failed marshaling gallery categories: %w", err)
+	}
+
+	galleryDao.Name = req.Name
+	galleryDao.Description = req.Description
+	galleryDao.Template = req.Template
+	galleryDao.Language = language
+	galleryDao.Direction = string(direction)
+	galleryDao.ArgumentsSchema = schema
+	galleryDao.Categories = types.JSON(categories)
+	return nil
+}
+
+func galleryDaoToGalleryModel(galleryDao dao.GalleryTemplate) *models.GalleryTemplate {
+	var categories []models.GalleryCategory
+	if err := galleryDao.Categories.Unmarshal(&categories); err != nil {
+		log.Printf("Failed unmarshaling gallery categories: %v", err)
+	}
+
+	return &models.GalleryTemplate{
+		ID:          galleryDao.ID,
+		Name:        galleryDao.Name,
+		Description: galleryDao.Description,
+		Template:    galleryDao.Template,
+		Language:    galleryDao.Language,
+		Direction:   models.TextDirection(galleryDao.Direction),
+		Schema:      schemaFromJSON(galleryDao.ArgumentsSchema),
+		Categories:  categories,
+		Version:     galleryDao.Version,
+		PublishedBy: galleryDao.PublishedBy,
+		CreatedAt:   galleryDao.CreatedAt,
+		UpdatedAt:   galleryDao.UpdatedAt,
+	}
+}
@@ -0,0 +1,499 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pro-posal/webserver/config"
+	"github.com/pro-posal/webserver/dao"
+	"github.com/pro-posal/webserver/internal/mailer"
+	"github.com/pro-posal/webserver/internal/utils"
+	"github.com/pro-posal/webserver/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+type InviteUserRequest struct {
+	CompanyID   string
+	Email       string
+	Role        models.Role
+	RequestedBy string
+}
+
+// AcceptInvitationRequest signs the invitee up with the given details, or, when the address
+// already has an account, only needs that account's password.
+type AcceptInvitationRequest struct {
+	Token     string
+	FirstName string
+	LastName  string
+	Phone     string
+	Password  string
+}
+
+var (
+	ErrInvitationNotFound     = errors.New("invitation not found")
+	ErrInvalidInvitationToken = errors.New("invalid or expired invitation")
+	ErrInvalidInvitationRole  = errors.New("invitations can only grant company admin, contributor, project manager or prospect roles")
+	ErrInvitationPending      = errors.New("this address already has a pending invitation, resend it instead")
+	ErrAlreadyCompanyMember   = errors.New("this user already has a role in the company")
+	ErrInvitationPassword     = errors.New("this address already has an account, sign in with its password to accept")
+)
+
+// InvitationRateLimitedError is returned when an invitation is resent too soon after it was last sent.
+type InvitationRateLimitedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *InvitationRateLimitedError) Error() string {
+	return fmt.Sprintf("the invitation was sent recently, retry after %v", e.RetryAfter)
+}
+
+var invitableRoles = []models.Role{
+	models.CompanyAdminRole,
+	models.CompanyContributorRole,
+	models.CompanyProjectManagerRole,
+	models.ProspectRole,
+}
+
+// InviteUser emails an invitation to join the company with the given role.
+func (s *userManagementServiceImpl) InviteUser(ctx context.Context, req InviteUserRequest) (*models.Invitation, error) {
+	if err := s.authorizeInvitations(ctx, req.CompanyID, req.RequestedBy); err != nil {
+		return nil, err
+	}
+
+	validRole := false
+	for _, role := range invitableRoles {
+		validRole = validRole || role == req.Role
+	}
+	if !validRole {
+		return nil, ErrInvalidInvitationRole
+	}
+	if err := checkCanGrantRole(ctx, s.db.Conn, req.RequestedBy, req.CompanyID, req.Role); err != nil {
+		return nil, err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if !strings.Contains(email, "@") {
+		return nil, fmt.Errorf("invalid email address %s", req.Email)
+	}
+	emailHash := utils.HashEmail(email)
+
+	// Someone whose grants expired can be invited back
+	member, err := dao.Permissions(
+		qm.InnerJoin("users u ON u.id = permissions.user_id"),
+		qm.Where("u.email_hash = ? AND u.deleted_at IS NULL", emailHash),
+		dao.PermissionWhere.CompanyID.EQ(req.CompanyID),
+		permissionInEffect("permissions"),
+	).Exists(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed checking company members: %w", err)
+	}
+	if member {
+		return nil, ErrAlreadyCompanyMember
+	}
+
+	pending, err := dao.Invitations(
+		dao.InvitationWhere.CompanyID.EQ(req.CompanyID),
+		dao.InvitationWhere.EmailHash.EQ(emailHash),
+		dao.InvitationWhere.AcceptedAt.IsNull(),
+		dao.InvitationWhere.RevokedAt.IsNull(),
+	).Exists(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed checking pending invitations: %w", err)
+	}
+	if pending {
+		return nil, ErrInvitationPending
+	}
+
+	now := time.Now().UTC()
+	invitationDao := dao.Invitation{
+		ID:        uuid.NewString(),
+		CompanyID: req.CompanyID,
+		Email:     email,
+		EmailHash: emailHash,
+		Role:      string(req.Role),
+		InvitedBy: req.RequestedBy,
+		CreatedAt: now,
+	}
+	token, err := renewInvitationToken(&invitationDao, now)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := invitationDao.Insert(ctx, tx, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("failed inserting invitation to database: %w", err)
+	}
+	invitation := invitationDaoToModel(&invitationDao)
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  req.CompanyID,
+		ActorID:    req.RequestedBy,
+		Action:     AuditActionCreate,
+		EntityType: AuditEntityInvitation,
+		EntityID:   invitation.ID,
+		After:      invitation,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	// The invitation exists either way; if the email can't be sent it can be resent
+	if err := s.sendInvitationEmail(ctx, &invitationDao, token); err != nil {
+		log.Printf("Failed sending invitation %v: %v", invitationDao.ID, err)
+	}
+
+	log.Printf("User %v invited a new %v to company %v", req.RequestedBy, req.Role, req.CompanyID)
+	return invitation, nil
+}
+
+// GetInvitations lists the company's invitations that were neither accepted nor revoked, expired ones included.
+func (s *userManagementServiceImpl) GetInvitations(ctx context.Context, companyID string, requestedBy string) ([]*models.Invitation, error) {
+	if err := s.authorizeInvitations(ctx, companyID, requestedBy); err != nil {
+		return nil, err
+	}
+
+	invitationsDao, err := dao.Invitations(
+		dao.InvitationWhere.CompanyID.EQ(companyID),
+		dao.InvitationWhere.AcceptedAt.IsNull(),
+		dao.InvitationWhere.RevokedAt.IsNull(),
+		qm.OrderBy(dao.InvitationColumns.CreatedAt+" DESC"),
+	).All(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching invitations from database: %w", err)
+	}
+
+	invitations := make([]*models.Invitation, len(invitationsDao))
+	for i, invitationDao := range invitationsDao {
+		invitations[i] = invitationDaoToModel(invitationDao)
+	}
+	return invitations, nil
+}
+
+// ResendInvitation emails a new link, which replaces the previous one and starts the expiration over.
+func (s *userManagementServiceImpl) ResendInvitation(ctx context.Context, companyID string, invitationID string, requestedBy string) (*models.Invitation, error) {
+	if err := s.authorizeInvitations(ctx, companyID, requestedBy); err != nil {
+		return nil, err
+	}
+
+	invitationDao, err := s.findPendingInvitation(ctx, companyID, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := time.Duration(config.AppConfig.Auth.InvitationResendIntervalMinutes) * time.Minute
+	if wait := time.Until(invitationDao.SentAt.Add(interval)); wait > 0 {
+		return nil, &InvitationRateLimitedError{RetryAfter: wait}
+	}
+
+	before := invitationDaoToModel(invitationDao)
+	token, err := renewInvitationToken(invitationDao, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = invitationDao.Update(ctx, tx, boil.Whitelist(
+		dao.InvitationColumns.TokenHash,
+		dao.InvitationColumns.ExpiresAt,
+		dao.InvitationColumns.SentAt,
+		dao.InvitationColumns.UpdatedAt,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed updating invitation: %w", err)
+	}
+	invitation := invitationDaoToModel(invitationDao)
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  companyID,
+		ActorID:    requestedBy,
+		Action:     AuditActionUpdate,
+		EntityType: AuditEntityInvitation,
+		EntityID:   invitation.ID,
+		Before:     before,
+		After:      invitation,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	if err := s.sendInvitationEmail(ctx, invitationDao, token); err != nil {
+		return nil, fmt.Errorf("failed sending invitation: %w", err)
+	}
+	return invitation, nil
+}
+
+func (s *userManagementServiceImpl) RevokeInvitation(ctx context.Context, companyID string, invitationID string, requestedBy string) error {
+	if err := s.authorizeInvitations(ctx, companyID, requestedBy); err != nil {
+		return err
+	}
+
+	invitationDao, err := s.findPendingInvitation(ctx, companyID, invitationID)
+	if err != nil {
+		return err
+	}
+
+	invitationDao.RevokedAt = null.TimeFrom(time.Now().UTC())
+	invitationDao.UpdatedAt = time.Now().UTC()
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = invitationDao.Update(ctx, tx, boil.Whitelist(dao.InvitationColumns.RevokedAt, dao.InvitationColumns.UpdatedAt))
+	if err != nil {
+		return fmt.Errorf("failed revoking invitation: %w", err)
+	}
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  companyID,
+		ActorID:    requestedBy,
+		Action:     AuditActionRevoke,
+		EntityType: AuditEntityInvitation,
+		EntityID:   invitationDao.ID,
+		Before:     invitationDaoToModel(invitationDao),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	log.Printf("User %v revoked invitation %v of company %v", requestedBy, invitationID, companyID)
+	return nil
+}
+
+// AcceptInvitation grants the invited role, creating the user when the address has no account yet.
+// New users are invited_by the inviter, and their email counts as verified since they received the link.
+func (s *userManagementServiceImpl) AcceptInvitation(ctx context.Context, req AcceptInvitationRequest) (*models.User, error) {
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	invitationDao, err := dao.Invitations(
+		dao.InvitationWhere.TokenHash.EQ(utils.HashToken(req.Token)),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidInvitationToken
+		}
+		return nil, fmt.Errorf("failed fetching invitation from database: %w", err)
+	}
+	if invitationDao.AcceptedAt.Valid || invitationDao.RevokedAt.Valid || time.Now().After(invitationDao.ExpiresAt) {
+		return nil, ErrInvalidInvitationToken
+	}
+
+	companyExists, err := dao.Companies(
+		dao.CompanyWhere.ID.EQ(invitationDao.CompanyID),
+		dao.CompanyWhere.DeletedAt.IsNull(),
+	).Exists(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching company from database: %w", err)
+	}
+	if !companyExists {
+		return nil, ErrInvalidInvitationToken
+	}
+
+	now := time.Now().UTC()
+	userDao, err := dao.Users(
+		dao.UserWhere.EmailHash.EQ(invitationDao.EmailHash),
+		dao.UserWhere.DeletedAt.IsNull(),
+	).One(ctx, tx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed fetching user from database: %w", err)
+	}
+
+	if userDao != nil {
+		if userDao.IsServiceAccount || !utils.ComparePasswords(userDao.PasswordHash, req.Password) {
+			return nil, ErrInvitationPassword
+		}
+	} else {
+		if req.FirstName == "" || req.LastName == "" {
+			return nil, errors.New("first and last name are required")
+		}
+		if req.Password == "" {
+			return nil, errors.New("password is required")
+		}
+		hashedPassword, err := utils.HashPassword(req.Password)
+		if err != nil {
+			return nil, fmt.Errorf("failed hashing password: %w", err)
+		}
+
+		userDao = &dao.User{
+			ID:              uuid.NewString(),
+			FirstName:       req.FirstName,
+			LastName:        req.LastName,
+			Phone:           req.Phone,
+			Email:           invitationDao.Email,
+			EmailHash:       invitationDao.EmailHash,
+			PasswordHash:    hashedPassword,
+			InvitedBy:       null.StringFrom(invitationDao.InvitedBy),
+			EmailVerifiedAt: null.TimeFrom(now),
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		if err := userDao.Insert(ctx, tx, boil.Infer()); err != nil {
+			return nil, fmt.Errorf("failed inserting user to database: %w", err)
+		}
+	}
+
+	member, err := dao.Permissions(
+		dao.PermissionWhere.UserID.EQ(userDao.ID),
+		dao.PermissionWhere.CompanyID.EQ(invitationDao.CompanyID),
+		permissionInEffect("permissions"),
+	).Exists(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed checking company members: %w", err)
+	}
+	if !member {
+		permissionDao := dao.Permission{
+			ID:        uuid.NewString(),
+			UserID:    userDao.ID,
+			CompanyID: invitationDao.CompanyID,
+			Role:      invitationDao.Role,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := permissionDao.Insert(ctx, tx, boil.Infer()); err != nil {
+			return nil, fmt.Errorf("failed inserting permission to database: %w", err)
+		}
+		err = recordAudit(ctx, tx, auditEntry{
+			CompanyID:  invitationDao.CompanyID,
+			ActorID:    userDao.ID,
+			Action:     AuditActionCreate,
+			EntityType: AuditEntityPermission,
+			EntityID:   permissionDao.ID,
+			After:      permissionDaoToPermissionModel(&permissionDao),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	invitationDao.AcceptedAt = null.TimeFrom(now)
+	invitationDao.AcceptedBy = null.StringFrom(userDao.ID)
+	invitationDao.UpdatedAt = now
+	_, err = invitationDao.Update(ctx, tx, boil.Whitelist(
+		dao.InvitationColumns.AcceptedAt,
+		dao.InvitationColumns.AcceptedBy,
+		dao.InvitationColumns.UpdatedAt,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed updating invitation: %w", err)
+	}
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  invitationDao.CompanyID,
+		ActorID:    userDao.ID,
+		Action:     AuditActionAccept,
+		EntityType: AuditEntityInvitation,
+		EntityID:   invitationDao.ID,
+		After:      invitationDaoToModel(invitationDao),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	log.Printf("User %v accepted invitation %v to company %v", userDao.ID, invitationDao.ID, invitationDao.CompanyID)
+	return userDaoToUserModel(*userDao), nil
+}
+
+func (s *userManagementServiceImpl) authorizeInvitations(ctx context.Context, companyID string, requestedBy string) error {
+	allowed, err := userHasCompanyCapability(ctx, s.db.Conn, requestedBy, companyID, models.CapabilityMembersManage)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return &UnauthorizedError{}
+	}
+	return nil
+}
+
+func (s *userManagementServiceImpl) findPendingInvitation(ctx context.Context, companyID string, invitationID string) (*dao.Invitation, error) {
+	invitationDao, err := dao.Invitations(
+		dao.InvitationWhere.ID.EQ(invitationID),
+		dao.InvitationWhere.CompanyID.EQ(companyID),
+		dao.InvitationWhere.AcceptedAt.IsNull(),
+		dao.InvitationWhere.RevokedAt.IsNull(),
+	).One(ctx, s.db.Conn)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed fetching invitation from database: %w", err)
+	}
+	return invitationDao, nil
+}
+
+func (s *userManagementServiceImpl) sendInvitationEmail(ctx context.Context, invitationDao *dao.Invitation, token string) error {
+	companyDao, err := dao.FindCompany(ctx, s.db.Conn, invitationDao.CompanyID)
+	if err != nil {
+		return fmt.Errorf("failed fetching company from database: %w", err)
+	}
+	inviterDao, err := dao.FindUser(ctx, s.db.Conn, invitationDao.InvitedBy)
+	if err != nil {
+		return fmt.Errorf("failed fetching user from database: %w", err)
+	}
+
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      invitationDao.Email,
+		Subject: fmt.Sprintf("You're invited to join %s", companyDao.Name),
+		Body: fmt.Sprintf("Hi,\n\n%s %s invited you to join %s on Pro-Posal. Use the link below to accept, it expires in %d hours.\n\n%s/accept-invitation?token=%s\n\nIf you weren't expecting this invitation you can ignore this email.",
+			inviterDao.FirstName, inviterDao.LastName, companyDao.Name, config.AppConfig.Auth.InvitationExpirationHours, config.AppConfig.Mail.AppBaseURL, token),
+	})
+}
+
+// renewInvitationToken sets a new token on the invitation and returns it; only its hash is stored.
+func renewInvitationToken(invitationDao *dao.Invitation, now time.Time) (string, error) {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", err
+	}
+	invitationDao.TokenHash = utils.HashToken(token)
+	invitationDao.ExpiresAt = now.Add(time.Duration(config.AppConfig.Auth.InvitationExpirationHours) * time.Hour)
+	invitationDao.SentAt = now
+	invitationDao.UpdatedAt = now
+	return token, nil
+}
+
+func invitationDaoToModel(invitationDao *dao.Invitation) *models.Invitation {
+	return &models.Invitation{
+		ID:        invitationDao.ID,
+		CompanyID: invitationDao.CompanyID,
+		Email:     invitationDao.Email,
+		Role:      models.Role(invitationDao.Role),
+		InvitedBy: invitationDao.InvitedBy,
+		ExpiresAt: invitationDao.ExpiresAt,
+		SentAt:    invitationDao.SentAt,
+		CreatedAt: invitationDao.CreatedAt,
+	}
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pro-posal/webserver/config"
+	"github.com/pro-posal/webserver/dao"
+	"github.com/pro-posal/webserver/internal/mailer"
+	"github.com/pro-posal/webserver/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// LoginThrottledError is returned instead of checking the password while an account is locked,
+// inside its backoff delay, or when too many failed logins came from the caller's address.
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+	// Locked is set when the account itself is locked rather than just slowed down
+	Locked bool
+}
+
+func (e *LoginThrottledError) Error() string {
+	if e.Locked {
+		return fmt.Sprintf("account is locked, retry after %v", e.RetryAfter)
+	}
+	return fmt.Sprintf("too many failed login attempts, retry after %v", e.RetryAfter)
+}
+
+// maxLoginBackoff caps the delay between two password attempts on the same account.
+const maxLoginBackoff = 5 * time.Minute
+
+// loginBackoff doubles the wait after every consecutive failure: 1s, 2s, 4s and so on.
+func loginBackoff(failures int) time.Duration {
+	if failures <= 0 {
+		return 0
+	}
+	if failures > 10 {
+		return maxLoginBackoff
+	}
+	backoff := time.Second << (failures - 1)
+	if backoff > maxLoginBackoff {
+		return maxLoginBackoff
+	}
+	return backoff
+}
+
+// checkAddressThrottle limits how many failed logins a single address can make per window, whatever accounts they target.
+func (s *authServiceImpl) checkAddressThrottle(ctx context.Context, ipAddress string) error {
+	if ipAddress == "" {
+		return nil
+	}
+
+	window := time.Duration(config.AppConfig.Auth.Login.IPWindowMinutes) * time.Minute
+	var failures int
+	var oldest null.Time
+	err := queries.Raw(`SELECT COUNT(*), MIN(created_at) FROM login_attempts
+		WHERE ip_address = $1 AND NOT succeeded AND created_at > $2`,
+		ipAddress, time.Now().UTC().Add(-window),
+	).QueryRowContext(ctx, s.db.Conn).Scan(&failures, &oldest)
+	if err != nil {
+		return fmt.Errorf("failed counting login attempts: %w", err)
+	}
+
+	if failures >= config.AppConfig.Auth.Login.IPMaxFailedAttempts && oldest.Valid {
+		return &LoginThrottledError{RetryAfter: time.Until(oldest.Time.Add(window))}
+	}
+	return nil
+}
+
+// checkAccountThrottle refuses attempts on a locked account or before its backoff delay is over.
+func checkAccountThrottle(userDao *dao.User) error {
+	if userDao.LockedUntil.Valid && time.Now().Before(userDao.LockedUntil.Time) {
+		return &LoginThrottledError{RetryAfter: time.Until(userDao.LockedUntil.Time), Locked: true}
+	}
+	if userDao.LastFailedLoginAt.Valid {
+		retryAt := userDao.LastFailedLoginAt.Time.Add(loginBackoff(userDao.FailedLoginCount))
+		if time.Now().Before(retryAt) {
+			return &LoginThrottledError{RetryAfter: time.Until(retryAt)}
+		}
+	}
+	return nil
+}
+
+func (s *authServiceImpl) recordLoginAttempt(ctx context.Context, exec boil.ContextExecutor, emailHash string, userID null.String, ipAddress string, succeeded bool) {
+	attemptDao := dao.LoginAttempt{
+		ID:        uuid.NewString(),
+		EmailHash: emailHash,
+		UserID:    userID,
+		IPAddress: null.NewString(ipAddress, ipAddress != ""),
+		Succeeded: succeeded,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := attemptDao.Insert(ctx, exec, boil.Infer()); err != nil {
+		log.Printf("Failed recording login attempt: %v", err)
+	}
+}
+
+// registerFailedLogin counts a wrong password or second factor against the account and locks it,
+// emailing the user, once the configured number of consecutive failures is reached. Callers holding
+// the user row locked pass their transaction.
+func registerFailedLogin(ctx context.Context, exec boil.ContextExecutor, m mailer.Mailer, userDao *dao.User) error {
+	now := time.Now().UTC()
+	var failures int
+	err := queries.Raw(`UPDATE users SET failed_login_count = failed_login_count + 1, last_failed_login_at = $1
+		WHERE id = $2 RETURNING failed_login_count`,
+		now, userDao.ID,
+	).QueryRowContext(ctx, exec).Scan(&failures)
+	if err != nil {
+		return fmt.Errorf("failed counting failed login: %w", err)
+	}
+
+	if failures < config.AppConfig.Auth.Login.MaxFailedAttempts {
+		return nil
+	}
+
+	lockout := time.Duration(config.AppConfig.Auth.Login.LockoutMinutes) * time.Minute
+	userDao.LockedUntil = null.TimeFrom(now.Add(lockout))
+	userDao.FailedLoginCount = 0
+	userDao.LastFailedLoginAt = null.Time{}
+	_, err = userDao.Update(ctx, exec, boil.Whitelist("locked_until", "failed_login_count", "last_failed_login_at"))
+	if err != nil {
+		return fmt.Errorf("failed locking user: %w", err)
+	}
+
+	log.Printf("Locked user %v for %v after %d failed logins", userDao.ID, lockout, failures)
+	err = m.Send(ctx, mailer.Message{
+		To:      userDao.Email,
+		Subject: "Your account was locked",
+		Body: fmt.Sprintf("Hi %s,\n\nWe locked your account for %v after %d failed sign in attempts.\n\nIf this wasn't you, reset your password once the lock expires: %s/forgot-password",
+			userDao.FirstName, lockout, failures, config.AppConfig.Mail.AppBaseURL),
+	})
+	if err != nil {
+		log.Printf("Failed sending lockout email to user %v: %v", userDao.ID, err)
+	}
+	return nil
+}
+
+func (s *authServiceImpl) resetFailedLogins(ctx context.Context, exec boil.ContextExecutor, userDao *dao.User) error {
+	if userDao.FailedLoginCount == 0 && !userDao.LastFailedLoginAt.Valid && !userDao.LockedUntil.Valid {
+		return nil
+	}
+
+	userDao.FailedLoginCount = 0
+	userDao.LastFailedLoginAt = null.Time{}
+	userDao.LockedUntil = null.Time{}
+	_, err := userDao.Update(ctx, exec, boil.Whitelist("failed_login_count", "last_failed_login_at", "locked_until"))
+	if err != nil {
+		return fmt.Errorf("failed resetting failed logins: %w", err)
+	}
+	return nil
+}
+
+// UnlockUser lifts a lockout early, only platform admins can.
+func (s *authServiceImpl) UnlockUser(ctx context.Context, userID string, requestedBy string) (*models.User, error) {
+	isAdmin, err := userHasRole(ctx, s.db.Conn, requestedBy, models.AdminRole)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, &UnauthorizedError{}
+	}
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	userDao, err := dao.Users(dao.UserWhere.ID.EQ(userID), qm.For("UPDATE")).One(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if err := s.resetFailedLogins(ctx, tx, userDao); err != nil {
+		return nil, err
+	}
+	err = recordUserAudit(ctx, tx, userDao.ID, auditEntry{
+		ActorID:    requestedBy,
+		Action:     AuditActionUnlock,
+		EntityType: AuditEntityUser,
+		EntityID:   userDao.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	log.Printf("User %v was unlocked by %v", userID, requestedBy)
+	return userDaoToUserModel(*userDao), nil
+}
+
+// PruneLoginAttempts deletes login attempts recorded before the given time.
+func (s *authServiceImpl) PruneLoginAttempts(ctx context.Context, before time.Time) (int64, error) {
+	deleted, err := dao.LoginAttempts(dao.LoginAttemptWhere.CreatedAt.LT(before)).DeleteAll(ctx, s.db.Conn)
+	if err != nil {
+		return 0, fmt.Errorf("failed pruning login attempts: %w", err)
+	}
+	return deleted, nil
+}
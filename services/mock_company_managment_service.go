@@ -35,6 +35,35 @@ func (m *MockCompanyManagementService) EXPECT() *MockCompanyManagementServiceMoc
 	return m.recorder
 }
 
+// AcceptOwnershipTransfer mocks base method.
+func (m *MockCompanyManagementService) AcceptOwnershipTransfer(ctx context.Context, transferID, requestedBy string) (*models.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptOwnershipTransfer", ctx, transferID, requestedBy)
+	ret0, _ := ret[0].(*models.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptOwnershipTransfer indicates an expected call of AcceptOwnershipTransfer.
+func (mr *MockCompanyManagementServiceMockRecorder) AcceptOwnershipTransfer(ctx, transferID, requestedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptOwnershipTransfer", reflect.TypeOf((*MockCompanyManagementService)(nil).AcceptOwnershipTransfer), ctx, transferID, requestedBy)
+}
+
+// CancelOwnershipTransfer mocks base method.
+func (m *MockCompanyManagementService) CancelOwnershipTransfer(ctx context.Context, companyID, transferID, requestedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOwnershipTransfer", ctx, companyID, transferID, requestedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelOwnershipTransfer indicates an expected call of CancelOwnershipTransfer.
+func (mr *MockCompanyManagementServiceMockRecorder) CancelOwnershipTransfer(ctx, companyID, transferID, requestedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOwnershipTransfer", reflect.TypeOf((*MockCompanyManagementService)(nil).CancelOwnershipTransfer), ctx, companyID, transferID, requestedBy)
+}
+
 // CreateCompany mocks base method.
 func (m *MockCompanyManagementService) CreateCompany(arg0 context.Context, arg1 CreateCompanyRequest) (*models.Company, error) {
 	m.ctrl.T.Helper()
@@ -50,19 +79,33 @@ func (mr *MockCompanyManagementServiceMockRecorder) CreateCompany(arg0, arg1 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCompany", reflect.TypeOf((*MockCompanyManagementService)(nil).CreateCompany), arg0, arg1)
 }
 
+// DeclineOwnershipTransfer mocks base method.
+func (m *MockCompanyManagementService) DeclineOwnershipTransfer(ctx context.Context, transferID, requestedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineOwnershipTransfer", ctx, transferID, requestedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineOwnershipTransfer indicates an expected call of DeclineOwnershipTransfer.
+func (mr *MockCompanyManagementServiceMockRecorder) DeclineOwnershipTransfer(ctx, transferID, requestedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineOwnershipTransfer", reflect.TypeOf((*MockCompanyManagementService)(nil).DeclineOwnershipTransfer), ctx, transferID, requestedBy)
+}
+
 // DeleteCompany mocks base method.
-func (m *MockCompanyManagementService) DeleteCompany(arg0 context.Context, arg1 string) (*models.Company, error) {
+func (m *MockCompanyManagementService) DeleteCompany(arg0 context.Context, arg1, arg2 string) (*models.Company, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCompany", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteCompany", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*models.Company)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteCompany indicates an expected call of DeleteCompany.
-func (mr *MockCompanyManagementServiceMockRecorder) DeleteCompany(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockCompanyManagementServiceMockRecorder) DeleteCompany(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCompany", reflect.TypeOf((*MockCompanyManagementService)(nil).DeleteCompany), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCompany", reflect.TypeOf((*MockCompanyManagementService)(nil).DeleteCompany), arg0, arg1, arg2)
 }
 
 // GetCompanies mocks base method.
@@ -80,6 +123,51 @@ func (mr *MockCompanyManagementServiceMockRecorder) GetCompanies(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanies", reflect.TypeOf((*MockCompanyManagementService)(nil).GetCompanies), arg0, arg1)
 }
 
+// GetCompany mocks base method.
+func (m *MockCompanyManagementService) GetCompany(arg0 context.Context, arg1 string) (*models.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompany", arg0, arg1)
+	ret0, _ := ret[0].(*models.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompany indicates an expected call of GetCompany.
+func (mr *MockCompanyManagementServiceMockRecorder) GetCompany(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompany", reflect.TypeOf((*MockCompanyManagementService)(nil).GetCompany), arg0, arg1)
+}
+
+// GetOwnershipTransfers mocks base method.
+func (m *MockCompanyManagementService) GetOwnershipTransfers(ctx context.Context, userID string) ([]*models.OwnershipTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnershipTransfers", ctx, userID)
+	ret0, _ := ret[0].([]*models.OwnershipTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnershipTransfers indicates an expected call of GetOwnershipTransfers.
+func (mr *MockCompanyManagementServiceMockRecorder) GetOwnershipTransfers(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnershipTransfers", reflect.TypeOf((*MockCompanyManagementService)(nil).GetOwnershipTransfers), ctx, userID)
+}
+
+// TransferOwnership mocks base method.
+func (m *MockCompanyManagementService) TransferOwnership(arg0 context.Context, arg1 TransferOwnershipRequest) (*models.OwnershipTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferOwnership", arg0, arg1)
+	ret0, _ := ret[0].(*models.OwnershipTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferOwnership indicates an expected call of TransferOwnership.
+func (mr *MockCompanyManagementServiceMockRecorder) TransferOwnership(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferOwnership", reflect.TypeOf((*MockCompanyManagementService)(nil).TransferOwnership), arg0, arg1)
+}
+
 // UpdateCompany mocks base method.
 func (m *MockCompanyManagementService) UpdateCompany(arg0 context.Context, arg1 string, arg2 UpdateCompanyRequest) (*models.Company, error) {
 	m.ctrl.T.Helper()
@@ -1,8 +1,50 @@
 package services
 
-import "github.com/pro-posal/webserver/internal/database"
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pro-posal/webserver/dao"
+	"github.com/pro-posal/webserver/internal/database"
+	"github.com/pro-posal/webserver/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+type CreateOfferRequest struct {
+	CompanyID          string
+	ContractTemplateID string
+	CustomerID         string
+	Arguments          map[string]any
+	RequestedBy        string
+}
+
+type RespondToOfferRequest struct {
+	CompanyID       string
+	OfferID         string
+	Accept          bool
+	RejectionReason string
+	RequestedBy     string
+}
+
+var (
+	ErrOfferNotFound        = errors.New("offer not found")
+	ErrOfferAlreadyAnswered = errors.New("the offer was already accepted or rejected")
+)
 
 type OfferManagementService interface {
+	CreateOffer(context.Context, CreateOfferRequest) (*models.Offer, error)
+	GetOffers(ctx context.Context, companyID string, requestedBy string) ([]*models.Offer, error)
+	GetOffer(ctx context.Context, companyID string, offerID string, requestedBy string) (*models.Offer, error)
+	RespondToOffer(context.Context, RespondToOfferRequest) (*models.Offer, error)
 }
 
 type OfferManagementServiceImpl struct {
@@ -14,3 +56,284 @@ func NewOfferManagementService(db *database.DBConnector) OfferManagementService
 		db: db,
 	}
 }
+
+// CreateOffer renders the contract template for the customer and sends it to them. Customers
+// without a role in the company get a prospect permission for the template so they can open it.
+func (s *OfferManagementServiceImpl) CreateOffer(ctx context.Context, req CreateOfferRequest) (*models.Offer, error) {
+	templateExists, err := dao.ContractTemplates(
+		dao.ContractTemplateWhere.ID.EQ(req.ContractTemplateID),
+		dao.ContractTemplateWhere.CompanyID.EQ(req.CompanyID),
+		dao.ContractTemplateWhere.DeletedAt.IsNull(),
+	).Exists(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving contract template: %w", err)
+	}
+	if !templateExists {
+		return nil, fmt.Errorf("no contract template found with ID %s", req.ContractTemplateID)
+	}
+
+	customerExists, err := dao.Users(
+		dao.UserWhere.ID.EQ(req.CustomerID),
+		dao.UserWhere.DeletedAt.IsNull(),
+		dao.UserWhere.IsServiceAccount.EQ(false),
+	).Exists(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching user from database: %w", err)
+	}
+	if !customerExists {
+		return nil, fmt.Errorf("no customer found with ID %s", req.CustomerID)
+	}
+
+	if req.Arguments == nil {
+		req.Arguments = map[string]any{}
+	}
+	templates := &ContractTemplateManagementServiceImpl{db: s.db}
+	rendered, err := templates.RenderContractsTemplate(ctx, req.ContractTemplateID, RenderContractTemplateRequest{Arguments: req.Arguments})
+	if err != nil {
+		return nil, err
+	}
+	arguments, err := json.Marshal(req.Arguments)
+	if err != nil {
+		return nil, fmt.Errorf("failed marshaling offer arguments: %w", err)
+	}
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	offerDao := dao.Offer{
+		ID:                 uuid.NewString(),
+		CreatedBy:          req.RequestedBy,
+		CustomerID:         req.CustomerID,
+		CompanyID:          req.CompanyID,
+		ContractTemplateID: req.ContractTemplateID,
+		Arguments:          types.JSON(arguments),
+		FinalizedOffer:     null.StringFrom(rendered.Content),
+		FinalizedAt:        now,
+		SentAt:             null.TimeFrom(now),
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	if err := offerDao.Insert(ctx, tx, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("failed inserting offer to database: %w", err)
+	}
+
+	if err := grantProspectAccess(ctx, tx, req.CustomerID, req.CompanyID, req.ContractTemplateID); err != nil {
+		return nil, err
+	}
+
+	offer := offerDaoToModel(&offerDao)
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  req.CompanyID,
+		ActorID:    req.RequestedBy,
+		Action:     AuditActionCreate,
+		EntityType: AuditEntityOffer,
+		EntityID:   offer.ID,
+		After:      offer,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	log.Printf("User %v sent offer %v to customer %v", req.RequestedBy, offerDao.ID, req.CustomerID)
+	return offer, nil
+}
+
+// GetOffers lists the company's offers; prospects only see the offers sent to them.
+func (s *OfferManagementServiceImpl) GetOffers(ctx context.Context, companyID string, requestedBy string) ([]*models.Offer, error) {
+	query := []qm.QueryMod{
+		dao.OfferWhere.CompanyID.EQ(companyID),
+		dao.OfferWhere.DeletedAt.IsNull(),
+		qm.OrderBy(dao.OfferColumns.CreatedAt + " DESC"),
+	}
+
+	staff, err := userHasCompanyCapability(ctx, s.db.Conn, requestedBy, companyID, models.CapabilityOffersView)
+	if err != nil {
+		return nil, err
+	}
+	if !staff {
+		now := time.Now().UTC()
+		query = append(query,
+			dao.OfferWhere.CustomerID.EQ(requestedBy),
+			qm.Where(`EXISTS (SELECT 1 FROM permissions p WHERE p.user_id = offers.customer_id
+				AND p.company_id = offers.company_id AND p.contract_id = offers.contract_template_id AND p.role = ?
+				AND (p.starts_at IS NULL OR p.starts_at <= ?) AND (p.expires_at IS NULL OR p.expires_at > ?))`, string(models.ProspectRole), now, now),
+		)
+	}
+
+	offersDao, err := dao.Offers(query...).All(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching offers: %w", err)
+	}
+
+	offers := make([]*models.Offer, len(offersDao))
+	for i, offerDao := range offersDao {
+		offers[i] = offerDaoToModel(offerDao)
+	}
+	return offers, nil
+}
+
+// GetOffer returns an offer, noting when its customer opens it for the first time.
+func (s *OfferManagementServiceImpl) GetOffer(ctx context.Context, companyID string, offerID string, requestedBy string) (*models.Offer, error) {
+	offerDao, err := findOffer(ctx, s.db.Conn, companyID, offerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if offerDao.CustomerID == requestedBy && !offerDao.OpenedAt.Valid {
+		offerDao.OpenedAt = null.TimeFrom(time.Now().UTC())
+		if _, err := offerDao.Update(ctx, s.db.Conn, boil.Whitelist(dao.OfferColumns.OpenedAt)); err != nil {
+			return nil, fmt.Errorf("error updating offer: %w", err)
+		}
+	}
+
+	return offerDaoToModel(offerDao), nil
+}
+
+// RespondToOffer accepts or rejects an offer, which only its customer can do, and only once.
+func (s *OfferManagementServiceImpl) RespondToOffer(ctx context.Context, req RespondToOfferRequest) (*models.Offer, error) {
+	offerDao, err := findOffer(ctx, s.db.Conn, req.CompanyID, req.OfferID)
+	if err != nil {
+		return nil, err
+	}
+	if offerDao.CustomerID != req.RequestedBy {
+		return nil, &UnauthorizedError{}
+	}
+	before := offerDaoToModel(offerDao)
+
+	now := time.Now().UTC()
+	answer := dao.M{dao.OfferColumns.UpdatedAt: now}
+	if req.Accept {
+		answer[dao.OfferColumns.AcceptedAt] = now
+	} else {
+		answer[dao.OfferColumns.RejectedAt] = now
+		answer[dao.OfferColumns.RejectionReason] = null.NewString(req.RejectionReason, req.RejectionReason != "")
+	}
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Checked in the update itself so two answers sent at once can't both win
+	answered, err := dao.Offers(
+		dao.OfferWhere.ID.EQ(offerDao.ID),
+		dao.OfferWhere.AcceptedAt.IsNull(),
+		dao.OfferWhere.RejectedAt.IsNull(),
+	).UpdateAll(ctx, tx, answer)
+	if err != nil {
+		return nil, fmt.Errorf("error updating offer: %w", err)
+	}
+	if answered == 0 {
+		return nil, ErrOfferAlreadyAnswered
+	}
+
+	if err := offerDao.Reload(ctx, tx); err != nil {
+		return nil, fmt.Errorf("error fetching offer: %w", err)
+	}
+
+	offer := offerDaoToModel(offerDao)
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  req.CompanyID,
+		ActorID:    req.RequestedBy,
+		Action:     AuditActionRespond,
+		EntityType: AuditEntityOffer,
+		EntityID:   offer.ID,
+		Before:     before,
+		After:      offer,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+	log.Printf("Customer %v responded to offer %v, accepted: %v", req.RequestedBy, offerDao.ID, req.Accept)
+	return offer, nil
+}
+
+func findOffer(ctx context.Context, exec boil.ContextExecutor, companyID string, offerID string) (*dao.Offer, error) {
+	offerDao, err := dao.Offers(
+		dao.OfferWhere.ID.EQ(offerID),
+		dao.OfferWhere.CompanyID.EQ(companyID),
+		dao.OfferWhere.DeletedAt.IsNull(),
+	).One(ctx, exec)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOfferNotFound
+		}
+		return nil, fmt.Errorf("error fetching offer: %w", err)
+	}
+	return offerDao, nil
+}
+
+// grantProspectAccess lets a customer open the offers of a contract template, unless they
+// already work for the company.
+func grantProspectAccess(ctx context.Context, exec boil.ContextExecutor, userID string, companyID string, contractTemplateID string) error {
+	hasAccess, err := dao.Permissions(
+		dao.PermissionWhere.UserID.EQ(userID),
+		dao.PermissionWhere.CompanyID.EQ(companyID),
+		qm.Where("(role <> ? OR contract_id = ?)", string(models.ProspectRole), contractTemplateID),
+	).Exists(ctx, exec)
+	if err != nil {
+		return fmt.Errorf("error checking user permissions: %w", err)
+	}
+	if hasAccess {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	permissionDao := dao.Permission{
+		ID:         uuid.NewString(),
+		UserID:     userID,
+		CompanyID:  companyID,
+		Role:       string(models.ProspectRole),
+		ContractID: contractTemplateID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := permissionDao.Insert(ctx, exec, boil.Infer()); err != nil {
+		return fmt.Errorf("failed inserting permission to database: %w", err)
+	}
+	return recordAudit(ctx, exec, auditEntry{
+		CompanyID:  companyID,
+		Action:     AuditActionCreate,
+		EntityType: AuditEntityPermission,
+		EntityID:   permissionDao.ID,
+		After:      permissionDaoToPermissionModel(&permissionDao),
+	})
+}
+
+func offerDaoToModel(offerDao *dao.Offer) *models.Offer {
+	arguments := map[string]any{}
+	if err := offerDao.Arguments.Unmarshal(&arguments); err != nil {
+		log.Printf("Failed unmarshaling arguments of offer %v: %v", offerDao.ID, err)
+	}
+
+	return &models.Offer{
+		ID:                 offerDao.ID,
+		CompanyID:          offerDao.CompanyID,
+		ContractTemplateID: offerDao.ContractTemplateID,
+		CustomerID:         offerDao.CustomerID,
+		CreatedBy:          offerDao.CreatedBy,
+		Arguments:          arguments,
+		Content:            offerDao.FinalizedOffer.String,
+		FinalizedAt:        offerDao.FinalizedAt,
+		SentAt:             offerDao.SentAt.Ptr(),
+		OpenedAt:           offerDao.OpenedAt.Ptr(),
+		AcceptedAt:         offerDao.AcceptedAt.Ptr(),
+		RejectedAt:         offerDao.RejectedAt.Ptr(),
+		RejectionReason:    offerDao.RejectionReason.String,
+		CreatedAt:          offerDao.CreatedAt,
+		UpdatedAt:          offerDao.UpdatedAt,
+	}
+}
@@ -0,0 +1,760 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pro-posal/webserver/config"
+	"github.com/pro-posal/webserver/dao"
+	"github.com/pro-posal/webserver/internal/oidc"
+	"github.com/pro-posal/webserver/internal/utils"
+	"github.com/pro-posal/webserver/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+type IdentityProviderRequest struct {
+	CompanyID    string
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	GroupsClaim  string
+	GroupRoles   map[string]models.Role
+	DefaultRole  models.Role
+	Enabled      bool
+	RequestedBy  string
+}
+
+// StartOIDCLoginRequest starts a sign in. LinkUserID is set when a signed in user links the
+// identity to their own account instead.
+type StartOIDCLoginRequest struct {
+	ProviderID string
+	LinkUserID string
+	Client     SessionClient
+}
+
+type CompleteOIDCLoginRequest struct {
+	State  string
+	Code   string
+	Client SessionClient
+}
+
+var (
+	ErrInvalidIdentityProvider = errors.New("identity provider not found")
+	ErrInvalidOIDCRole         = errors.New("identity providers can only grant company admin, contributor, project manager or prospect roles")
+	ErrInvalidOIDCState        = errors.New("invalid or expired sign in, please start over")
+	ErrOIDCLoginFailed         = errors.New("single sign-on failed")
+	ErrOIDCNoRole              = errors.New("none of your groups may sign in to this company")
+	ErrOIDCEmailNotVerified    = errors.New("your identity provider did not verify your email address")
+	ErrOIDCAccountExists       = errors.New("an account with your email address already exists, sign in with your password and link your identity provider from there")
+	ErrOIDCIdentityLinked      = errors.New("this identity is already linked to another account")
+)
+
+const defaultGroupsClaim = "groups"
+
+// oidcRolePriority decides which role wins when a user is in several mapped groups
+var oidcRolePriority = map[models.Role]int{
+	models.ProspectRole:              1,
+	models.CompanyProjectManagerRole: 2,
+	models.CompanyContributorRole:    3,
+	models.CompanyAdminRole:          4,
+}
+
+func (s *authServiceImpl) CreateIdentityProvider(ctx context.Context, req IdentityProviderRequest) (*models.IdentityProvider, error) {
+	if err := s.checkIdentityProviderRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	if req.ClientSecret == "" {
+		return nil, errors.New("client secret is required")
+	}
+
+	groupRoles, err := json.Marshal(req.GroupRoles)
+	if err != nil {
+		return nil, fmt.Errorf("failed encoding group roles: %w", err)
+	}
+
+	now := time.Now().UTC()
+	providerDao := dao.IdentityProvider{
+		ID:           uuid.NewString(),
+		CompanyID:    req.CompanyID,
+		Name:         req.Name,
+		Issuer:       strings.TrimSuffix(req.Issuer, "/"),
+		ClientID:     req.ClientID,
+		ClientSecret: req.ClientSecret,
+		Scopes:       strings.Join(req.Scopes, " "),
+		GroupsClaim:  groupsClaimOrDefault(req.GroupsClaim),
+		GroupRoles:   string(groupRoles),
+		DefaultRole:  null.NewString(string(req.DefaultRole), req.DefaultRole != ""),
+		Enabled:      req.Enabled,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := providerDao.Insert(ctx, tx, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("failed creating identity provider: %w", err)
+	}
+
+	provider, err := identityProviderDaoToModel(&providerDao)
+	if err != nil {
+		return nil, err
+	}
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  req.CompanyID,
+		ActorID:    req.RequestedBy,
+		Action:     AuditActionCreate,
+		EntityType: AuditEntityIdentityProvider,
+		EntityID:   provider.ID,
+		After:      provider,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	log.Printf("User %v added identity provider %v (%v) to company %v", req.RequestedBy, providerDao.ID, providerDao.Issuer, req.CompanyID)
+	return provider, nil
+}
+
+// UpdateIdentityProvider replaces the provider's settings; an empty client secret keeps the current one.
+func (s *authServiceImpl) UpdateIdentityProvider(ctx context.Context, providerID string, req IdentityProviderRequest) (*models.IdentityProvider, error) {
+	if err := s.checkIdentityProviderRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	providerDao, err := dao.IdentityProviders(
+		dao.IdentityProviderWhere.ID.EQ(providerID),
+		dao.IdentityProviderWhere.CompanyID.EQ(req.CompanyID),
+	).One(ctx, s.db.Conn)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidIdentityProvider
+		}
+		return nil, fmt.Errorf("failed fetching identity provider from database: %w", err)
+	}
+
+	before, err := identityProviderDaoToModel(providerDao)
+	if err != nil {
+		return nil, err
+	}
+	groupRoles, err := json.Marshal(req.GroupRoles)
+	if err != nil {
+		return nil, fmt.Errorf("failed encoding group roles: %w", err)
+	}
+
+	providerDao.Name = req.Name
+	providerDao.Issuer = strings.TrimSuffix(req.Issuer, "/")
+	providerDao.ClientID = req.ClientID
+	if req.ClientSecret != "" {
+		providerDao.ClientSecret = req.ClientSecret
+	}
+	providerDao.Scopes = strings.Join(req.Scopes, " ")
+	providerDao.GroupsClaim = groupsClaimOrDefault(req.GroupsClaim)
+	providerDao.GroupRoles = string(groupRoles)
+	providerDao.DefaultRole = null.NewString(string(req.DefaultRole), req.DefaultRole != "")
+	providerDao.Enabled = req.Enabled
+	providerDao.UpdatedAt = time.Now().UTC()
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := providerDao.Update(ctx, tx, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("failed updating identity provider: %w", err)
+	}
+
+	provider, err := identityProviderDaoToModel(providerDao)
+	if err != nil {
+		return nil, err
+	}
+	// The client secret isn't part of the provider model, so a new one shows up as an update without changes
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  req.CompanyID,
+		ActorID:    req.RequestedBy,
+		Action:     AuditActionUpdate,
+		EntityType: AuditEntityIdentityProvider,
+		EntityID:   provider.ID,
+		Before:     before,
+		After:      provider,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+	return provider, nil
+}
+
+func (s *authServiceImpl) GetIdentityProviders(ctx context.Context, companyID string, requestedBy string) ([]*models.IdentityProvider, error) {
+	allowed, err := userHasCompanyCapability(ctx, s.db.Conn, requestedBy, companyID, models.CapabilityCompanyManage)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, &UnauthorizedError{}
+	}
+
+	providersDao, err := dao.IdentityProviders(
+		dao.IdentityProviderWhere.CompanyID.EQ(companyID),
+		qm.OrderBy(dao.IdentityProviderColumns.CreatedAt),
+	).All(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching identity providers from database: %w", err)
+	}
+
+	providers := make([]*models.IdentityProvider, len(providersDao))
+	for i, providerDao := range providersDao {
+		if providers[i], err = identityProviderDaoToModel(providerDao); err != nil {
+			return nil, err
+		}
+	}
+	return providers, nil
+}
+
+// DeleteIdentityProvider removes the provider and the links between its accounts and our users.
+// The users and their permissions stay.
+func (s *authServiceImpl) DeleteIdentityProvider(ctx context.Context, companyID string, providerID string, requestedBy string) error {
+	allowed, err := userHasCompanyCapability(ctx, s.db.Conn, requestedBy, companyID, models.CapabilityCompanyManage)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return &UnauthorizedError{}
+	}
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	providerDao, err := dao.IdentityProviders(
+		dao.IdentityProviderWhere.ID.EQ(providerID),
+		dao.IdentityProviderWhere.CompanyID.EQ(companyID),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidIdentityProvider
+		}
+		return fmt.Errorf("failed fetching identity provider from database: %w", err)
+	}
+
+	if _, err := dao.UserIdentities(dao.UserIdentityWhere.ProviderID.EQ(providerID)).DeleteAll(ctx, tx); err != nil {
+		return fmt.Errorf("failed deleting user identities: %w", err)
+	}
+	if _, err := dao.OidcLoginStates(dao.OidcLoginStateWhere.ProviderID.EQ(providerID)).DeleteAll(ctx, tx); err != nil {
+		return fmt.Errorf("failed deleting login states: %w", err)
+	}
+	if _, err := providerDao.Delete(ctx, tx); err != nil {
+		return fmt.Errorf("failed deleting identity provider: %w", err)
+	}
+
+	provider, err := identityProviderDaoToModel(providerDao)
+	if err != nil {
+		return err
+	}
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  companyID,
+		ActorID:    requestedBy,
+		Action:     AuditActionDelete,
+		EntityType: AuditEntityIdentityProvider,
+		EntityID:   provider.ID,
+		Before:     provider,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	log.Printf("User %v deleted identity provider %v of company %v", requestedBy, providerID, companyID)
+	return nil
+}
+
+// StartOIDCLogin returns the provider URL the user signs in at. The state, nonce and PKCE
+// verifier are kept server side until the user comes back with a code.
+func (s *authServiceImpl) StartOIDCLogin(ctx context.Context, req StartOIDCLoginRequest) (*models.OIDCLogin, error) {
+	providerDao, err := s.findEnabledIdentityProvider(ctx, req.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := s.oidc.Discover(ctx, providerDao.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCLoginFailed, err)
+	}
+
+	state, err := oidc.GenerateVerifier()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := oidc.GenerateVerifier()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := oidc.GenerateVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(time.Duration(config.AppConfig.Auth.OIDCLoginStateExpirationMinutes) * time.Minute)
+	stateDao := dao.OidcLoginState{
+		ID:           uuid.NewString(),
+		ProviderID:   providerDao.ID,
+		StateHash:    utils.HashToken(state),
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		UserAgent:    null.NewString(req.Client.UserAgent, req.Client.UserAgent != ""),
+		IPAddress:    null.NewString(req.Client.IPAddress, req.Client.IPAddress != ""),
+		ExpiresAt:    expiresAt,
+		CreatedAt:    now,
+		LinkUserID:   null.NewString(req.LinkUserID, req.LinkUserID != ""),
+	}
+	if err := stateDao.Insert(ctx, s.db.Conn, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("failed saving login state: %w", err)
+	}
+
+	// Abandoned sign ins are cleaned up as new ones start
+	_, err = dao.OidcLoginStates(dao.OidcLoginStateWhere.ExpiresAt.LT(now.Add(-24*time.Hour))).DeleteAll(ctx, s.db.Conn)
+	if err != nil {
+		log.Printf("Failed deleting expired login states: %v", err)
+	}
+
+	return &models.OIDCLogin{
+		AuthorizationURL: provider.AuthCodeURL(oidcConfig(providerDao), state, nonce, verifier),
+		ExpiresAt:        expiresAt,
+	}, nil
+}
+
+// CompleteOIDCLogin finishes a sign in with the code the identity provider redirected back with.
+// Users are matched by their identity at the provider, then by email address, and are created
+// when they are new. A sign in started to link an account always signs in that account. Their role in the company follows their groups on every sign in.
+func (s *authServiceImpl) CompleteOIDCLogin(ctx context.Context, req CompleteOIDCLoginRequest) (*models.AuthToken, error) {
+	// The state is used up whatever happens next, so a code can't be replayed with it
+	stateHash := utils.HashToken(req.State)
+	now := time.Now().UTC()
+	claimed, err := dao.OidcLoginStates(
+		dao.OidcLoginStateWhere.StateHash.EQ(stateHash),
+		dao.OidcLoginStateWhere.UsedAt.IsNull(),
+		dao.OidcLoginStateWhere.ExpiresAt.GT(now),
+	).UpdateAll(ctx, s.db.Conn, dao.M{dao.OidcLoginStateColumns.UsedAt: now})
+	if err != nil {
+		return nil, fmt.Errorf("failed updating login state: %w", err)
+	}
+	if claimed == 0 {
+		return nil, ErrInvalidOIDCState
+	}
+
+	stateDao, err := dao.OidcLoginStates(dao.OidcLoginStateWhere.StateHash.EQ(stateHash)).One(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching login state from database: %w", err)
+	}
+
+	providerDao, err := s.findEnabledIdentityProvider(ctx, stateDao.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := s.verifyOIDCCode(ctx, providerDao, req.Code, stateDao)
+	if err != nil {
+		log.Printf("Single sign-on with identity provider %v failed: %v", providerDao.ID, err)
+		return nil, fmt.Errorf("%w: %v", ErrOIDCLoginFailed, err)
+	}
+
+	role, err := resolveOIDCRole(providerDao, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	userDao, err := s.provisionOIDCUser(ctx, tx, providerDao, stateDao, claims)
+	if err != nil {
+		return nil, err
+	}
+	if err := syncOIDCRole(ctx, tx, userDao.ID, providerDao.CompanyID, role); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	log.Printf("User %v signed in with identity provider %v as %v", userDao.ID, providerDao.ID, role)
+	return s.completeLogin(ctx, userDao, req.Client)
+}
+
+func (s *authServiceImpl) verifyOIDCCode(ctx context.Context, providerDao *dao.IdentityProvider, code string, stateDao *dao.OidcLoginState) (*oidc.Claims, error) {
+	provider, err := s.oidc.Discover(ctx, providerDao.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	config := oidcConfig(providerDao)
+	idToken, err := s.oidc.Exchange(ctx, provider, config, code, stateDao.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+	return s.oidc.VerifyIDToken(ctx, provider, config.ClientID, idToken, stateDao.Nonce)
+}
+
+// resolveOIDCRole picks the highest role among the user's mapped groups, falling back to the
+// provider's default role.
+func resolveOIDCRole(providerDao *dao.IdentityProvider, claims *oidc.Claims) (models.Role, error) {
+	var groupRoles map[string]models.Role
+	if err := json.Unmarshal([]byte(providerDao.GroupRoles), &groupRoles); err != nil {
+		return "", fmt.Errorf("failed decoding group roles of identity provider %v: %w", providerDao.ID, err)
+	}
+
+	role := models.Role(providerDao.DefaultRole.String)
+	mapped := false
+	for _, group := range claims.Groups(providerDao.GroupsClaim) {
+		groupRole, ok := groupRoles[group]
+		if ok && (!mapped || oidcRolePriority[groupRole] > oidcRolePriority[role]) {
+			role = groupRole
+			mapped = true
+		}
+	}
+
+	if role == "" {
+		return "", ErrOIDCNoRole
+	}
+	return role, nil
+}
+
+func (s *authServiceImpl) provisionOIDCUser(ctx context.Context, exec boil.ContextExecutor, providerDao *dao.IdentityProvider, stateDao *dao.OidcLoginState, claims *oidc.Claims) (*dao.User, error) {
+	now := time.Now().UTC()
+
+	identityDao, err := dao.UserIdentities(
+		dao.UserIdentityWhere.ProviderID.EQ(providerDao.ID),
+		dao.UserIdentityWhere.Subject.EQ(claims.Subject),
+	).One(ctx, exec)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed fetching user identity from database: %w", err)
+	}
+	if identityDao != nil {
+		if stateDao.LinkUserID.Valid && stateDao.LinkUserID.String != identityDao.UserID {
+			return nil, ErrOIDCIdentityLinked
+		}
+		userDao, err := dao.Users(
+			dao.UserWhere.ID.EQ(identityDao.UserID),
+			dao.UserWhere.DeletedAt.IsNull(),
+		).One(ctx, exec)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrOIDCLoginFailed
+			}
+			return nil, fmt.Errorf("failed fetching user from database: %w", err)
+		}
+
+		identityDao.LastLoginAt = now
+		if _, err := identityDao.Update(ctx, exec, boil.Whitelist(dao.UserIdentityColumns.LastLoginAt)); err != nil {
+			return nil, fmt.Errorf("failed updating user identity: %w", err)
+		}
+		return userDao, nil
+	}
+
+	var userDao *dao.User
+	if stateDao.LinkUserID.Valid {
+		userDao, err = dao.Users(
+			dao.UserWhere.ID.EQ(stateDao.LinkUserID.String),
+			dao.UserWhere.DeletedAt.IsNull(),
+			dao.UserWhere.IsServiceAccount.EQ(false),
+		).One(ctx, exec)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrOIDCLoginFailed
+			}
+			return nil, fmt.Errorf("failed fetching user from database: %w", err)
+		}
+		log.Printf("User %v linked an identity of identity provider %v", userDao.ID, providerDao.ID)
+	} else {
+		userDao, err = s.matchOIDCUser(ctx, exec, providerDao, claims)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	identity := dao.UserIdentity{
+		ID:          uuid.NewString(),
+		ProviderID:  providerDao.ID,
+		UserID:      userDao.ID,
+		Subject:     claims.Subject,
+		CreatedAt:   now,
+		LastLoginAt: now,
+	}
+	if err := identity.Insert(ctx, exec, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("failed linking user identity: %w", err)
+	}
+	return userDao, nil
+}
+
+// matchOIDCUser finds the account of a new identity by its email address, creating it when there
+// is none. Existing accounts are only linked when their user joined the provider's company through
+// an invitation and is still a member, anyone else links the identity from their own session.
+func (s *authServiceImpl) matchOIDCUser(ctx context.Context, exec boil.ContextExecutor, providerDao *dao.IdentityProvider, claims *oidc.Claims) (*dao.User, error) {
+	if claims.Email == "" {
+		return nil, fmt.Errorf("%w: the id token has no email address", ErrOIDCLoginFailed)
+	}
+	email := strings.ToLower(claims.Email)
+
+	userDao, err := dao.Users(
+		dao.UserWhere.EmailHash.EQ(utils.HashEmail(email)),
+		dao.UserWhere.DeletedAt.IsNull(),
+		dao.UserWhere.IsServiceAccount.EQ(false),
+	).One(ctx, exec)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed fetching user from database: %w", err)
+	}
+
+	if userDao != nil {
+		// Linking an existing account on an address the provider didn't verify would let anyone take it over
+		if !claims.EmailVerified {
+			return nil, ErrOIDCEmailNotVerified
+		}
+		// Any company can register a provider, and being given a role by a company doesn't mean
+		// the user trusts it with their account. Accepting its invitation does.
+		invited, err := dao.Invitations(
+			dao.InvitationWhere.CompanyID.EQ(providerDao.CompanyID),
+			dao.InvitationWhere.AcceptedBy.EQ(null.StringFrom(userDao.ID)),
+		).Exists(ctx, exec)
+		if err != nil {
+			return nil, fmt.Errorf("failed checking invitations: %w", err)
+		}
+		member, err := dao.Permissions(
+			dao.PermissionWhere.UserID.EQ(userDao.ID),
+			dao.PermissionWhere.CompanyID.EQ(providerDao.CompanyID),
+			permissionInEffect("permissions"),
+		).Exists(ctx, exec)
+		if err != nil {
+			return nil, fmt.Errorf("failed checking company members: %w", err)
+		}
+		if !invited || !member {
+			return nil, ErrOIDCAccountExists
+		}
+		return userDao, nil
+	}
+
+	firstName, lastName := claims.GivenName, claims.FamilyName
+	if firstName == "" && lastName == "" {
+		firstName, lastName, _ = strings.Cut(claims.Name, " ")
+	}
+	now := time.Now().UTC()
+	userDao = &dao.User{
+		ID:        uuid.NewString(),
+		FirstName: firstName,
+		LastName:  lastName,
+		Email:     email,
+		EmailHash: utils.HashEmail(email),
+		// Users created by single sign-on have no password until they reset it
+		PasswordHash: "",
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if claims.EmailVerified {
+		userDao.EmailVerifiedAt = null.TimeFrom(now)
+	}
+	if err := userDao.Insert(ctx, exec, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("failed creating user: %w", err)
+	}
+	log.Printf("Created user %v on first sign in with identity provider %v", userDao.ID, providerDao.ID)
+	return userDao, nil
+}
+
+// syncOIDCRole gives the user the role their groups map to. Platform admins and the company's
+// owner keep their role, it is never managed by the identity provider. The provider manages a
+// single permission per user and company: the admin one when there is one, else the oldest.
+func syncOIDCRole(ctx context.Context, exec boil.ContextExecutor, userID string, companyID string, role models.Role) error {
+	permissionDao, err := dao.Permissions(
+		dao.PermissionWhere.UserID.EQ(userID),
+		dao.PermissionWhere.CompanyID.EQ(companyID),
+		qm.OrderBy("role = ? DESC, created_at, id", string(models.AdminRole)),
+	).One(ctx, exec)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed fetching permission from database: %w", err)
+	}
+
+	now := time.Now().UTC()
+	if permissionDao == nil {
+		permissionDao = &dao.Permission{
+			ID:        uuid.NewString(),
+			UserID:    userID,
+			CompanyID: companyID,
+			Role:      string(role),
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := permissionDao.Insert(ctx, exec, boil.Infer()); err != nil {
+			return fmt.Errorf("failed granting role: %w", err)
+		}
+		return recordAudit(ctx, exec, auditEntry{
+			CompanyID:  companyID,
+			ActorID:    userID,
+			Action:     AuditActionCreate,
+			EntityType: AuditEntityPermission,
+			EntityID:   permissionDao.ID,
+			After:      permissionDaoToPermissionModel(permissionDao),
+		})
+	}
+
+	if permissionDao.Role == string(models.AdminRole) {
+		return nil
+	}
+	// A grant limited to a contract, a custom role or a period isn't the role the groups map to
+	synced := permissionDao.Role == string(role) && permissionDao.ContractID == "" && !permissionDao.CompanyRoleID.Valid &&
+		!permissionDao.StartsAt.Valid && !permissionDao.ExpiresAt.Valid
+	if synced {
+		return nil
+	}
+	owner, err := dao.Companies(
+		dao.CompanyWhere.ID.EQ(companyID),
+		dao.CompanyWhere.ContactID.EQ(userID),
+	).Exists(ctx, exec)
+	if err != nil {
+		return fmt.Errorf("failed checking company owner: %w", err)
+	}
+	if owner {
+		return nil
+	}
+	before := permissionDaoToPermissionModel(permissionDao)
+	permissionDao.Role = string(role)
+	permissionDao.ContractID = ""
+	permissionDao.CompanyRoleID = null.String{}
+	permissionDao.StartsAt = null.Time{}
+	permissionDao.ExpiresAt = null.Time{}
+	permissionDao.ExpiryNotifiedAt = null.Time{}
+	permissionDao.UpdatedAt = now
+	_, err = permissionDao.Update(ctx, exec, boil.Whitelist(
+		dao.PermissionColumns.Role,
+		dao.PermissionColumns.ContractID,
+		dao.PermissionColumns.CompanyRoleID,
+		dao.PermissionColumns.StartsAt,
+		dao.PermissionColumns.ExpiresAt,
+		dao.PermissionColumns.ExpiryNotifiedAt,
+		dao.PermissionColumns.UpdatedAt,
+	))
+	if err != nil {
+		return fmt.Errorf("failed updating role: %w", err)
+	}
+	return recordAudit(ctx, exec, auditEntry{
+		CompanyID:  companyID,
+		ActorID:    userID,
+		Action:     AuditActionUpdate,
+		EntityType: AuditEntityPermission,
+		EntityID:   permissionDao.ID,
+		Before:     before,
+		After:      permissionDaoToPermissionModel(permissionDao),
+	})
+}
+
+func (s *authServiceImpl) checkIdentityProviderRequest(ctx context.Context, req IdentityProviderRequest) error {
+	allowed, err := userHasCompanyCapability(ctx, s.db.Conn, req.RequestedBy, req.CompanyID, models.CapabilityCompanyManage)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return &UnauthorizedError{}
+	}
+
+	if req.Name == "" || req.Issuer == "" || req.ClientID == "" {
+		return errors.New("name, issuer and client id are required")
+	}
+	for _, role := range req.GroupRoles {
+		if _, ok := oidcRolePriority[role]; !ok {
+			return ErrInvalidOIDCRole
+		}
+	}
+	if _, ok := oidcRolePriority[req.DefaultRole]; req.DefaultRole != "" && !ok {
+		return ErrInvalidOIDCRole
+	}
+
+	// Catch a mistyped issuer now rather than when the first user tries to sign in
+	if _, err := s.oidc.Discover(ctx, req.Issuer); err != nil {
+		return fmt.Errorf("failed reaching identity provider: %w", err)
+	}
+	return nil
+}
+
+func (s *authServiceImpl) findEnabledIdentityProvider(ctx context.Context, providerID string) (*dao.IdentityProvider, error) {
+	providerDao, err := dao.IdentityProviders(
+		dao.IdentityProviderWhere.ID.EQ(providerID),
+		dao.IdentityProviderWhere.Enabled.EQ(true),
+		qm.Where("EXISTS (SELECT 1 FROM companies c WHERE c.id = identity_providers.company_id AND c.deleted_at IS NULL)"),
+	).One(ctx, s.db.Conn)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidIdentityProvider
+		}
+		return nil, fmt.Errorf("failed fetching identity provider from database: %w", err)
+	}
+	return providerDao, nil
+}
+
+func oidcConfig(providerDao *dao.IdentityProvider) oidc.Config {
+	redirectURL := config.AppConfig.Auth.OIDCRedirectURL
+	if redirectURL == "" {
+		redirectURL = config.AppConfig.Mail.AppBaseURL + "/sso/callback"
+	}
+	return oidc.Config{
+		ClientID:     providerDao.ClientID,
+		ClientSecret: providerDao.ClientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       append([]string{"email", "profile"}, strings.Fields(providerDao.Scopes)...),
+	}
+}
+
+func groupsClaimOrDefault(claim string) string {
+	if claim == "" {
+		return defaultGroupsClaim
+	}
+	return claim
+}
+
+func identityProviderDaoToModel(providerDao *dao.IdentityProvider) (*models.IdentityProvider, error) {
+	groupRoles := map[string]models.Role{}
+	if err := json.Unmarshal([]byte(providerDao.GroupRoles), &groupRoles); err != nil {
+		return nil, fmt.Errorf("failed decoding group roles of identity provider %v: %w", providerDao.ID, err)
+	}
+	return &models.IdentityProvider{
+		ID:          providerDao.ID,
+		CompanyID:   providerDao.CompanyID,
+		Name:        providerDao.Name,
+		Issuer:      providerDao.Issuer,
+		ClientID:    providerDao.ClientID,
+		Scopes:      strings.Fields(providerDao.Scopes),
+		GroupsClaim: providerDao.GroupsClaim,
+		GroupRoles:  groupRoles,
+		DefaultRole: models.Role(providerDao.DefaultRole.String),
+		Enabled:     providerDao.Enabled,
+		CreatedAt:   providerDao.CreatedAt,
+		UpdatedAt:   providerDao.UpdatedAt,
+	}, nil
+}
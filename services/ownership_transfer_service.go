@@ -0,0 +1,460 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pro-posal/webserver/config"
+	"github.com/pro-posal/webserver/dao"
+	"github.com/pro-posal/webserver/internal/mailer"
+	"github.com/pro-posal/webserver/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// TransferOwnershipRequest nominates ToUserID as the company's new owner. The current owner keeps
+// PreviousOwnerRole once the transfer is accepted.
+type TransferOwnershipRequest struct {
+	CompanyID         string
+	ToUserID          string
+	PreviousOwnerRole models.Role
+	RequestedBy       string
+}
+
+var (
+	ErrOwnershipTransferNotFound = errors.New("ownership transfer not found")
+	ErrOwnershipTransferPending  = errors.New("the company already has a pending ownership transfer, cancel it first")
+	ErrInvalidNewOwner           = errors.New("the new owner has to be another member of the company")
+	ErrInvalidPreviousOwnerRole  = errors.New("the previous owner can only keep a company admin, contributor or project manager role")
+	ErrPreviousOwnerNotAdmin     = errors.New("the previous owner no longer holds an admin permission in the company")
+)
+
+var previousOwnerRoles = []models.Role{
+	models.CompanyAdminRole,
+	models.CompanyContributorRole,
+	models.CompanyProjectManagerRole,
+}
+
+// TransferOwnership starts handing the company over to another member, only its owner can. The
+// recipient is emailed and has to accept before anything changes.
+func (s *CompanyManagementServiceImpl) TransferOwnership(ctx context.Context, req TransferOwnershipRequest) (*models.OwnershipTransfer, error) {
+	companyDao, err := findOwnedCompany(ctx, s.db.Conn, req.CompanyID, req.RequestedBy)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(previousOwnerRoles, req.PreviousOwnerRole) {
+		return nil, ErrInvalidPreviousOwnerRole
+	}
+	if req.ToUserID == req.RequestedBy {
+		return nil, ErrInvalidNewOwner
+	}
+	if err := checkNewOwner(ctx, s.db.Conn, req.CompanyID, req.ToUserID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	pending, err := dao.OwnershipTransfers(
+		dao.OwnershipTransferWhere.CompanyID.EQ(req.CompanyID),
+		pendingOwnershipTransfer(now),
+	).Exists(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed checking pending ownership transfers: %w", err)
+	}
+	if pending {
+		return nil, ErrOwnershipTransferPending
+	}
+
+	transferDao := dao.OwnershipTransfer{
+		ID:                uuid.NewString(),
+		CompanyID:         req.CompanyID,
+		FromUserID:        req.RequestedBy,
+		ToUserID:          req.ToUserID,
+		PreviousOwnerRole: string(req.PreviousOwnerRole),
+		ExpiresAt:         now.Add(time.Duration(config.AppConfig.Auth.OwnershipTransferExpirationHours) * time.Hour),
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := transferDao.Insert(ctx, tx, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("failed inserting ownership transfer to database: %w", err)
+	}
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  req.CompanyID,
+		ActorID:    req.RequestedBy,
+		Action:     AuditActionCreate,
+		EntityType: AuditEntityOwnershipTransfer,
+		EntityID:   transferDao.ID,
+		After:      ownershipTransferDaoToModel(&transferDao),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	// The recipient also finds the transfer in their pending transfers
+	if err := s.sendOwnershipTransferEmail(ctx, companyDao, &transferDao); err != nil {
+		log.Printf("Failed sending ownership transfer %v: %v", transferDao.ID, err)
+	}
+
+	log.Printf("User %v started transferring company %v to %v", req.RequestedBy, req.CompanyID, req.ToUserID)
+	return ownershipTransferDaoToModel(&transferDao), nil
+}
+
+// CancelOwnershipTransfer withdraws a pending transfer, only the company's owner can.
+func (s *CompanyManagementServiceImpl) CancelOwnershipTransfer(ctx context.Context, companyID string, transferID string, requestedBy string) error {
+	if _, err := findOwnedCompany(ctx, s.db.Conn, companyID, requestedBy); err != nil {
+		return err
+	}
+
+	transferDao, err := findPendingOwnershipTransfer(ctx, s.db.Conn,
+		dao.OwnershipTransferWhere.ID.EQ(transferID),
+		dao.OwnershipTransferWhere.CompanyID.EQ(companyID),
+	)
+	if err != nil {
+		return err
+	}
+
+	before := ownershipTransferDaoToModel(transferDao)
+	transferDao.CancelledAt = null.TimeFrom(time.Now().UTC())
+	transferDao.UpdatedAt = time.Now().UTC()
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = transferDao.Update(ctx, tx, boil.Whitelist(dao.OwnershipTransferColumns.CancelledAt, dao.OwnershipTransferColumns.UpdatedAt))
+	if err != nil {
+		return fmt.Errorf("failed cancelling ownership transfer: %w", err)
+	}
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  companyID,
+		ActorID:    requestedBy,
+		Action:     AuditActionRevoke,
+		EntityType: AuditEntityOwnershipTransfer,
+		EntityID:   transferDao.ID,
+		Before:     before,
+		After:      ownershipTransferDaoToModel(transferDao),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	log.Printf("User %v cancelled ownership transfer %v of company %v", requestedBy, transferID, companyID)
+	return nil
+}
+
+// GetOwnershipTransfers lists the pending transfers of companies to the user.
+func (s *CompanyManagementServiceImpl) GetOwnershipTransfers(ctx context.Context, userID string) ([]*models.OwnershipTransfer, error) {
+	transfersDao, err := dao.OwnershipTransfers(
+		dao.OwnershipTransferWhere.ToUserID.EQ(userID),
+		pendingOwnershipTransfer(time.Now().UTC()),
+		qm.OrderBy(dao.OwnershipTransferColumns.CreatedAt+" DESC"),
+	).All(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching ownership transfers from database: %w", err)
+	}
+
+	transfers := make([]*models.OwnershipTransfer, len(transfersDao))
+	for i, transferDao := range transfersDao {
+		transfers[i] = ownershipTransferDaoToModel(transferDao)
+	}
+	return transfers, nil
+}
+
+// AcceptOwnershipTransfer makes the recipient the company's owner. The contact, the owner's admin
+// permission and the previous owner's new role change together, or not at all.
+func (s *CompanyManagementServiceImpl) AcceptOwnershipTransfer(ctx context.Context, transferID string, requestedBy string) (*models.Company, error) {
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	transferDao, err := findPendingOwnershipTransfer(ctx, tx,
+		dao.OwnershipTransferWhere.ID.EQ(transferID),
+		dao.OwnershipTransferWhere.ToUserID.EQ(requestedBy),
+		qm.For("UPDATE"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	companyDao, err := dao.Companies(
+		dao.CompanyWhere.ID.EQ(transferDao.CompanyID),
+		dao.CompanyWhere.DeletedAt.IsNull(),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOwnershipTransferNotFound
+		}
+		return nil, fmt.Errorf("error retrieving company: %w", err)
+	}
+	// The company changed hands some other way since the transfer was started
+	if companyDao.ContactID != transferDao.FromUserID {
+		return nil, ErrOwnershipTransferNotFound
+	}
+	if err := checkNewOwner(ctx, tx, companyDao.ID, requestedBy); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	previous := companyDaoToCompanyModel(*companyDao)
+
+	// Every owner-level permission the previous owner holds in the company is withdrawn, they only
+	// keep the role they picked when starting the transfer
+	downgraded, err := dao.Permissions(
+		dao.PermissionWhere.CompanyID.EQ(companyDao.ID),
+		dao.PermissionWhere.UserID.EQ(transferDao.FromUserID),
+		dao.PermissionWhere.Role.IN([]string{string(models.AdminRole), string(models.CompanyAdminRole)}),
+	).DeleteAll(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed removing the previous owner's admin permissions: %w", err)
+	}
+	if downgraded == 0 {
+		return nil, ErrPreviousOwnerNotAdmin
+	}
+
+	ownerPermission := dao.Permission{
+		ID:        uuid.NewString(),
+		UserID:    requestedBy,
+		CompanyID: companyDao.ID,
+		Role:      string(models.CompanyAdminRole),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := ownerPermission.Insert(ctx, tx, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("failed inserting permission to database: %w", err)
+	}
+
+	previousOwnerPermission := dao.Permission{
+		ID:        uuid.NewString(),
+		UserID:    transferDao.FromUserID,
+		CompanyID: companyDao.ID,
+		Role:      transferDao.PreviousOwnerRole,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := previousOwnerPermission.Insert(ctx, tx, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("failed inserting permission to database: %w", err)
+	}
+
+	companyDao.ContactID = requestedBy
+	companyDao.UpdatedAt = now
+	if _, err := companyDao.Update(ctx, tx, boil.Whitelist(dao.CompanyColumns.ContactID, dao.CompanyColumns.UpdatedAt)); err != nil {
+		return nil, fmt.Errorf("error updating company: %w", err)
+	}
+
+	transferBefore := ownershipTransferDaoToModel(transferDao)
+	transferDao.AcceptedAt = null.TimeFrom(now)
+	transferDao.UpdatedAt = now
+	if _, err := transferDao.Update(ctx, tx, boil.Whitelist(dao.OwnershipTransferColumns.AcceptedAt, dao.OwnershipTransferColumns.UpdatedAt)); err != nil {
+		return nil, fmt.Errorf("failed updating ownership transfer: %w", err)
+	}
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  companyDao.ID,
+		ActorID:    requestedBy,
+		Action:     AuditActionAccept,
+		EntityType: AuditEntityOwnershipTransfer,
+		EntityID:   transferDao.ID,
+		Before:     transferBefore,
+		After:      ownershipTransferDaoToModel(transferDao),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  companyDao.ID,
+		ActorID:    requestedBy,
+		Action:     AuditActionTransfer,
+		EntityType: AuditEntityCompany,
+		EntityID:   companyDao.ID,
+		Before:     previous,
+		After:      companyDaoToCompanyModel(*companyDao),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	log.Printf("User %v accepted ownership transfer %v of company %v from %v", requestedBy, transferDao.ID, companyDao.ID, transferDao.FromUserID)
+	if err := s.sendOwnershipTransferredEmail(ctx, companyDao, transferDao); err != nil {
+		log.Printf("Failed notifying user %v of ownership transfer %v: %v", transferDao.FromUserID, transferDao.ID, err)
+	}
+	return companyDaoToCompanyModel(*companyDao), nil
+}
+
+// DeclineOwnershipTransfer turns down a transfer to the user, the company stays with its owner.
+func (s *CompanyManagementServiceImpl) DeclineOwnershipTransfer(ctx context.Context, transferID string, requestedBy string) error {
+	transferDao, err := findPendingOwnershipTransfer(ctx, s.db.Conn,
+		dao.OwnershipTransferWhere.ID.EQ(transferID),
+		dao.OwnershipTransferWhere.ToUserID.EQ(requestedBy),
+	)
+	if err != nil {
+		return err
+	}
+
+	before := ownershipTransferDaoToModel(transferDao)
+	transferDao.DeclinedAt = null.TimeFrom(time.Now().UTC())
+	transferDao.UpdatedAt = time.Now().UTC()
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = transferDao.Update(ctx, tx, boil.Whitelist(dao.OwnershipTransferColumns.DeclinedAt, dao.OwnershipTransferColumns.UpdatedAt))
+	if err != nil {
+		return fmt.Errorf("failed declining ownership transfer: %w", err)
+	}
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  transferDao.CompanyID,
+		ActorID:    requestedBy,
+		Action:     AuditActionDecline,
+		EntityType: AuditEntityOwnershipTransfer,
+		EntityID:   transferDao.ID,
+		Before:     before,
+		After:      ownershipTransferDaoToModel(transferDao),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	log.Printf("User %v declined ownership transfer %v of company %v", requestedBy, transferID, transferDao.CompanyID)
+	return nil
+}
+
+// findOwnedCompany returns the company when the user is its owner, its contact.
+func findOwnedCompany(ctx context.Context, exec boil.ContextExecutor, companyID string, userID string) (*dao.Company, error) {
+	companyDao, err := dao.Companies(
+		dao.CompanyWhere.ID.EQ(companyID),
+		dao.CompanyWhere.DeletedAt.IsNull(),
+	).One(ctx, exec)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("no company found with ID %s", companyID)
+		}
+		return nil, fmt.Errorf("error retrieving company: %w", err)
+	}
+	if companyDao.ContactID != userID {
+		return nil, &UnauthorizedError{}
+	}
+	return companyDao, nil
+}
+
+// checkNewOwner checks that the user is a person, not a service account, with a permission in
+// effect in the company.
+func checkNewOwner(ctx context.Context, exec boil.ContextExecutor, companyID string, userID string) error {
+	member, err := dao.Permissions(
+		qm.InnerJoin("users u ON u.id = permissions.user_id"),
+		qm.Where("u.id = ? AND u.deleted_at IS NULL AND NOT u.is_service_account", userID),
+		dao.PermissionWhere.CompanyID.EQ(companyID),
+		permissionInEffect("permissions"),
+	).Exists(ctx, exec)
+	if err != nil {
+		return fmt.Errorf("failed checking company members: %w", err)
+	}
+	if !member {
+		return ErrInvalidNewOwner
+	}
+	return nil
+}
+
+func pendingOwnershipTransfer(now time.Time) qm.QueryMod {
+	return qm.Expr(
+		dao.OwnershipTransferWhere.AcceptedAt.IsNull(),
+		dao.OwnershipTransferWhere.DeclinedAt.IsNull(),
+		dao.OwnershipTransferWhere.CancelledAt.IsNull(),
+		dao.OwnershipTransferWhere.ExpiresAt.GT(now),
+	)
+}
+
+func findPendingOwnershipTransfer(ctx context.Context, exec boil.ContextExecutor, mods ...qm.QueryMod) (*dao.OwnershipTransfer, error) {
+	transferDao, err := dao.OwnershipTransfers(append(mods, pendingOwnershipTransfer(time.Now().UTC()))...).One(ctx, exec)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOwnershipTransferNotFound
+		}
+		return nil, fmt.Errorf("failed fetching ownership transfer from database: %w", err)
+	}
+	return transferDao, nil
+}
+
+func (s *CompanyManagementServiceImpl) sendOwnershipTransferEmail(ctx context.Context, companyDao *dao.Company, transferDao *dao.OwnershipTransfer) error {
+	ownerDao, err := dao.FindUser(ctx, s.db.Conn, transferDao.FromUserID)
+	if err != nil {
+		return fmt.Errorf("failed fetching user from database: %w", err)
+	}
+	recipientDao, err := dao.FindUser(ctx, s.db.Conn, transferDao.ToUserID)
+	if err != nil {
+		return fmt.Errorf("failed fetching user from database: %w", err)
+	}
+
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      recipientDao.Email,
+		Subject: fmt.Sprintf("%s %s wants to hand %s over to you", ownerDao.FirstName, ownerDao.LastName, companyDao.Name),
+		Body: fmt.Sprintf("Hi %s,\n\n%s %s asked you to become the owner of %s on Pro-Posal. Sign in to accept or decline, the request expires in %d hours.\n\n%s/ownership-transfers",
+			recipientDao.FirstName, ownerDao.FirstName, ownerDao.LastName, companyDao.Name, config.AppConfig.Auth.OwnershipTransferExpirationHours, config.AppConfig.Mail.AppBaseURL),
+	})
+}
+
+func (s *CompanyManagementServiceImpl) sendOwnershipTransferredEmail(ctx context.Context, companyDao *dao.Company, transferDao *dao.OwnershipTransfer) error {
+	ownerDao, err := dao.FindUser(ctx, s.db.Conn, transferDao.FromUserID)
+	if err != nil {
+		return fmt.Errorf("failed fetching user from database: %w", err)
+	}
+	recipientDao, err := dao.FindUser(ctx, s.db.Conn, transferDao.ToUserID)
+	if err != nil {
+		return fmt.Errorf("failed fetching user from database: %w", err)
+	}
+
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      ownerDao.Email,
+		Subject: fmt.Sprintf("%s now owns %s", recipientDao.FirstName, companyDao.Name),
+		Body: fmt.Sprintf("Hi %s,\n\n%s %s accepted your request and is now the owner of %s. You keep access to the company as %s.",
+			ownerDao.FirstName, recipientDao.FirstName, recipientDao.LastName, companyDao.Name, transferDao.PreviousOwnerRole),
+	})
+}
+
+func ownershipTransferDaoToModel(transferDao *dao.OwnershipTransfer) *models.OwnershipTransfer {
+	return &models.OwnershipTransfer{
+		ID:                transferDao.ID,
+		CompanyID:         transferDao.CompanyID,
+		FromUserID:        transferDao.FromUserID,
+		ToUserID:          transferDao.ToUserID,
+		PreviousOwnerRole: models.Role(transferDao.PreviousOwnerRole),
+		ExpiresAt:         transferDao.ExpiresAt,
+		AcceptedAt:        transferDao.AcceptedAt.Ptr(),
+		DeclinedAt:        transferDao.DeclinedAt.Ptr(),
+		CancelledAt:       transferDao.CancelledAt.Ptr(),
+		CreatedAt:         transferDao.CreatedAt,
+	}
+}
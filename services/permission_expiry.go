@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/pro-posal/webserver/config"
+	"github.com/pro-posal/webserver/dao"
+	"github.com/pro-posal/webserver/internal/mailer"
+	"github.com/pro-posal/webserver/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// NotifyExpiringPermissions emails the company's admins about permissions expiring within the
+// notice period, once per expiration. It returns how many permissions they were told about;
+// permissions whose notice couldn't be sent are tried again on the next call.
+func (s *PermissionManagementServiceImpl) NotifyExpiringPermissions(ctx context.Context, notice time.Duration) (int, error) {
+	now := time.Now().UTC()
+	permissionsDao, err := dao.Permissions(
+		dao.PermissionWhere.ExpiresAt.GT(null.TimeFrom(now)),
+		dao.PermissionWhere.ExpiresAt.LTE(null.TimeFrom(now.Add(notice))),
+		dao.PermissionWhere.ExpiryNotifiedAt.IsNull(),
+		qm.Load(dao.PermissionRels.User),
+		qm.Load(dao.PermissionRels.Company),
+	).All(ctx, s.db.Conn)
+	if err != nil {
+		return 0, fmt.Errorf("error fetching expiring permissions: %w", err)
+	}
+
+	notified := 0
+	for _, permissionDao := range permissionsDao {
+		if err := s.sendExpiryNotice(ctx, permissionDao); err != nil {
+			log.Printf("Failed notifying the admins of company %v that permission %v expires: %v", permissionDao.CompanyID, permissionDao.ID, err)
+			continue
+		}
+
+		permissionDao.ExpiryNotifiedAt = null.TimeFrom(now)
+		if _, err := permissionDao.Update(ctx, s.db.Conn, boil.Whitelist(dao.PermissionColumns.ExpiryNotifiedAt)); err != nil {
+			return notified, fmt.Errorf("error updating permission: %w", err)
+		}
+		notified++
+	}
+	return notified, nil
+}
+
+// DeleteExpiredPermissions deletes the permissions that have expired, recording each one in the
+// audit log of its company. It returns how many were deleted.
+func (s *PermissionManagementServiceImpl) DeleteExpiredPermissions(ctx context.Context) (int, error) {
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	permissionsDao, err := dao.Permissions(
+		dao.PermissionWhere.ExpiresAt.LTE(null.TimeFrom(time.Now().UTC())),
+		qm.Load(dao.PermissionRels.CompanyRole),
+		qm.For("UPDATE"),
+	).All(ctx, tx)
+	if err != nil {
+		return 0, fmt.Errorf("error fetching expired permissions: %w", err)
+	}
+	if len(permissionsDao) == 0 {
+		return 0, nil
+	}
+
+	for _, permissionDao := range permissionsDao {
+		err := recordAudit(ctx, tx, auditEntry{
+			CompanyID:  permissionDao.CompanyID,
+			Action:     AuditActionExpire,
+			EntityType: AuditEntityPermission,
+			EntityID:   permissionDao.ID,
+			Before:     permissionDaoToPermissionModel(permissionDao),
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	if _, err := permissionsDao.DeleteAll(ctx, tx); err != nil {
+		return 0, fmt.Errorf("error deleting expired permissions: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed committing transaction: %w", err)
+	}
+	return len(permissionsDao), nil
+}
+
+func (s *PermissionManagementServiceImpl) sendExpiryNotice(ctx context.Context, permissionDao *dao.Permission) error {
+	admins, err := dao.Users(
+		qm.Distinct("users.*"),
+		qm.InnerJoin("permissions ON permissions.user_id = users.id"),
+		qm.Where("permissions.company_id = ? AND users.deleted_at IS NULL", permissionDao.CompanyID),
+		qm.WhereIn("permissions.role IN ?", string(models.AdminRole), string(models.CompanyAdminRole)),
+		permissionInEffect("permissions"),
+	).All(ctx, s.db.Conn)
+	if err != nil {
+		return fmt.Errorf("error fetching company admins: %w", err)
+	}
+
+	user := permissionDao.R.GetUser()
+	company := permissionDao.R.GetCompany()
+	for _, admin := range admins {
+		err := s.mailer.Send(ctx, mailer.Message{
+			To:      admin.Email,
+			Subject: fmt.Sprintf("Access of %s %s to %s is about to expire", user.FirstName, user.LastName, company.Name),
+			Body: fmt.Sprintf("Hi %s,\n\nThe %s role of %s %s in %s expires on %s UTC. Extend the permission before then if they still need access, otherwise it is removed automatically.\n\n%s/companies/%s/permissions",
+				admin.FirstName, permissionDao.Role, user.FirstName, user.LastName, company.Name, permissionDao.ExpiresAt.Time.Format("2006-01-02 15:04"), config.AppConfig.Mail.AppBaseURL, company.ID),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -5,66 +5,128 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/pro-posal/webserver/dao"
+	"github.com/pro-posal/webserver/internal/authz"
 	"github.com/pro-posal/webserver/internal/database"
+	"github.com/pro-posal/webserver/internal/mailer"
 	"github.com/pro-posal/webserver/models"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
+// CreatePermissionRequest grants a built-in role, or with CompanyRoleID one of the company's own roles.
+// StartsAt and ExpiresAt are optional and limit when the role is granted.
 type CreatePermissionRequest struct {
-	UserID     string
-	CompanyID  string
-	Role       string
-	ContractID string
+	UserID        string
+	CompanyID     string
+	Role          string
+	ContractID    string
+	CompanyRoleID string
+	StartsAt      *time.Time
+	ExpiresAt     *time.Time
+	RequestedBy   string
 }
 
 type UpdatePermissionRequest struct {
-	Id         string
-	Role       string
-	ContractID string
+	Id            string
+	Role          string
+	ContractID    string
+	CompanyRoleID string
+	StartsAt      *time.Time
+	ExpiresAt     *time.Time
+	RequestedBy   string
 }
 
+var ErrInvalidPermissionPeriod = errors.New("a permission has to expire in the future and after it starts")
+
 type PermissionManagementService interface {
 	CreatePermission(context.Context, CreatePermissionRequest) (*models.Permission, error)
 	UpdatePermission(context.Context, UpdatePermissionRequest) (*models.Permission, error)
 	GetPermissions(context.Context, string) ([]*models.UserPermission, error)
 	DeletePermission(context.Context, string) (*models.Permission, error)
+	CreateCompanyRole(context.Context, CompanyRoleRequest) (*models.CompanyRole, error)
+	GetCompanyRoles(ctx context.Context, companyID string) ([]*models.CompanyRole, error)
+	UpdateCompanyRole(context.Context, CompanyRoleRequest) (*models.CompanyRole, error)
+	DeleteCompanyRole(ctx context.Context, companyID string, roleID string, requestedBy string) error
+	NotifyExpiringPermissions(ctx context.Context, notice time.Duration) (int, error)
+	DeleteExpiredPermissions(context.Context) (int, error)
 }
 
 type PermissionManagementServiceImpl struct {
-	db *database.DBConnector
+	db     *database.DBConnector
+	mailer mailer.Mailer
 }
 
-func NewPermissionManagementService(db *database.DBConnector) PermissionManagementService {
+func NewPermissionManagementService(db *database.DBConnector, mailer mailer.Mailer) PermissionManagementService {
 	return &PermissionManagementServiceImpl{
-		db: db,
+		db:     db,
+		mailer: mailer,
 	}
 }
 
 func (s *PermissionManagementServiceImpl) CreatePermission(ctx context.Context, req CreatePermissionRequest) (*models.Permission, error) {
+	if err := checkPermissionPeriod(req.StartsAt, req.ExpiresAt); err != nil {
+		return nil, err
+	}
+	companyRole, err := s.checkGrant(ctx, req.CompanyID, req.Role, req.CompanyRoleID, req.RequestedBy)
+	if err != nil {
+		return nil, err
+	}
+
 	permissionDao := dao.Permission{
-		ID:         uuid.NewString(),
-		UserID:     req.UserID,
-		CompanyID:  req.CompanyID,
-		Role:       req.Role,
-		ContractID: req.ContractID,
-		CreatedAt:  time.Now(),
-		UpdatedAt:  time.Now(),
+		ID:            uuid.NewString(),
+		UserID:        req.UserID,
+		CompanyID:     req.CompanyID,
+		Role:          req.Role,
+		ContractID:    req.ContractID,
+		CompanyRoleID: null.NewString(req.CompanyRoleID, req.CompanyRoleID != ""),
+		StartsAt:      utcTimeFromPtr(req.StartsAt),
+		ExpiresAt:     utcTimeFromPtr(req.ExpiresAt),
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	err := permissionDao.Insert(ctx, s.db.Conn, boil.Infer())
+	err = permissionDao.Insert(ctx, tx, boil.Infer())
 	if err != nil {
 		return nil, fmt.Errorf("failed inserting permission to database: %w", err)
 	}
 
-	return permissionDaoToPermissionModel(&permissionDao), nil
+	permissionDao.R = permissionDao.R.NewStruct()
+	permissionDao.R.CompanyRole = companyRole
+	permission := permissionDaoToPermissionModel(&permissionDao)
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  req.CompanyID,
+		ActorID:    req.RequestedBy,
+		Action:     AuditActionCreate,
+		EntityType: AuditEntityPermission,
+		EntityID:   permission.ID,
+		After:      permission,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+	return permission, nil
 }
 
 func (s *PermissionManagementServiceImpl) UpdatePermission(ctx context.Context, req UpdatePermissionRequest) (*models.Permission, error) {
+	if err := checkPermissionPeriod(req.StartsAt, req.ExpiresAt); err != nil {
+		return nil, err
+	}
 	permission, err := dao.Permissions(
 		qm.Where("id = ?", req.Id),
 	).One(ctx, s.db.Conn)
@@ -75,16 +137,58 @@ func (s *PermissionManagementServiceImpl) UpdatePermission(ctx context.Context,
 		return nil, fmt.Errorf("error fetching permission: %w", err)
 	}
 
+	// Both the role taken away and the one given have to be the caller's to grant
+	if _, err := s.checkGrant(ctx, permission.CompanyID, permission.Role, permission.CompanyRoleID.String, req.RequestedBy); err != nil {
+		return nil, err
+	}
+	companyRole, err := s.checkGrant(ctx, permission.CompanyID, req.Role, req.CompanyRoleID, req.RequestedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	before := permissionDaoToPermissionModel(permission)
 	permission.Role = req.Role
 	permission.ContractID = req.ContractID
+	permission.CompanyRoleID = null.NewString(req.CompanyRoleID, req.CompanyRoleID != "")
+	permission.StartsAt = utcTimeFromPtr(req.StartsAt)
+	expiresAt := utcTimeFromPtr(req.ExpiresAt)
+	if expiresAt != permission.ExpiresAt {
+		// A new expiration gets its own notice
+		permission.ExpiryNotifiedAt = null.Time{}
+	}
+	permission.ExpiresAt = expiresAt
 	permission.UpdatedAt = time.Now()
 
-	_, err = permission.Update(ctx, s.db.Conn, boil.Infer())
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = permission.Update(ctx, tx, boil.Infer())
 	if err != nil {
 		return nil, fmt.Errorf("error updating permission: %w", err)
 	}
 
+	permission.R = permission.R.NewStruct()
+	permission.R.CompanyRole = companyRole
 	updatedPermission := permissionDaoToPermissionModel(permission)
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  permission.CompanyID,
+		ActorID:    req.RequestedBy,
+		Action:     AuditActionUpdate,
+		EntityType: AuditEntityPermission,
+		EntityID:   permission.ID,
+		Before:     before,
+		After:      updatedPermission,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
 	return updatedPermission, nil
 }
 
@@ -93,6 +197,7 @@ func (s *PermissionManagementServiceImpl) GetPermissions(ctx context.Context, co
 		qm.Select("permissions.*, users.*"),
 		qm.InnerJoin("users on permissions.user_id = users.id"),
 		qm.Where("permissions.company_id = ?", companyId),
+		qm.Load(dao.PermissionRels.CompanyRole),
 	).All(ctx, s.db.Conn)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching permissions: %w", err)
@@ -128,22 +233,141 @@ func (s *PermissionManagementServiceImpl) DeletePermission(ctx context.Context,
 	}
 	permission := permissionDaoToPermissionModel(permissionDao)
 
-	_, err = permissionDao.Delete(ctx, s.db.Conn)
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = permissionDao.Delete(ctx, tx)
 	if err != nil {
 		return nil, fmt.Errorf("error deleting permission: %w", err)
 	}
 
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  permission.CompanyID,
+		Action:     AuditActionDelete,
+		EntityType: AuditEntityPermission,
+		EntityID:   permission.ID,
+		Before:     permission,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
 	return permission, nil
 }
 
+// checkGrant checks that the caller may grant the role in the company and, for custom roles,
+// returns the company role being granted.
+func (s *PermissionManagementServiceImpl) checkGrant(ctx context.Context, companyID string, role string, companyRoleID string, requestedBy string) (*dao.CompanyRole, error) {
+	if models.Role(role) != models.CompanyCustomRole {
+		if companyRoleID != "" {
+			return nil, fmt.Errorf("%w: only %v permissions refer to a company role", ErrInvalidCompanyRole, models.CompanyCustomRole)
+		}
+		return nil, checkCanGrantRole(ctx, s.db.Conn, requestedBy, companyID, models.Role(role))
+	}
+
+	companyRole, err := findCompanyRole(ctx, s.db.Conn, companyID, companyRoleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCanGrant(ctx, s.db.Conn, requestedBy, companyID, companyRoleCapabilities(companyRole)); err != nil {
+		return nil, err
+	}
+	return companyRole, nil
+}
+
+// checkPermissionPeriod checks the optional validity period of a permission.
+func checkPermissionPeriod(startsAt *time.Time, expiresAt *time.Time) error {
+	if expiresAt == nil {
+		return nil
+	}
+	if !expiresAt.After(time.Now()) || (startsAt != nil && !expiresAt.After(*startsAt)) {
+		return ErrInvalidPermissionPeriod
+	}
+	return nil
+}
+
+func utcTimeFromPtr(t *time.Time) null.Time {
+	if t == nil {
+		return null.Time{}
+	}
+	return null.TimeFrom(t.UTC())
+}
+
+// permissionInEffect limits a query to permissions that have started and haven't expired; table is
+// the name or alias of the permissions table in the query.
+func permissionInEffect(table string) qm.QueryMod {
+	now := time.Now().UTC()
+	return qm.Where(fmt.Sprintf("(%[1]s.starts_at IS NULL OR %[1]s.starts_at <= ?) AND (%[1]s.expires_at IS NULL OR %[1]s.expires_at > ?)", table), now, now)
+}
+
+// permissionDaoToPermissionModel expects the company role of custom role permissions to be loaded.
 func permissionDaoToPermissionModel(permissionDao *dao.Permission) *models.Permission {
-	return &models.Permission{
-		ID:         permissionDao.ID,
-		UserID:     permissionDao.UserID,
-		CompanyID:  permissionDao.CompanyID,
-		Role:       models.Role(permissionDao.Role),
-		ContractID: permissionDao.ContractID,
-		CreatedAt:  permissionDao.CreatedAt,
-		UpdatedAt:  permissionDao.UpdatedAt,
+	permission := &models.Permission{
+		ID:            permissionDao.ID,
+		UserID:        permissionDao.UserID,
+		CompanyID:     permissionDao.CompanyID,
+		Role:          models.Role(permissionDao.Role),
+		ContractID:    permissionDao.ContractID,
+		CompanyRoleID: permissionDao.CompanyRoleID.String,
+		StartsAt:      permissionDao.StartsAt.Ptr(),
+		ExpiresAt:     permissionDao.ExpiresAt.Ptr(),
+		CreatedAt:     permissionDao.CreatedAt,
+		UpdatedAt:     permissionDao.UpdatedAt,
+	}
+	if companyRole := permissionDao.R.GetCompanyRole(); companyRole != nil {
+		permission.Capabilities = companyRoleCapabilities(companyRole)
+	}
+	return permission
+}
+
+// userHasRole reports whether the user holds any of the given roles in any company.
+func userHasRole(ctx context.Context, exec boil.ContextExecutor, userID string, roles ...models.Role) (bool, error) {
+	return userHasCompanyRole(ctx, exec, userID, "", roles...)
+}
+
+// userHasCompanyRole reports whether the user holds any of the given roles in the company;
+// an empty companyID matches permissions in any company. Admin roles in companies that require
+// two-factor authentication only count once the user has enabled it, permissions only count while
+// in effect, and requests made with an API key only get the roles held in the key's company.
+func userHasCompanyRole(ctx context.Context, exec boil.ContextExecutor, userID string, companyID string, roles ...models.Role) (bool, error) {
+	grant := apiKeyGrantFromContext(ctx)
+	if grant != nil {
+		if companyID != "" && companyID != grant.CompanyID {
+			return false, nil
+		}
+		companyID = grant.CompanyID
+	}
+
+	roleNames := make([]interface{}, 0, len(roles))
+	for _, role := range roles {
+		roleNames = append(roleNames, string(role))
+	}
+
+	query := []qm.QueryMod{
+		qm.Where("user_id = ?", userID),
+		qm.WhereIn("role IN ?", roleNames...),
+		qm.Where(authz.TwoFactorPolicyCondition),
+		permissionInEffect("permissions"),
+	}
+	switch {
+	case companyID == "":
+	case grant == nil && slices.Contains(roles, models.AdminRole):
+		// Platform admins hold the admin role in a single company but it applies to every company
+		query = append(query, qm.Where("(company_id = ? OR role = ?)", companyID, string(models.AdminRole)))
+	default:
+		query = append(query, qm.Where("company_id = ?", companyID))
+	}
+
+	exists, err := dao.Permissions(query...).Exists(ctx, exec)
+	if err != nil {
+		return false, fmt.Errorf("error checking user permissions: %w", err)
 	}
+	return exists, nil
 }
@@ -0,0 +1,429 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pro-posal/webserver/dao"
+	"github.com/pro-posal/webserver/internal/database"
+	"github.com/pro-posal/webserver/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+type TrashManagementService interface {
+	GetTrash(ctx context.Context, companyID string, requestedBy string) ([]*models.TrashItem, error)
+	RestoreDeletion(ctx context.Context, companyID string, deletionID string, requestedBy string) ([]*models.TrashItem, error)
+	PurgeExpired(ctx context.Context, deletedBefore time.Time) (*models.TrashPurgeResult, error)
+}
+
+type TrashManagementServiceImpl struct {
+	db *database.DBConnector
+}
+
+func NewTrashManagementService(db *database.DBConnector) TrashManagementService {
+	return &TrashManagementServiceImpl{
+		db: db,
+	}
+}
+
+// trashQuery lists every soft-deleted record that belongs to the company; deleted users
+// belong to the companies they hold permissions in.
+const trashQuery = `
+SELECT trash.*, NULLIF(TRIM(d.first_name || ' ' || d.last_name), '') AS deleted_by_name
+FROM (
+	SELECT 'category' AS type, c.id, c.description AS name, c.category_id AS parent_id, c.deletion_id, c.deleted_at, c.deleted_by
+	FROM categories c
+	WHERE c.company_id = $1 AND c.deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'contract_template', t.id, t.name, NULL, t.deletion_id, t.deleted_at, t.deleted_by
+	FROM contract_templates t
+	WHERE t.company_id = $1 AND t.deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'company', co.id, co.name, NULL, co.deletion_id, co.deleted_at, co.deleted_by
+	FROM companies co
+	WHERE co.id = $1 AND co.deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'user', u.id, u.first_name || ' ' || u.last_name, NULL, u.deletion_id, u.deleted_at, u.deleted_by
+	FROM users u
+	WHERE u.deleted_at IS NOT NULL AND EXISTS (SELECT 1 FROM permissions p WHERE p.user_id = u.id AND p.company_id = $1)
+) AS trash
+LEFT JOIN users d ON d.id = trash.deleted_by
+ORDER BY trash.deleted_at DESC, trash.type, trash.name`
+
+type trashRow struct {
+	Type          string      `boil:"type"`
+	ID            string      `boil:"id"`
+	Name          string      `boil:"name"`
+	ParentID      null.String `boil:"parent_id"`
+	DeletionID    null.String `boil:"deletion_id"`
+	DeletedAt     time.Time   `boil:"deleted_at"`
+	DeletedBy     null.String `boil:"deleted_by"`
+	DeletedByName null.String `boil:"deleted_by_name"`
+}
+
+// GetTrash lists what can be restored, one entry per deletion: a category deleted together
+// with its children is listed once, through the category that was deleted.
+func (s *TrashManagementServiceImpl) GetTrash(ctx context.Context, companyID string, requestedBy string) ([]*models.TrashItem, error) {
+	if err := s.authorizeTrash(ctx, companyID, requestedBy); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.trashRows(ctx, s.db.Conn, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	deletions := map[string][]*trashRow{}
+	for _, row := range rows {
+		deletions[row.DeletionID.String] = append(deletions[row.DeletionID.String], row)
+	}
+
+	items := []*models.TrashItem{}
+	for _, row := range rows {
+		deletion := deletions[row.DeletionID.String]
+		if !isDeletionRoot(row, deletion) {
+			continue
+		}
+		item := trashRowToTrashItem(row)
+		item.RestoresWith = len(deletion) - 1
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// RestoreDeletion revives every record removed by the given deletion in one transaction.
+func (s *TrashManagementServiceImpl) RestoreDeletion(ctx context.Context, companyID string, deletionID string, requestedBy string) ([]*models.TrashItem, error) {
+	if err := s.authorizeTrash(ctx, companyID, requestedBy); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := s.trashRows(ctx, tx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var restored []*trashRow
+	for _, row := range rows {
+		if row.DeletionID.String == deletionID {
+			restored = append(restored, row)
+		}
+	}
+	if len(restored) == 0 {
+		return nil, fmt.Errorf("no deleted records found for deletion %s", deletionID)
+	}
+	// Users belong to every company they hold permissions in, only platform admins bring them back
+	if restoresUser(restored) {
+		isAdmin, err := userHasRole(ctx, tx, requestedBy, models.AdminRole)
+		if err != nil {
+			return nil, err
+		}
+		if !isAdmin {
+			return nil, &UnauthorizedError{}
+		}
+	}
+
+	if err := validateRestore(ctx, tx, restored); err != nil {
+		return nil, err
+	}
+
+	undelete := map[string]interface{}{
+		"deleted_at":  null.Time{},
+		"deleted_by":  null.String{},
+		"deletion_id": null.String{},
+		"updated_at":  time.Now(),
+	}
+	deletion := qm.Where("deletion_id = ?", deletionID)
+
+	if _, err := dao.Categories(deletion, qm.Where("company_id = ?", companyID)).UpdateAll(ctx, tx, undelete); err != nil {
+		return nil, fmt.Errorf("failed restoring categories: %w", err)
+	}
+	if _, err := dao.ContractTemplates(deletion, qm.Where("company_id = ?", companyID)).UpdateAll(ctx, tx, undelete); err != nil {
+		return nil, fmt.Errorf("failed restoring contract templates: %w", err)
+	}
+	if _, err := dao.Companies(deletion, qm.Where("id = ?", companyID)).UpdateAll(ctx, tx, undelete); err != nil {
+		return nil, fmt.Errorf("failed restoring company: %w", err)
+	}
+	if _, err := dao.Users(deletion, qm.Where("EXISTS (SELECT 1 FROM permissions p WHERE p.user_id = users.id AND p.company_id = ?)", companyID)).UpdateAll(ctx, tx, undelete); err != nil {
+		return nil, fmt.Errorf("failed restoring users: %w", err)
+	}
+
+	var items []*models.TrashItem
+	for _, row := range restored {
+		items = append(items, trashRowToTrashItem(row))
+	}
+	err = recordAudit(ctx, tx, auditEntry{
+		CompanyID:  companyID,
+		ActorID:    requestedBy,
+		Action:     AuditActionRestore,
+		EntityType: AuditEntityTrash,
+		EntityID:   deletionID,
+		After:      items,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+	return items, nil
+}
+
+// purgeableCompany and purgeableUser only match records nothing else still points to, so a
+// purge never fails on a foreign key; those records are retried on the next run.
+const (
+	purgeableCompany = `deleted_at < $1
+		AND NOT EXISTS (SELECT 1 FROM categories c WHERE c.company_id = companies.id)
+		AND NOT EXISTS (SELECT 1 FROM contract_templates t WHERE t.company_id = companies.id)
+		AND NOT EXISTS (SELECT 1 FROM offers o WHERE o.company_id = companies.id)`
+	purgeableUser = `deleted_at < $1
+		AND NOT EXISTS (SELECT 1 FROM offers o WHERE o.created_by = users.id OR o.customer_id = users.id)
+		AND NOT EXISTS (SELECT 1 FROM companies c WHERE c.contact_id = users.id)
+		AND NOT EXISTS (SELECT 1 FROM gallery_templates g WHERE g.published_by = users.id)`
+)
+
+// purgedRow is a record removed by a purge and the company it was audited in.
+type purgedRow struct {
+	ID        string `boil:"id"`
+	CompanyID string `boil:"company_id"`
+}
+
+// PurgeExpired hard deletes records that were soft deleted before the given time. Every purged
+// record is audited in the companies it belonged to, purged companies take their audit log along.
+func (s *TrashManagementServiceImpl) PurgeExpired(ctx context.Context, deletedBefore time.Time) (*models.TrashPurgeResult, error) {
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result := &models.TrashPurgeResult{}
+	exec := func(query string) (int64, error) {
+		res, err := queries.Raw(query, deletedBefore).ExecContext(ctx, tx)
+		if err != nil {
+			return 0, err
+		}
+		return res.RowsAffected()
+	}
+	purge := func(query string) ([]*purgedRow, error) {
+		var rows []*purgedRow
+		if err := queries.Raw(query, deletedBefore).Bind(ctx, tx, &rows); err != nil {
+			return nil, err
+		}
+		return rows, nil
+	}
+	audit := func(entityType string, rows []*purgedRow) error {
+		for _, row := range rows {
+			err := recordAudit(ctx, tx, auditEntry{
+				CompanyID:  row.CompanyID,
+				Action:     AuditActionPurge,
+				EntityType: entityType,
+				EntityID:   row.ID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Descriptions go first, then sub categories, then categories
+	for level := 0; level < 3; level++ {
+		purged, err := purge(`DELETE FROM categories WHERE deleted_at < $1
+			AND NOT EXISTS (SELECT 1 FROM categories child WHERE child.category_id = categories.id)
+			RETURNING id, company_id`)
+		if err != nil {
+			return nil, fmt.Errorf("failed purging categories: %w", err)
+		}
+		if err := audit(AuditEntityCategory, purged); err != nil {
+			return nil, err
+		}
+		result.Categories += int64(len(purged))
+	}
+
+	purgedTemplates, err := purge(`DELETE FROM contract_templates WHERE deleted_at < $1
+		AND NOT EXISTS (SELECT 1 FROM offers o WHERE o.contract_template_id = contract_templates.id)
+		RETURNING id, company_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed purging contract templates: %w", err)
+	}
+	if err := audit(AuditEntityContractTemplate, purgedTemplates); err != nil {
+		return nil, err
+	}
+	result.ContractTemplates = int64(len(purgedTemplates))
+
+	for _, query := range []string{
+		`DELETE FROM permissions WHERE company_id IN (SELECT id FROM companies WHERE ` + purgeableCompany + `)`,
+		`DELETE FROM company_roles WHERE company_id IN (SELECT id FROM companies WHERE ` + purgeableCompany + `)`,
+		`DELETE FROM api_keys WHERE company_id IN (SELECT id FROM companies WHERE ` + purgeableCompany + `)`,
+		`DELETE FROM user_identities WHERE provider_id IN (SELECT id FROM identity_providers WHERE company_id IN (SELECT id FROM companies WHERE ` + purgeableCompany + `))`,
+		`DELETE FROM oidc_login_states WHERE provider_id IN (SELECT id FROM identity_providers WHERE company_id IN (SELECT id FROM companies WHERE ` + purgeableCompany + `))`,
+		`DELETE FROM identity_providers WHERE company_id IN (SELECT id FROM companies WHERE ` + purgeableCompany + `)`,
+		`DELETE FROM invitations WHERE company_id IN (SELECT id FROM companies WHERE ` + purgeableCompany + `)`,
+		`DELETE FROM company_legal_clauses WHERE company_id IN (SELECT id FROM companies WHERE ` + purgeableCompany + `)`,
+		`DELETE FROM ownership_transfers WHERE company_id IN (SELECT id FROM companies WHERE ` + purgeableCompany + `)`,
+		`DELETE FROM audit_logs WHERE company_id IN (SELECT id FROM companies WHERE ` + purgeableCompany + `)`,
+	} {
+		if _, err := exec(query); err != nil {
+			return nil, fmt.Errorf("failed purging company records: %w", err)
+		}
+	}
+	result.Companies, err = exec(`DELETE FROM companies WHERE ` + purgeableCompany)
+	if err != nil {
+		return nil, fmt.Errorf("failed purging companies: %w", err)
+	}
+
+	// Users are audited in the companies they held permissions in, which go first
+	var userCompanies []*purgedRow
+	err = queries.Raw(`SELECT DISTINCT user_id AS id, company_id FROM permissions
+		WHERE user_id IN (SELECT id FROM users WHERE `+purgeableUser+`)`, deletedBefore).Bind(ctx, tx, &userCompanies)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching companies of purged users: %w", err)
+	}
+
+	for _, query := range []string{
+		`DELETE FROM permissions WHERE user_id IN (SELECT id FROM users WHERE ` + purgeableUser + `)`,
+		`DELETE FROM session WHERE user_id IN (SELECT id FROM users WHERE ` + purgeableUser + `)`,
+		`DELETE FROM password_reset_tokens WHERE user_id IN (SELECT id FROM users WHERE ` + purgeableUser + `)`,
+		`DELETE FROM two_factor_recovery_codes WHERE user_id IN (SELECT id FROM users WHERE ` + purgeableUser + `)`,
+		`DELETE FROM login_challenges WHERE user_id IN (SELECT id FROM users WHERE ` + purgeableUser + `)`,
+		`DELETE FROM login_attempts WHERE user_id IN (SELECT id FROM users WHERE ` + purgeableUser + `)`,
+		`DELETE FROM user_identities WHERE user_id IN (SELECT id FROM users WHERE ` + purgeableUser + `)`,
+		`DELETE FROM invitations WHERE invited_by IN (SELECT id FROM users WHERE ` + purgeableUser + `)
+			OR accepted_by IN (SELECT id FROM users WHERE ` + purgeableUser + `)`,
+		`DELETE FROM api_keys WHERE user_id IN (SELECT id FROM users WHERE ` + purgeableUser + `)
+			OR created_by IN (SELECT id FROM users WHERE ` + purgeableUser + `)`,
+		`DELETE FROM ownership_transfers WHERE from_user_id IN (SELECT id FROM users WHERE ` + purgeableUser + `)
+			OR to_user_id IN (SELECT id FROM users WHERE ` + purgeableUser + `)`,
+	} {
+		if _, err := exec(query); err != nil {
+			return nil, fmt.Errorf("failed purging user records: %w", err)
+		}
+	}
+	result.Users, err = exec(`DELETE FROM users WHERE ` + purgeableUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed purging users: %w", err)
+	}
+	if err := audit(AuditEntityUser, userCompanies); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *TrashManagementServiceImpl) authorizeTrash(ctx context.Context, companyID string, requestedBy string) error {
+	isAdmin, err := userHasCompanyCapability(ctx, s.db.Conn, requestedBy, companyID, models.CapabilityCompanyManage)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return &UnauthorizedError{}
+	}
+	return nil
+}
+
+func (s *TrashManagementServiceImpl) trashRows(ctx context.Context, exec boil.ContextExecutor, companyID string) ([]*trashRow, error) {
+	var rows []*trashRow
+	err := queries.Raw(trashQuery, companyID).Bind(ctx, exec, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted records from database: %w", err)
+	}
+	return rows, nil
+}
+
+// validateRestore refuses restores that would leave the tree or the template names inconsistent.
+func validateRestore(ctx context.Context, exec boil.ContextExecutor, restored []*trashRow) error {
+	for _, row := range restored {
+		switch models.TrashItemType(row.Type) {
+		case models.TrashItemCategory:
+			if !row.ParentID.Valid || isDeletionMember(row.ParentID.String, restored) {
+				continue
+			}
+			parentDeleted, err := dao.Categories(
+				qm.Where("id = ? AND deleted_at IS NOT NULL", row.ParentID.String),
+			).Exists(ctx, exec)
+			if err != nil {
+				return fmt.Errorf("error checking parent category: %w", err)
+			}
+			if parentDeleted {
+				return fmt.Errorf("category %q belongs to a deleted category, restore that first", row.Name)
+			}
+		case models.TrashItemContractTemplate:
+			template, err := dao.FindContractTemplate(ctx, exec, row.ID)
+			if err != nil {
+				return fmt.Errorf("error retrieving contract template: %w", err)
+			}
+			nameTaken, err := dao.ContractTemplates(
+				qm.Where("name = ? AND company_id = ? AND deleted_at IS NULL", template.Name, template.CompanyID),
+			).Exists(ctx, exec)
+			if err != nil {
+				return fmt.Errorf("error checking if contract template exists: %w", err)
+			}
+			if nameTaken {
+				return fmt.Errorf("a contract template named %q already exists", template.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func restoresUser(restored []*trashRow) bool {
+	for _, row := range restored {
+		if models.TrashItemType(row.Type) == models.TrashItemUser {
+			return true
+		}
+	}
+	return false
+}
+
+func isDeletionRoot(row *trashRow, deletion []*trashRow) bool {
+	if row.Type != string(models.TrashItemCategory) || !row.ParentID.Valid {
+		return true
+	}
+	return !isDeletionMember(row.ParentID.String, deletion)
+}
+
+func isDeletionMember(id string, deletion []*trashRow) bool {
+	for _, row := range deletion {
+		if row.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// deletedByUser records who deleted a record; requests without a session leave it empty.
+func deletedByUser(userID string) null.String {
+	if userID == "" || userID == uuid.Nil.String() {
+		return null.String{}
+	}
+	return null.StringFrom(userID)
+}
+
+func trashRowToTrashItem(row *trashRow) *models.TrashItem {
+	return &models.TrashItem{
+		ID:            row.ID,
+		Type:          models.TrashItemType(row.Type),
+		Name:          row.Name,
+		DeletionID:    row.DeletionID.String,
+		DeletedAt:     row.DeletedAt,
+		DeletedBy:     row.DeletedBy.String,
+		DeletedByName: row.DeletedByName.String,
+	}
+}
@@ -0,0 +1,487 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pro-posal/webserver/config"
+	"github.com/pro-posal/webserver/dao"
+	"github.com/pro-posal/webserver/internal/totp"
+	"github.com/pro-posal/webserver/internal/utils"
+	"github.com/pro-posal/webserver/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+type CompleteTwoFactorLoginRequest struct {
+	ChallengeToken string
+	// Either Code from the authenticator app or one of the RecoveryCodes is required
+	Code         string
+	RecoveryCode string
+	Client       SessionClient
+}
+
+type EnrollTwoFactorRequest struct {
+	UserID          string
+	CurrentPassword string
+}
+
+type ConfirmTwoFactorRequest struct {
+	UserID          string
+	CurrentPassword string
+	Code            string
+}
+
+type DisableTwoFactorRequest struct {
+	UserID          string
+	CurrentPassword string
+	Code            string
+}
+
+var (
+	ErrInvalidLoginChallenge      = errors.New("invalid or expired login challenge")
+	ErrInvalidTwoFactorCode       = errors.New("invalid two-factor code")
+	ErrTwoFactorAlreadyEnabled    = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled        = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorRequiredByCompany = errors.New("two-factor authentication is required by a company you administer")
+)
+
+const (
+	recoveryCodeCount = 10
+	// maxChallengeAttempts is how many wrong codes a login challenge accepts before it is burnt
+	maxChallengeAttempts = 5
+	// totpSkew is how many 30 second steps of clock drift are tolerated either way
+	totpSkew = 1
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// CompleteTwoFactorLogin finishes a login started by CreateAuthToken for a user with two-factor
+// authentication enabled.
+func (s *authServiceImpl) CompleteTwoFactorLogin(ctx context.Context, req CompleteTwoFactorLoginRequest) (*models.AuthToken, error) {
+	if err := s.checkAddressThrottle(ctx, req.Client.IPAddress); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	challengeDao, err := dao.LoginChallenges(
+		dao.LoginChallengeWhere.TokenHash.EQ(utils.HashToken(req.ChallengeToken)),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidLoginChallenge
+		}
+		return nil, fmt.Errorf("failed fetching login challenge from database: %w", err)
+	}
+	if challengeDao.UsedAt.Valid || challengeDao.Attempts >= maxChallengeAttempts || time.Now().After(challengeDao.ExpiresAt) {
+		return nil, ErrInvalidLoginChallenge
+	}
+
+	userDao, err := dao.Users(
+		dao.UserWhere.ID.EQ(challengeDao.UserID),
+		dao.UserWhere.DeletedAt.IsNull(),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidLoginChallenge
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	// A challenge only takes a few codes, the account throttle keeps new challenges from adding up to more
+	if err := checkAccountThrottle(userDao); err != nil {
+		return nil, err
+	}
+
+	ok, err := verifySecondFactor(ctx, tx, userDao, req.Code, req.RecoveryCode)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// Count the failed attempt even though the login fails
+		challengeDao.Attempts++
+		if _, err := challengeDao.Update(ctx, tx, boil.Whitelist(dao.LoginChallengeColumns.Attempts)); err != nil {
+			return nil, fmt.Errorf("failed to update login challenge in database: %w", err)
+		}
+		s.recordLoginAttempt(ctx, tx, userDao.EmailHash, null.StringFrom(userDao.ID), req.Client.IPAddress, false)
+		if err := registerFailedLogin(ctx, tx, s.mailer, userDao); err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, fmt.Errorf("failed committing transaction: %w", err)
+		}
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	challengeDao.UsedAt = null.TimeFrom(time.Now().UTC())
+	if _, err := challengeDao.Update(ctx, tx, boil.Whitelist(dao.LoginChallengeColumns.UsedAt)); err != nil {
+		return nil, fmt.Errorf("failed to update login challenge in database: %w", err)
+	}
+	if err := s.resetFailedLogins(ctx, tx, userDao); err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(userDao.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing user ID %v: %w", userDao.ID, err) // Should never happen
+	}
+	sessionID := uuid.New()
+	token, err := s.startSession(ctx, tx, sessionID, userID, sessionID, req.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+	return token, nil
+}
+
+// EnrollTwoFactor starts enrolling a new TOTP secret, which needs the password; it only takes
+// effect once ConfirmTwoFactor receives a code generated from it.
+func (s *authServiceImpl) EnrollTwoFactor(ctx context.Context, req EnrollTwoFactorRequest) (*models.TwoFactorEnrollment, error) {
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	userDao, err := dao.Users(dao.UserWhere.ID.EQ(req.UserID), qm.For("UPDATE")).One(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if userDao.TotpEnabledAt.Valid {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if err := checkAccountThrottle(userDao); err != nil {
+		return nil, err
+	}
+	if !utils.ComparePasswords(userDao.PasswordHash, req.CurrentPassword) {
+		return nil, s.failSecondFactor(ctx, tx, userDao, ErrInvalidCurrentPassword)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	userDao.TotpSecret = null.StringFrom(secret)
+	userDao.UpdatedAt = time.Now()
+	_, err = userDao.Update(ctx, tx, boil.Whitelist("totp_secret", "updated_at"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user in database: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+	return &models.TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(secret, config.AppConfig.Auth.TwoFactorIssuer, userDao.Email),
+	}, nil
+}
+
+// ConfirmTwoFactor enables two-factor authentication with the password and the first code from
+// the enrolled secret, and returns the recovery codes, which are not shown again.
+func (s *authServiceImpl) ConfirmTwoFactor(ctx context.Context, req ConfirmTwoFactorRequest) (*models.RecoveryCodes, error) {
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	userDao, err := dao.Users(dao.UserWhere.ID.EQ(req.UserID), qm.For("UPDATE")).One(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if userDao.TotpEnabledAt.Valid {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if !userDao.TotpSecret.Valid {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	if err := checkAccountThrottle(userDao); err != nil {
+		return nil, err
+	}
+	if !utils.ComparePasswords(userDao.PasswordHash, req.CurrentPassword) {
+		return nil, s.failSecondFactor(ctx, tx, userDao, ErrInvalidCurrentPassword)
+	}
+
+	step, ok := totp.Validate(userDao.TotpSecret.String, req.Code, time.Now(), totpSkew)
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	userDao.TotpEnabledAt = null.TimeFrom(time.Now())
+	userDao.TotpLastUsedStep = null.Int64From(step)
+	userDao.UpdatedAt = time.Now()
+	_, err = userDao.Update(ctx, tx, boil.Whitelist("totp_enabled_at", "totp_last_used_step", "updated_at"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user in database: %w", err)
+	}
+
+	codes, err := replaceRecoveryCodes(ctx, tx, userDao.ID)
+	if err != nil {
+		return nil, err
+	}
+	err = recordUserAudit(ctx, tx, userDao.ID, auditEntry{
+		ActorID:    userDao.ID,
+		Action:     AuditActionTwoFactorEnable,
+		EntityType: AuditEntityUser,
+		EntityID:   userDao.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+	return codes, nil
+}
+
+// DisableTwoFactor turns two-factor authentication off, which needs both the password and a
+// current code. Admins of a company that requires it can't turn it off.
+func (s *authServiceImpl) DisableTwoFactor(ctx context.Context, req DisableTwoFactorRequest) error {
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	userDao, err := dao.Users(dao.UserWhere.ID.EQ(req.UserID), qm.For("UPDATE")).One(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if !userDao.TotpEnabledAt.Valid {
+		return ErrTwoFactorNotEnabled
+	}
+	if err := checkAccountThrottle(userDao); err != nil {
+		return err
+	}
+	if !utils.ComparePasswords(userDao.PasswordHash, req.CurrentPassword) {
+		return s.failSecondFactor(ctx, tx, userDao, ErrInvalidCurrentPassword)
+	}
+
+	required, err := companyRequiresTwoFactor(ctx, tx, userDao.ID)
+	if err != nil {
+		return err
+	}
+	if required {
+		return ErrTwoFactorRequiredByCompany
+	}
+
+	ok, err := verifySecondFactor(ctx, tx, userDao, req.Code, "")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return s.failSecondFactor(ctx, tx, userDao, ErrInvalidTwoFactorCode)
+	}
+
+	userDao.TotpSecret = null.String{}
+	userDao.TotpEnabledAt = null.Time{}
+	userDao.TotpLastUsedStep = null.Int64{}
+	userDao.UpdatedAt = time.Now()
+	_, err = userDao.Update(ctx, tx, boil.Whitelist("totp_secret", "totp_enabled_at", "totp_last_used_step", "updated_at"))
+	if err != nil {
+		return fmt.Errorf("failed to update user in database: %w", err)
+	}
+
+	_, err = dao.TwoFactorRecoveryCodes(dao.TwoFactorRecoveryCodeWhere.UserID.EQ(userDao.ID)).DeleteAll(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("failed deleting recovery codes: %w", err)
+	}
+	err = recordUserAudit(ctx, tx, userDao.ID, auditEntry{
+		ActorID:    userDao.ID,
+		Action:     AuditActionTwoFactorDisable,
+		EntityType: AuditEntityUser,
+		EntityID:   userDao.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed committing transaction: %w", err)
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces all of the user's recovery codes, used or not.
+func (s *authServiceImpl) RegenerateRecoveryCodes(ctx context.Context, userID string, code string) (*models.RecoveryCodes, error) {
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	userDao, err := dao.Users(dao.UserWhere.ID.EQ(userID), qm.For("UPDATE")).One(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if !userDao.TotpEnabledAt.Valid {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	if err := checkAccountThrottle(userDao); err != nil {
+		return nil, err
+	}
+
+	ok, err := verifySecondFactor(ctx, tx, userDao, code, "")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, s.failSecondFactor(ctx, tx, userDao, ErrInvalidTwoFactorCode)
+	}
+
+	codes, err := replaceRecoveryCodes(ctx, tx, userDao.ID)
+	if err != nil {
+		return nil, err
+	}
+	err = recordUserAudit(ctx, tx, userDao.ID, auditEntry{
+		ActorID:    userDao.ID,
+		Action:     AuditActionRecoveryCodesRegenerate,
+		EntityType: AuditEntityUser,
+		EntityID:   userDao.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+	return codes, nil
+}
+
+// failSecondFactor counts a wrong code or password of a signed in user against their account, like
+// a failed login, so a stolen session can't be used to guess them. It commits the transaction and
+// returns the error to report.
+func (s *authServiceImpl) failSecondFactor(ctx context.Context, tx *sql.Tx, userDao *dao.User, failure error) error {
+	if err := registerFailedLogin(ctx, tx, s.mailer, userDao); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed committing transaction: %w", err)
+	}
+	return failure
+}
+
+// createLoginChallenge stores a challenge for a user who passed the password check.
+func createLoginChallenge(ctx context.Context, exec boil.ContextExecutor, userID string, client SessionClient) (*models.LoginChallenge, error) {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	challengeDao := dao.LoginChallenge{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		TokenHash: utils.HashToken(token),
+		UserAgent: null.NewString(client.UserAgent, client.UserAgent != ""),
+		IPAddress: null.NewString(client.IPAddress, client.IPAddress != ""),
+		ExpiresAt: now.Add(time.Duration(config.AppConfig.Auth.LoginChallengeExpirationMinutes) * time.Minute),
+		CreatedAt: now,
+	}
+	if err := challengeDao.Insert(ctx, exec, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("failed to insert login challenge into database: %w", err)
+	}
+
+	return &models.LoginChallenge{Token: token, ExpiresAt: challengeDao.ExpiresAt}, nil
+}
+
+// verifySecondFactor checks a TOTP code, which can't be used twice, or else burns a recovery code.
+// The user row should be locked by the caller.
+func verifySecondFactor(ctx context.Context, exec boil.ContextExecutor, userDao *dao.User, code string, recoveryCode string) (bool, error) {
+	if code != "" && userDao.TotpSecret.Valid {
+		step, ok := totp.Validate(userDao.TotpSecret.String, code, time.Now(), totpSkew)
+		if !ok || (userDao.TotpLastUsedStep.Valid && step <= userDao.TotpLastUsedStep.Int64) {
+			return false, nil
+		}
+
+		userDao.TotpLastUsedStep = null.Int64From(step)
+		_, err := userDao.Update(ctx, exec, boil.Whitelist("totp_last_used_step"))
+		if err != nil {
+			return false, fmt.Errorf("failed to update user in database: %w", err)
+		}
+		return true, nil
+	}
+
+	if recoveryCode == "" {
+		return false, nil
+	}
+
+	used, err := dao.TwoFactorRecoveryCodes(
+		dao.TwoFactorRecoveryCodeWhere.UserID.EQ(userDao.ID),
+		dao.TwoFactorRecoveryCodeWhere.CodeHash.EQ(hashRecoveryCode(recoveryCode)),
+		dao.TwoFactorRecoveryCodeWhere.UsedAt.IsNull(),
+	).UpdateAll(ctx, exec, dao.M{dao.TwoFactorRecoveryCodeColumns.UsedAt: time.Now().UTC()})
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	return used > 0, nil
+}
+
+func replaceRecoveryCodes(ctx context.Context, exec boil.ContextExecutor, userID string) (*models.RecoveryCodes, error) {
+	_, err := dao.TwoFactorRecoveryCodes(dao.TwoFactorRecoveryCodeWhere.UserID.EQ(userID)).DeleteAll(ctx, exec)
+	if err != nil {
+		return nil, fmt.Errorf("failed deleting recovery codes: %w", err)
+	}
+
+	codes := &models.RecoveryCodes{}
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw := make([]byte, 7)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, fmt.Errorf("failed generating recovery code: %w", err)
+		}
+		encoded := strings.ToLower(recoveryCodeEncoding.EncodeToString(raw))[:10]
+		code := encoded[:5] + "-" + encoded[5:]
+
+		codeDao := dao.TwoFactorRecoveryCode{
+			ID:        uuid.NewString(),
+			UserID:    userID,
+			CodeHash:  hashRecoveryCode(code),
+			CreatedAt: time.Now().UTC(),
+		}
+		if err := codeDao.Insert(ctx, exec, boil.Infer()); err != nil {
+			return nil, fmt.Errorf("failed to insert recovery code into database: %w", err)
+		}
+		codes.Codes = append(codes.Codes, code)
+	}
+	return codes, nil
+}
+
+// hashRecoveryCode ignores case, spaces and dashes so codes can be typed loosely.
+func hashRecoveryCode(code string) string {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(code)))
+	return utils.HashToken(normalized)
+}
+
+// companyRequiresTwoFactor reports whether the user administers a company that requires two-factor authentication.
+func companyRequiresTwoFactor(ctx context.Context, exec boil.ContextExecutor, userID string) (bool, error) {
+	required, err := dao.Permissions(
+		qm.InnerJoin("companies c ON c.id = permissions.company_id"),
+		qm.Where("permissions.user_id = ? AND c.require_two_factor AND c.deleted_at IS NULL", userID),
+		qm.WhereIn("permissions.role IN ?", string(models.AdminRole), string(models.CompanyAdminRole)),
+	).Exists(ctx, exec)
+	if err != nil {
+		return false, fmt.Errorf("error checking company two-factor policy: %w", err)
+	}
+	return required, nil
+}
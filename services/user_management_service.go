@@ -5,15 +5,22 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"log"
+	"strings"
 	"time"
 
+	"github.com/golang-jwt/jwt"
 	"github.com/google/uuid"
+	"github.com/pro-posal/webserver/config"
 	"github.com/pro-posal/webserver/dao"
 	"github.com/pro-posal/webserver/internal/database"
+	"github.com/pro-posal/webserver/internal/keys"
+	"github.com/pro-posal/webserver/internal/mailer"
 	"github.com/pro-posal/webserver/internal/utils"
 	"github.com/pro-posal/webserver/models"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
 type CreateUserRequest struct {
@@ -26,25 +33,74 @@ type CreateUserRequest struct {
 }
 
 type ChangeUserPasswordRequest struct {
-	Uuid        string
+	Uuid            string
+	CurrentPassword string
+	NewPassword     string
+}
+
+type RequestPasswordResetRequest struct {
+	Email     string
+	IPAddress string
+}
+
+type ResetPasswordRequest struct {
+	Token       string
 	NewPassword string
 }
 
+type UpdateEmailRequest struct {
+	Uuid            string
+	CurrentPassword string
+	NewEmail        string
+}
+
+var (
+	ErrInvalidCurrentPassword    = errors.New("current password is incorrect")
+	ErrInvalidPasswordResetToken = errors.New("invalid or expired password reset token")
+	ErrInvalidVerificationToken  = errors.New("invalid or expired email verification token")
+	ErrPasswordResetRateLimited  = errors.New("a password reset email was sent recently")
+)
+
+// VerificationRateLimitedError is returned when a verification email was sent too recently.
+type VerificationRateLimitedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *VerificationRateLimitedError) Error() string {
+	return fmt.Sprintf("a verification email was sent recently, retry after %v", e.RetryAfter)
+}
+
+const emailVerificationPurpose = "verify_email"
+
 type UserManagementService interface {
 	CreateUser(context.Context, CreateUserRequest) (*models.User, error)
 	ListUsers(context.Context) ([]*models.User, error)
 	GetUserByID(context.Context, string) (*models.User, error)
 	UpdateUserPassword(context.Context, ChangeUserPasswordRequest) (*models.User, error)
-	DeleteUser(context.Context, string) (*models.User, error)
+	DeleteUser(context.Context, string, string) (*models.User, error)
+	RequestPasswordReset(context.Context, RequestPasswordResetRequest) error
+	ResetPassword(context.Context, ResetPasswordRequest) error
+	VerifyEmail(ctx context.Context, token string) (*models.User, error)
+	ResendVerificationEmail(ctx context.Context, email string) error
+	UpdateEmail(context.Context, UpdateEmailRequest) (*models.User, error)
+	InviteUser(context.Context, InviteUserRequest) (*models.Invitation, error)
+	GetInvitations(ctx context.Context, companyID string, requestedBy string) ([]*models.Invitation, error)
+	ResendInvitation(ctx context.Context, companyID string, invitationID string, requestedBy string) (*models.Invitation, error)
+	RevokeInvitation(ctx context.Context, companyID string, invitationID string, requestedBy string) error
+	AcceptInvitation(context.Context, AcceptInvitationRequest) (*models.User, error)
 }
 
 type userManagementServiceImpl struct {
-	db *database.DBConnector
+	db     *database.DBConnector
+	mailer mailer.Mailer
+	keys   *keys.KeyStore
 }
 
-func NewUserManagementService(db *database.DBConnector) UserManagementService {
+func NewUserManagementService(db *database.DBConnector, mailer mailer.Mailer, keyStore *keys.KeyStore) UserManagementService {
 	return &userManagementServiceImpl{
-		db: db,
+		db:     db,
+		mailer: mailer,
+		keys:   keyStore,
 	}
 }
 
@@ -81,6 +137,11 @@ func (s *userManagementServiceImpl) CreateUser(ctx context.Context, req CreateUs
 		return nil, fmt.Errorf("failed inserting user to database: %w", err)
 	}
 
+	// The account exists either way; if the email can't be sent the user can ask for it again
+	if err := s.sendVerificationEmail(ctx, &userDao); err != nil {
+		log.Printf("Failed sending verification email to user %v: %v", userDao.ID, err)
+	}
+
 	return userDaoToUserModel(userDao), nil
 }
 
@@ -106,11 +167,32 @@ func (s *userManagementServiceImpl) GetUserByID(ctx context.Context, userId stri
 	return userDaoToUserModel(*userObj), nil
 }
 
+// UpdateUserPassword changes the password of a signed in user. Wrong current passwords count
+// against the account like failed logins, so a stolen session can't be used to guess it.
 func (s *userManagementServiceImpl) UpdateUserPassword(ctx context.Context, req ChangeUserPasswordRequest) (*models.User, error) {
-	userDao, err := dao.FindUser(ctx, s.db.Conn, req.Uuid)
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	userDao, err := dao.Users(dao.UserWhere.ID.EQ(req.Uuid), qm.For("UPDATE")).One(ctx, tx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find user: %w", err)
 	}
+	if err := checkAccountThrottle(userDao); err != nil {
+		return nil, err
+	}
+
+	if !utils.ComparePasswords(userDao.PasswordHash, req.CurrentPassword) {
+		if err := registerFailedLogin(ctx, tx, s.mailer, userDao); err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, fmt.Errorf("failed committing transaction: %w", err)
+		}
+		return nil, ErrInvalidCurrentPassword
+	}
 
 	hashedPassword, err := utils.HashPassword(req.NewPassword)
 	if err != nil {
@@ -120,41 +202,370 @@ func (s *userManagementServiceImpl) UpdateUserPassword(ctx context.Context, req
 	userDao.PasswordHash = hashedPassword
 	userDao.UpdatedAt = time.Now()
 
-	_, err = userDao.Update(ctx, s.db.Conn, boil.Whitelist("password_hash", "updated_at"))
+	_, err = userDao.Update(ctx, tx, boil.Whitelist("password_hash", "updated_at"))
 	if err != nil {
 		return nil, fmt.Errorf("failed to update user password in database: %w", err)
 	}
 
 	updatedUser := userDaoToUserModel(*userDao)
+	err = recordUserAudit(ctx, tx, userDao.ID, auditEntry{
+		ActorID:    userDao.ID,
+		Action:     AuditActionPasswordChange,
+		EntityType: AuditEntityUser,
+		EntityID:   userDao.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
 	return updatedUser, nil
 }
 
-func (s *userManagementServiceImpl) DeleteUser(ctx context.Context, userId string) (*models.User, error) {
+func (s *userManagementServiceImpl) DeleteUser(ctx context.Context, userId string, deletedBy string) (*models.User, error) {
 	userDao, err := dao.FindUser(ctx, s.db.Conn, userId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find user: %w", err)
 	}
 
+	before := userDaoToUserModel(*userDao)
 	deletedAt := null.TimeFrom(time.Now())
 	userDao.DeletedAt = deletedAt
+	userDao.DeletedBy = deletedByUser(deletedBy)
+	userDao.DeletionID = null.StringFrom(uuid.NewString())
 
-	_, err = userDao.Update(ctx, s.db.Conn, boil.Infer())
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = userDao.Update(ctx, tx, boil.Infer())
 	if err != nil {
 		return nil, fmt.Errorf("error updating company: %w", err)
 	}
+	err = recordUserAudit(ctx, tx, userDao.ID, auditEntry{
+		ActorID:    deletedBy,
+		Action:     AuditActionDelete,
+		EntityType: AuditEntityUser,
+		EntityID:   userDao.ID,
+		Before:     before,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	return userDaoToUserModel(*userDao), nil
+
+}
+
+// RequestPasswordReset emails a single-use reset link to the user, at most once per the interval
+// verification emails are limited to. Unknown emails and requests within the interval are ignored
+// without an error so the endpoint can't be used to find out who has an account.
+func (s *userManagementServiceImpl) RequestPasswordReset(ctx context.Context, req RequestPasswordResetRequest) error {
+	userDao, err := dao.Users(
+		dao.UserWhere.EmailHash.EQ(utils.HashEmail(req.Email)),
+		dao.UserWhere.DeletedAt.IsNull(),
+		dao.UserWhere.IsServiceAccount.EQ(false),
+	).One(ctx, s.db.Conn)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Printf("Password reset requested for an unknown email")
+			return nil
+		}
+		return fmt.Errorf("failed fetching user from database: %w", err)
+	}
+
+	interval := time.Duration(config.AppConfig.Auth.PasswordResetRequestIntervalMinutes) * time.Minute
+	recent, err := dao.PasswordResetTokens(
+		dao.PasswordResetTokenWhere.UserID.EQ(userDao.ID),
+		dao.PasswordResetTokenWhere.CreatedAt.GT(time.Now().UTC().Add(-interval)),
+	).Exists(ctx, s.db.Conn)
+	if err != nil {
+		return fmt.Errorf("failed checking recent password resets: %w", err)
+	}
+	if recent {
+		return fmt.Errorf("password reset for user %v requested again within %v: %w", userDao.ID, interval, ErrPasswordResetRateLimited)
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().UTC().Add(time.Duration(config.AppConfig.Auth.PasswordResetExpirationTimeMinutes) * time.Minute)
+	resetDao := dao.PasswordResetToken{
+		ID:          uuid.NewString(),
+		UserID:      userDao.ID,
+		TokenHash:   utils.HashToken(token),
+		RequestedIP: null.NewString(req.IPAddress, req.IPAddress != ""),
+		ExpiresAt:   expiresAt,
+		CreatedAt:   time.Now().UTC(),
+	}
+	err = resetDao.Insert(ctx, s.db.Conn, boil.Infer())
+	if err != nil {
+		return fmt.Errorf("failed to insert password reset token into database: %w", err)
+	}
+
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      userDao.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the link below to choose a new password. It expires in %d minutes and can only be used once.\n\n%s/reset-password?token=%s\n\nIf you didn't ask to reset your password you can ignore this email.",
+			userDao.FirstName, config.AppConfig.Auth.PasswordResetExpirationTimeMinutes, config.AppConfig.Mail.AppBaseURL, token),
+	})
+}
+
+// ResetPassword sets a new password using an emailed reset token. The token and any other
+// outstanding ones are used up, and every session of the user is revoked.
+func (s *userManagementServiceImpl) ResetPassword(ctx context.Context, req ResetPasswordRequest) error {
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	resetDao, err := dao.PasswordResetTokens(
+		dao.PasswordResetTokenWhere.TokenHash.EQ(utils.HashToken(req.Token)),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidPasswordResetToken
+		}
+		return fmt.Errorf("failed fetching password reset token from database: %w", err)
+	}
+	if resetDao.UsedAt.Valid || time.Now().After(resetDao.ExpiresAt) {
+		return ErrInvalidPasswordResetToken
+	}
+
+	userDao, err := dao.Users(
+		dao.UserWhere.ID.EQ(resetDao.UserID),
+		dao.UserWhere.DeletedAt.IsNull(),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidPasswordResetToken
+		}
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	hashedPassword, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		return fmt.Errorf("failed hashing new password: %w", err)
+	}
+
+	userDao.PasswordHash = hashedPassword
+	userDao.UpdatedAt = time.Now()
+	_, err = userDao.Update(ctx, tx, boil.Whitelist("password_hash", "updated_at"))
+	if err != nil {
+		return fmt.Errorf("failed to update user password in database: %w", err)
+	}
+
+	_, err = dao.PasswordResetTokens(
+		dao.PasswordResetTokenWhere.UserID.EQ(userDao.ID),
+		dao.PasswordResetTokenWhere.UsedAt.IsNull(),
+	).UpdateAll(ctx, tx, dao.M{dao.PasswordResetTokenColumns.UsedAt: time.Now().UTC()})
+	if err != nil {
+		return fmt.Errorf("failed to use up password reset tokens: %w", err)
+	}
+
+	_, err = dao.Sessions(
+		dao.SessionWhere.UserID.EQ(userDao.ID),
+		dao.SessionWhere.RevokedAt.IsNull(),
+	).UpdateAll(ctx, tx, dao.M{dao.SessionColumns.RevokedAt: time.Now().UTC()})
+	if err != nil {
+		return fmt.Errorf("failed to revoke user sessions: %w", err)
+	}
+	if err := revokeUserAPIKeys(ctx, tx, userDao.ID, userDao.ID); err != nil {
+		return err
+	}
+
+	// Reset by whoever holds the emailed link, which is meant to be the user
+	err = recordUserAudit(ctx, tx, userDao.ID, auditEntry{
+		ActorID:    userDao.ID,
+		Action:     AuditActionPasswordReset,
+		EntityType: AuditEntityUser,
+		EntityID:   userDao.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed committing transaction: %w", err)
+	}
+	return nil
+}
+
+// VerifyEmail marks the user's email address as verified. The link only works for the address it
+// was sent to, so changing the email again invalidates older links.
+func (s *userManagementServiceImpl) VerifyEmail(ctx context.Context, token string) (*models.User, error) {
+	claims, err := parsePurposeToken(s.keys, token, emailVerificationPurpose)
+	if err != nil {
+		log.Printf("Failed parsing email verification token: %v", err)
+		return nil, ErrInvalidVerificationToken
+	}
+	userID, _ := claims["sub"].(string)
+	emailHash, _ := claims["eh"].(string)
+
+	userDao, err := dao.Users(
+		dao.UserWhere.ID.EQ(userID),
+		dao.UserWhere.DeletedAt.IsNull(),
+	).One(ctx, s.db.Conn)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidVerificationToken
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if userDao.EmailHash != emailHash {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	if !userDao.EmailVerifiedAt.Valid {
+		userDao.EmailVerifiedAt = null.TimeFrom(time.Now())
+		userDao.UpdatedAt = time.Now()
+		_, err = userDao.Update(ctx, s.db.Conn, boil.Whitelist("email_verified_at", "updated_at"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to update user in database: %w", err)
+		}
+	}
 
 	return userDaoToUserModel(*userDao), nil
+}
+
+// ResendVerificationEmail sends a new verification link, at most once per configured interval.
+// Unknown and already verified addresses are ignored without an error.
+func (s *userManagementServiceImpl) ResendVerificationEmail(ctx context.Context, email string) error {
+	userDao, err := dao.Users(
+		dao.UserWhere.EmailHash.EQ(utils.HashEmail(email)),
+		dao.UserWhere.DeletedAt.IsNull(),
+	).One(ctx, s.db.Conn)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed fetching user from database: %w", err)
+	}
+	if userDao.EmailVerifiedAt.Valid {
+		return nil
+	}
+
+	interval := time.Duration(config.AppConfig.Auth.EmailVerificationResendIntervalMinutes) * time.Minute
+	if userDao.VerificationSentAt.Valid {
+		if wait := time.Until(userDao.VerificationSentAt.Time.Add(interval)); wait > 0 {
+			return &VerificationRateLimitedError{RetryAfter: wait}
+		}
+	}
+
+	return s.sendVerificationEmail(ctx, userDao)
+}
+
+// UpdateEmail changes the user's email address, which has to be verified again.
+func (s *userManagementServiceImpl) UpdateEmail(ctx context.Context, req UpdateEmailRequest) (*models.User, error) {
+	userDao, err := dao.FindUser(ctx, s.db.Conn, req.Uuid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if !utils.ComparePasswords(userDao.PasswordHash, req.CurrentPassword) {
+		return nil, ErrInvalidCurrentPassword
+	}
+
+	newEmail := strings.ToLower(strings.TrimSpace(req.NewEmail))
+	emailHash := utils.HashEmail(newEmail)
+	if emailHash == userDao.EmailHash {
+		return userDaoToUserModel(*userDao), nil
+	}
+
+	exists, err := dao.Users(dao.UserWhere.EmailHash.EQ(emailHash)).Exists(ctx, s.db.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed checking for existing user: %w", err)
+	}
+	if exists {
+		return nil, fmt.Errorf("user with email %s already exists", newEmail)
+	}
+
+	before := userDaoToUserModel(*userDao)
+	userDao.Email = newEmail
+	userDao.EmailHash = emailHash
+	userDao.EmailVerifiedAt = null.Time{}
+	userDao.VerificationSentAt = null.Time{}
+	userDao.UpdatedAt = time.Now()
+
+	tx, err := s.db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = userDao.Update(ctx, tx, boil.Whitelist("email", "email_hash", "email_verified_at", "verification_sent_at", "updated_at"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user email in database: %w", err)
+	}
+	err = recordUserAudit(ctx, tx, userDao.ID, auditEntry{
+		ActorID:    userDao.ID,
+		Action:     AuditActionUpdate,
+		EntityType: AuditEntityUser,
+		EntityID:   userDao.ID,
+		Before:     before,
+		After:      userDaoToUserModel(*userDao),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed committing transaction: %w", err)
+	}
+
+	if err := s.sendVerificationEmail(ctx, userDao); err != nil {
+		log.Printf("Failed sending verification email to user %v: %v", userDao.ID, err)
+	}
+
+	return userDaoToUserModel(*userDao), nil
+}
+
+func (s *userManagementServiceImpl) sendVerificationEmail(ctx context.Context, userDao *dao.User) error {
+	expiration := time.Duration(config.AppConfig.Auth.EmailVerificationExpirationMinutes) * time.Minute
+	token, err := signPurposeToken(s.keys, emailVerificationPurpose, jwt.MapClaims{
+		"sub": userDao.ID,
+		"eh":  userDao.EmailHash,
+	}, expiration)
+	if err != nil {
+		return err
+	}
+
+	userDao.VerificationSentAt = null.TimeFrom(time.Now())
+	_, err = userDao.Update(ctx, s.db.Conn, boil.Whitelist("verification_sent_at"))
+	if err != nil {
+		return fmt.Errorf("failed to update user in database: %w", err)
+	}
 
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      userDao.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nPlease confirm your email address by opening the link below. It expires in %v.\n\n%s/verify-email?token=%s",
+			userDao.FirstName, expiration, config.AppConfig.Mail.AppBaseURL, token),
+	})
 }
 
 func userDaoToUserModel(userDao dao.User) *models.User {
 	return &models.User{
-		ID:        userDao.ID,
-		FirstName: userDao.FirstName,
-		LastName:  userDao.LastName,
-		Phone:     userDao.Phone,
-		Email:     userDao.Email,
-		CreatedAt: userDao.CreatedAt,
-		UpdatedAt: userDao.UpdatedAt,
+		ID:               userDao.ID,
+		FirstName:        userDao.FirstName,
+		LastName:         userDao.LastName,
+		Phone:            userDao.Phone,
+		Email:            userDao.Email,
+		EmailVerified:    userDao.EmailVerifiedAt.Valid,
+		IsServiceAccount: userDao.IsServiceAccount,
+		CreatedAt:        userDao.CreatedAt,
+		UpdatedAt:        userDao.UpdatedAt,
 	}
 }